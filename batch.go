@@ -3,18 +3,87 @@ package edgar
 import (
 	"bytes"
 	"fmt"
-	"time"
+	"io"
+	"strings"
+	"sync"
 )
 
 // BatchOptions configures batch download and parsing
 type BatchOptions struct {
 	CIK              string // Required: CIK to fetch filings for
-	FormType         string // Required: Form type to filter (e.g., "4", "3", "5", "13D", "13G")
+	FormType         string // Required: Form type to filter (e.g., "4", "3", "5", "13D", "13G"), unless Accessions is set
 	DateFrom         string // Optional: Start date (YYYY-MM-DD), empty = no limit
 	DateTo           string // Optional: End date (YYYY-MM-DD), empty = no limit
 	Email            string // Required: Email for SEC User-Agent header
 	IncludePaginated bool   // If true, fetch all paginated filings (can be slow)
 	ListOnly         bool   // If true, only list filings without downloading/parsing
+
+	// Accessions, if set, selects these filings by accession number instead of
+	// by FormType and date range, e.g. to retry the failures of a previous run.
+	// Accessions not among the CIK's filings are reported as CategoryNotFound
+	// errors.
+	Accessions []string
+
+	Store   *FilingStore // Optional: local archive checked before hitting SEC; new downloads are saved to it
+	Offline bool         // If true, list filings from Store's manifest instead of SEC (requires Store)
+
+	Client *Client // Optional: client for SEC requests (default: a Client for Email sharing the package-level rate limit)
+
+	Concurrency int // Number of parallel download workers (default: 1); all share the 10 req/sec rate limit
+
+	CheckpointPath string // Optional: file recording processed filings so an interrupted run can be resumed
+	Resume         bool   // If true, skip filings already parsed in CheckpointPath (requires CheckpointPath)
+
+	Logger Logger // Optional: receives progress messages (default: slog.Default())
+
+	// Progress, if set, is called with the number of filings processed so far
+	// and the number to process (filings restored from a checkpoint are not
+	// counted): once before the first download, then after each filing. Calls
+	// are serialized. The periodic "progress" log message drops to debug level.
+	Progress func(completed, total int)
+
+	ResolveFootnotes bool // If true, embed resolved footnote text on each Form 4 transaction (Form4Output.ResolveFootnotes)
+
+	// Strict validates Form 4 and Schedule 13D/G XML against the SEC schema
+	// rules before parsing (see ParseOptions.Strict); filings that break them
+	// fail with a *SchemaError in a ParseError
+	Strict bool
+
+	// Canonical writes canonical NDJSON to Output (see FormatOptions.Canonical)
+	Canonical bool
+
+	// TransactionFilter drops Form 4 transactions that don't match it; filings
+	// left without transactions are omitted from the results (see
+	// BatchResult.Filtered). Checkpoints keep the unfiltered filings.
+	TransactionFilter TransactionFilter
+
+	// Profile is the concept mapping profile for 10-K/10-Q snapshots (see
+	// XBRL.ApplyProfile). Empty selects it from the company's SIC code, or the
+	// default mappings in Offline mode.
+	Profile string
+
+	// Output receives the parsed results in OutputFormat: "json" (default), "csv"
+	// (Form 4 only), "parquet" or "xlsx" once the batch completes. With "ndjson" each
+	// filing is written as soon as it (and every filing before it) is parsed,
+	// and BatchResult.Filings is left empty so memory stays constant.
+	Output       io.Writer
+	OutputFormat string
+
+	onFiling func(*ParsedForm, Filing) error // Set by FetchAndParseBatchStream
+}
+
+// streaming reports whether results are handed over (written to Output, or
+// passed to the FetchAndParseBatchStream callback) as they complete
+func (opts BatchOptions) streaming() bool {
+	return opts.onFiling != nil || opts.Output != nil && opts.OutputFormat == "ndjson"
+}
+
+// client returns the Client to use for SEC requests
+func (opts BatchOptions) client() *Client {
+	if opts.Client != nil {
+		return opts.Client
+	}
+	return defaultClient(opts.Email)
 }
 
 // BatchResult contains the results of a batch operation
@@ -23,7 +92,28 @@ type BatchResult struct {
 	FilingList []Filing      // Filing metadata only - only populated when ListOnly=true
 	TotalFound int           // Total filings matching criteria
 	Fetched    int           // Number actually downloaded and parsed (0 when ListOnly=true)
-	Errors     []error       // Any errors encountered during processing
+	Filtered   int           // Parsed filings omitted by TransactionFilter (included in Fetched)
+	Errors     []error       // Any errors encountered during processing; *FilingError for failed filings
+}
+
+// FetchAndParseBatchStream is FetchAndParseBatch calling fn with each parsed
+// filing instead of collecting them, so results can be written to a database
+// as they arrive with memory that stays constant. Filings are passed in
+// filing order, as soon as they and every filing before them are processed;
+// failed filings and those dropped by TransactionFilter are not passed, and
+// BatchResult.Filings is left empty. Calls are serialized.
+//
+// If fn returns an error, no further filings are fetched and the error is
+// returned with the result so far. Output must not be set.
+func FetchAndParseBatchStream(opts BatchOptions, fn func(parsed *ParsedForm, filing Filing) error) (*BatchResult, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("a callback is required")
+	}
+	if opts.Output != nil {
+		return nil, fmt.Errorf("Output is not used with FetchAndParseBatchStream; write from the callback")
+	}
+	opts.onFiling = fn
+	return FetchAndParseBatch(opts)
 }
 
 // FetchAndParseBatch fetches all filings for a CIK matching the criteria and parses them
@@ -37,38 +127,82 @@ func FetchAndParseBatch(opts BatchOptions) (*BatchResult, error) {
 	if opts.CIK == "" {
 		return nil, fmt.Errorf("CIK is required")
 	}
-	if opts.FormType == "" {
+	if opts.FormType == "" && len(opts.Accessions) == 0 {
 		return nil, fmt.Errorf("FormType is required")
 	}
-	if opts.Email == "" {
-		return nil, fmt.Errorf("Email is required")
+	if opts.Offline && opts.Store == nil {
+		return nil, fmt.Errorf("Offline mode requires a Store")
 	}
-
-	// Fetch submissions
-	fmt.Printf("Fetching submissions for CIK %s...\n", opts.CIK)
-	subs, err := FetchSubmissions(opts.CIK, opts.Email)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch submissions: %w", err)
+	if opts.Resume && opts.CheckpointPath == "" {
+		return nil, fmt.Errorf("Resume requires a CheckpointPath")
+	}
+	if !isBatchFormat(opts.OutputFormat) {
+		return nil, fmt.Errorf("unsupported output format: %s", opts.OutputFormat)
+	}
+	if _, ok := GetConceptProfile(opts.Profile); !ok && opts.Profile != "" && opts.Profile != DefaultProfile {
+		return nil, fmt.Errorf("unknown concept profile %q", opts.Profile)
+	}
+	if opts.Client != nil && opts.Email == "" {
+		opts.Email = opts.Client.Email
+	}
+	if opts.Email == "" && !opts.Offline {
+		return nil, fmt.Errorf("Email is required")
 	}
+	client := opts.client()
+	log := loggerOrDefault(opts.Logger)
 
-	// Get all filings (recent + paginated if requested)
+	// Get all filings (recent + paginated if requested, or the local store when offline)
 	var allFilings []Filing
-	if opts.IncludePaginated {
-		fmt.Println("Fetching paginated filings (this may take a while)...")
-		allFilings, err = subs.GetAllFilings(opts.Email)
+	var err error
+	if opts.Offline {
+		log.Info("listing stored filings", "cik", opts.CIK, "store", opts.Store.Root)
+		allFilings, err = opts.Store.Filings(opts.CIK)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch paginated filings: %w", err)
+			return nil, fmt.Errorf("failed to read filing store: %w", err)
 		}
 	} else {
-		allFilings = subs.GetRecentFilings()
+		log.Info("fetching submissions", "cik", opts.CIK)
+		subs, err := client.FetchSubmissions(opts.CIK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch submissions: %w", err)
+		}
+		if opts.Profile == "" {
+			opts.Profile = ProfileForSIC(subs.SIC)
+			log.Debug("selected concept profile", "sic", subs.SIC, "profile", opts.Profile)
+		}
+
+		if opts.IncludePaginated {
+			log.Info("fetching paginated filings (this may take a while)", "files", len(subs.Filings.Files))
+			allFilings, err = client.GetAllFilings(subs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch paginated filings: %w", err)
+			}
+		} else {
+			allFilings = subs.GetRecentFilings()
+		}
 	}
 
-	// Filter by form type
-	filings := FilterByForm(allFilings, opts.FormType)
-	fmt.Printf("Found %d Form %s filings\n", len(filings), opts.FormType)
+	var filings []Filing
+	if len(opts.Accessions) > 0 {
+		// Selected filings, whatever their form and date
+		var missing []string
+		filings, missing = filterByAccession(allFilings, opts.Accessions)
+		log.Info("found requested filings", "count", len(filings), "requested", len(opts.Accessions))
+		for _, accession := range missing {
+			result.Errors = append(result.Errors, &FilingError{
+				AccessionNumber: accession,
+				Category:        CategoryNotFound,
+				Err:             fmt.Errorf("filing %s %w for CIK %s", accession, ErrNotFound, opts.CIK),
+			})
+		}
+	} else {
+		// Filter by form type
+		filings = FilterByForm(allFilings, opts.FormType)
+		log.Info("found filings", "form", opts.FormType, "count", len(filings))
+	}
 
 	// Filter by date range if specified
-	if opts.DateFrom != "" || opts.DateTo != "" {
+	if len(opts.Accessions) == 0 && (opts.DateFrom != "" || opts.DateTo != "") {
 		from := opts.DateFrom
 		to := opts.DateTo
 
@@ -81,7 +215,7 @@ func FetchAndParseBatch(opts BatchOptions) (*BatchResult, error) {
 		}
 
 		filings = FilterByDateRange(filings, from, to)
-		fmt.Printf("Filtered to %d filings in date range %s to %s\n", len(filings), from, to)
+		log.Info("filtered by date range", "from", from, "to", to, "count", len(filings))
 	}
 
 	result.TotalFound = len(filings)
@@ -89,58 +223,416 @@ func FetchAndParseBatch(opts BatchOptions) (*BatchResult, error) {
 	// If list-only mode, just return the metadata
 	if opts.ListOnly {
 		result.FilingList = filings
-		fmt.Printf("Listed %d filings (use without --list-only to download and parse)\n", len(filings))
+		log.Info("listed filings without downloading", "count", len(filings))
 		return result, nil
 	}
 
 	// Download and parse each filing
-	fmt.Printf("Downloading and parsing %d filings...\n", len(filings))
+	log.Info("downloading and parsing filings", "count", len(filings), "workers", max(opts.Concurrency, 1))
 
-	rateLimiter := time.NewTicker(100 * time.Millisecond) // 10 req/sec
-	defer rateLimiter.Stop()
+	// Fetch and parse with a worker pool; results are slotted by index so the
+	// output order matches the filing order regardless of completion order
+	outcomes := make([]batchOutcome, len(filings))
+	jobs := make(chan int)
 
+	// Restore filings completed by a previous run
+	var checkpoint *batchCheckpoint
+	var pending []int
+	if opts.CheckpointPath != "" {
+		var err error
+		checkpoint, err = openCheckpoint(opts)
+		if err != nil {
+			return nil, err
+		}
+		defer checkpoint.Close()
+	}
 	for i, filing := range filings {
-		// Rate limiting
-		<-rateLimiter.C
+		if checkpoint != nil {
+			if parsed, ok := checkpoint.done[filing.AccessionNumber]; ok {
+				annotateParsed(parsed, filing, opts)
+				outcomes[i] = batchOutcome{parsed: parsed, done: true, filtered: !filterParsed(parsed, opts)}
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+	if skipped := len(filings) - len(pending); skipped > 0 {
+		log.Info("resuming from checkpoint", "processed", skipped, "remaining", len(pending))
+	}
+	var checkpointErr error
 
-		// Progress indicator
-		if (i+1)%10 == 0 || i == 0 {
-			fmt.Printf("  Progress: %d/%d\n", i+1, len(filings))
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	// With NDJSON output, filings are written in order as soon as they are ready
+	var stream *batchStream
+	if opts.streaming() {
+		emit := opts.onFiling
+		if emit == nil {
+			enc := NewNDJSONEncoder(opts.Output)
+			enc.SetCanonical(opts.Canonical)
+			emit = func(parsed *ParsedForm, _ Filing) error { return enc.Encode(parsed) }
+		}
+		stream = newBatchStream(emit, filings, outcomes)
+		for i := range filings {
+			if outcomes[i].done {
+				stream.done(i)
+			}
 		}
+	}
 
-		// Fetch the XML
-		xmlData, err := FetchForm(filing.URL, opts.Email)
-		if err != nil {
-			errMsg := fmt.Errorf("failed to fetch %s: %w", filing.AccessionNumber, err)
-			result.Errors = append(result.Errors, errMsg)
-			continue
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	completed := 0
+	logProgress := log.Info
+	if opts.Progress != nil {
+		logProgress = log.Debug
+		opts.Progress(0, len(pending))
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				parsed, err := fetchAndParse(client, filings[i], opts)
+				if err == nil {
+					annotateParsed(parsed, filings[i], opts)
+				}
+				if err != nil {
+					log.Warn("failed to process filing", "accession", filings[i].AccessionNumber, "error", err)
+				} else {
+					log.Debug("parsed filing", "accession", filings[i].AccessionNumber, "form", filings[i].Form)
+				}
+
+				// Checkpoint before filtering, so a resumed run can use other filters
+				var cpErr error
+				if checkpoint != nil {
+					cpErr = checkpoint.record(filings[i].AccessionNumber, parsed, err)
+				}
+				outcomes[i] = batchOutcome{parsed: parsed, err: err, done: true, filtered: err == nil && !filterParsed(parsed, opts)}
+
+				// Progress indicator
+				progressMu.Lock()
+				completed++
+				if completed%10 == 0 || completed == 1 {
+					logProgress("progress", "completed", completed, "total", len(pending))
+				}
+				if opts.Progress != nil {
+					opts.Progress(completed, len(pending))
+				}
+				if cpErr != nil && checkpointErr == nil {
+					checkpointErr = cpErr
+				}
+				if stream != nil {
+					stream.done(i)
+				}
+				progressMu.Unlock()
+			}
+		}()
+	}
+
+	for _, i := range pending {
+		// Stop fetching once the output or callback has failed
+		progressMu.Lock()
+		stopped := stream != nil && stream.err != nil
+		progressMu.Unlock()
+		if stopped {
+			break
 		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-		// Parse the form
-		parsed, err := ParseAny(bytes.NewReader(xmlData))
+	if checkpointErr != nil {
+		result.Errors = append(result.Errors, checkpointErr)
+	}
+
+	for i := range filings {
+		if !outcomes[i].done {
+			continue // Not fetched after the stream failed
+		}
+		parsed, err := outcomes[i].parsed, outcomes[i].err
 		if err != nil {
-			errMsg := fmt.Errorf("failed to parse %s: %w", filing.AccessionNumber, err)
-			result.Errors = append(result.Errors, errMsg)
+			result.Errors = append(result.Errors, newFilingError(filings[i], err))
 			continue
 		}
-
-		// Add metadata to the parsed form based on type
-		if parsed.FormType == "4" {
-			if form4Output, ok := parsed.Data.(*Form4Output); ok {
-				form4Output.SetSource(filing.URL)
-				form4Output.SetFilingMetadata(filing.AccessionNumber, filing.FilingDate, filing.ReportDate)
-			}
+		result.Fetched++
+		if outcomes[i].filtered {
+			result.Filtered++
+			continue
+		}
+		if stream != nil {
+			continue // Already written to Output
 		}
-		// For XBRL (10-K, 10-Q), metadata is in the snapshot itself
 
 		result.Filings = append(result.Filings, parsed)
-		result.Fetched++
 	}
 
-	fmt.Printf("Successfully parsed %d/%d filings\n", result.Fetched, result.TotalFound)
+	log.Info("batch complete", "parsed", result.Fetched, "total", result.TotalFound)
+	if result.Filtered > 0 {
+		log.Info("omitted filings without matching transactions", "count", result.Filtered)
+	}
 	if len(result.Errors) > 0 {
-		fmt.Printf("Encountered %d errors during processing\n", len(result.Errors))
+		log.Warn("errors during processing", "count", len(result.Errors))
+	}
+
+	if stream != nil && stream.err != nil {
+		return result, stream.err
+	}
+	if opts.Output != nil && stream == nil {
+		data, err := FormatBatch(opts.OutputFormat, result.Filings)
+		if err != nil {
+			return result, err
+		}
+		if _, err := opts.Output.Write(data); err != nil {
+			return result, fmt.Errorf("failed to write batch output: %w", err)
+		}
 	}
 
 	return result, nil
 }
+
+// filterByAccession returns the filings with the given accession numbers, in
+// filing order, and the accession numbers that matched none of them
+func filterByAccession(filings []Filing, accessions []string) (matched []Filing, missing []string) {
+	wanted := make(map[string]bool, len(accessions))
+	for _, accession := range accessions {
+		wanted[accession] = true
+	}
+	found := make(map[string]bool)
+	for _, f := range filings {
+		if wanted[f.AccessionNumber] && !found[f.AccessionNumber] {
+			found[f.AccessionNumber] = true
+			matched = append(matched, f)
+		}
+	}
+	for _, accession := range accessions {
+		if !found[accession] {
+			missing = append(missing, accession)
+			found[accession] = true // Report duplicates once
+		}
+	}
+	return matched, missing
+}
+
+// batchOutcome is the result of processing one filing in a batch
+type batchOutcome struct {
+	parsed   *ParsedForm
+	err      error
+	done     bool // Processed, or restored from the checkpoint
+	filtered bool // Omitted by the transaction filter
+}
+
+// filterParsed applies the batch's transaction filter to a Form 4 and reports
+// whether the filing is kept; other forms are always kept
+func filterParsed(parsed *ParsedForm, opts BatchOptions) bool {
+	if opts.TransactionFilter.IsZero() {
+		return true
+	}
+	if f4, ok := parsed.AsForm4Output(); ok {
+		return f4.FilterTransactions(opts.TransactionFilter)
+	}
+	return true
+}
+
+// annotateParsed adds the filing's metadata to a parsed form based on its type
+func annotateParsed(parsed *ParsedForm, filing Filing, opts BatchOptions) {
+	switch data := parsed.Data.(type) {
+	case *Form4Output:
+		data.SetSource(filing.URL)
+		data.SetFilingMetadata(filing.AccessionNumber, filing.FilingDate, filing.ReportDate)
+		if opts.ResolveFootnotes {
+			data.ResolveFootnotes()
+		}
+	case *Schedule13Filing:
+		data.FilingDate = filing.FilingDate
+		data.AccessionNumber = filing.AccessionNumber
+		// The index form type is authoritative: HTML documents rarely state the
+		// amendment in a form the detector can see
+		if form := normalizeFormType(filing.Form); strings.HasPrefix(form, "SC 13") {
+			parsed.FormType = form
+			data.FormType = form
+			data.IsAmendment = strings.Contains(form, "/A")
+			if !data.IsAmendment {
+				data.AmendmentNumber = nil
+			}
+		}
+	case *FinancialSnapshot:
+		// The cover page (DEI) has no filing date or accession number
+		data.FilingDate = filing.FilingDate
+		data.AccessionNumber = filing.AccessionNumber
+	}
+}
+
+// batchStream hands completed filings to emit (an NDJSON encoder or the
+// FetchAndParseBatchStream callback) in filing order
+// Callers serialize calls to done
+type batchStream struct {
+	emit     func(*ParsedForm, Filing) error
+	filings  []Filing
+	outcomes []batchOutcome
+	ready    []bool
+	next     int   // Index of the next filing to write
+	err      error // First write error; later filings are not written
+}
+
+func newBatchStream(emit func(*ParsedForm, Filing) error, filings []Filing, outcomes []batchOutcome) *batchStream {
+	return &batchStream{emit: emit, filings: filings, outcomes: outcomes, ready: make([]bool, len(outcomes))}
+}
+
+// done marks filing i as processed and writes every consecutive ready filing
+func (s *batchStream) done(i int) {
+	s.ready[i] = true
+	for s.next < len(s.ready) && s.ready[s.next] {
+		o := &s.outcomes[s.next]
+		if o.err == nil && !o.filtered && s.err == nil {
+			s.err = s.emit(o.parsed, s.filings[s.next])
+		}
+		o.parsed = nil // Release the parsed form once written
+		s.next++
+	}
+}
+
+// FetchFiling downloads and parses one filing from a submissions list (see
+// Submissions.GetRecentFilings), with its filing metadata set as in a batch
+func (c *Client) FetchFiling(filing Filing) (*ParsedForm, error) {
+	return c.fetchFiling(filing, BatchOptions{})
+}
+
+// fetchFiling is FetchFiling with batch options (e.g. a concept profile)
+func (c *Client) fetchFiling(filing Filing, opts BatchOptions) (*ParsedForm, error) {
+	parsed, err := fetchAndParse(c, filing, opts)
+	if err != nil {
+		return nil, err
+	}
+	annotateParsed(parsed, filing, opts)
+	return parsed, nil
+}
+
+// fetchAndParse loads a filing and parses it with ParseAnyWithOptions
+// XBRL filings that are not being archived are streamed straight into the
+// inline XBRL parser instead of being buffered in memory. Filings from before
+// inline XBRL have a plain HTML primary document, so their facts are read
+// from the XBRL archive instead, which is also what the store keeps for them.
+func fetchAndParse(client *Client, filing Filing, opts BatchOptions) (*ParsedForm, error) {
+	if isXBRLForm(filing.Form) && (filing.IsXBRL && !filing.IsInlineXBRL || isXBRLArchive(filing)) {
+		return loadXBRLArchive(client, filing, opts)
+	}
+	if opts.Store == nil && isXBRLForm(filing.Form) {
+		return streamXBRL(client, filing, opts.Profile)
+	}
+
+	// Fetch the XML (from the local store when available, otherwise from SEC)
+	xmlData, err := loadFiling(client, filing, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := ParseAnyWithOptions(bytes.NewReader(xmlData), ParseOptions{Profile: opts.Profile, Strict: opts.Strict})
+	if err != nil {
+		return nil, &ParseError{AccessionNumber: filing.AccessionNumber, FormType: filing.Form, Err: err}
+	}
+	return parsed, nil
+}
+
+// streamXBRL fetches an inline XBRL document and parses it as it downloads
+func streamXBRL(client *Client, filing Filing, profile string) (*ParsedForm, error) {
+	body, err := client.FetchFormStream(filing.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", filing.AccessionNumber, err)
+	}
+	defer body.Close()
+
+	xbrl, err := ParseInlineXBRLReader(body)
+	if err != nil {
+		return nil, &ParseError{AccessionNumber: filing.AccessionNumber, FormType: filing.Form, Err: fmt.Errorf("failed to parse XBRL: %w", err)}
+	}
+	if len(xbrl.Facts) == 0 {
+		// Pre-iXBRL filings are plain HTML
+		return nil, &ParseError{AccessionNumber: filing.AccessionNumber, FormType: filing.Form, Err: fmt.Errorf("no inline XBRL facts found: %w", ErrNotXBRL)}
+	}
+
+	parsed, err := xbrlSnapshotForm(xbrl, profile)
+	if err != nil {
+		return nil, &ParseError{AccessionNumber: filing.AccessionNumber, FormType: filing.Form, Err: err}
+	}
+	return parsed, nil
+}
+
+// loadXBRLArchive loads a filing's XBRL archive, from the store when
+// available, and builds its snapshot
+func loadXBRLArchive(client *Client, filing Filing, opts BatchOptions) (*ParsedForm, error) {
+	// Stored under the archive's name, next to where the primary document would be
+	archive := filing
+	if !isXBRLArchive(filing) {
+		archive.URL = XBRLZipURL(filing.CIK, filing.AccessionNumber)
+	}
+	data, err := loadFiling(client, archive, opts)
+	if err != nil {
+		return nil, err
+	}
+	xbrl, err := ParseXBRLZip(data)
+	if err != nil {
+		return nil, &ParseError{AccessionNumber: filing.AccessionNumber, FormType: filing.Form, Err: err}
+	}
+	parsed, err := xbrlSnapshotForm(xbrl, opts.Profile)
+	if err != nil {
+		return nil, &ParseError{AccessionNumber: filing.AccessionNumber, FormType: filing.Form, Err: err}
+	}
+	return parsed, nil
+}
+
+// isXBRLArchive reports whether a filing's document is its XBRL archive, as
+// for pre-inline XBRL filings listed from the store
+func isXBRLArchive(filing Filing) bool {
+	return strings.HasSuffix(filing.URL, "-xbrl.zip")
+}
+
+// isXBRLForm reports whether a form type's primary document is inline XBRL
+// (20-F, 40-F and 6-K for foreign private issuers, though many 6-K reports carry
+// no XBRL)
+func isXBRLForm(form string) bool {
+	switch strings.TrimSuffix(form, "/A") {
+	case "10-K", "10-Q", "20-F", "40-F", "6-K":
+		return true
+	}
+	return false
+}
+
+// loadFiling returns a filing's document from the store if present, otherwise
+// downloads it from SEC (rate limited by the Client) and saves it to the store
+func loadFiling(client *Client, filing Filing, opts BatchOptions) ([]byte, error) {
+	if opts.Store != nil {
+		data, ok, err := opts.Store.Get(filing)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return data, nil
+		}
+	}
+
+	if opts.Offline {
+		return nil, fmt.Errorf("filing %s %w", filing.AccessionNumber, errNotInStore)
+	}
+
+	data, err := client.FetchForm(filing.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", filing.AccessionNumber, err)
+	}
+
+	if opts.Store != nil {
+		if err := opts.Store.Put(filing, data); err != nil {
+			return nil, fmt.Errorf("failed to store %s: %w", filing.AccessionNumber, err)
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,621 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFetchAndParseBatch_ConcurrentOrder(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	for day := 1; day <= 12; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+			URL:             fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/1640147/%d/ownership.xml", day),
+		}
+		if err := store.Put(filing, data); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	var logs bytes.Buffer
+	result, err := FetchAndParseBatch(BatchOptions{
+		CIK:         "1640147",
+		FormType:    "4",
+		Store:       store,
+		Offline:     true,
+		Concurrency: 4,
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if result.Fetched != 12 {
+		t.Fatalf("Expected 12 parsed filings, got %d (errors: %v)", result.Fetched, result.Errors)
+	}
+
+	// Output order must follow the filing list (newest first), not completion order
+	for i, parsed := range result.Filings {
+		want := fmt.Sprintf("0001640147-25-%06d", 12-i)
+		if got := parsed.Data.(*Form4Output).Metadata.AccessionNumber; got != want {
+			t.Errorf("Filing %d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	// Progress goes to the configured logger, not stdout
+	if !strings.Contains(logs.String(), `msg="batch complete" parsed=12 total=12`) {
+		t.Errorf("Expected completion message in logs, got:\n%s", logs.String())
+	}
+}
+
+func TestFetchAndParseBatch_Progress(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	for day := 1; day <= 5; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+		}
+		if err := store.Put(filing, data); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	var logs bytes.Buffer
+	var calls [][2]int
+	_, err = FetchAndParseBatch(BatchOptions{
+		CIK:         "1640147",
+		FormType:    "4",
+		Store:       store,
+		Offline:     true,
+		Concurrency: 3,
+		Logger:      slog.New(slog.NewTextHandler(&logs, nil)),
+		Progress: func(completed, total int) {
+			calls = append(calls, [2]int{completed, total})
+		},
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+
+	if len(calls) != 6 {
+		t.Fatalf("Expected 6 progress calls (start + 5 filings), got %v", calls)
+	}
+	for i, c := range calls {
+		if c != [2]int{i, 5} {
+			t.Errorf("Progress call %d = %v, want [%d 5]", i, c, i)
+		}
+	}
+	// The progress callback replaces the periodic info message
+	if strings.Contains(logs.String(), "msg=progress") {
+		t.Errorf("Expected no progress log at info level, got:\n%s", logs.String())
+	}
+}
+
+func TestClientFetchFiling(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	filing := Filing{
+		CIK:             "1640147",
+		AccessionNumber: "0001640147-25-000001",
+		Form:            "4",
+		FilingDate:      "2025-03-03",
+		URL:             server.URL + "/ownership.xml",
+	}
+	parsed, err := NewClient("jane@acme.test").FetchFiling(filing)
+	if err != nil {
+		t.Fatalf("FetchFiling failed: %v", err)
+	}
+	f4, ok := parsed.Data.(*Form4Output)
+	if !ok {
+		t.Fatalf("Expected a Form 4, got %T", parsed.Data)
+	}
+	if f4.Metadata.AccessionNumber != filing.AccessionNumber || f4.Metadata.FilingDate != filing.FilingDate || f4.Metadata.Source != filing.URL {
+		t.Errorf("Expected the filing metadata to be set, got %+v", f4.Metadata)
+	}
+}
+
+// handlerTransport serves every request from a handler, whatever its host
+type handlerTransport struct{ handler http.Handler }
+
+func (t handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, r)
+	return rec.Result(), nil
+}
+
+// aaplInstance is a minimal standalone XBRL instance of a pre-inline XBRL 10-K
+const aaplInstance = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+  xmlns:us-gaap="http://fasb.org/us-gaap/2012" xmlns:dei="http://xbrl.sec.gov/dei/2012">
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">320193</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2012-09-30</xbrli:startDate><xbrli:endDate>2013-09-28</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <dei:DocumentPeriodEndDate contextRef="FY">2013-09-28</dei:DocumentPeriodEndDate>
+  <us-gaap:Revenues contextRef="FY" unitRef="usd" decimals="-6">170910000000</us-gaap:Revenues>
+</xbrli:xbrl>`
+
+func TestClientFetchFiling_XBRLArchive(t *testing.T) {
+	archive := buildZip(t, [2]string{"aapl-20130928.xml", aaplInstance})
+
+	var paths []string
+	client := NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: handlerTransport{http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !strings.HasSuffix(r.URL.Path, "-xbrl.zip") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(archive)
+	})}}
+
+	// Pre-inline XBRL: the primary document is plain HTML
+	filing := Filing{
+		CIK:             "320193",
+		AccessionNumber: "0001193125-13-416534",
+		Form:            "10-K",
+		FilingDate:      "2013-10-30",
+		IsXBRL:          true,
+		URL:             "https://www.sec.gov/Archives/edgar/data/320193/000119312513416534/d590790d10k.htm",
+	}
+	parsed, err := client.FetchFiling(filing)
+	if err != nil {
+		t.Fatalf("FetchFiling failed: %v (requested %v)", err, paths)
+	}
+	snap, ok := parsed.Data.(*FinancialSnapshot)
+	if !ok {
+		t.Fatalf("Expected a snapshot, got %T", parsed.Data)
+	}
+	if snap.Revenue == nil || *snap.Revenue != 170910e6 {
+		t.Errorf("Expected revenue from the archive's instance, got %v", snap.Revenue)
+	}
+	if snap.FilingDate != filing.FilingDate {
+		t.Errorf("Expected filing date %s, got %s", filing.FilingDate, snap.FilingDate)
+	}
+	if want := "/Archives/edgar/data/320193/000119312513416534/0001193125-13-416534-xbrl.zip"; len(paths) != 1 || paths[0] != want {
+		t.Errorf("Expected one request for %s, got %v", want, paths)
+	}
+}
+
+func TestFetchAndParseBatch_XBRLArchiveStore(t *testing.T) {
+	archive := buildZip(t, [2]string{"aapl-20130928.xml", aaplInstance})
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	var paths []string
+	client := NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: handlerTransport{http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/submissions/"):
+			fmt.Fprint(w, `{"cik": "320193", "name": "Apple Inc.", "sic": "3571", "filings": {"recent": {"form": ["10-K"], "filingDate": ["2013-10-30"], "accessionNumber": ["0001193125-13-416534"], "primaryDocument": ["d590790d10k.htm"], "isXBRL": [1], "isInlineXBRL": [0]}}}`)
+		case strings.HasSuffix(r.URL.Path, "-xbrl.zip"):
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	})}}
+
+	revenue := func(result *BatchResult) *float64 {
+		t.Helper()
+		if result.Fetched != 1 || len(result.Errors) != 0 {
+			t.Fatalf("Expected 1 parsed filing and no errors, got %d and %v", result.Fetched, result.Errors)
+		}
+		snap, ok := result.Filings[0].Data.(*FinancialSnapshot)
+		if !ok {
+			t.Fatalf("Expected a snapshot, got %T", result.Filings[0].Data)
+		}
+		return snap.Revenue
+	}
+
+	// The archive is stored in place of the plain HTML primary document
+	opts := BatchOptions{CIK: "320193", FormType: "10-K", Client: client, Store: store}
+	for run := 1; run <= 2; run++ {
+		result, err := FetchAndParseBatch(opts)
+		if err != nil {
+			t.Fatalf("Batch run %d failed: %v", run, err)
+		}
+		if v := revenue(result); v == nil || *v != 170910e6 {
+			t.Errorf("Run %d: expected revenue from the archive, got %v", run, v)
+		}
+	}
+	var archiveRequests int
+	for _, p := range paths {
+		if strings.HasSuffix(p, "-xbrl.zip") {
+			archiveRequests++
+		}
+	}
+	if archiveRequests != 1 {
+		t.Errorf("Expected the archive to be downloaded once, got %d requests (%v)", archiveRequests, paths)
+	}
+	entries, err := store.Manifest()
+	if err != nil || len(entries) != 1 || entries[0].Path != "320193/2013/0001193125-13-416534/0001193125-13-416534-xbrl.zip" {
+		t.Fatalf("Expected the archive in the manifest, got %+v (%v)", entries, err)
+	}
+
+	// Offline, the archive is read back from the store
+	result, err := FetchAndParseBatch(BatchOptions{CIK: "320193", FormType: "10-K", Store: store, Offline: true})
+	if err != nil {
+		t.Fatalf("Offline batch failed: %v", err)
+	}
+	if v := revenue(result); v == nil || *v != 170910e6 {
+		t.Errorf("Offline: expected revenue from the stored archive, got %v", v)
+	}
+}
+
+func TestFetchAndParseBatch_Resume(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFilingStore(dir + "/store")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	var filings []Filing
+	for day := 1; day <= 3; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+			URL:             fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/1640147/%d/ownership.xml", day),
+		}
+		content := data
+		if day == 2 {
+			content = []byte("not a filing") // Fails to parse on the first run
+		}
+		if err := store.Put(filing, content); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+		filings = append(filings, filing)
+	}
+
+	opts := BatchOptions{
+		CIK:            "1640147",
+		FormType:       "4",
+		Store:          store,
+		Offline:        true,
+		CheckpointPath: dir + "/batch.checkpoint",
+		Logger:         DiscardLogger(),
+	}
+
+	first, err := FetchAndParseBatch(opts)
+	if err != nil {
+		t.Fatalf("First run failed: %v", err)
+	}
+	if first.Fetched != 2 || len(first.Errors) != 1 {
+		t.Fatalf("Expected 2 parsed and 1 error, got %d and %v", first.Fetched, first.Errors)
+	}
+
+	// Fix the failed filing and remove the ones already processed: a resumed
+	// run must restore those from the checkpoint and only retry the failure
+	if err := store.Put(filings[1], data); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	for _, i := range []int{0, 2} {
+		if err := os.Remove(dir + "/store/" + store.RelPath(filings[i])); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+	}
+
+	opts.Resume = true
+	resumed, err := FetchAndParseBatch(opts)
+	if err != nil {
+		t.Fatalf("Resumed run failed: %v", err)
+	}
+	if resumed.Fetched != 3 || len(resumed.Errors) != 0 {
+		t.Fatalf("Expected 3 parsed and no errors, got %d and %v", resumed.Fetched, resumed.Errors)
+	}
+	for i, parsed := range resumed.Filings {
+		f4, ok := parsed.Data.(*Form4Output)
+		if !ok {
+			t.Fatalf("Filing %d: expected *Form4Output, got %T", i, parsed.Data)
+		}
+		if want := fmt.Sprintf("0001640147-25-%06d", 3-i); f4.Metadata.AccessionNumber != want {
+			t.Errorf("Filing %d: expected %s, got %s", i, want, f4.Metadata.AccessionNumber)
+		}
+	}
+
+	// A checkpoint from a different batch must not be reused
+	opts.FormType = "13D"
+	if _, err := FetchAndParseBatch(opts); err == nil {
+		t.Error("Expected error resuming a checkpoint from a different batch")
+	}
+}
+
+func TestFetchAndParseBatch_ResumeTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFilingStore(dir + "/store")
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	var filings []Filing
+	for day := 1; day <= 2; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+			URL:             fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/1640147/%d/ownership.xml", day),
+		}
+		content := data
+		if day == 2 {
+			content = []byte("not a filing")
+		}
+		if err := store.Put(filing, content); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+		filings = append(filings, filing)
+	}
+
+	opts := BatchOptions{
+		CIK:            "1640147",
+		FormType:       "4",
+		Store:          store,
+		Offline:        true,
+		CheckpointPath: dir + "/batch.checkpoint",
+		Logger:         DiscardLogger(),
+	}
+	if _, err := FetchAndParseBatch(opts); err != nil {
+		t.Fatalf("First run failed: %v", err)
+	}
+
+	// The run is killed mid-record
+	f, err := os.OpenFile(opts.CheckpointPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open checkpoint: %v", err)
+	}
+	f.WriteString(`{"accessionNumber":"0001640147-25-0000`)
+	f.Close()
+
+	// The first resume retries the failure; the second must restore it from
+	// the record written after the torn line, with the store gone
+	if err := store.Put(filings[1], data); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	opts.Resume = true
+	for run := 1; run <= 2; run++ {
+		resumed, err := FetchAndParseBatch(opts)
+		if err != nil {
+			t.Fatalf("Resume %d failed: %v", run, err)
+		}
+		if resumed.Fetched != 2 || len(resumed.Errors) != 0 {
+			t.Fatalf("Resume %d: expected 2 parsed and no errors, got %d and %v", run, resumed.Fetched, resumed.Errors)
+		}
+		if err := os.RemoveAll(dir + "/store/1640147"); err != nil {
+			t.Fatalf("RemoveAll failed: %v", err)
+		}
+	}
+}
+
+func TestFetchAndParseBatch_Accessions(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	for day := 1; day <= 4; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+		}
+		if err := store.Put(filing, data); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	// Accessions select filings regardless of form type and date range
+	result, err := FetchAndParseBatch(BatchOptions{
+		CIK:        "1640147",
+		DateFrom:   "2026-01-01",
+		Accessions: []string{"0001640147-25-000001", "0001640147-25-000099", "0001640147-25-000003"},
+		Store:      store,
+		Offline:    true,
+		Logger:     DiscardLogger(),
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if result.TotalFound != 2 || result.Fetched != 2 {
+		t.Fatalf("Expected 2 filings found and parsed, got %d and %d", result.TotalFound, result.Fetched)
+	}
+	for i, want := range []string{"0001640147-25-000003", "0001640147-25-000001"} {
+		if got := result.Filings[i].Data.(*Form4Output).Metadata.AccessionNumber; got != want {
+			t.Errorf("Filing %d: expected %s, got %s", i, want, got)
+		}
+	}
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error for the unknown accession, got %v", result.Errors)
+	}
+	if ErrorCategory(result.Errors[0]) != CategoryNotFound {
+		t.Errorf("Expected category %s, got %s", CategoryNotFound, ErrorCategory(result.Errors[0]))
+	}
+	var filingErr *FilingError
+	if !errors.As(result.Errors[0], &filingErr) || filingErr.AccessionNumber != "0001640147-25-000099" {
+		t.Errorf("Expected a FilingError for 0001640147-25-000099, got %v", result.Errors[0])
+	}
+}
+
+func TestFetchAndParseBatchStream(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	for day := 1; day <= 6; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+		}
+		content := data
+		if day == 4 {
+			content = []byte("not a filing")
+		}
+		if err := store.Put(filing, content); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	opts := BatchOptions{CIK: "1640147", FormType: "4", Store: store, Offline: true, Concurrency: 3, Logger: DiscardLogger()}
+
+	// Filings arrive in filing order (newest first) with their index entry;
+	// the failed one is only in the errors
+	var got []string
+	result, err := FetchAndParseBatchStream(opts, func(parsed *ParsedForm, filing Filing) error {
+		if parsed.GetAccession() != filing.AccessionNumber {
+			t.Errorf("Callback got %s with filing %s", parsed.GetAccession(), filing.AccessionNumber)
+		}
+		got = append(got, filing.AccessionNumber)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	want := []string{"0001640147-25-000006", "0001640147-25-000005", "0001640147-25-000003", "0001640147-25-000002", "0001640147-25-000001"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Callback order = %v, want %v", got, want)
+	}
+	if result.Fetched != 5 || len(result.Errors) != 1 || len(result.Filings) != 0 {
+		t.Errorf("Expected 5 parsed, 1 error and no collected filings, got %d, %v and %d", result.Fetched, result.Errors, len(result.Filings))
+	}
+
+	// A callback error stops the batch
+	errStop := errors.New("database unavailable")
+	calls := 0
+	opts.Concurrency = 1
+	result, err = FetchAndParseBatchStream(opts, func(*ParsedForm, Filing) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Expected the callback error, got %v", err)
+	}
+	if calls != 2 || result.Fetched >= 5 {
+		t.Errorf("Expected the batch to stop after the failing callback, got %d calls and %d parsed", calls, result.Fetched)
+	}
+
+	opts.Output = &bytes.Buffer{}
+	if _, err := FetchAndParseBatchStream(opts, func(*ParsedForm, Filing) error { return nil }); err == nil {
+		t.Error("Expected an error when Output is set")
+	}
+}
+
+func TestFetchAndParseBatch_NDJSONStream(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	for day := 1; day <= 6; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+			URL:             fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/1640147/%d/ownership.xml", day),
+		}
+		if err := store.Put(filing, data); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	result, err := FetchAndParseBatch(BatchOptions{
+		CIK:          "1640147",
+		FormType:     "4",
+		Store:        store,
+		Offline:      true,
+		Concurrency:  3,
+		Logger:       DiscardLogger(),
+		Output:       &out,
+		OutputFormat: "ndjson",
+	})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if result.Fetched != 6 {
+		t.Fatalf("Expected 6 parsed filings, got %d (errors: %v)", result.Fetched, result.Errors)
+	}
+	if len(result.Filings) != 0 {
+		t.Errorf("Streamed filings should not be retained, got %d", len(result.Filings))
+	}
+
+	// One filing per line, in filing order (newest first)
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		var form Form4Output
+		if err := json.Unmarshal([]byte(line), &form); err != nil {
+			t.Fatalf("Line %d is not valid JSON: %v", i, err)
+		}
+		want := fmt.Sprintf("0001640147-25-%06d", 6-i)
+		if form.Metadata.AccessionNumber != want {
+			t.Errorf("Line %d: expected %s, got %s", i, want, form.Metadata.AccessionNumber)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FormatOptions configures how parsed forms are encoded (see FormatFormWithOptions)
+type FormatOptions struct {
+	// Canonical makes JSON and NDJSON output deterministic: object keys are
+	// sorted, empty lists are always [] and empty maps {} (never null), and
+	// HTML characters are not escaped. Semantically identical results then
+	// encode to identical bytes, so golden files and diffs don't churn.
+	// CSV, Parquet and Excel output is unaffected.
+	Canonical bool
+}
+
+// FormatFormWithOptions is FormatForm with options (see FormatOptions)
+func FormatFormWithOptions(format string, form *ParsedForm, opts FormatOptions) ([]byte, error) {
+	if opts.Canonical && (format == "" || format == "json") {
+		return CanonicalJSON(form.outputForm())
+	}
+	if format == "" || format == "json" {
+		return FormatForm(format, form)
+	}
+	return FormatBatchWithOptions(format, []*ParsedForm{form}, opts)
+}
+
+// FormatBatchWithOptions is FormatBatch with options (see FormatOptions)
+func FormatBatchWithOptions(format string, filings []*ParsedForm, opts FormatOptions) ([]byte, error) {
+	if !opts.Canonical {
+		return FormatBatch(format, filings)
+	}
+	switch format {
+	case "", "json":
+		data := make([]any, len(filings))
+		for i, f := range filings {
+			data[i] = f.outputForm().Data
+		}
+		return CanonicalJSON(data)
+	case "ndjson":
+		var buf bytes.Buffer
+		enc := NewNDJSONEncoder(&buf)
+		enc.SetCanonical(true)
+		for _, f := range filings {
+			if err := enc.Encode(f); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	}
+	return FormatBatch(format, filings)
+}
+
+// CanonicalJSON returns pretty-printed canonical JSON for v (see
+// FormatOptions.Canonical)
+func CanonicalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeCanonical(&buf, v, "  "); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// encodeCanonical writes v as canonical JSON followed by a newline, indented
+// unless indent is ""
+func encodeCanonical(buf *bytes.Buffer, v any, indent string) error {
+	tree, err := canonicalValue(reflect.ValueOf(v))
+	if err != nil {
+		return fmt.Errorf("failed to marshal canonical JSON: %w", err)
+	}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(tree); err != nil {
+		return fmt.Errorf("failed to marshal canonical JSON: %w", err)
+	}
+	return nil
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// canonicalValue converts a value to what encoding/json would encode it as,
+// built from maps (whose keys encoding/json sorts), slices and scalars, with
+// nil slices and maps made empty
+func canonicalValue(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Type().Implements(jsonMarshalerType) && (v.Kind() != reflect.Pointer || !v.IsNil()) {
+		return canonicalMarshaler(v.Interface().(json.Marshaler))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return canonicalValue(v.Elem())
+	case reflect.Struct:
+		obj := make(map[string]any)
+		if err := canonicalFields(v, obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case reflect.Map:
+		obj := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, err := canonicalValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			obj[fmt.Sprint(iter.Key().Interface())] = value // String and integer keys
+		}
+		return obj, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), nil // Base64, as encoding/json
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			item, err := canonicalValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			list[i] = item
+		}
+		return list, nil
+	}
+	return v.Interface(), nil
+}
+
+// canonicalFields adds a struct's JSON fields to obj, following the
+// encoding/json rules for tags, omitempty and (exported) embedded structs,
+// whose fields come after the struct's own
+func canonicalFields(v reflect.Value, obj map[string]any) error {
+	typ := v.Type()
+	var embedded []reflect.Value
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Anonymous && name == "" {
+			if fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && isEmptyJSONValue(fv) {
+			continue
+		}
+		value, err := canonicalValue(fv)
+		if err != nil {
+			return err
+		}
+		obj[name] = value
+	}
+	for _, fv := range embedded {
+		inner := make(map[string]any)
+		if err := canonicalFields(fv, inner); err != nil {
+			return err
+		}
+		for name, value := range inner {
+			if _, ok := obj[name]; !ok { // Shallower fields win
+				obj[name] = value
+			}
+		}
+	}
+	return nil
+}
+
+// canonicalMarshaler decodes a value's own JSON encoding so its objects are
+// sorted too
+func canonicalMarshaler(m json.Marshaler) (any, error) {
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// isEmptyJSONValue reports whether omitempty drops a value
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
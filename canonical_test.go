@@ -0,0 +1,126 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	forms := []*ParsedForm{
+		parseTestForm(t, "testdata/form4/snow/input.xml"),
+		parseTestForm(t, "testdata/schedule13/13d_2024_1/input.htm"),
+		parseTestForm(t, "testdata/schedule13/jushi_13g_xml/input.xml"),
+		parseTestForm(t, "testdata/xbrl/moderna_10k/input.htm"),
+	}
+	for _, form := range forms {
+		regular, err := FormatJSON(form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		canonical, err := FormatFormWithOptions("json", form, FormatOptions{Canonical: true})
+		if err != nil {
+			t.Fatalf("%s: %v", form.FormType, err)
+		}
+
+		// The same values, with empty lists and maps instead of nulls
+		var want, got any
+		json.Unmarshal(regular, &want)
+		json.Unmarshal(canonical, &got)
+		if err := sameJSONValues(want, got, ""); err != nil {
+			t.Errorf("%s: %v", form.FormType, err)
+		}
+		if bytes.Contains(canonical, []byte(`<`)) || bytes.Contains(canonical, []byte(`&`)) {
+			t.Errorf("%s: HTML characters are escaped", form.FormType)
+		}
+
+		// Already sorted: re-encoding the decoded output changes nothing
+		again, err := CanonicalJSON(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, canonical) {
+			t.Errorf("%s: canonical output is not stable", form.FormType)
+		}
+
+		result, err := VerifyOutput(bytes.NewReader(canonical))
+		if err != nil || !result.OK() {
+			t.Errorf("%s: VerifyOutput = %+v, %v", form.FormType, result, err)
+		}
+	}
+
+	// Nil and empty slices encode the same
+	a := &Form4Output{Footnotes: nil}
+	b := &Form4Output{Footnotes: []FootnoteOutput{}}
+	ja, _ := CanonicalJSON(a)
+	jb, _ := CanonicalJSON(b)
+	if !bytes.Equal(ja, jb) || !bytes.Contains(ja, []byte(`"footnotes": []`)) {
+		t.Errorf("nil and empty footnotes encode differently:\n%s\n%s", ja, jb)
+	}
+
+	// NDJSON: one compact canonical line per filing
+	ndjson, err := FormatBatchWithOptions("ndjson", forms, FormatOptions{Canonical: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(ndjson), "\n"), "\n")
+	if len(lines) != len(forms) {
+		t.Fatalf("Got %d NDJSON lines, want %d", len(lines), len(forms))
+	}
+	first, _ := CanonicalJSON(forms[0].Data)
+	var compact bytes.Buffer
+	json.Compact(&compact, first)
+	if lines[0] != compact.String() {
+		t.Errorf("NDJSON line differs from the canonical JSON")
+	}
+}
+
+// sameJSONValues compares decoded JSON, allowing null where got has an
+// empty list or object
+func sameJSONValues(want, got any, path string) error {
+	switch w := want.(type) {
+	case nil:
+		switch g := got.(type) {
+		case nil:
+			return nil
+		case []any:
+			if len(g) == 0 {
+				return nil
+			}
+		case map[string]any:
+			if len(g) == 0 {
+				return nil
+			}
+		}
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok || len(g) != len(w) {
+			break
+		}
+		for key, value := range w {
+			if err := sameJSONValues(value, g[key], path+"."+key); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			break
+		}
+		for i := range w {
+			if err := sameJSONValues(w[i], g[i], fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if reflect.DeepEqual(want, got) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: got %v, want %v", path, got, want)
+}
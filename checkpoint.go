@@ -0,0 +1,207 @@
+package edgar
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// checkpointHeader is the first line of a checkpoint file, identifying the batch it belongs to
+type checkpointHeader struct {
+	CIK      string `json:"cik"`
+	FormType string `json:"formType"`
+	DateFrom string `json:"dateFrom,omitempty"`
+	DateTo   string `json:"dateTo,omitempty"`
+}
+
+// checkpointRecord is one processed filing (appended as it completes)
+type checkpointRecord struct {
+	AccessionNumber string          `json:"accessionNumber"`
+	FormType        string          `json:"formType,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	Error           string          `json:"error,omitempty"`
+}
+
+// batchCheckpoint persists batch progress so an interrupted run can be resumed
+//
+// The file is JSON lines: a header followed by one record per processed filing.
+// Successful records carry the parsed output so a resumed run can return the
+// complete result without re-downloading. Failed filings are retried on resume.
+type batchCheckpoint struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]*ParsedForm // Accession -> parsed form restored from a previous run
+}
+
+// openCheckpoint creates (or, when resuming, loads and reopens) the checkpoint file
+func openCheckpoint(opts BatchOptions) (*batchCheckpoint, error) {
+	header := checkpointHeader{
+		CIK:      archiveCIK(opts.CIK),
+		FormType: opts.FormType,
+		DateFrom: opts.DateFrom,
+		DateTo:   opts.DateTo,
+	}
+	cp := &batchCheckpoint{done: make(map[string]*ParsedForm)}
+
+	if opts.Resume {
+		exists, err := cp.load(opts.CheckpointPath, header)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			f, err := os.OpenFile(opts.CheckpointPath, os.O_APPEND|os.O_RDWR, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+			}
+			if err := endTornLine(f); err != nil {
+				f.Close()
+				return nil, err
+			}
+			cp.file = f
+			return cp, nil
+		}
+	}
+
+	// Start a fresh checkpoint
+	if dir := filepath.Dir(opts.CheckpointPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+		}
+	}
+	f, err := os.Create(opts.CheckpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	cp.file = f
+	if err := cp.writeLine(header); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return cp, nil
+}
+
+// endTornLine ends a partially written last line (from an interrupted run)
+// with a newline, so the next record starts a line of its own
+func endTornLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	if _, err := f.Write([]byte{'\n'}); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// load reads a previous checkpoint; returns false if the file does not exist
+func (cp *batchCheckpoint) load(path string, want checkpointHeader) (bool, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	if !scanner.Scan() {
+		return false, nil // Empty file: start over
+	}
+	var header checkpointHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return false, fmt.Errorf("corrupt checkpoint header: %w", err)
+	}
+	if header != want {
+		return false, fmt.Errorf("checkpoint %s belongs to a different batch (CIK %s, form %s)", path, header.CIK, header.FormType)
+	}
+
+	for scanner.Scan() {
+		var rec checkpointRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue // A partially written last line from an interrupted run
+		}
+		if rec.Error != "" {
+			delete(cp.done, rec.AccessionNumber)
+			continue
+		}
+		parsed, err := decodeParsedForm(rec.FormType, rec.Data)
+		if err != nil {
+			continue // Re-process filings whose output can't be restored
+		}
+		cp.done[rec.AccessionNumber] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return true, nil
+}
+
+// record appends the outcome of one filing
+func (cp *batchCheckpoint) record(accession string, parsed *ParsedForm, procErr error) error {
+	rec := checkpointRecord{AccessionNumber: accession}
+	if procErr != nil {
+		rec.Error = procErr.Error()
+	} else {
+		data, err := json.Marshal(parsed.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal checkpoint record: %w", err)
+		}
+		rec.FormType = parsed.FormType
+		rec.Data = data
+	}
+	return cp.writeLine(rec)
+}
+
+func (cp *batchCheckpoint) writeLine(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint record: %w", err)
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if _, err := cp.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (cp *batchCheckpoint) Close() error {
+	return cp.file.Close()
+}
+
+// decodeParsedForm restores a ParsedForm's typed Data from its JSON output
+func decodeParsedForm(formType string, raw json.RawMessage) (*ParsedForm, error) {
+	var data interface{}
+	switch {
+	case formType == "4":
+		data = &Form4Output{}
+	case formType == "XBRL":
+		data = &FinancialSnapshot{}
+	case strings.HasPrefix(formType, "SC 13"):
+		data = &Schedule13Filing{}
+	default:
+		return nil, fmt.Errorf("unsupported form type %q", formType)
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, err
+	}
+	return &ParsedForm{FormType: formType, Data: data}, nil
+}
@@ -0,0 +1,264 @@
+package edgar
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client performs SEC requests with the required User-Agent and rate limiting
+//
+// The package-level fetch functions (FetchForm, FetchSubmissions, ...) use a
+// default Client built from the email argument, all sharing one rate limit;
+// each Client created directly has its own. Create a Client directly to
+// configure behavior such as throttle simulation. There is no default email:
+// requests fail unless Email is a valid address (see ValidateEmail).
+type Client struct {
+	Email string // Required: Email for SEC User-Agent header
+
+	// HTTPClient is used for all requests (proxy, TLS config, timeouts)
+	// nil uses a default client with a 30 second timeout
+	HTTPClient *http.Client
+
+	// RequestInterval is the minimum delay between requests, for a rate below
+	// the SEC maximum; 0 uses RateLimit (10 requests per second)
+	RequestInterval time.Duration
+
+	// SimulateThrottle fakes SEC throttling (429 with Retry-After) on a schedule
+	// without contacting the SEC for those requests. Use it to exercise retry,
+	// backoff and alerting before running against the real SEC. nil disables it.
+	SimulateThrottle *ThrottleSimulation
+
+	// Requests made through this Client, including concurrent ones, share
+	// its rate limit
+	limitOnce sync.Once
+	limiter   *rateLimiter
+}
+
+// NewClient creates a Client for the given contact email
+func NewClient(email string) *Client {
+	return &Client{Email: email}
+}
+
+// defaultClient is the Client behind the package-level fetch functions; they
+// share one rate limit across calls
+func defaultClient(email string) *Client {
+	return &Client{Email: email, limiter: defaultLimiter}
+}
+
+// rateLimiter returns the Client's limiter, creating it on first use
+func (c *Client) rateLimiter() *rateLimiter {
+	c.limitOnce.Do(func() {
+		if c.limiter == nil {
+			c.limiter = &rateLimiter{}
+		}
+	})
+	return c.limiter
+}
+
+// HTTPStatusError is returned when the SEC responds with a non-200 status
+type HTTPStatusError struct {
+	StatusCode int
+	URL        string
+	RetryAfter time.Duration // From the Retry-After header (0 if absent)
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("SEC returned status %d", e.StatusCode)
+}
+
+// Is matches ErrRateLimited for 429 and ErrNotFound for 404 responses
+func (e *HTTPStatusError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// ThrottleSimulation describes a schedule of fake throttling responses
+//
+// Requests are counted per Client: after every Every requests are let through,
+// the next Burst requests receive 429 Too Many Requests with a Retry-After
+// header. For example {Every: 10, Burst: 2} throttles requests 11-12, 23-24, ...
+type ThrottleSimulation struct {
+	Every      int           // Requests allowed through between throttle bursts (must be > 0)
+	Burst      int           // Consecutive 429 responses per burst (default: 1)
+	RetryAfter time.Duration // Retry-After advertised on 429s (default: 1s, rounded up to whole seconds)
+
+	mu    sync.Mutex
+	count int
+}
+
+// next reports whether the next request should be throttled
+func (s *ThrottleSimulation) next() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Every <= 0 {
+		return false
+	}
+	burst := s.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+
+	pos := s.count % (s.Every + burst)
+	s.count++
+	return pos >= s.Every
+}
+
+// retryAfterSeconds returns the Retry-After header value in whole seconds
+func (s *ThrottleSimulation) retryAfterSeconds() int {
+	if s.RetryAfter <= 0 {
+		return 1
+	}
+	return int((s.RetryAfter + time.Second - 1) / time.Second)
+}
+
+// throttleTransport answers scheduled requests with a fake 429 instead of sending them
+type throttleTransport struct {
+	sim  *ThrottleSimulation
+	next http.RoundTripper
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.sim.next() {
+		return t.next.RoundTrip(req)
+	}
+
+	body := "Request Rate Threshold Exceeded (simulated)"
+	header := make(http.Header)
+	header.Set("Retry-After", strconv.Itoa(t.sim.retryAfterSeconds()))
+	header.Set("Content-Type", "text/plain")
+	return &http.Response{
+		Status:        "429 Too Many Requests",
+		StatusCode:    http.StatusTooManyRequests,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// httpClient returns the *http.Client used for requests
+func (c *Client) httpClient() *http.Client {
+	client := c.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	if c.SimulateThrottle != nil {
+		// Wrap a copy so the caller's client is left untouched
+		wrapped := *client
+		next := wrapped.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		wrapped.Transport = &throttleTransport{sim: c.SimulateThrottle, next: next}
+		return &wrapped
+	}
+	return client
+}
+
+// FetchForm fetches a filing document from the SEC by URL
+// A download cut short (see ErrTruncatedDownload) is an error, so it can be
+// retried rather than failing to parse
+func (c *Client) FetchForm(url string) ([]byte, error) {
+	body, err := c.FetchFormStream(url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	// Read response
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if err := checkComplete(data, url); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// FetchFormStream fetches a filing document and returns the body unread
+// Reads fail with ErrTruncatedDownload if the body ends before Content-Length
+// The caller must close the returned reader
+func (c *Client) FetchFormStream(url string) (io.ReadCloser, error) {
+	return c.get(url)
+}
+
+// get performs a rate-limited GET against sec.gov / data.sec.gov with the
+// required User-Agent. Accept-Encoding is left unset so the transport requests
+// gzip and decompresses the response transparently
+// The caller must close the returned reader
+func (c *Client) get(url string) (io.ReadCloser, error) {
+	// Every request carries the caller's own contact email
+	if err := ValidateEmail(c.Email); err != nil {
+		return nil, err
+	}
+
+	// Rate limiting
+	interval := RateLimit
+	if c.RequestInterval > interval {
+		interval = c.RequestInterval
+	}
+	c.rateLimiter().wait(interval)
+
+	// Create request
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required User-Agent header with email
+	req.Header.Set("User-Agent", BuildUserAgent(c.Email))
+
+	// Execute request
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	// Check status
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			URL:        url,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	// ContentLength is -1 when the transport decompressed the body; a cut-off
+	// gzip stream then surfaces as io.ErrUnexpectedEOF
+	return &lengthCheckedBody{ReadCloser: resp.Body, url: url, want: resp.ContentLength}, nil
+}
+
+// parseRetryAfter parses a Retry-After header (delay in seconds or an HTTP date)
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package edgar_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/RxDataLab/go-edgar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_SimulateThrottle verifies fake 429s follow the schedule and never reach the server
+func TestClient_SimulateThrottle(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	client := edgar.NewClient("jane@acme.test")
+	client.SimulateThrottle = &edgar.ThrottleSimulation{Every: 2, Burst: 1, RetryAfter: 30 * time.Second}
+
+	var throttled []int
+	for i := 1; i <= 6; i++ {
+		_, err := client.FetchForm(server.URL)
+		if err == nil {
+			continue
+		}
+
+		var statusErr *edgar.HTTPStatusError
+		require.True(t, errors.As(err, &statusErr), "unexpected error: %v", err)
+		assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode)
+		assert.Equal(t, 30*time.Second, statusErr.RetryAfter)
+		throttled = append(throttled, i)
+	}
+
+	assert.Equal(t, []int{3, 6}, throttled)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&hits))
+}
+
+// TestClient_StatusError verifies real non-200 responses surface as HTTPStatusError
+func TestClient_StatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := edgar.NewClient("jane@acme.test").FetchForm(server.URL)
+
+	var statusErr *edgar.HTTPStatusError
+	require.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, 10*time.Minute, statusErr.RetryAfter)
+	assert.Equal(t, server.URL, statusErr.URL)
+	assert.EqualError(t, err, "SEC returned status 429")
+}
+
+// TestClient_RequiresEmail verifies requests without valid contact info never reach the SEC
+func TestClient_RequiresEmail(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer server.Close()
+
+	_, err := edgar.NewClient("").FetchForm(server.URL)
+	assert.EqualError(t, err, "email is required for SEC requests")
+	_, err = edgar.FetchForm(server.URL, "jane")
+	assert.EqualError(t, err, "invalid email format: jane")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&hits))
+
+	var userAgent string
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	})
+	_, err = edgar.FetchForm(server.URL, "jane@acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, edgar.BuildUserAgent("jane@acme.test"), userAgent)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// TestClient_HTTPClient verifies all fetchers use the injected *http.Client
+func TestClient_HTTPClient(t *testing.T) {
+	var urls []string
+	client := edgar.NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		urls = append(urls, r.URL.String())
+		body := `{"cik": "78003", "name": "PFIZER INC", "filings": {"recent": {}, "files": []}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	})}
+
+	subs, err := client.FetchSubmissions("78003")
+	require.NoError(t, err)
+	assert.Equal(t, "PFIZER INC", subs.Name)
+
+	_, err = client.FetchForm("https://www.sec.gov/Archives/edgar/data/78003/x/ownership.xml")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"https://data.sec.gov/submissions/CIK0000078003.json",
+		"https://www.sec.gov/Archives/edgar/data/78003/x/ownership.xml",
+	}, urls)
+}
+
+// TestClient_TruncatedDownload verifies short bodies and unterminated documents are retryable errors
+func TestClient_TruncatedDownload(t *testing.T) {
+	const doc = `<?xml version="1.0"?>
+<ownershipDocument>
+  <schemaVersion>X0508</schemaVersion>
+</ownershipDocument>
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/short.xml":
+			// The connection closes after half the advertised length
+			w.Header().Set("Content-Length", "1000")
+			io.WriteString(w, doc[:40])
+		case "/cut.xml":
+			io.WriteString(w, doc[:60])
+		case "/page.htm":
+			io.WriteString(w, "<!DOCTYPE html>\n<html><body><p>Item 4</p></body>\n")
+		default:
+			io.WriteString(w, doc)
+		}
+	}))
+	defer server.Close()
+
+	client := edgar.NewClient("jane@acme.test")
+	for _, path := range []string{"/short.xml", "/cut.xml"} {
+		_, err := client.FetchForm(server.URL + path)
+		require.Error(t, err, path)
+		assert.True(t, errors.Is(err, edgar.ErrTruncatedDownload), "%s: %v", path, err)
+		assert.True(t, edgar.IsRetryable(err), path)
+	}
+
+	data, err := client.FetchForm(server.URL + "/ownership.xml")
+	require.NoError(t, err)
+	assert.Equal(t, doc, string(data))
+	_, err = client.FetchForm(server.URL + "/page.htm")
+	require.NoError(t, err, "a page without </html> is complete")
+
+	// Streaming reads check the length too
+	body, err := client.FetchFormStream(server.URL + "/short.xml")
+	require.NoError(t, err)
+	defer body.Close()
+	_, err = io.ReadAll(body)
+	assert.True(t, errors.Is(err, edgar.ErrTruncatedDownload), "unexpected error: %v", err)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// parseCIKs collects the CIKs of a batch run from --cik (comma-separated) and
+// --cik-file (one or more per line, # comments allowed), in order and without
+// duplicates
+func parseCIKs(list, file string) ([]string, error) {
+	var ciks []string
+	seen := make(map[string]bool)
+	add := func(field string) error {
+		cik := strings.TrimSpace(field)
+		if cik == "" {
+			return nil
+		}
+		key, err := edgar.NormalizeCIK(cik)
+		if err != nil {
+			return err
+		}
+		if !seen[key] {
+			seen[key] = true
+			ciks = append(ciks, cik)
+		}
+		return nil
+	}
+
+	for _, field := range strings.Split(list, ",") {
+		if err := add(field); err != nil {
+			return nil, err
+		}
+	}
+
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CIK file: %w", err)
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for n := 1; scanner.Scan(); n++ {
+			line, _, _ := strings.Cut(scanner.Text(), "#")
+			for _, field := range strings.FieldsFunc(line, func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			}) {
+				if err := add(field); err != nil {
+					return nil, fmt.Errorf("%s: line %d: %w", file, n, err)
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read CIK file: %w", err)
+		}
+	}
+
+	if len(ciks) == 0 {
+		return nil, fmt.Errorf("no CIKs given")
+	}
+	return ciks, nil
+}
+
+// resolveTickers looks up the CIKs of comma-separated tickers in the SEC
+// company_tickers dataset
+func resolveTickers(list, email string, netOpts networkOptions) ([]string, error) {
+	if email == "" {
+		var err error
+		if email, err = edgar.GetSecEmail(); err != nil {
+			return nil, err
+		}
+	}
+	client, err := newClient(email, netOpts)
+	if err != nil {
+		return nil, err
+	}
+	tickers, err := client.FetchCompanyTickers()
+	if err != nil {
+		return nil, err
+	}
+
+	var ciks []string
+	for _, ticker := range strings.Split(list, ",") {
+		if ticker = strings.TrimSpace(ticker); ticker == "" {
+			continue
+		}
+		entry, ok := tickers.Lookup(ticker)
+		if !ok {
+			return nil, fmt.Errorf("unknown ticker %q", ticker)
+		}
+		ciks = append(ciks, entry.CIK)
+	}
+	if len(ciks) == 0 {
+		return nil, fmt.Errorf("no tickers given")
+	}
+	return ciks, nil
+}
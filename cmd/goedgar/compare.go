@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// runCompare implements "goedgar compare": the latest snapshots of a set of
+// companies side by side, for quick sector screens
+func runCompare(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	var (
+		email      string
+		tickers    string
+		cik        string
+		basis      string
+		metrics    string
+		asJSON     bool
+		configPath string
+		netOpts    networkOptions
+	)
+	fs.StringVar(&email, "email", "", "Email for SEC User-Agent header (or use SEC_EMAIL env var)")
+	fs.StringVar(&email, "e", "", "Email for SEC User-Agent (shorthand)")
+	fs.StringVar(&tickers, "tickers", "", "Comma-separated tickers to compare (e.g. MRNA,BNTX,NVAX)")
+	fs.StringVar(&cik, "cik", "", "Comma-separated CIKs to compare (listed after --tickers)")
+	fs.StringVar(&basis, "basis", edgar.BasisAnnual, "Compare the latest annual (10-K, 20-F, 40-F) or quarterly (10-Q) reports")
+	fs.StringVar(&metrics, "metrics", strings.Join(edgar.PeerMetrics, ","), "Comma-separated snapshot fields to compare (JSON names, as in the CSV export)")
+	fs.BoolVar(&asJSON, "json", false, "Print the comparison as JSON")
+	fs.StringVar(&configPath, "config", "", "Config file (default: ~/.config/goedgar/config.yaml or config.toml)")
+	fs.DurationVar(&netOpts.timeout, "timeout", 30*time.Second, "Timeout for each SEC request")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: goedgar compare (--tickers <T1,T2,...> | --cik <CIK1,CIK2,...>) [options]\n\n")
+		fmt.Fprintf(os.Stderr, "Fetch each company's latest 10-K (or 20-F/40-F) and print the same metrics\n")
+		fmt.Fprintf(os.Stderr, "side by side. Differences in currency or period are listed as warnings.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  goedgar compare --tickers MRNA,BNTX,NVAX\n")
+		fmt.Fprintf(os.Stderr, "  goedgar compare --tickers PFE,MRK --basis quarterly --metrics revenue,netIncome,cash\n")
+		fmt.Fprintf(os.Stderr, "  goedgar compare --cik 1682852,78003 --json\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	var companies []string
+	for _, list := range []string{tickers, cik} {
+		for _, company := range strings.Split(list, ",") {
+			if company = strings.TrimSpace(company); company != "" {
+				companies = append(companies, company)
+			}
+		}
+	}
+	if len(companies) == 0 {
+		fs.Usage()
+		return fmt.Errorf("no companies given")
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	netOpts.rate = cfg.RateLimit
+	if email == "" && os.Getenv(edgar.SecEmailEnvVar) == "" {
+		email = cfg.Email
+	}
+	if email == "" {
+		if email, err = edgar.GetSecEmail(); err != nil {
+			return err
+		}
+	}
+	client, err := newClient(email, netOpts)
+	if err != nil {
+		return err
+	}
+
+	comparison, err := client.ComparePeers(companies, edgar.PeerOptions{Basis: basis, Metrics: strings.Split(metrics, ",")})
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(comparison); err != nil {
+			return err
+		}
+	} else {
+		printComparison(w, comparison)
+	}
+	for _, p := range comparison.Peers {
+		if p.Error == "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("no company could be compared")
+}
+
+// printComparison writes the comparison with one column per company
+func printComparison(w io.Writer, c *edgar.PeerComparison) {
+	width := len("period end")
+	for _, metric := range c.Metrics {
+		width = max(width, len(metric))
+	}
+	// Values align right; padding the labels keeps them on the left
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	row := func(label string, cell func(p edgar.Peer) string) {
+		fmt.Fprintf(tw, "%-*s\t", width, label)
+		for _, p := range c.Peers {
+			fmt.Fprintf(tw, "%s\t", cell(p))
+		}
+		fmt.Fprintln(tw)
+	}
+	row("", func(p edgar.Peer) string { return strings.ToUpper(p.Company) })
+	row("form", func(p edgar.Peer) string { return p.FormType })
+	row("period end", func(p edgar.Peer) string { return p.FiscalYearEnd })
+	row("currency", func(p edgar.Peer) string { return p.Currency })
+	for i, metric := range c.Metrics {
+		row(metric, func(p edgar.Peer) string { return formatMetric(metric, p.Values[i]) })
+	}
+	tw.Flush()
+
+	for _, p := range c.Peers {
+		if p.Error != "" {
+			fmt.Fprintf(w, "\n%s: %s", p.Company, p.Error)
+		}
+	}
+	for _, warning := range c.Warnings {
+		fmt.Fprintf(w, "\nwarning: %s", warning)
+	}
+	fmt.Fprintln(w)
+}
+
+// formatMetric formats a snapshot value by the kind of metric: margins as
+// percentages, ratios as multiples, share counts and EPS as numbers, and
+// amounts in the filing's currency in millions or billions
+func formatMetric(metric string, v *float64) string {
+	if v == nil {
+		return "n/a"
+	}
+	switch {
+	case strings.HasSuffix(metric, "Margin"):
+		return fmt.Sprintf("%.1f%%", *v*100)
+	case metric == "currentRatio" || metric == "debtToEquity":
+		return fmt.Sprintf("%.2fx", *v)
+	case metric == "cashRunwayMonths":
+		return fmt.Sprintf("%.1f mo", *v)
+	case strings.HasPrefix(metric, "eps"):
+		return fmt.Sprintf("%.2f", *v)
+	case strings.HasSuffix(metric, "Shares") || strings.HasSuffix(metric, "SharesOutstanding"):
+		return formatShares(*v)
+	case math.Abs(*v) >= 1e9:
+		return fmt.Sprintf("%.2fB", *v/1e9)
+	case math.Abs(*v) >= 1e6:
+		return fmt.Sprintf("%.1fM", *v/1e6)
+	}
+	return groupThousands(fmt.Sprintf("%.0f", *v))
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// config holds CLI defaults from the config file and environment; flags given
+// on the command line override them
+//
+// The file is $XDG_CONFIG_HOME/goedgar/config.yaml (or config.toml; ~/.config
+// when XDG_CONFIG_HOME is unset), one "key: value" (YAML) or "key = value"
+// (TOML) per line:
+//
+//	email: jane@fund.com
+//	output_dir: ~/edgar/output
+//	rate_limit: 5        # Requests per second, at most 10
+//	cache_dir: ~/edgar/filings
+//	form: 10-K
+type config struct {
+	Email     string  // SEC User-Agent email; SEC_EMAIL takes precedence
+	OutputDir string  // Output files and checkpoints (GOEDGAR_OUTPUT_DIR), default ./output
+	RateLimit float64 // Requests per second (GOEDGAR_RATE_LIMIT), 0 for the SEC maximum
+	CacheDir  string  // Local filing archive, as --store (GOEDGAR_CACHE_DIR)
+	Form      string  // Default --form (GOEDGAR_FORM)
+}
+
+// configEnvVar names an alternative config file
+const configEnvVar = "GOEDGAR_CONFIG"
+
+// loadConfig reads the config file at path, or the default location when path
+// is empty (a missing default file is not an error), then applies environment
+// variables
+func loadConfig(path string) (*config, error) {
+	cfg := &config{}
+
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv(configEnvVar)
+		explicit = path != ""
+	}
+	var paths []string
+	if explicit {
+		paths = []string{path}
+	} else {
+		paths = defaultConfigPaths()
+	}
+
+	for _, p := range paths {
+		f, err := os.Open(expandHome(p))
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open config: %w", err)
+		}
+		err = cfg.parse(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		break
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// defaultConfigPaths returns the config files looked for, in order
+func defaultConfigPaths() []string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	dir = filepath.Join(dir, "goedgar")
+	return []string{
+		filepath.Join(dir, "config.yaml"),
+		filepath.Join(dir, "config.yml"),
+		filepath.Join(dir, "config.toml"),
+	}
+}
+
+// parse reads flat "key: value" or "key = value" lines. Comments (#), blank
+// lines, YAML document markers and TOML table headers are skipped; values may
+// be quoted.
+func (c *config) parse(f *os.File) error {
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" || line == "---" || strings.HasPrefix(line, "[") {
+			continue
+		}
+		i := strings.IndexAny(line, ":=")
+		if i < 0 {
+			return fmt.Errorf("line %d: want key: value or key = value", n)
+		}
+		key := strings.ReplaceAll(strings.TrimSpace(line[:i]), "-", "_")
+		value := unquote(strings.TrimSpace(line[i+1:]))
+		if err := c.set(key, value); err != nil {
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// set sets one setting by its config file key
+func (c *config) set(key, value string) error {
+	switch key {
+	case "email":
+		c.Email = value
+	case "output_dir":
+		c.OutputDir = expandHome(value)
+	case "cache_dir":
+		c.CacheDir = expandHome(value)
+	case "form":
+		c.Form = value
+	case "rate_limit":
+		rate, err := parseRateLimit(value)
+		if err != nil {
+			return err
+		}
+		c.RateLimit = rate
+	default:
+		return fmt.Errorf("unknown setting %q (use email, output_dir, rate_limit, cache_dir or form)", key)
+	}
+	return nil
+}
+
+// applyEnv overrides settings from environment variables
+func (c *config) applyEnv() error {
+	for _, env := range []struct{ name, key string }{
+		{"GOEDGAR_OUTPUT_DIR", "output_dir"},
+		{"GOEDGAR_RATE_LIMIT", "rate_limit"},
+		{"GOEDGAR_CACHE_DIR", "cache_dir"},
+		{"GOEDGAR_FORM", "form"},
+	} {
+		if value := os.Getenv(env.name); value != "" {
+			if err := c.set(env.key, value); err != nil {
+				return fmt.Errorf("%s: %w", env.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// maxRate is the SEC's fair access limit in requests per second
+const maxRate = 10
+
+// parseRateLimit parses a request rate in requests per second
+func parseRateLimit(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 || rate > maxRate {
+		return 0, fmt.Errorf("invalid rate limit %q (requests per second, more than 0 and at most %d)", value, maxRate)
+	}
+	return rate, nil
+}
+
+// stripComment removes a # comment outside quotes
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquote removes matching single or double quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+//go:embed explain.json
+var explainJSON []byte
+
+// explainDocs holds the embedded reference documentation for "goedgar explain"
+type explainDocs struct {
+	Codes  map[string]string `json:"codes"`
+	Forms  map[string]string `json:"forms"`
+	Fields map[string]string `json:"fields"`
+	Usage  string            `json:"usage"`
+}
+
+func loadExplainDocs() (*explainDocs, error) {
+	var docs explainDocs
+	if err := json.Unmarshal(explainJSON, &docs); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded docs: %w", err)
+	}
+	return &docs, nil
+}
+
+// runExplain implements "goedgar explain [code|form|field] <term>"
+func runExplain(args []string, w io.Writer) error {
+	docs, err := loadExplainDocs()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		fmt.Fprintln(w, docs.Usage)
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Codes:  %s\n", strings.Join(sortedKeys(docs.Codes), " "))
+		fmt.Fprintf(w, "Forms:  %s\n", strings.Join(sortedKeys(docs.Forms), " "))
+		fmt.Fprintf(w, "Fields: %s\n", strings.Join(sortedKeys(docs.Fields), " "))
+		return nil
+	}
+
+	kind, term := "", args[0]
+	if len(args) > 1 {
+		kind, term = strings.ToLower(args[0]), strings.Join(args[1:], " ")
+	}
+
+	switch kind {
+	case "code":
+		return explainCode(w, docs, term)
+	case "form":
+		return explainForm(w, docs, term)
+	case "field":
+		return explainField(w, docs, term)
+	case "":
+		// Guess the topic: single letters are codes, then forms, then fields
+		if len(term) == 1 {
+			if err := explainCode(w, docs, term); err == nil {
+				return nil
+			}
+		}
+		if err := explainForm(w, docs, term); err == nil {
+			return nil
+		}
+		if err := explainField(w, docs, term); err == nil {
+			return nil
+		}
+		return fmt.Errorf("nothing to explain for %q (try 'goedgar explain' for a list of topics)", term)
+	default:
+		return fmt.Errorf("unknown topic %q: use code, form or field", args[0])
+	}
+}
+
+func explainCode(w io.Writer, docs *explainDocs, term string) error {
+	code := strings.ToUpper(strings.TrimSpace(term))
+	text, ok := docs.Codes[code]
+	if !ok {
+		return fmt.Errorf("unknown transaction code %q", term)
+	}
+
+	if title := edgar.TransactionCodeDescription(code); title != "" {
+		fmt.Fprintf(w, "Transaction code %s: %s\n\n", code, title)
+	} else {
+		fmt.Fprintf(w, "Transaction code %s\n\n", code)
+	}
+	fmt.Fprintln(w, text)
+	return nil
+}
+
+func explainForm(w io.Writer, docs *explainDocs, term string) error {
+	form := strings.ToUpper(strings.TrimSpace(term))
+	form = strings.TrimPrefix(form, "SCHEDULE ")
+	form = strings.TrimPrefix(form, "SC ")
+	form = strings.TrimSuffix(form, "/A")
+
+	text, ok := docs.Forms[form]
+	if !ok {
+		return fmt.Errorf("unknown form %q", term)
+	}
+	fmt.Fprintln(w, text)
+	return nil
+}
+
+func explainField(w io.Writer, docs *explainDocs, term string) error {
+	// Field names are matched case-insensitively
+	for name, text := range docs.Fields {
+		if strings.EqualFold(name, strings.TrimSpace(term)) {
+			fmt.Fprintf(w, "%s\n\n%s\n", name, text)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown field %q", term)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// Exit codes, so orchestration can tell failures apart and retry the right
+// ones (2 is the flag package's exit code for invalid flags)
+const (
+	exitError   = 1 // Invalid options or any other error
+	exitNetwork = 3 // SEC could not be reached or refused a request
+	exitParse   = 4 // A document was fetched but could not be parsed
+	exitPartial = 5 // Some filings (or CIKs, or sources) succeeded and some failed
+)
+
+// parseFailure marks an error from parsing a document as a parse failure,
+// unless a failed download or file read caused it
+func parseFailure(err error) error {
+	if edgar.ErrorCategory(err) != edgar.CategoryOther {
+		return err
+	}
+	return &edgar.FilingError{Category: edgar.CategoryParse, Err: err}
+}
+
+// failure is one entry of the --errors-json report
+type failure struct {
+	CIK       string `json:"cik,omitempty"`
+	Accession string `json:"accession,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Source    string `json:"source,omitempty"` // Input of "goedgar parse" or single-file mode
+	Category  string `json:"category"`
+	Retryable bool   `json:"retryable"`
+	Error     string `json:"error"`
+}
+
+// failureReport collects the failures of a run for the exit code and
+// --errors-json
+type failureReport struct {
+	Failures  []failure
+	Succeeded int    // Filings, CIKs or sources that completed
+	Path      string // --errors-json file ("-" for stderr), or "" for none
+	Sidecar   string // Batch failures file written instead when Path is "" and a CIK's filings failed
+	first     error
+}
+
+// add records a failure; cik and source may be empty
+func (r *failureReport) add(cik, source string, err error) {
+	if r.first == nil {
+		r.first = err
+	}
+	f := failure{
+		CIK:       cik,
+		Source:    source,
+		Category:  edgar.ErrorCategory(err),
+		Retryable: edgar.IsRetryable(err),
+		Error:     err.Error(),
+	}
+	var filingErr *edgar.FilingError
+	if errors.As(err, &filingErr) {
+		f.Accession = filingErr.AccessionNumber
+		f.URL = filingErr.URL
+	}
+	r.Failures = append(r.Failures, f)
+}
+
+// exitCode is the exit status for a run that ended with err (nil when it ran
+// to completion, possibly with recorded failures)
+func (r *failureReport) exitCode(err error) int {
+	if err == nil && len(r.Failures) == 0 {
+		return 0
+	}
+	if r.Succeeded > 0 {
+		return exitPartial
+	}
+	if err == nil {
+		err = r.first
+	}
+	switch edgar.ErrorCategory(err) {
+	case edgar.CategoryNetwork, edgar.CategoryRateLimited, edgar.CategoryNotFound, edgar.CategoryHTTP:
+		return exitNetwork
+	case edgar.CategoryParse:
+		return exitParse
+	}
+	return exitError
+}
+
+// write saves the report as a JSON array to path ("-" for stderr)
+func (r *failureReport) write(path string) error {
+	failures := r.Failures
+	if failures == nil {
+		failures = []failure{}
+	}
+	data, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stderr.Write(data)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create errors JSON directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write errors JSON: %w", err)
+	}
+	return nil
+}
+
+// batchFailed reports whether any failure belongs to a CIK, so --retry-errors
+// can do something with the sidecar
+func (r *failureReport) batchFailed() bool {
+	for _, f := range r.Failures {
+		if f.CIK != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// exit prints err, writes the --errors-json report (even when empty, so its
+// presence means the run finished) or the sidecar of failed batch filings, and
+// exits with the matching code
+func (r *failureReport) exit(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if len(r.Failures) == 0 {
+			r.add("", "", err)
+		}
+	}
+	if r.Path != "" {
+		if werr := r.write(r.Path); werr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", werr)
+		}
+	} else if r.Sidecar != "" && r.batchFailed() {
+		if werr := r.write(r.Sidecar); werr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", werr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Failures saved to %s (reprocess them with --retry-errors %s)\n", r.Sidecar, r.Sidecar)
+		}
+	}
+	os.Exit(r.exitCode(err))
+}
+
+// readFailures reads the failures saved by --errors-json or the batch sidecar
+func readFailures(path string) ([]failure, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var failures []failure
+	if err := json.Unmarshal(data, &failures); err != nil {
+		return nil, fmt.Errorf("failed to read errors JSON %s: %w", path, err)
+	}
+	return failures, nil
+}
+
+// retryTargets groups saved failures by CIK: the accession numbers of the
+// filings that failed, or nil to rerun a CIK that failed as a whole. Failures
+// without a CIK (single-file and "goedgar parse" sources) are counted in
+// skipped.
+func retryTargets(failures []failure) (ciks []string, accessions map[string][]string, skipped int) {
+	accessions = make(map[string][]string)
+	whole := make(map[string]bool)
+	for _, f := range failures {
+		if f.CIK == "" {
+			skipped++
+			continue
+		}
+		if _, seen := accessions[f.CIK]; !seen && !whole[f.CIK] {
+			ciks = append(ciks, f.CIK)
+		}
+		if f.Accession == "" {
+			whole[f.CIK] = true
+		} else {
+			accessions[f.CIK] = append(accessions[f.CIK], f.Accession)
+		}
+	}
+	for cik := range whole {
+		accessions[cik] = nil
+	}
+	return ciks, accessions, skipped
+}
@@ -2,15 +2,44 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/RxDataLab/go-edgar"
 )
 
 func main() {
+	// Failures decide the exit code (see failures.go)
+	report := &failureReport{}
+
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		report.exit(runExplain(os.Args[2:], os.Stdout))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "parse" {
+		report.exit(runParse(os.Args[2:], report))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "summarize" {
+		report.exit(runSummarize(os.Args[2:], os.Stdout))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "compare" {
+		report.exit(runCompare(os.Args[2:], os.Stdout))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		report.exit(runVerify(os.Args[2:], os.Stdout))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		report.exit(runWatch(os.Args[2:]))
+	}
+
 	// Define flags
 	var (
 		// Single file mode
@@ -18,38 +47,122 @@ func main() {
 		outputPath   string
 		email        string
 		pretty       bool
+		format       string
+		footnotes    bool
+		strict       bool
+		canonical    bool
+		mappingsPath string
+		profile      string
+		factsOnly    bool
+		coverage     bool
+
+		// Network
+		netOpts networkOptions
+
+		// Config file
+		configPath string
+		outputDir  string
+
+		// Logging
+		logLevel   string
+		verbose    bool
+		noProgress bool
+		status     statusOptions
 
 		// Batch mode
 		cik              string
+		cikFile          string
+		ticker           string
 		formType         string
 		dateFrom         string
 		dateTo           string
+		lastPeriod       string
+		year             string
 		includePaginated bool
 		listOnly         bool
+		storeDir         string
+		offline          bool
+		concurrency      int
+		resume           bool
+		checkpointPath   string
+		retryErrors      string
+
+		// Form 4 transaction filters (batch mode)
+		codes        string
+		minValue     float64
+		officersOnly bool
+		exclude10b51 bool
 	)
 
 	flag.BoolVar(&saveOriginal, "save-original", false, "Save the original XML/HTML file")
 	flag.BoolVar(&saveOriginal, "s", false, "Save the original XML/HTML file (shorthand)")
-	flag.StringVar(&outputPath, "output", "", "Output JSON file path (default: stdout)")
-	flag.StringVar(&outputPath, "o", "", "Output JSON file path (shorthand)")
+	flag.StringVar(&outputPath, "output", "", "Output file path (default: stdout)")
+	flag.StringVar(&outputPath, "o", "", "Output file path (shorthand)")
 	flag.StringVar(&email, "email", "", "Email for SEC User-Agent header (or use SEC_EMAIL env var)")
 	flag.StringVar(&email, "e", "", "Email for SEC User-Agent (shorthand)")
 	flag.BoolVar(&pretty, "pretty", false, "Pretty print table output (XBRL only)")
+	flag.StringVar(&format, "format", "json", "Output format: json, ndjson, csv (one row per Form 4 transaction per owner, or per 10-K/10-Q snapshot), parquet or xlsx")
+	flag.BoolVar(&footnotes, "resolve-footnotes", false, "Embed resolved footnote text on each Form 4 transaction (JSON output)")
+	flag.BoolVar(&strict, "strict", false, "Validate Form 4 and Schedule 13D/G XML against the SEC schema rules before parsing; violations fail the filing")
+	flag.BoolVar(&canonical, "canonical", false, "Deterministic JSON/NDJSON: sorted keys, [] and {} instead of null for empty lists and maps")
+	flag.StringVar(&profile, "profile", "", "XBRL concept mapping profile: default, "+strings.Join(edgar.ConceptProfiles(), ", ")+" (batch mode default: from the company's SIC code)")
+	flag.BoolVar(&factsOnly, "facts", false, "Export every XBRL fact (all contexts, with period, dimensions and unit) as csv or ndjson instead of the snapshot")
+	flag.BoolVar(&coverage, "coverage", false, "Report XBRL concept mapping coverage (mapped/unmapped facts, missing labels, top unmapped concepts) instead of the snapshot")
+	flag.StringVar(&mappingsPath, "concept-mappings", "", "JSON file of extra XBRL concept mappings, merged into the built-in ones (XBRL only)")
+
+	flag.StringVar(&logLevel, "log-level", "info", "Log verbosity for batch progress: debug, info, warn, error")
+	flag.BoolVar(&status.quiet, "quiet", false, "Only print warnings and errors (same as --log-level error, no progress bar)")
+	flag.BoolVar(&status.quiet, "q", false, "Only print warnings and errors (shorthand)")
+	flag.BoolVar(&verbose, "verbose", false, "Print debug messages (same as --log-level debug)")
+	flag.BoolVar(&verbose, "v", false, "Print debug messages (shorthand)")
+	flag.BoolVar(&noProgress, "no-progress", false, "Don't draw the batch progress bar (drawn when stderr is a terminal)")
+
+	// Network flags
+	flag.StringVar(&netOpts.proxy, "proxy", "", "HTTP(S) proxy URL for SEC requests (default: HTTPS_PROXY/HTTP_PROXY env vars)")
+	flag.StringVar(&netOpts.caCert, "ca-cert", "", "PEM file with extra CA certificates to trust (e.g. corporate TLS proxy)")
+	flag.DurationVar(&netOpts.timeout, "timeout", 30*time.Second, "Timeout for each SEC request")
+	flag.Float64Var(&netOpts.rate, "rate-limit", 0, "SEC requests per second, at most 10 (default: 10)")
+
+	flag.StringVar(&configPath, "config", "", "Config file (default: ~/.config/goedgar/config.yaml or config.toml, or "+configEnvVar+")")
+	flag.StringVar(&outputDir, "output-dir", "", "Directory for output files and checkpoints (default: ./output)")
+	flag.StringVar(&report.Path, "errors-json", "", "Write failed filings (accession, URL, error category, retryable) as a JSON array to this file (- for stderr)")
 
 	// Batch mode flags
-	flag.StringVar(&cik, "cik", "", "CIK to fetch filings for (batch mode)")
+	flag.StringVar(&cik, "cik", "", "CIK to fetch filings for, or a comma-separated list (batch mode)")
+	flag.StringVar(&cikFile, "cik-file", "", "File of CIKs to fetch filings for, one per line (batch mode)")
+	flag.StringVar(&ticker, "ticker", "", "Ticker to fetch filings for, resolved to its CIK, or a comma-separated list (batch mode, e.g. PFE)")
 	flag.StringVar(&formType, "form", "4", "Form type to fetch (default: 4)")
 	flag.StringVar(&dateFrom, "from", "", "Start date for filtering (YYYY-MM-DD)")
 	flag.StringVar(&dateTo, "to", "", "End date for filtering (YYYY-MM-DD)")
+	flag.StringVar(&lastPeriod, "last", "", "Filter to a period ending today instead of --from/--to (e.g. 90d, 12w, 6m, 1y)")
+	flag.StringVar(&year, "year", "", "Filter to a calendar year or quarter instead of --from/--to (e.g. 2024, 2024Q3)")
 	flag.BoolVar(&includePaginated, "all", false, "Include all paginated filings (can be slow)")
 	flag.BoolVar(&listOnly, "list-only", false, "List filings without downloading/parsing (batch mode only)")
+	flag.StringVar(&storeDir, "store", "", "Local filing archive: reuse stored filings and save new downloads (batch mode)")
+	flag.BoolVar(&offline, "offline", false, "Re-parse filings from --store without contacting SEC (batch mode)")
+	flag.IntVar(&concurrency, "concurrency", 1, "Parallel download workers, sharing the 10 req/sec SEC limit (batch mode)")
+	flag.IntVar(&concurrency, "j", 1, "Parallel download workers (shorthand)")
+	flag.BoolVar(&resume, "resume", false, "Resume an interrupted batch from its checkpoint (batch mode)")
+	flag.StringVar(&checkpointPath, "checkpoint", "", "Checkpoint file (default: <output dir>/<batch output name>.checkpoint)")
+	flag.StringVar(&retryErrors, "retry-errors", "", "Reprocess only the failed filings in this --errors-json or batch failures file, which is rewritten with those still failing (batch mode)")
+	flag.StringVar(&codes, "code", "", "Keep only Form 4 transactions with these codes, comma-separated (batch mode, e.g. P,S)")
+	flag.Float64Var(&minValue, "min-value", 0, "Keep only Form 4 transactions worth at least this many dollars, shares × price (batch mode)")
+	flag.BoolVar(&officersOnly, "officers-only", false, "Keep only Form 4 filings by officers (batch mode)")
+	flag.BoolVar(&exclude10b51, "exclude-10b51", false, "Drop Form 4 transactions made under a 10b5-1 plan (batch mode)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: goedgar [options] [<source>]\n\n")
 		fmt.Fprintf(os.Stderr, "Parse SEC forms from URL, file path, or fetch by CIK.\n\n")
 		fmt.Fprintf(os.Stderr, "Modes:\n")
 		fmt.Fprintf(os.Stderr, "  Single file: goedgar [options] <source>\n")
-		fmt.Fprintf(os.Stderr, "  Batch mode:  goedgar --cik <CIK> [--form 4] [--from DATE] [--to DATE]\n\n")
+		fmt.Fprintf(os.Stderr, "  Batch mode:  goedgar --cik <CIK> [--form 4] [--from DATE] [--to DATE | --last 90d | --year 2024]\n")
+		fmt.Fprintf(os.Stderr, "               goedgar --ticker <TICKER> [--form 4] ...\n")
+		fmt.Fprintf(os.Stderr, "  Many inputs: goedgar parse <source>... | cat urls.txt | goedgar parse -\n")
+		fmt.Fprintf(os.Stderr, "  Summary:     goedgar summarize <batch.json>\n")
+		fmt.Fprintf(os.Stderr, "  Peers:       goedgar compare --tickers <T1,T2,...>\n")
+		fmt.Fprintf(os.Stderr, "  Verify:      goedgar verify <output.json>...\n")
+		fmt.Fprintf(os.Stderr, "  Watch:       goedgar watch --ticker <TICKER> [--form 4,13D] [-o file]\n")
+		fmt.Fprintf(os.Stderr, "  Reference:   goedgar explain [code|form|field] <term>\n\n")
 		fmt.Fprintf(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
@@ -58,30 +171,240 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  goedgar ./ownership.xml\n\n")
 		fmt.Fprintf(os.Stderr, "  # Batch mode (Form 4)\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 0000078003 --form 4 --from 2025-01-01 --to 2025-06-30\n")
-		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4  # All recent Form 4s\n\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 0000078003 --form 4 --last 90d  # Form 4s filed in the last 90 days\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 0000078003 --form 4 --year 2024Q4  # Form 4s filed in Q4 2024\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4  # All recent Form 4s\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --ticker PFE --form 4  # Same, by ticker\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 78003,1682852 --form 10-K  # One output file per CIK\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik-file ciks.txt --form 4 --format ndjson -o all.ndjson  # Combined NDJSON\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --format parquet  # Analytics-ready Parquet\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --format xlsx  # Excel workbook (summary, transactions, owners)\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 78003 --form 4 --all --format ndjson -o - | jq -c .issuer  # Stream one filing per line\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --code P,S --min-value 100000 --exclude-10b51  # Discretionary trades over $100k\n\n")
 		fmt.Fprintf(os.Stderr, "  # Schedule 13D/G (includes amendments 13D/A, 13G/A)\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 1496099 --form 13D  # All 13D filings (includes 13D/A)\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 1496099 --form 13G --list-only  # List 13G filings without parsing\n\n")
 		fmt.Fprintf(os.Stderr, "  # List mode (just show URLs, don't parse)\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --list-only\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 1682852 --form 10-K --from 2023-01-01 --list-only\n\n")
+		fmt.Fprintf(os.Stderr, "  # Local archive (download once, re-parse offline)\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --store ./archive\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --store ./archive --offline\n\n")
+		fmt.Fprintf(os.Stderr, "  # Retry failures (saved to <output dir>/<batch output name>.errors.json)\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --form 4 --retry-errors output/form4_1631574.errors.json\n\n")
 		fmt.Fprintf(os.Stderr, "  # 10-K/10-Q (XBRL)\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 1682852 --form 10-K  # Latest 10-K\n")
 		fmt.Fprintf(os.Stderr, "  goedgar --cik 1682852 --form 10-K --from 2023-01-01  # All 10-Ks from 2023\n")
-		fmt.Fprintf(os.Stderr, "  goedgar --cik 1682852 --form 10-Q --pretty  # Latest 10-Q with table\n\n")
-		fmt.Fprintf(os.Stderr, "Environment:\n")
-		fmt.Fprintf(os.Stderr, "  SEC_EMAIL    Email for SEC User-Agent header (required for URL fetching)\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1682852 --form 10-K --all --format csv  # Historical financials, one row per year\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1682852 --form 10-Q --pretty  # Latest 10-Q with table\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --concept-mappings ./my_mappings.json ./10k.htm  # Extra concept mappings\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --profile bank ./bank_10k.htm  # Bank line items (net interest income, deposits)\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --facts --format csv ./10k.htm -o facts.csv  # All facts for your own mapping\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --coverage --pretty ./10k.htm  # Why snapshot fields are missing\n\n")
+		fmt.Fprintf(os.Stderr, "Environment (overrides the config file):\n")
+		fmt.Fprintf(os.Stderr, "  SEC_EMAIL           Email for SEC User-Agent header (required for URL fetching)\n")
+		fmt.Fprintf(os.Stderr, "  GOEDGAR_CONFIG      Config file\n")
+		fmt.Fprintf(os.Stderr, "  GOEDGAR_OUTPUT_DIR  Output directory\n")
+		fmt.Fprintf(os.Stderr, "  GOEDGAR_RATE_LIMIT  SEC requests per second\n")
+		fmt.Fprintf(os.Stderr, "  GOEDGAR_CACHE_DIR   Local filing archive (as --store)\n")
+		fmt.Fprintf(os.Stderr, "  GOEDGAR_FORM        Default --form\n\n")
+		fmt.Fprintf(os.Stderr, "Config file (~/.config/goedgar/config.yaml, or config.toml with key = value):\n")
+		fmt.Fprintf(os.Stderr, "  email: you@company.com\n")
+		fmt.Fprintf(os.Stderr, "  output_dir: ~/edgar/output\n")
+		fmt.Fprintf(os.Stderr, "  rate_limit: 5\n")
+		fmt.Fprintf(os.Stderr, "  cache_dir: ~/edgar/filings\n")
+		fmt.Fprintf(os.Stderr, "  form: 10-K\n")
 	}
 
 	flag.Parse()
 
-	// Determine mode: batch (CIK) or single file
-	if cik != "" {
+	// Config file and environment defaults, for flags not given
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	given := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	if email == "" && os.Getenv(edgar.SecEmailEnvVar) == "" {
+		email = cfg.Email
+	}
+	if cfg.Form != "" && !given["form"] {
+		formType = cfg.Form
+	}
+	if storeDir == "" {
+		storeDir = cfg.CacheDir
+	}
+	if outputDir == "" {
+		outputDir = cfg.OutputDir
+	}
+	if outputDir == "" {
+		outputDir = "./output"
+	}
+	if !given["rate-limit"] {
+		netOpts.rate = cfg.RateLimit
+	} else if netOpts.rate <= 0 || netOpts.rate > maxRate {
+		fmt.Fprintf(os.Stderr, "Error: --rate-limit must be more than 0 and at most %d requests per second\n", maxRate)
+		os.Exit(1)
+	}
+
+	if (cik != "" || cikFile != "") && ticker != "" {
+		fmt.Fprintf(os.Stderr, "Error: use either --cik/--cik-file or --ticker\n")
+		os.Exit(1)
+	}
+	if retryErrors != "" && (cik != "" || cikFile != "" || ticker != "" || resume || checkpointPath != "") {
+		fmt.Fprintf(os.Stderr, "Error: --retry-errors takes its CIKs from the failures file; don't combine it with --cik, --cik-file, --ticker, --resume or --checkpoint\n")
+		os.Exit(1)
+	}
+	batch := cik != "" || cikFile != "" || ticker != "" || retryErrors != ""
+
+	// --last and --year expand to --from/--to
+	if lastPeriod != "" || year != "" {
+		if dateFrom != "" || dateTo != "" || (lastPeriod != "" && year != "") {
+			fmt.Fprintf(os.Stderr, "Error: use only one of --from/--to, --last or --year\n")
+			os.Exit(1)
+		}
+		if lastPeriod != "" {
+			dateFrom, dateTo, err = edgar.LastDateRange(lastPeriod, time.Now())
+		} else {
+			dateFrom, dateTo, err = edgar.YearDateRange(year)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	filter, err := transactionFilter(codes, minValue, officersOnly, exclude10b51)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !filter.IsZero() && !batch {
+		fmt.Fprintf(os.Stderr, "Error: --code, --min-value, --officers-only and --exclude-10b51 are only supported in batch mode\n")
+		os.Exit(1)
+	}
+
+	switch format {
+	case "json", "ndjson", "csv", "parquet", "xlsx":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported --format %q (use json, ndjson, csv, parquet or xlsx)\n", format)
+		os.Exit(1)
+	}
+	if factsOnly {
+		if batch {
+			fmt.Fprintf(os.Stderr, "Error: --facts is only supported for a single file\n")
+			os.Exit(1)
+		}
+		if format == "json" {
+			format = "ndjson"
+		}
+		if format != "csv" && format != "ndjson" {
+			fmt.Fprintf(os.Stderr, "Error: --facts supports --format csv or ndjson\n")
+			os.Exit(1)
+		}
+	}
+
+	if mappingsPath != "" {
+		if err := edgar.LoadConceptMappings(mappingsPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if status.quiet && verbose {
+		fmt.Fprintf(os.Stderr, "Error: use either --quiet or --verbose\n")
+		os.Exit(1)
+	}
+	if status.quiet {
+		logLevel = "error"
+	} else if verbose {
+		logLevel = "debug"
+	}
+
+	// The progress bar owns the last terminal line, so logs go through it
+	var bar *progressBar
+	var logOutput io.Writer = os.Stderr
+	if batch && !status.quiet && !noProgress && isTerminal(os.Stderr) {
+		bar = newProgressBar(os.Stderr)
+		logOutput = bar
+	}
+	logger, err := newLogger(logLevel, logOutput)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Determine mode: batch (CIK or ticker) or single file
+	if batch {
 		// Batch mode
-		if err := runBatch(cik, formType, dateFrom, dateTo, includePaginated, listOnly, email, outputPath); err != nil {
+		var ciks []string
+		var retry map[string][]string
+		if retryErrors != "" {
+			var failures []failure
+			var skipped int
+			failures, err = readFailures(retryErrors)
+			if err == nil {
+				ciks, retry, skipped = retryTargets(failures)
+				if skipped > 0 {
+					logger.Warn("skipping failures without a CIK (retry single files with goedgar parse)", "count", skipped)
+				}
+				if len(ciks) == 0 {
+					err = fmt.Errorf("no batch failures to retry in %s", retryErrors)
+				}
+			}
+		} else if ticker != "" {
+			if offline {
+				fmt.Fprintf(os.Stderr, "Error: --ticker needs the SEC ticker list; use --cik with --offline\n")
+				os.Exit(1)
+			}
+			ciks, err = resolveTickers(ticker, email, netOpts)
+			if err == nil {
+				logger.Info("resolved tickers", "tickers", ticker, "ciks", strings.Join(ciks, ","))
+			}
+		} else {
+			ciks, err = parseCIKs(cik, cikFile)
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		opts := edgar.BatchOptions{
+			FormType:         formType,
+			DateFrom:         dateFrom,
+			DateTo:           dateTo,
+			Email:            email,
+			IncludePaginated: includePaginated,
+			ListOnly:         listOnly,
+			Offline:          offline,
+			Concurrency:      concurrency,
+			CheckpointPath:   checkpointPath,
+			Resume:           resume,
+			Logger:           logger,
+			ResolveFootnotes: footnotes,
+			Strict:           strict,
+			Canonical:        canonical,
+			Profile:          profile,
+
+			TransactionFilter: filter,
+		}
+		if bar != nil {
+			opts.Progress = bar.Update
+		}
+
+		// Save failed filings for --retry-errors; a retry updates its own file
+		if report.Path == "" && !listOnly {
+			if retryErrors != "" {
+				report.Path = retryErrors
+			} else {
+				name := "batch"
+				if len(ciks) == 1 {
+					name = ciks[0]
+				}
+				report.Sidecar = filepath.Join(outputDir, batchFilename(name, formType, dateFrom, dateTo, "errors.json"))
+			}
+		}
+		report.exit(runBatches(ciks, retry, opts, netOpts, status, storeDir, outputDir, outputPath, format, report))
 	} else {
 		// Single file mode - require source argument
 		if flag.NArg() < 1 {
@@ -92,23 +415,63 @@ func main() {
 
 		source := flag.Arg(0)
 
-		if err := run(source, email, saveOriginal, outputPath, pretty); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+		switch {
+		case coverage:
+			err = runCoverage(source, email, netOpts, profile, pretty)
+		case factsOnly:
+			err = runFacts(source, email, netOpts, outputPath, format, profile)
+		default:
+			err = run(source, singleOptions{
+				email:        email,
+				netOpts:      netOpts,
+				status:       status,
+				saveOriginal: saveOriginal,
+				outputDir:    outputDir,
+				outputPath:   outputPath,
+				format:       format,
+				profile:      profile,
+				pretty:       pretty,
+				footnotes:    footnotes,
+				strict:       strict,
+				canonical:    canonical,
+			})
+		}
+		if err != nil {
+			report.add("", source, err)
 		}
+		report.exit(err)
 	}
 }
 
-func run(source, email string, saveOriginal bool, outputPath string, pretty bool) error {
+// singleOptions configures single-file mode (run)
+type singleOptions struct {
+	email        string
+	netOpts      networkOptions
+	status       statusOptions
+	saveOriginal bool // --save-original: keep the fetched document next to the output
+	outputDir    string
+	outputPath   string // -o; empty writes to stdout
+	format       string
+	profile      string
+	pretty       bool // XBRL: print a table instead of JSON
+	footnotes    bool // Form 4: resolve footnote references to their text
+	strict       bool
+	canonical    bool
+}
+
+func run(source string, opts singleOptions) error {
+	email := opts.email
+
 	// Determine if source is URL or file path
 	isURL := strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
 
-	var xmlData []byte
+	var xmlData []byte  // Kept only to save the original
+	var input io.Reader // The document, streamed when not saved
 	var urlMeta *edgar.FilingMetadata
 	var err error
 
 	// Determine if we should show progress messages (not when outputting JSON to stdout)
-	showProgress := saveOriginal || outputPath != ""
+	showProgress := opts.saveOriginal || opts.outputPath != ""
 
 	if isURL {
 		// Get email for SEC requests (fail fast if not provided)
@@ -127,34 +490,59 @@ func run(source, email string, saveOriginal bool, outputPath string, pretty bool
 
 		// Fetch from SEC
 		if showProgress {
-			fmt.Fprintf(os.Stderr, "Fetching from SEC: %s\n", source)
+			opts.status.printf("Fetching from SEC: %s\n", source)
+		}
+		client, err := newClient(email, opts.netOpts)
+		if err != nil {
+			return err
+		}
+		if opts.saveOriginal {
+			xmlData, err = client.FetchForm(source)
+		} else {
+			var body io.ReadCloser
+			body, err = client.FetchFormStream(source)
+			if err == nil {
+				defer body.Close()
+				input = body
+			}
 		}
-		xmlData, err = edgar.FetchForm(source, email)
 		if err != nil {
 			return fmt.Errorf("failed to fetch form: %w", err)
 		}
 	} else {
 		// Read from file
 		if showProgress {
-			fmt.Fprintf(os.Stderr, "Reading from file: %s\n", source)
+			opts.status.printf("Reading from file: %s\n", source)
+		}
+		if opts.saveOriginal {
+			xmlData, err = os.ReadFile(source)
+		} else {
+			var f *os.File
+			f, err = os.Open(source)
+			if err == nil {
+				defer f.Close()
+				input = f
+			}
 		}
-		xmlData, err = os.ReadFile(source)
 		if err != nil {
 			return fmt.Errorf("failed to read file: %w", err)
 		}
 	}
+	if input == nil {
+		input = bytes.NewReader(xmlData)
+	}
 
 	// Parse the form (auto-detect type)
 	if showProgress {
-		fmt.Fprintf(os.Stderr, "Parsing form...\n")
+		opts.status.printf("Parsing form...\n")
 	}
-	form, err := edgar.ParseAny(bytes.NewReader(xmlData))
+	form, err := edgar.ParseAnyWithOptions(input, edgar.ParseOptions{Profile: opts.profile, Strict: opts.strict})
 	if err != nil {
-		return fmt.Errorf("failed to parse form: %w", err)
+		return parseFailure(fmt.Errorf("failed to parse form: %w", err))
 	}
 
 	if showProgress {
-		fmt.Fprintf(os.Stderr, "Detected form type: %s\n", form.FormType)
+		opts.status.printf("Detected form type: %s\n", form.FormType)
 	}
 
 	// Extract metadata from parsed form
@@ -163,42 +551,25 @@ func run(source, email string, saveOriginal bool, outputPath string, pretty bool
 	// Merge metadata from URL and form
 	meta := edgar.MergeMetadata(urlMeta, formMeta)
 
-	// Populate source and accession metadata in the form output
-	if form.FormType == "4" {
-		if f4, ok := form.Data.(*edgar.Form4Output); ok {
-			// Set source (URL or file path)
-			f4.SetSource(source)
-			// Set accession number if available from URL
-			if meta.Accession != "" {
-				f4.SetFilingMetadata(meta.Accession, "", "")
-			}
-		}
-	} else if form.FormType == "SC 13D" || form.FormType == "SC 13G" {
-		// For Schedule 13 filings, populate filer CIK from URL
-		if sc13, ok := form.Data.(*edgar.Schedule13Filing); ok {
-			// The CIK in the URL is the filer's CIK (the investor), not the issuer
-			if meta.CIK != "" {
-				sc13.FilerCIK = meta.CIK
-			}
-		}
-	}
+	annotateForm(form, source, meta, opts.footnotes)
 
-	// Prepare save options with default output directory
 	saveOpts := edgar.SaveOptions{
-		SaveOriginal: saveOriginal,
-		OutputDir:    "./output",
+		SaveOriginal: opts.saveOriginal,
+		OutputDir:    opts.outputDir,
+		Format:       opts.format,
+		Canonical:    opts.canonical,
 	}
 
 	// Determine output path
-	if outputPath != "" {
-		saveOpts.OutputPath = outputPath
-	} else if saveOriginal {
+	if opts.outputPath != "" {
+		saveOpts.OutputPath = opts.outputPath
+	} else if opts.saveOriginal {
 		// If saving original, also save JSON with smart naming
-		saveOpts.OutputPath = edgar.GenerateFilename(meta, "json")
+		saveOpts.OutputPath = edgar.GenerateFilename(meta, opts.format)
 	}
 
 	// Save files if requested
-	if saveOriginal || outputPath != "" {
+	if opts.saveOriginal || opts.outputPath != "" {
 		result, err := edgar.SaveFiles(xmlData, form, meta, saveOpts)
 		if err != nil {
 			return fmt.Errorf("failed to save files: %w", err)
@@ -206,17 +577,17 @@ func run(source, email string, saveOriginal bool, outputPath string, pretty bool
 
 		if showProgress {
 			if result.OriginalPath != "" {
-				fmt.Fprintf(os.Stderr, "Saved original XML: %s\n", result.OriginalPath)
+				opts.status.printf("Saved original XML: %s\n", result.OriginalPath)
 			}
 			if result.OutputPath != "" {
-				fmt.Fprintf(os.Stderr, "Saved JSON output: %s\n", result.OutputPath)
+				opts.status.printf("Saved %s output: %s\n", strings.ToUpper(opts.format), result.OutputPath)
 			}
 		}
 	}
 
 	// Check for missing required fields (XBRL only)
 	if form.FormType == "XBRL" {
-		if snapshot, ok := form.Data.(*edgar.FinancialSnapshot); ok {
+		if snapshot, ok := form.AsSnapshot(); ok {
 			if len(snapshot.MissingRequiredFields) > 0 {
 				fmt.Fprintf(os.Stderr, "\n⚠️  Warning: Missing %d required GAAP field(s):\n", len(snapshot.MissingRequiredFields))
 				for _, field := range snapshot.MissingRequiredFields {
@@ -228,31 +599,151 @@ func run(source, email string, saveOriginal bool, outputPath string, pretty bool
 	}
 
 	// If no output file specified, print to stdout
-	if outputPath == "" && !saveOriginal {
+	if opts.outputPath == "" && !opts.saveOriginal {
 		// For XBRL, optionally print pretty table
-		if form.FormType == "XBRL" && pretty {
-			if snapshot, ok := form.Data.(*edgar.FinancialSnapshot); ok {
+		if form.FormType == "XBRL" && opts.pretty {
+			if snapshot, ok := form.AsSnapshot(); ok {
 				printXBRLTable(snapshot)
 				return nil
 			}
 		}
 
-		// Default: JSON output
-		jsonData, err := edgar.FormatJSON(form)
+		binary := opts.format == "parquet" || opts.format == "xlsx"
+		if binary && isTerminal(os.Stdout) {
+			return fmt.Errorf("--format %s is binary; write it to a file with -o or --save-original", opts.format)
+		}
+		data, err := edgar.FormatFormWithOptions(opts.format, form, edgar.FormatOptions{Canonical: opts.canonical})
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", strings.ToUpper(opts.format), err)
+		}
+		if opts.format == "json" {
+			fmt.Println(string(data))
+		} else {
+			os.Stdout.Write(data) // NDJSON and CSV end in a newline; binary formats take none
+		}
+	}
+
+	return nil
+}
+
+// annotateForm populates source and accession metadata in the form output
+func annotateForm(form *edgar.ParsedForm, source string, meta *edgar.FilingMetadata, footnotes bool) {
+	if form.FormType == "4" {
+		if f4, ok := form.AsForm4Output(); ok {
+			// Set source (URL or file path)
+			f4.SetSource(source)
+			// Set accession number if available from URL
+			if meta.Accession != "" {
+				f4.SetFilingMetadata(meta.Accession, "", "")
+			}
+			if footnotes {
+				f4.ResolveFootnotes()
+			}
+		}
+	} else if strings.HasPrefix(form.FormType, "SC 13") {
+		// For Schedule 13 filings, populate filer CIK from URL
+		if sc13, ok := form.AsSchedule13(); ok {
+			// The CIK in the URL is the filer's CIK (the investor), not the issuer
+			if meta.CIK != "" {
+				sc13.FilerCIK = meta.CIK
+			}
+		}
+	}
+}
+
+// runFacts exports every fact of a 10-K/10-Q document (see XBRL.ExportFacts)
+func runFacts(source, email string, netOpts networkOptions, outputPath, format, profile string) error {
+	xbrl, err := loadXBRL(source, email, netOpts, profile)
+	if err != nil {
+		return err
+	}
+
+	out := io.Writer(os.Stdout)
+	if outputPath != "" && outputPath != "-" {
+		f, err := os.Create(outputPath)
 		if err != nil {
-			return fmt.Errorf("failed to format JSON: %w", err)
+			return fmt.Errorf("failed to create output file: %w", err)
 		}
-		fmt.Println(string(jsonData))
+		defer f.Close()
+		out = f
+	}
+	return xbrl.ExportFacts(out, format)
+}
+
+// runCoverage prints the concept mapping coverage of a 10-K/10-Q document
+// (see XBRL.ConceptCoverage)
+func runCoverage(source, email string, netOpts networkOptions, profile string, pretty bool) error {
+	xbrl, err := loadXBRL(source, email, netOpts, profile)
+	if err != nil {
+		return err
+	}
+	report := xbrl.ConceptCoverage(20)
+
+	if !pretty {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
 	}
 
+	fmt.Printf("Facts:    %d mapped, %d unmapped (%d total)\n", report.MappedFacts, report.UnmappedFacts, report.TotalFacts)
+	fmt.Printf("Concepts: %d mapped of %d\n", report.MappedConcepts, report.Concepts)
+	fmt.Printf("\nLabels without a value (%d):\n", len(report.MissingLabels))
+	for _, label := range report.MissingLabels {
+		fmt.Printf("  %s\n", label)
+	}
+	fmt.Printf("\nMost frequent unmapped concepts:\n")
+	for _, u := range report.TopUnmapped {
+		fmt.Printf("  %4d  %s\n", u.Facts, u.Concept)
+	}
 	return nil
 }
 
+// loadXBRL fetches or reads a 10-K/10-Q document and parses its facts
+func loadXBRL(source, email string, netOpts networkOptions, profile string) (*edgar.XBRL, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		if email == "" {
+			if email, err = edgar.GetSecEmail(); err != nil {
+				return nil, err
+			}
+		}
+		client, err := newClient(email, netOpts)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = client.FetchForm(source); err != nil {
+			return nil, fmt.Errorf("failed to fetch form: %w", err)
+		}
+	} else if data, err = os.ReadFile(source); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	xbrl, err := edgar.ParseXBRLAuto(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse XBRL: %w", err)
+	}
+	if profile != "" {
+		if err := xbrl.ApplyProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+	return xbrl, nil
+}
+
 func printXBRLTable(snapshot *edgar.FinancialSnapshot) {
 	fmt.Println()
 	fmt.Println("═══════════════════════════════════════════════════")
 	if snapshot.CompanyName != "" {
-		fmt.Printf("  %s\n", snapshot.CompanyName)
+		fmt.Printf("  %s", snapshot.CompanyName)
+		if snapshot.Ticker != "" {
+			fmt.Printf(" (%s", snapshot.Ticker)
+			if snapshot.Exchange != "" {
+				fmt.Printf(", %s", snapshot.Exchange)
+			}
+			fmt.Print(")")
+		}
+		fmt.Println()
 	}
 	fmt.Println("           Financial Snapshot")
 	fmt.Println("═══════════════════════════════════════════════════")
@@ -264,7 +755,21 @@ func printXBRLTable(snapshot *edgar.FinancialSnapshot) {
 		fmt.Println()
 	}
 	if snapshot.FormType != "" {
-		fmt.Printf("Form Type: %s\n", snapshot.FormType)
+		fmt.Printf("Form Type: %s", snapshot.FormType)
+		if snapshot.IsAmendment {
+			fmt.Print(" (amendment)")
+		}
+		fmt.Println()
+	}
+	if snapshot.Profile != "" && snapshot.Profile != edgar.DefaultProfile {
+		fmt.Printf("Profile: %s\n", snapshot.Profile)
+	}
+	if (snapshot.Currency != "" && snapshot.Currency != "USD") || len(snapshot.OtherCurrencies) > 0 {
+		fmt.Printf("Currency: %s", snapshot.Currency)
+		if len(snapshot.OtherCurrencies) > 0 {
+			fmt.Printf(" (%s values left out)", strings.Join(snapshot.OtherCurrencies, ", "))
+		}
+		fmt.Println()
 	}
 	fmt.Println()
 
@@ -272,22 +777,57 @@ func printXBRLTable(snapshot *edgar.FinancialSnapshot) {
 	fmt.Printf("%-35s %15s\n", "─────────────────────────────────", "──────────────")
 
 	printMetric("Cash & Equivalents", snapshot.Cash)
+	if snapshot.ShortTermInvestments != nil || snapshot.LongTermInvestments != nil {
+		printMetric("Total Liquidity (incl. investments)", snapshot.TotalLiquidity)
+	}
 	printMetric("Total Debt", snapshot.TotalDebt)
 	printMetric("Revenue", snapshot.Revenue)
 	printMetric("Net Income (Loss)", snapshot.NetIncome)
-	printMetric("R&D Expense", snapshot.RDExpense)
-	printMetric("G&A Expense", snapshot.GAExpense)
+	switch snapshot.Profile {
+	case "bank":
+		printMetric("Net Interest Income", snapshot.NetInterestIncome)
+		printMetric("Provision for Credit Losses", snapshot.ProvisionForCreditLosses)
+		printMetric("Loans", snapshot.Loans)
+		printMetric("Deposits", snapshot.Deposits)
+	case "insurance":
+		printMetric("Premiums Earned", snapshot.PremiumsEarned)
+		printMetric("Policyholder Benefits", snapshot.PolicyholderBenefits)
+		printMetric("Net Investment Income", snapshot.NetInvestmentIncome)
+	case "reit":
+		printMetric("Real Estate Investments", snapshot.RealEstateInvestments)
+		printMetric("Funds From Operations", snapshot.FundsFromOperations)
+	default:
+		printMetric("R&D Expense", snapshot.RDExpense)
+		printMetric("G&A Expense", snapshot.GAExpense)
+	}
 
-	if snapshot.DilutedShares > 0 {
-		millions := snapshot.DilutedShares / 1_000_000
+	if snapshot.DilutedShares != nil && *snapshot.DilutedShares > 0 {
+		millions := *snapshot.DilutedShares / 1_000_000
 		fmt.Printf("%-35s %12.1fM\n", "Diluted Shares", millions)
 	}
 
+	ratios := snapshot.Ratios
+	fmt.Println()
+	printRatio("Gross Margin", ratios.GrossMargin, "%", 100)
+	printRatio("Operating Margin", ratios.OperatingMargin, "%", 100)
+	printRatio("Current Ratio", ratios.CurrentRatio, "x", 1)
+	printRatio("Debt / Equity", ratios.DebtToEquity, "x", 1)
+	printMetric("Free Cash Flow", ratios.FreeCashFlow)
+	printRatio("Cash Runway", ratios.CashRunwayMonths, " mo", 1)
+	if runway, err := edgar.Runway([]*edgar.FinancialSnapshot{snapshot}, edgar.DefaultRunwayAssumptions); err == nil {
+		printRatio("Runway (cash + investments)", runway.Quarters, " qtr", 1)
+	}
+
 	fmt.Println("═══════════════════════════════════════════════════")
 	fmt.Println()
 }
 
-func printMetric(label string, value float64) {
+func printMetric(label string, v *float64) {
+	if v == nil {
+		fmt.Printf("%-35s %15s\n", label, "n/a")
+		return
+	}
+	value := *v
 	if value == 0 {
 		fmt.Printf("%-35s %15s\n", label, "$0")
 		return
@@ -296,34 +836,168 @@ func printMetric(label string, value float64) {
 	billions := value / 1_000_000_000
 	millions := value / 1_000_000
 
-	if billions >= 1 {
+	// Compare magnitudes so losses print in the same units as income
+	if math.Abs(billions) >= 1 {
 		fmt.Printf("%-35s %12.2fB\n", label, billions)
-	} else if millions >= 1 {
+	} else if math.Abs(millions) >= 1 {
 		fmt.Printf("%-35s %12.1fM\n", label, millions)
 	} else {
 		fmt.Printf("%-35s %15.0f\n", label, value)
 	}
 }
 
-func runBatch(cik, formType, dateFrom, dateTo string, includePaginated, listOnly bool, email, outputPath string) error {
-	// Get email for SEC requests
-	if email == "" {
+// printRatio prints a derived ratio scaled by scale, e.g. margins as percentages
+func printRatio(label string, v *float64, unit string, scale float64) {
+	if v == nil {
+		fmt.Printf("%-35s %15s\n", label, "n/a")
+		return
+	}
+	fmt.Printf("%-35s %15s\n", label, fmt.Sprintf("%.1f%s", *v*scale, unit))
+}
+
+// transactionFilter builds the Form 4 transaction filter from the flags
+func transactionFilter(codes string, minValue float64, officersOnly, exclude10b51 bool) (edgar.TransactionFilter, error) {
+	filter := edgar.TransactionFilter{
+		MinValue:     minValue,
+		OfficersOnly: officersOnly,
+		Exclude10b51: exclude10b51,
+	}
+	if minValue < 0 {
+		return filter, fmt.Errorf("--min-value must not be negative")
+	}
+	for _, code := range strings.Split(codes, ",") {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if len(code) != 1 || code[0] < 'A' || code[0] > 'Z' {
+			return filter, fmt.Errorf("invalid transaction code %q in --code (use letters such as P,S)", code)
+		}
+		filter.Codes = append(filter.Codes, code)
+	}
+	return filter, nil
+}
+
+// newLogger creates the logger used for batch progress, writing to w
+func newLogger(level string, w io.Writer) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid --log-level %q (use debug, info, warn or error)", level)
+	}
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: lvl})), nil
+}
+
+// runBatches runs a batch for each CIK with one client, so the whole run shares
+// the SEC rate limit. Each CIK gets its own output file, except that with
+// --format ndjson and -o every CIK's filings go to one combined stream. With
+// retry (--retry-errors), only the listed accession numbers of each CIK are
+// processed.
+func runBatches(ciks []string, retry map[string][]string, opts edgar.BatchOptions, netOpts networkOptions, status statusOptions, storeDir, outputDir, outputPath, format string, report *failureReport) error {
+	// Get email for SEC requests (not needed when re-parsing offline)
+	if opts.Email == "" && !opts.Offline {
 		var err error
-		email, err = edgar.GetSecEmail()
+		opts.Email, err = edgar.GetSecEmail()
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := newClient(opts.Email, netOpts)
+	if err != nil {
+		return err
+	}
+	opts.Client = client
+
+	// Open the local filing archive if requested
+	if storeDir != "" {
+		store, err := edgar.NewFilingStore(storeDir)
+		if err != nil {
+			return err
+		}
+		opts.Store = store
+	} else if opts.Offline {
+		return fmt.Errorf("--offline requires --store")
+	}
+
+	if len(ciks) == 1 {
+		opts.CIK = ciks[0]
+		opts.Accessions = retry[opts.CIK]
+		err := runBatch(opts, status, outputDir, outputPath, format, report)
+		if err != nil {
+			report.add(opts.CIK, "", err)
+		}
+		return err
+	}
+
+	if opts.CheckpointPath != "" {
+		return fmt.Errorf("--checkpoint needs a single CIK (each CIK keeps its own checkpoint in the output directory)")
+	}
+	combined := outputPath != ""
+	if combined {
+		if format != "ndjson" || opts.ListOnly {
+			return fmt.Errorf("-o with several CIKs needs --format ndjson (or leave out -o for one file per CIK)")
+		}
+		out, path, err := openBatchOutput(outputPath, outputDir, "")
 		if err != nil {
 			return err
 		}
+		defer out.Close()
+		opts.Output = out
+		opts.OutputFormat = format
+		outputPath = path
 	}
 
-	// Setup batch options
-	opts := edgar.BatchOptions{
-		CIK:              cik,
-		FormType:         formType,
-		DateFrom:         dateFrom,
-		DateTo:           dateTo,
-		Email:            email,
-		IncludePaginated: includePaginated,
-		ListOnly:         listOnly,
+	var failed []string
+	for i, cik := range ciks {
+		opts.CIK = cik
+		opts.Accessions = retry[cik]
+		opts.Logger.Info("starting batch", "cik", cik, "batch", i+1, "of", len(ciks))
+		if err := runBatch(opts, status, outputDir, "", format, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: CIK %s: %v\n", cik, err)
+			report.add(cik, "", err)
+			failed = append(failed, cik)
+		}
+	}
+	if combined && outputPath != "-" {
+		status.printf("Saved batch output: %s\n", outputPath)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d CIKs failed: %s", len(failed), len(ciks), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// runBatch fetches and parses one CIK's filings and writes the output; a
+// stream already in opts.Output is written to but not announced
+func runBatch(opts edgar.BatchOptions, status statusOptions, outputDir, outputPath, format string, report *failureReport) error {
+	cik, formType, dateFrom, dateTo := opts.CIK, opts.FormType, opts.DateFrom, opts.DateTo
+	listOnly := opts.ListOnly
+
+	// Retried filings get their own output next to the original run's, and
+	// leave its checkpoint alone
+	retrying := len(opts.Accessions) > 0
+	ext := format
+	if retrying {
+		ext = "retry." + format
+	}
+
+	// Record progress so an interrupted run can continue with --resume
+	if !listOnly && !retrying && opts.CheckpointPath == "" {
+		opts.CheckpointPath = filepath.Join(outputDir, batchFilename(cik, formType, dateFrom, dateTo, format)+".checkpoint")
+	}
+
+	// NDJSON is streamed to the output as filings are parsed instead of buffered
+	streaming := format == "ndjson" && !listOnly
+	combined := opts.Output != nil
+	if streaming && !combined {
+		out, path, err := openBatchOutput(outputPath, outputDir, batchFilename(cik, formType, dateFrom, dateTo, ext))
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		opts.Output = out
+		opts.OutputFormat = format
+		outputPath = path
 	}
 
 	// Fetch and parse batch
@@ -333,6 +1007,10 @@ func runBatch(cik, formType, dateFrom, dateTo string, includePaginated, listOnly
 	}
 
 	// Print errors if any
+	report.Succeeded += result.Fetched
+	for _, err := range result.Errors {
+		report.add(cik, "", err)
+	}
 	if len(result.Errors) > 0 {
 		fmt.Fprintf(os.Stderr, "\nErrors encountered:\n")
 		for i, err := range result.Errors {
@@ -355,13 +1033,14 @@ func runBatch(cik, formType, dateFrom, dateTo string, includePaginated, listOnly
 		}
 	} else {
 		// Check for missing required fields in XBRL filings
-		if formType == "10-K" || formType == "10-Q" {
+		switch strings.TrimSuffix(formType, "/A") {
+		case "10-K", "10-Q", "20-F", "40-F", "6-K":
 			filingsWithMissingFields := 0
 			allMissingFields := make(map[string]int) // field name -> count
 
 			for _, filing := range result.Filings {
 				if filing.FormType == "XBRL" {
-					if snapshot, ok := filing.Data.(*edgar.FinancialSnapshot); ok {
+					if snapshot, ok := filing.AsSnapshot(); ok {
 						if len(snapshot.MissingRequiredFields) > 0 {
 							filingsWithMissingFields++
 							for _, field := range snapshot.MissingRequiredFields {
@@ -381,10 +1060,18 @@ func runBatch(cik, formType, dateFrom, dateTo string, includePaginated, listOnly
 			}
 		}
 
-		// Output results as JSON array of parsed forms
-		jsonData, err = edgar.FormatJSONBatch(result.Filings)
+		if streaming {
+			if outputPath != "-" && !combined {
+				status.printf("Saved batch output: %s\n", outputPath)
+			}
+			removeCheckpoint(opts, result)
+			return nil
+		}
+
+		// JSON array of parsed forms, or one row per record for csv/parquet
+		jsonData, err = edgar.FormatBatchWithOptions(format, result.Filings, edgar.FormatOptions{Canonical: opts.Canonical})
 		if err != nil {
-			return fmt.Errorf("failed to format JSON: %w", err)
+			return fmt.Errorf("failed to format %s: %w", strings.ToUpper(format), err)
 		}
 	}
 
@@ -392,22 +1079,13 @@ func runBatch(cik, formType, dateFrom, dateTo string, includePaginated, listOnly
 	// Default: save to file with smart naming (batch results are often large)
 	// Use "-o -" to explicitly output to stdout
 	if outputPath == "" {
-		// Generate filename: {dateFrom}_{dateTo}_form{formType}_{cik}.json
-		// or if no dates: form{formType}_{cik}.json
-		var filename string
-		if dateFrom != "" && dateTo != "" {
-			filename = fmt.Sprintf("%s_%s_form%s_%s.json", dateFrom, dateTo, formType, cik)
-		} else if dateFrom != "" {
-			filename = fmt.Sprintf("%s_onwards_form%s_%s.json", dateFrom, formType, cik)
-		} else if dateTo != "" {
-			filename = fmt.Sprintf("until_%s_form%s_%s.json", dateTo, formType, cik)
-		} else {
-			filename = fmt.Sprintf("form%s_%s.json", formType, cik)
+		if listOnly {
+			ext = strings.TrimSuffix(ext, format) + "json" // Filing lists are always JSON
 		}
-		outputPath = fmt.Sprintf("./output/%s", filename)
+		outputPath = filepath.Join(outputDir, batchFilename(cik, formType, dateFrom, dateTo, ext))
 
 		// Ensure output directory exists
-		if err := os.MkdirAll("./output", 0755); err != nil {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 	}
@@ -415,13 +1093,63 @@ func runBatch(cik, formType, dateFrom, dateTo string, includePaginated, listOnly
 	// Write to file or stdout
 	if outputPath == "-" {
 		// Explicit stdout request
-		fmt.Println(string(jsonData))
+		if (format == "parquet" || format == "xlsx") && !listOnly {
+			os.Stdout.Write(jsonData) // Binary: no trailing newline
+		} else {
+			fmt.Println(string(jsonData))
+		}
 	} else {
 		if err := os.WriteFile(outputPath, jsonData, 0644); err != nil {
 			return fmt.Errorf("failed to write output file: %w", err)
 		}
-		fmt.Fprintf(os.Stderr, "Saved batch output: %s\n", outputPath)
+		status.printf("Saved batch output: %s\n", outputPath)
 	}
 
+	removeCheckpoint(opts, result)
 	return nil
 }
+
+// removeCheckpoint deletes the checkpoint after a clean run; it is kept when
+// there were errors so they can be retried with --resume
+func removeCheckpoint(opts edgar.BatchOptions, result *edgar.BatchResult) {
+	if opts.CheckpointPath != "" && len(result.Errors) == 0 {
+		os.Remove(opts.CheckpointPath)
+	}
+}
+
+// openBatchOutput opens the streaming output: stdout for "-", the given path,
+// or {outputDir}/{defaultName} when no path is given
+func openBatchOutput(outputPath, outputDir, defaultName string) (io.WriteCloser, string, error) {
+	if outputPath == "-" {
+		return nopCloser{os.Stdout}, outputPath, nil
+	}
+	if outputPath == "" {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return nil, "", fmt.Errorf("failed to create output directory: %w", err)
+		}
+		outputPath = filepath.Join(outputDir, defaultName)
+	}
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, outputPath, nil
+}
+
+// nopCloser keeps stdout open when used as a batch output
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+// batchFilename generates the default batch output filename:
+// {dateFrom}_{dateTo}_form{formType}_{cik}.{ext}, or form{formType}_{cik}.{ext} without dates
+func batchFilename(cik, formType, dateFrom, dateTo, ext string) string {
+	if dateFrom != "" && dateTo != "" {
+		return fmt.Sprintf("%s_%s_form%s_%s.%s", dateFrom, dateTo, formType, cik, ext)
+	} else if dateFrom != "" {
+		return fmt.Sprintf("%s_onwards_form%s_%s.%s", dateFrom, formType, cik, ext)
+	} else if dateTo != "" {
+		return fmt.Sprintf("until_%s_form%s_%s.%s", dateTo, formType, cik, ext)
+	}
+	return fmt.Sprintf("form%s_%s.%s", formType, cik, ext)
+}
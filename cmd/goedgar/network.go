@@ -0,0 +1,60 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// networkOptions configures how the CLI reaches the SEC
+type networkOptions struct {
+	proxy   string        // Proxy URL (empty: use environment)
+	caCert  string        // Extra CA certificates (PEM)
+	timeout time.Duration // Per-request timeout
+	rate    float64       // Requests per second (0: the SEC maximum of 10)
+}
+
+// newClient builds an edgar.Client with the configured proxy, TLS, timeout and
+// request rate
+func newClient(email string, opts networkOptions) (*edgar.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.proxy != "" {
+		proxyURL, err := url.Parse(opts.proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL: %s", opts.proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if opts.caCert != "" {
+		pem, err := os.ReadFile(opts.caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", opts.caCert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	client := edgar.NewClient(email)
+	client.HTTPClient = &http.Client{
+		Transport: transport,
+		Timeout:   opts.timeout,
+	}
+	if opts.rate > 0 {
+		client.RequestInterval = time.Duration(float64(time.Second) / opts.rate)
+	}
+	return client, nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// accessionPattern matches "ACCESSION" or "CIK/ACCESSION" (also separated by
+// ":", "," or whitespace), with or without the accession's dashes
+var accessionPattern = regexp.MustCompile(`^(?:(\d{1,10})\s*[/:,\s]\s*)?(\d{10})-?(\d{2})-?(\d{6})$`)
+
+// runParse implements "goedgar parse": parse each source given as an
+// argument, or one per line of stdin for "-", writing one NDJSON record per
+// filing so the tool composes with grep and other pipelines. Failed sources
+// are recorded in report.
+func runParse(args []string, report *failureReport) error {
+	fs := flag.NewFlagSet("parse", flag.ContinueOnError)
+	var (
+		email      string
+		outputPath string
+		profile    string
+		footnotes  bool
+		strict     bool
+		canonical  bool
+		quiet      bool
+		configPath string
+		netOpts    networkOptions
+	)
+	fs.StringVar(&email, "email", "", "Email for SEC User-Agent header (or use SEC_EMAIL env var)")
+	fs.StringVar(&email, "e", "", "Email for SEC User-Agent (shorthand)")
+	fs.StringVar(&outputPath, "output", "", "NDJSON output file (default: stdout)")
+	fs.StringVar(&outputPath, "o", "", "NDJSON output file (shorthand)")
+	fs.StringVar(&profile, "profile", "", "XBRL concept mapping profile: default, "+strings.Join(edgar.ConceptProfiles(), ", "))
+	fs.BoolVar(&footnotes, "resolve-footnotes", false, "Embed resolved footnote text on each Form 4 transaction")
+	fs.BoolVar(&strict, "strict", false, "Validate Form 4 and Schedule 13D/G XML against the SEC schema rules before parsing")
+	fs.BoolVar(&canonical, "canonical", false, "Deterministic NDJSON: sorted keys, [] and {} instead of null for empty lists and maps")
+	fs.BoolVar(&quiet, "quiet", false, "Only print warnings and errors")
+	fs.BoolVar(&quiet, "q", false, "Only print warnings and errors (shorthand)")
+	fs.StringVar(&configPath, "config", "", "Config file (default: ~/.config/goedgar/config.yaml or config.toml)")
+	fs.DurationVar(&netOpts.timeout, "timeout", 30*time.Second, "Timeout for each SEC request")
+	fs.StringVar(&report.Path, "errors-json", "", "Write failed sources (error category, retryable) as a JSON array to this file (- for stderr)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: goedgar parse [options] <source>...\n\n")
+		fmt.Fprintf(os.Stderr, "Parse each source and write one NDJSON record per filing. A source is a\n")
+		fmt.Fprintf(os.Stderr, "document URL, a local file, or an accession number (CIK/ACCESSION, or\n")
+		fmt.Fprintf(os.Stderr, "ACCESSION for filings made by the filer itself). \"-\" reads sources from\n")
+		fmt.Fprintf(os.Stderr, "stdin, one per line; blank lines and # comments are skipped.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  cat urls.txt | goedgar parse - > filings.ndjson\n")
+		fmt.Fprintf(os.Stderr, "  grep ownership.xml urls.txt | goedgar parse - | jq -c .issuer\n")
+		fmt.Fprintf(os.Stderr, "  goedgar parse 1631574/0001193125-25-314736 ./form4.xml\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no sources given (use - to read them from stdin)")
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	netOpts.rate = cfg.RateLimit
+	if email == "" && os.Getenv(edgar.SecEmailEnvVar) == "" {
+		email = cfg.Email
+	}
+
+	var out io.Writer = os.Stdout
+	if outputPath != "" && outputPath != "-" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	logLevel := "info"
+	if quiet {
+		logLevel = "error"
+	}
+	logger, err := newLogger(logLevel, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	p := &sourceParser{email: email, netOpts: netOpts, profile: profile, footnotes: footnotes, strict: strict}
+	enc := edgar.NewNDJSONEncoder(out)
+	enc.SetCanonical(canonical)
+	total, failed := 0, 0
+	each := func(source string) error {
+		total++
+		form, err := p.parse(source)
+		if err != nil {
+			failed++
+			report.add("", source, err)
+			logger.Warn("failed to parse", "source", source, "error", err)
+			return nil
+		}
+		report.Succeeded++
+		logger.Debug("parsed", "source", source, "form", form.FormType)
+		return enc.Encode(form)
+	}
+
+	for _, arg := range fs.Args() {
+		if arg != "-" {
+			if err := each(arg); err != nil {
+				return err
+			}
+			continue
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			if err := each(line); err != nil {
+				return err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+	}
+
+	logger.Info("parse complete", "parsed", total-failed, "total", total)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sources failed", failed, total)
+	}
+	return nil
+}
+
+// sourceParser fetches and parses sources, creating the SEC client on first use
+type sourceParser struct {
+	email     string
+	netOpts   networkOptions
+	profile   string
+	footnotes bool
+	strict    bool
+	client    *edgar.Client
+}
+
+// parse fetches (or reads) and parses one source
+func (p *sourceParser) parse(source string) (*edgar.ParsedForm, error) {
+	isURL := strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+	m := accessionPattern.FindStringSubmatch(source)
+
+	if !isURL && m == nil {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		defer f.Close()
+		form, err := edgar.ParseAnyWithOptions(f, p.parseOptions())
+		if err != nil {
+			return nil, parseFailure(err)
+		}
+		annotateForm(form, source, edgar.MergeMetadata(nil, edgar.ExtractMetadataFromForm(form)), p.footnotes)
+		return form, nil
+	}
+
+	client, err := p.secClient()
+	if err != nil {
+		return nil, err
+	}
+	url := source
+	if m != nil {
+		cik, accession := m[1], m[2]+"-"+m[3]+"-"+m[4]
+		if cik == "" {
+			cik = m[2] // Filer prefix of the accession number
+		}
+		if url, err = client.PrimaryDocumentURL(cik, accession); err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := client.FetchFormStream(url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	form, err := edgar.ParseAnyWithOptions(body, p.parseOptions())
+	if err != nil {
+		return nil, parseFailure(err)
+	}
+	urlMeta, _ := edgar.ExtractMetadataFromURL(url)
+	annotateForm(form, url, edgar.MergeMetadata(urlMeta, edgar.ExtractMetadataFromForm(form)), p.footnotes)
+	return form, nil
+}
+
+// parseOptions returns the options sources are parsed with
+func (p *sourceParser) parseOptions() edgar.ParseOptions {
+	return edgar.ParseOptions{Profile: p.profile, Strict: p.strict}
+}
+
+// secClient returns the SEC client, resolving the email the first time
+func (p *sourceParser) secClient() (*edgar.Client, error) {
+	if p.client != nil {
+		return p.client, nil
+	}
+	if p.email == "" {
+		var err error
+		if p.email, err = edgar.GetSecEmail(); err != nil {
+			return nil, err
+		}
+	}
+	client, err := newClient(p.email, p.netOpts)
+	if err != nil {
+		return nil, err
+	}
+	p.client = client
+	return client, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// statusOptions control informational messages on stderr
+type statusOptions struct {
+	quiet bool // --quiet: only errors and warnings print
+}
+
+// printf prints an informational message to stderr unless quiet is set
+func (o statusOptions) printf(format string, args ...any) {
+	if !o.quiet {
+		fmt.Fprintf(os.Stderr, format, args...)
+	}
+}
+
+// progressBar draws batch progress with an ETA on one terminal line. Log
+// output written through it clears the bar first and redraws it after, so the
+// two don't garble each other.
+type progressBar struct {
+	mu        sync.Mutex
+	out       io.Writer
+	start     time.Time
+	completed int
+	total     int
+	active    bool
+}
+
+// progressWidth is the number of cells in the bar
+const progressWidth = 30
+
+func newProgressBar(out io.Writer) *progressBar {
+	return &progressBar{out: out}
+}
+
+// Update records progress (edgar.BatchOptions.Progress). A count of 0 starts
+// a new batch; the line is cleared when the batch completes.
+func (p *progressBar) Update(completed, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if completed == 0 {
+		p.start = time.Now()
+	}
+	p.completed, p.total = completed, total
+	if completed >= total {
+		p.clear()
+		p.active = false
+		return
+	}
+	p.active = true
+	p.draw()
+}
+
+// Write passes log output through, keeping the bar on the last line
+func (p *progressBar) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.active {
+		p.clear()
+	}
+	n, err := p.out.Write(b)
+	if p.active {
+		p.draw()
+	}
+	return n, err
+}
+
+func (p *progressBar) clear() {
+	fmt.Fprint(p.out, "\r\033[K")
+}
+
+func (p *progressBar) draw() {
+	filled := progressWidth * p.completed / p.total
+	bar := strings.Repeat("#", filled) + strings.Repeat(".", progressWidth-filled)
+	line := fmt.Sprintf("\r[%s] %d/%d %3d%%", bar, p.completed, p.total, 100*p.completed/p.total)
+	if p.completed > 0 {
+		elapsed := time.Since(p.start)
+		eta := elapsed / time.Duration(p.completed) * time.Duration(p.total-p.completed)
+		line += "  ETA " + eta.Round(time.Second).String()
+	}
+	fmt.Fprint(p.out, line+"\033[K")
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// runSummarize implements "goedgar summarize": aggregate Form 4 batch output
+// into a quick report of insiders, transaction codes, largest trades and
+// 10b5-1 share
+func runSummarize(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("summarize", flag.ContinueOnError)
+	var (
+		top       int
+		asJSON    bool
+		byInsider bool
+		sentiment bool
+	)
+	fs.IntVar(&top, "top", 10, "Number of largest trades and insiders to list (0: all)")
+	fs.BoolVar(&asJSON, "json", false, "Print the summary as JSON")
+	fs.BoolVar(&byInsider, "by-insider", false, "Report each insider's activity over time (net shares, proceeds, codes, trade dates)")
+	fs.BoolVar(&sentiment, "sentiment", false, "Score each issuer's insider buying against selling, from -1 to +1")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: goedgar summarize [options] <batch.json>...\n\n")
+		fmt.Fprintf(os.Stderr, "Summarize Form 4 batch output (JSON array or NDJSON; \"-\" reads stdin):\n")
+		fmt.Fprintf(os.Stderr, "totals bought/sold per insider, counts by transaction code, the largest\n")
+		fmt.Fprintf(os.Stderr, "trades and the share made under 10b5-1 plans.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  goedgar summarize output/form4_1631574.json\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --ticker PFE --form 4 --last 1y --format ndjson -o - | goedgar summarize -\n")
+		fmt.Fprintf(os.Stderr, "  goedgar summarize --by-insider --json output/form4_1631574.json\n")
+		fmt.Fprintf(os.Stderr, "  goedgar summarize --sentiment output/*.json\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no batch files given")
+	}
+	if byInsider && sentiment {
+		return fmt.Errorf("--by-insider and --sentiment can't be combined")
+	}
+
+	var forms []*edgar.Form4Output
+	for _, path := range fs.Args() {
+		batch, err := readForm4File(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		forms = append(forms, batch...)
+	}
+
+	if sentiment {
+		scores := edgar.ScoreInsiderSentiment(forms, edgar.DefaultSentimentWeights)
+		if asJSON {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(scores)
+		}
+		printSentiment(w, scores, top)
+		return nil
+	}
+	if byInsider {
+		report := edgar.AggregateInsiderActivity(forms)
+		if asJSON {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		printInsiderActivity(w, report, top)
+		return nil
+	}
+
+	summary := edgar.SummarizeForm4Batch(forms, top)
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	printSummary(w, summary, top)
+	return nil
+}
+
+// readForm4File reads a batch file, or stdin for "-"
+func readForm4File(path string) ([]*edgar.Form4Output, error) {
+	if path == "-" {
+		return edgar.ReadForm4Batch(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return edgar.ReadForm4Batch(f)
+}
+
+// printSummary writes the summary as aligned text tables
+func printSummary(w io.Writer, s *edgar.Form4BatchSummary, top int) {
+	fmt.Fprintf(w, "Filings:       %d", s.Filings)
+	if s.FirstFiled != "" {
+		fmt.Fprintf(w, " (filed %s to %s)", s.FirstFiled, s.LastFiled)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Transactions:  %d\n", s.Transactions)
+	fmt.Fprintf(w, "Bought:        %s shares, %s\n", formatShares(s.Totals.SharesAcquired), formatDollars(s.Totals.ValueAcquired))
+	fmt.Fprintf(w, "Sold:          %s shares, %s\n", formatShares(s.Totals.SharesDisposed), formatDollars(s.Totals.ValueDisposed))
+	fmt.Fprintf(w, "10b5-1 plans:  %d transactions (%.0f%%), %s (%.0f%% of value)\n",
+		s.Plan10b51.Transactions, 100*s.Plan10b51.TransactionShare, formatDollars(s.Plan10b51.Value), 100*s.Plan10b51.ValueShare)
+
+	insiders := s.Insiders
+	if top > 0 && len(insiders) > top {
+		insiders = insiders[:top]
+	}
+	fmt.Fprintf(w, "\nInsiders (by value traded)\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Filings\tShares bought\tShares sold\tBought\tSold\t  Name\n")
+	for _, in := range insiders {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t  %s\n", in.Filings, formatShares(in.SharesAcquired),
+			formatShares(in.SharesDisposed), formatDollars(in.ValueAcquired), formatDollars(in.ValueDisposed), in.OwnerName)
+	}
+	tw.Flush()
+	if len(insiders) < len(s.Insiders) {
+		fmt.Fprintf(w, "... and %d more\n", len(s.Insiders)-len(insiders))
+	}
+
+	fmt.Fprintf(w, "\nTransaction codes\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range s.Codes {
+		fmt.Fprintf(tw, "  %s\t%d\t%s\n", c.Code, c.Count, c.Description)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nLargest trades\n")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Date\tCode\tShares\tPrice\tValue\t10b5-1\t  Owner\n")
+	for _, t := range s.LargestTrades {
+		plan := "no"
+		if t.Is10b51Plan {
+			plan = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%s\t%s\t  %s\n", t.TransactionDate, t.TransactionCode,
+			formatShares(t.Shares), t.PricePerShare, formatDollars(t.Value), plan, t.Owners)
+	}
+	tw.Flush()
+}
+
+// printInsiderActivity writes one row per insider and issuer
+func printInsiderActivity(w io.Writer, r *edgar.InsiderActivityReport, top int) {
+	insiders := r.Insiders
+	if top > 0 && len(insiders) > top {
+		insiders = insiders[:top]
+	}
+	fmt.Fprintf(w, "Filings:  %d\n\nInsiders (by value traded)\n", r.Filings)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "First trade\tLast trade\tTrades\tNet shares\tCost\tProceeds\t  Codes\t  Issuer\t  Name\n")
+	for _, in := range insiders {
+		var codes []string
+		for _, c := range in.Codes {
+			codes = append(codes, fmt.Sprintf("%s×%d", c.Code, c.Count))
+		}
+		issuer := in.Ticker
+		if issuer == "" {
+			issuer = in.IssuerName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t  %s\t  %s\t  %s\n", in.FirstTradeDate, in.LastTradeDate, in.Trades,
+			formatShares(in.NetShares), formatDollars(in.Cost), formatDollars(in.Proceeds), strings.Join(codes, " "), issuer, in.OwnerName)
+	}
+	tw.Flush()
+	if len(insiders) < len(r.Insiders) {
+		fmt.Fprintf(w, "... and %d more\n", len(r.Insiders)-len(insiders))
+	}
+}
+
+// printSentiment writes one row per issuer, most bullish first
+func printSentiment(w io.Writer, scores []edgar.IssuerSentiment, top int) {
+	shown := scores
+	if top > 0 && len(shown) > top {
+		shown = shown[:top]
+	}
+	fmt.Fprintf(w, "Insider sentiment (-1 only selling, +1 only buying; CEO/CFO weighted x2, 10b5-1 trades x0.25)\n")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Score\tFilings\tBuys\tSells\tBought\tSold\tFirst trade\tLast trade\t  Issuer\n")
+	for _, s := range shown {
+		issuer := s.IssuerName
+		if s.Ticker != "" {
+			issuer = s.Ticker + "  " + issuer
+		}
+		fmt.Fprintf(tw, "%+.2f\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t  %s\n", s.Score, s.Filings, s.Buys, s.Sells,
+			formatDollars(s.BuyValue), formatDollars(s.SellValue), s.FirstTradeDate, s.LastTradeDate, issuer)
+	}
+	tw.Flush()
+	if len(shown) < len(scores) {
+		fmt.Fprintf(w, "... and %d more\n", len(scores)-len(shown))
+	}
+}
+
+// formatShares formats a share count with thousands separators
+func formatShares(v float64) string {
+	return groupThousands(fmt.Sprintf("%.0f", v))
+}
+
+// formatDollars formats a dollar amount with thousands separators
+func formatDollars(v float64) string {
+	return "$" + groupThousands(fmt.Sprintf("%.0f", v))
+}
+
+// groupThousands inserts commas into a formatted integer
+func groupThousands(s string) string {
+	sign := ""
+	if len(s) > 0 && s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// runVerify implements "goedgar verify": check saved output files against the
+// output schema, failing if any file has issues
+func runVerify(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	var (
+		maxIssues int
+		asJSON    bool
+	)
+	fs.IntVar(&maxIssues, "max-issues", 20, "Issues to print per file (0: all)")
+	fs.BoolVar(&asJSON, "json", false, "Print the results as JSON (file -> result)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: goedgar verify [options] <file.json>...\n\n")
+		fmt.Fprintf(os.Stderr, "Check output files (single-file JSON, batch JSON or NDJSON; \"-\" reads stdin)\n")
+		fmt.Fprintf(os.Stderr, "against the Form 4, Schedule 13D/G and XBRL output schema: missing fields,\n")
+		fmt.Fprintf(os.Stderr, "wrong types, unknown fields and unknown Form 4 schemaVersion values.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  goedgar verify output/*.json\n")
+		fmt.Fprintf(os.Stderr, "  goedgar --cik 1631574 --form 4 --format ndjson -o - | goedgar verify -\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("no files given")
+	}
+
+	results := make(map[string]*edgar.VerifyResult)
+	failed := 0
+	for _, path := range fs.Args() {
+		result, err := verifyFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		results[path] = result
+		if !result.OK() {
+			failed++
+		}
+		if !asJSON {
+			printVerifyResult(w, path, result, maxIssues)
+		}
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed verification", failed, len(results))
+	}
+	return nil
+}
+
+// verifyFile verifies a file, or stdin for "-"
+func verifyFile(path string) (*edgar.VerifyResult, error) {
+	if path == "-" {
+		return edgar.VerifyOutput(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return edgar.VerifyOutput(f)
+}
+
+// printVerifyResult prints a file's record counts and up to max issues
+func printVerifyResult(w io.Writer, path string, result *edgar.VerifyResult, max int) {
+	var kinds []string
+	for kind, n := range result.Kinds {
+		kinds = append(kinds, fmt.Sprintf("%s: %d", kind, n))
+	}
+	sort.Strings(kinds)
+	counts := fmt.Sprintf("%d records", result.Records)
+	if len(kinds) > 0 {
+		counts += " (" + strings.Join(kinds, ", ") + ")"
+	}
+
+	if result.OK() {
+		fmt.Fprintf(w, "%s: OK, %s\n", path, counts)
+		return
+	}
+	fmt.Fprintf(w, "%s: %d issues, %s\n", path, len(result.Issues), counts)
+	for i, issue := range result.Issues {
+		if max > 0 && i == max {
+			fmt.Fprintf(w, "  ... and %d more\n", len(result.Issues)-max)
+			break
+		}
+		fmt.Fprintf(w, "  %s\n", issue)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// minWatchInterval keeps polling well inside SEC fair access
+const minWatchInterval = 30 * time.Second
+
+// runWatch implements "goedgar watch": poll the submissions of one or more
+// issuers and write each new filing, parsed, as one NDJSON line
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	var (
+		email      string
+		cik        string
+		ticker     string
+		forms      string
+		interval   time.Duration
+		since      string
+		outputPath string
+		once       bool
+		canonical  bool
+		quiet      bool
+		configPath string
+		netOpts    networkOptions
+	)
+	fs.StringVar(&email, "email", "", "Email for SEC User-Agent header (or use SEC_EMAIL env var)")
+	fs.StringVar(&email, "e", "", "Email for SEC User-Agent (shorthand)")
+	fs.StringVar(&cik, "cik", "", "CIK to watch, or a comma-separated list")
+	fs.StringVar(&ticker, "ticker", "", "Ticker to watch, or a comma-separated list")
+	fs.StringVar(&forms, "form", "4", "Form types to watch, comma-separated (e.g. 4,13D)")
+	fs.DurationVar(&interval, "interval", 10*time.Minute, "Time between polls (at least 30s)")
+	fs.StringVar(&since, "since", "", "Also emit filings filed on or after this date (YYYY-MM-DD) on the first poll")
+	fs.StringVar(&outputPath, "output", "", "File to append NDJSON to (default: stdout)")
+	fs.StringVar(&outputPath, "o", "", "File to append NDJSON to (shorthand)")
+	fs.BoolVar(&once, "once", false, "Poll once and exit (for cron)")
+	fs.BoolVar(&canonical, "canonical", false, "Deterministic NDJSON: sorted keys, [] and {} instead of null for empty lists and maps")
+	fs.BoolVar(&quiet, "quiet", false, "Only print warnings and errors")
+	fs.BoolVar(&quiet, "q", false, "Only print warnings and errors (shorthand)")
+	fs.StringVar(&configPath, "config", "", "Config file (default: ~/.config/goedgar/config.yaml or config.toml)")
+	fs.DurationVar(&netOpts.timeout, "timeout", 30*time.Second, "Timeout for each SEC request")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: goedgar watch (--cik <CIK> | --ticker <TICKER>) [--form 4,13D] [--interval 10m] [-o file]\n\n")
+		fmt.Fprintf(os.Stderr, "Poll for new filings and write each one, parsed, as a line of NDJSON.\n")
+		fmt.Fprintf(os.Stderr, "Filings already on EDGAR when the watch starts are skipped unless --since is given.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  goedgar watch --ticker PFE --form 4,13D -o pfe.ndjson\n")
+		fmt.Fprintf(os.Stderr, "  goedgar watch --cik 1682852,78003 --form 4 --interval 5m | jq -c .issuer\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	netOpts.rate = cfg.RateLimit
+	if email == "" && os.Getenv(edgar.SecEmailEnvVar) == "" {
+		email = cfg.Email
+	}
+	if email == "" {
+		if email, err = edgar.GetSecEmail(); err != nil {
+			return err
+		}
+	}
+	if interval < minWatchInterval {
+		return fmt.Errorf("--interval must be at least %s", minWatchInterval)
+	}
+	if since != "" {
+		if _, err := time.Parse("2006-01-02", since); err != nil {
+			return fmt.Errorf("invalid --since date %q (expected YYYY-MM-DD)", since)
+		}
+	}
+
+	var ciks []string
+	switch {
+	case cik != "" && ticker != "":
+		return fmt.Errorf("use either --cik or --ticker")
+	case ticker != "":
+		ciks, err = resolveTickers(ticker, email, netOpts)
+	default:
+		ciks, err = parseCIKs(cik, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if outputPath != "" && outputPath != "-" {
+		f, err := os.OpenFile(outputPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	client, err := newClient(email, netOpts)
+	if err != nil {
+		return err
+	}
+	logLevel := "info"
+	if quiet {
+		logLevel = "error"
+	}
+	logger, err := newLogger(logLevel, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	w := &watcher{
+		client:    client,
+		ciks:      ciks,
+		forms:     strings.Split(forms, ","),
+		since:     since,
+		seen:      make(map[string]bool),
+		baselined: make(map[string]bool),
+		enc:       edgar.NewNDJSONEncoder(out),
+		log:       logger,
+	}
+	w.enc.SetCanonical(canonical)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Info("watching for filings", "ciks", strings.Join(ciks, ","), "forms", forms, "interval", interval)
+	for {
+		if err := w.poll(); err != nil {
+			// Keep watching through transient SEC or network errors
+			if once {
+				return err
+			}
+			logger.Warn("poll failed", "error", err)
+		}
+		if once {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			logger.Info("stopped watching")
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// watcher remembers which filings have been seen between polls
+type watcher struct {
+	client *edgar.Client
+	ciks   []string
+	forms  []string
+	since  string
+	seen   map[string]bool // Accession numbers emitted, skipped, or failed for good
+	enc    *edgar.NDJSONEncoder
+	log    *slog.Logger
+	// CIKs whose existing filings have been recorded
+	baselined map[string]bool
+}
+
+// poll checks every CIK for filings not seen before and writes them, oldest
+// first. The first time a CIK's submissions are fetched its existing filings
+// are only recorded, except those filed on or after --since. A filing that
+// fails with a retryable error (e.g. SEC 5xx) is tried again on the next poll.
+func (w *watcher) poll() error {
+	var errs []error
+	for _, cik := range w.ciks {
+		subs, err := w.client.FetchSubmissions(cik)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CIK %s: %w", cik, err))
+			continue
+		}
+
+		baseline := !w.baselined[cik]
+		w.baselined[cik] = true
+
+		for _, filing := range w.matching(subs.GetRecentFilings()) {
+			if w.seen[filing.AccessionNumber] {
+				continue
+			}
+			if baseline && (w.since == "" || filing.FilingDate < w.since) {
+				w.seen[filing.AccessionNumber] = true
+				continue
+			}
+
+			parsed, err := w.client.FetchFiling(filing)
+			if err != nil {
+				retry := edgar.IsRetryable(err)
+				w.seen[filing.AccessionNumber] = !retry
+				w.log.Warn("failed to process filing", "accession", filing.AccessionNumber, "retry", retry, "error", err)
+				continue
+			}
+			if err := w.enc.Encode(parsed); err != nil {
+				return err
+			}
+			w.seen[filing.AccessionNumber] = true
+			w.log.Info("new filing", "cik", cik, "form", filing.Form, "accession", filing.AccessionNumber, "filed", filing.FilingDate)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matching returns the filings of the watched form types, oldest first
+func (w *watcher) matching(filings []edgar.Filing) []edgar.Filing {
+	var matched []edgar.Filing
+	included := make(map[string]bool)
+	for _, form := range w.forms {
+		if form = strings.TrimSpace(form); form == "" {
+			continue
+		}
+		for _, filing := range edgar.FilterByForm(filings, form) {
+			if !included[filing.AccessionNumber] {
+				included[filing.AccessionNumber] = true
+				matched = append(matched, filing)
+			}
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].FilingDate != matched[j].FilingDate {
+			return matched[i].FilingDate < matched[j].FilingDate
+		}
+		return matched[i].AcceptanceDateTime < matched[j].AcceptanceDateTime
+	})
+	return matched
+}
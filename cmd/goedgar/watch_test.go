@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// handlerTransport serves every request from a handler, whatever its host
+type handlerTransport struct{ handler http.Handler }
+
+func (t handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, r)
+	return rec.Result(), nil
+}
+
+func TestWatcherPoll(t *testing.T) {
+	form4, err := os.ReadFile("../../testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read Form 4: %v", err)
+	}
+
+	// Recent Form 4s per CIK as "filingDate|accession"; the test adds filings
+	// between polls
+	filings := map[string][]string{
+		"0000000001": {"2024-01-02|0000000001-24-000001"},
+		"0000000002": {"2024-01-03|0000000002-24-000001"},
+	}
+	submissionsDown := map[string]bool{"0000000002": true}
+	docStatus := make(map[string]int) // Status to answer for a document, once
+	docRequests := make(map[string]int)
+
+	client := edgar.NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: handlerTransport{http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if strings.HasPrefix(path, "/submissions/CIK") {
+			cik := strings.TrimSuffix(strings.TrimPrefix(path, "/submissions/CIK"), ".json")
+			if submissionsDown[cik] {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			var forms, dates, accessions, docs []string
+			for _, f := range filings[cik] {
+				date, accession, _ := strings.Cut(f, "|")
+				forms, dates, accessions = append(forms, `"4"`), append(dates, `"`+date+`"`), append(accessions, `"`+accession+`"`)
+				docs = append(docs, `"`+accession+`.xml"`)
+			}
+			fmt.Fprintf(w, `{"cik": "%s", "name": "Company %s", "filings": {"recent": {"form": [%s], "filingDate": [%s], "accessionNumber": [%s], "primaryDocument": [%s]}}}`,
+				strings.TrimLeft(cik, "0"), cik, strings.Join(forms, ","), strings.Join(dates, ","), strings.Join(accessions, ","), strings.Join(docs, ","))
+			return
+		}
+		doc := path[strings.LastIndex(path, "/")+1:]
+		docRequests[doc]++
+		if status := docStatus[doc]; status != 0 {
+			delete(docStatus, doc)
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		w.Write(form4)
+	})}}
+
+	var out bytes.Buffer
+	logger, _ := newLogger("error", io.Discard)
+	w := &watcher{
+		client:    client,
+		ciks:      []string{"0000000001", "0000000002"},
+		forms:     []string{"4"},
+		seen:      make(map[string]bool),
+		baselined: make(map[string]bool),
+		enc:       edgar.NewNDJSONEncoder(&out),
+		log:       logger,
+	}
+	lines := func() int { return strings.Count(out.String(), "\n") }
+
+	// CIK 2's submissions fail, so its existing filing isn't recorded yet
+	if err := w.poll(); err == nil || !strings.Contains(err.Error(), "CIK 0000000002") {
+		t.Errorf("first poll error = %v, want CIK 0000000002 failure", err)
+	}
+	if lines() != 0 {
+		t.Fatalf("first poll wrote %d filings, want 0", lines())
+	}
+
+	// CIK 2 is baselined when its submissions come back; a new CIK 1 filing
+	// fails with a 503 and one with a 404
+	submissionsDown["0000000002"] = false
+	filings["0000000001"] = append(filings["0000000001"], "2024-02-01|0000000001-24-000002", "2024-02-02|0000000001-24-000003")
+	docStatus["0000000001-24-000002.xml"] = http.StatusServiceUnavailable
+	docStatus["0000000001-24-000003.xml"] = http.StatusNotFound
+	if err := w.poll(); err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if lines() != 0 {
+		t.Fatalf("second poll wrote %d filings, want 0 (CIK 2's existing filing is not new)", lines())
+	}
+
+	// The 503 is retried and written; the 404 is not fetched again
+	if err := w.poll(); err != nil {
+		t.Fatalf("third poll: %v", err)
+	}
+	if lines() != 1 {
+		t.Fatalf("third poll wrote %d filings, want 1", lines())
+	}
+	if docRequests["0000000001-24-000002.xml"] != 2 || docRequests["0000000001-24-000003.xml"] != 1 {
+		t.Errorf("document requests = %v", docRequests)
+	}
+	if docRequests["0000000001-24-000001.xml"] != 0 || docRequests["0000000002-24-000001.xml"] != 0 {
+		t.Errorf("existing filings were fetched: %v", docRequests)
+	}
+}
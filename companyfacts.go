@@ -0,0 +1,142 @@
+package edgar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CompanyFacts is the XBRL company facts JSON for a CIK
+// (https://data.sec.gov/api/xbrl/companyfacts/CIK##########.json)
+type CompanyFacts struct {
+	CIK        int                                  `json:"cik"`
+	EntityName string                               `json:"entityName"`
+	Facts      map[string]map[string]CompanyConcept `json:"facts"` // Taxonomy ("dei", "us-gaap") -> concept name
+}
+
+// CompanyConcept holds every reported value of one concept, keyed by unit ("shares", "USD")
+type CompanyConcept struct {
+	Label       string                   `json:"label"`
+	Description string                   `json:"description"`
+	Units       map[string][]CompanyFact `json:"units"`
+}
+
+// CompanyFact is one reported value of a concept
+type CompanyFact struct {
+	Start string  `json:"start,omitempty"` // Period start (duration facts only)
+	End   string  `json:"end"`             // Period end (instant facts) or as-of date
+	Val   float64 `json:"val"`
+	Accn  string  `json:"accn"` // Accession number of the reporting filing
+	FY    int     `json:"fy"`
+	FP    string  `json:"fp"`
+	Form  string  `json:"form"`
+	Filed string  `json:"filed"`
+	Frame string  `json:"frame,omitempty"`
+}
+
+// SharesOutstanding is an issuer's reported share count and where it came from
+type SharesOutstanding struct {
+	Shares          int64  `json:"shares"`
+	AsOf            string `json:"asOf"`    // YYYY-MM-DD
+	Concept         string `json:"concept"` // e.g. "dei:EntityCommonStockSharesOutstanding"
+	Form            string `json:"form"`    // Reporting filing, e.g. "10-Q"
+	Accession       string `json:"accession"`
+	Filed           string `json:"filed"`
+	MultipleClasses bool   `json:"multipleClasses,omitempty"` // Several values for the date (one per share class)
+}
+
+// sharesOutstandingConcepts are tried in order; the DEI cover page count is the
+// most recent figure a filer reports
+var sharesOutstandingConcepts = []struct{ taxonomy, name string }{
+	{"dei", "EntityCommonStockSharesOutstanding"},
+	{"us-gaap", "CommonStockSharesOutstanding"},
+}
+
+// CompanyFactsURL returns the company facts API URL for a CIK (zero-padded to
+// 10 digits; an invalid CIK is used as given)
+func CompanyFactsURL(cik string) string {
+	padded, err := PadCIK(cik)
+	if err != nil {
+		padded = strings.TrimSpace(cik)
+	}
+	return fmt.Sprintf("https://data.sec.gov/api/xbrl/companyfacts/CIK%s.json", padded)
+}
+
+// FetchCompanyFacts fetches and parses the XBRL company facts JSON from SEC
+func FetchCompanyFacts(cik string, email string) (*CompanyFacts, error) {
+	return defaultClient(email).FetchCompanyFacts(cik)
+}
+
+// FetchCompanyFacts fetches and parses the XBRL company facts JSON from SEC
+func (c *Client) FetchCompanyFacts(cik string) (*CompanyFacts, error) {
+	body, err := c.get(CompanyFactsURL(cik))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch company facts: %w", err)
+	}
+	defer body.Close()
+	return ParseCompanyFacts(body)
+}
+
+// ParseCompanyFacts parses a company facts JSON from a reader (for local files or testing)
+func ParseCompanyFacts(r io.Reader) (*CompanyFacts, error) {
+	var facts CompanyFacts
+	if err := json.NewDecoder(r).Decode(&facts); err != nil {
+		return nil, fmt.Errorf("failed to parse company facts JSON: %w", err)
+	}
+	return &facts, nil
+}
+
+// SharesOutstanding returns the latest reported shares outstanding on or before
+// asOf (YYYY-MM-DD). An empty asOf, or no value that early, returns the latest
+// value. When a filing reports one value per share class the first is used and
+// MultipleClasses is set.
+func (f *CompanyFacts) SharesOutstanding(asOf string) (SharesOutstanding, bool) {
+	for _, concept := range sharesOutstandingConcepts {
+		facts := f.Facts[concept.taxonomy][concept.name].Units["shares"]
+		if len(facts) == 0 {
+			continue
+		}
+
+		best := latestFact(facts, asOf)
+		if best < 0 && asOf != "" {
+			best = latestFact(facts, "")
+		}
+		if best < 0 {
+			continue
+		}
+
+		fact := facts[best]
+		out := SharesOutstanding{
+			Shares:    int64(fact.Val),
+			AsOf:      fact.End,
+			Concept:   concept.taxonomy + ":" + concept.name,
+			Form:      fact.Form,
+			Accession: fact.Accn,
+			Filed:     fact.Filed,
+		}
+		for i, other := range facts {
+			if i != best && other.End == fact.End && other.Accn == fact.Accn && other.Val != fact.Val {
+				out.MultipleClasses = true
+			}
+		}
+		return out, true
+	}
+	return SharesOutstanding{}, false
+}
+
+// latestFact returns the index of the fact with the latest end date (then latest
+// filing) not after asOf, or -1
+func latestFact(facts []CompanyFact, asOf string) int {
+	best := -1
+	for i, fact := range facts {
+		if fact.Val <= 0 || (asOf != "" && fact.End > asOf) {
+			continue
+		}
+		if best < 0 || fact.End > facts[best].End ||
+			(fact.End == facts[best].End && fact.Filed > facts[best].Filed) {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,74 @@
+package edgar
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Footnote markers and separators seen around CUSIPs on cover pages, e.g.
+// "088786108**", "88160R 10 1", "G0403H-10-8", "037833100(1)". Asterisks are
+// only stripped at the end since "*" is a valid CUSIP character.
+var (
+	cusipSeparators = regexp.MustCompile(`\(\d+\)|[\s\x{00a0}-]+`)
+	cusipFootnotes  = regexp.MustCompile(`[*†‡§]+$`)
+)
+
+// NormalizeCUSIP cleans an extracted CUSIP: footnote markers, spaces and
+// hyphens are removed and letters are uppercased. The result is not validated
+// (see ValidateCUSIP).
+func NormalizeCUSIP(raw string) string {
+	cusip := cusipSeparators.ReplaceAllString(raw, "")
+	return strings.ToUpper(cusipFootnotes.ReplaceAllString(cusip, ""))
+}
+
+// ValidateCUSIP checks that cusip is 9 characters (6-character issuer, 2-character
+// issue, check digit) and that the check digit matches. Pass NormalizeCUSIP output.
+func ValidateCUSIP(cusip string) error {
+	if len(cusip) != 9 {
+		return fmt.Errorf("invalid CUSIP %q: expected 9 characters, got %d", cusip, len(cusip))
+	}
+
+	sum := 0
+	for i := 0; i < 8; i++ {
+		v, ok := cusipCharValue(cusip[i])
+		if !ok {
+			return fmt.Errorf("invalid CUSIP %q: unexpected character %q", cusip, cusip[i])
+		}
+		// Double every second character, then add the digits of each value
+		if i%2 == 1 {
+			v *= 2
+		}
+		sum += v/10 + v%10
+	}
+
+	want := byte('0' + (10-sum%10)%10)
+	if cusip[8] != want {
+		return fmt.Errorf("invalid CUSIP %q: check digit %q, expected %q", cusip, cusip[8], want)
+	}
+	return nil
+}
+
+// cusipCharValue maps a CUSIP character to its check-digit value
+// (0-9 for digits, 10-35 for A-Z, then *, @ and # for private placements)
+func cusipCharValue(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10, true
+	case c == '*':
+		return 36, true
+	case c == '@':
+		return 37, true
+	case c == '#':
+		return 38, true
+	}
+	return 0, false
+}
+
+// setIssuerCUSIP stores the normalized CUSIP and whether it passes validation
+func (s *Schedule13Filing) setIssuerCUSIP(raw string) {
+	s.IssuerCUSIP = NormalizeCUSIP(raw)
+	s.IssuerCUSIPValid = ValidateCUSIP(s.IssuerCUSIP) == nil
+}
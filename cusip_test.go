@@ -0,0 +1,60 @@
+package edgar
+
+import "testing"
+
+func TestNormalizeCUSIP(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"088786108", "088786108"},
+		{" 088786108** ", "088786108"},
+		{"07725L102†", "07725L102"},
+		{"88160R 10 1", "88160R101"},
+		{"g0403h-10-8", "G0403H108"},
+		{"037833100(1)", "037833100"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeCUSIP(tt.raw); got != tt.want {
+			t.Errorf("NormalizeCUSIP(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCUSIP(t *testing.T) {
+	valid := []string{"037833100", "00032Q104", "48213Y107", "88160R101", "G0403H108"}
+	for _, c := range valid {
+		if err := ValidateCUSIP(c); err != nil {
+			t.Errorf("ValidateCUSIP(%q) = %v, want nil", c, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"03783310",    // Too short
+		"037833101",   // Wrong check digit
+		"03783$100",   // Invalid character
+		"Page 2 of 9", // Extraction picked up surrounding text
+	}
+	for _, c := range invalid {
+		if err := ValidateCUSIP(c); err == nil {
+			t.Errorf("ValidateCUSIP(%q) = nil, want error", c)
+		}
+	}
+}
+
+func TestSchedule13IssuerCUSIPValid(t *testing.T) {
+	var f Schedule13Filing
+	f.setIssuerCUSIP("088786108**")
+	if f.IssuerCUSIP != "088786108" || !f.IssuerCUSIPValid {
+		t.Errorf("got %q valid=%v, want 088786108 valid=true", f.IssuerCUSIP, f.IssuerCUSIPValid)
+	}
+
+	f.setIssuerCUSIP("See Item 2(e)")
+	if f.IssuerCUSIPValid {
+		t.Errorf("%q should not be valid", f.IssuerCUSIP)
+	}
+	if out := f.ToOutput(); out.Issuer.CUSIPValid {
+		t.Error("output cusipValid should be false")
+	}
+}
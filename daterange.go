@@ -0,0 +1,60 @@
+package edgar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LastDateRange expands a relative period such as "90d", "12w", "6m" or "1y"
+// into the from and to dates (YYYY-MM-DD, inclusive) ending on now's date
+func LastDateRange(period string, now time.Time) (from, to string, err error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if len(period) < 2 {
+		return "", "", fmt.Errorf("invalid period %q (expected e.g. 90d, 12w, 6m or 1y)", period)
+	}
+	n, err := strconv.Atoi(period[:len(period)-1])
+	if err != nil || n <= 0 {
+		return "", "", fmt.Errorf("invalid period %q (expected e.g. 90d, 12w, 6m or 1y)", period)
+	}
+
+	var start time.Time
+	switch period[len(period)-1] {
+	case 'd':
+		start = now.AddDate(0, 0, -n)
+	case 'w':
+		start = now.AddDate(0, 0, -7*n)
+	case 'm':
+		start = now.AddDate(0, -n, 0)
+	case 'y':
+		start = now.AddDate(-n, 0, 0)
+	default:
+		return "", "", fmt.Errorf("invalid period %q (unit must be d, w, m or y)", period)
+	}
+	return start.Format("2006-01-02"), now.Format("2006-01-02"), nil
+}
+
+// YearDateRange expands a calendar year ("2024") or quarter ("2024Q3") into
+// its first and last dates (YYYY-MM-DD, inclusive)
+func YearDateRange(spec string) (from, to string, err error) {
+	spec = strings.ToUpper(strings.TrimSpace(spec))
+	yearPart, quarterPart, hasQuarter := strings.Cut(spec, "Q")
+	year, err := strconv.Atoi(strings.TrimSuffix(yearPart, "-"))
+	if err != nil || year < 1900 || year > 9999 {
+		return "", "", fmt.Errorf("invalid year %q (expected e.g. 2024 or 2024Q3)", spec)
+	}
+
+	startMonth, months := time.January, 12
+	if hasQuarter {
+		quarter, err := strconv.Atoi(quarterPart)
+		if err != nil || quarter < 1 || quarter > 4 {
+			return "", "", fmt.Errorf("invalid quarter %q (expected Q1 to Q4)", spec)
+		}
+		startMonth, months = time.Month(3*quarter-2), 3
+	}
+
+	start := time.Date(year, startMonth, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, months, -1)
+	return start.Format("2006-01-02"), end.Format("2006-01-02"), nil
+}
@@ -0,0 +1,61 @@
+package edgar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastDateRange(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		period   string
+		from, to string
+	}{
+		{"90d", "2024-12-31", "2025-03-31"},
+		{"2w", "2025-03-17", "2025-03-31"},
+		{"3M", "2024-12-31", "2025-03-31"},
+		{"1y", "2024-03-31", "2025-03-31"},
+	}
+	for _, tt := range tests {
+		from, to, err := LastDateRange(tt.period, now)
+		if err != nil {
+			t.Fatalf("LastDateRange(%q): %v", tt.period, err)
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("LastDateRange(%q) = %s..%s, want %s..%s", tt.period, from, to, tt.from, tt.to)
+		}
+	}
+
+	for _, period := range []string{"", "d", "90", "0d", "-5d", "3q"} {
+		if _, _, err := LastDateRange(period, now); err == nil {
+			t.Errorf("LastDateRange(%q) expected an error", period)
+		}
+	}
+}
+
+func TestYearDateRange(t *testing.T) {
+	tests := []struct {
+		spec     string
+		from, to string
+	}{
+		{"2024", "2024-01-01", "2024-12-31"},
+		{"2024Q1", "2024-01-01", "2024-03-31"},
+		{"2024-q2", "2024-04-01", "2024-06-30"},
+		{"2023Q4", "2023-10-01", "2023-12-31"},
+	}
+	for _, tt := range tests {
+		from, to, err := YearDateRange(tt.spec)
+		if err != nil {
+			t.Fatalf("YearDateRange(%q): %v", tt.spec, err)
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("YearDateRange(%q) = %s..%s, want %s..%s", tt.spec, from, to, tt.from, tt.to)
+		}
+	}
+
+	for _, spec := range []string{"", "24", "2024Q5", "2024Q", "last year"} {
+		if _, _, err := YearDateRange(spec); err == nil {
+			t.Errorf("YearDateRange(%q) expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package edgar parses SEC EDGAR filings into Go structs.
+//
+// Supported forms:
+//   - Form 4 (insider transactions) via Parse and Form4.ToOutput
+//   - Schedule 13D/G (5%+ beneficial ownership, XML and HTML) via ParseSchedule13Auto
+//   - 10-K/10-Q financial statements (inline and standalone XBRL) via ParseXBRLAuto
+//
+// ParseAny auto-detects the form type and returns a ParsedForm whose Data
+// field holds the simplified output for that form. FetchForm and
+// FetchAndParseBatch download filings from the SEC with rate limiting and the
+// User-Agent header the SEC requires (a real contact email).
+//
+// Parse a Form 4 from disk:
+//
+//	data, _ := os.ReadFile("ownership.xml")
+//	form4, err := edgar.Parse(data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, txn := range form4.GetSales() {
+//		fmt.Println(txn.TransactionDate, txn.Amounts.Shares.Value)
+//	}
+//
+// Extract a financial snapshot from a 10-K:
+//
+//	xbrl, err := edgar.ParseXBRLAuto(data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	snapshot, _ := xbrl.GetSnapshot()
+//	if snapshot.Revenue != nil {
+//		fmt.Printf("Revenue: %.0f\n", *snapshot.Revenue) // Values are nil when not reported
+//	}
+//
+// See the Example functions for complete, compile-checked usage of each API.
+package edgar
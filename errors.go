@@ -0,0 +1,150 @@
+package edgar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+)
+
+// Error categories reported by ErrorCategory
+const (
+	CategoryNetwork     = "network"      // SEC could not be reached, or the connection dropped
+	CategoryRateLimited = "rate_limited" // SEC answered 429 Too Many Requests
+	CategoryNotFound    = "not_found"    // SEC answered 404
+	CategoryHTTP        = "http"         // Any other non-200 SEC response
+	CategoryOffline     = "offline"      // Not in the local store in offline mode
+	CategoryIO          = "io"           // Reading or writing local files
+	CategoryParse       = "parse"        // The document was fetched but could not be parsed
+	CategoryOther       = "other"
+)
+
+// Errors for common failure modes, to test for with errors.Is
+var (
+	ErrRateLimited     = errors.New("rate limited by SEC")   // SEC answered 429 (an *HTTPStatusError)
+	ErrNotFound        = errors.New("not found")             // SEC answered 404, or a requested filing isn't among a CIK's filings
+	ErrUnsupportedForm = errors.New("unsupported form type") // The document is a form this package doesn't parse
+	ErrNotXBRL         = errors.New("not an XBRL document")  // Neither inline nor standalone XBRL, or an XBRL archive
+	ErrSchemaViolation = errors.New("schema violation")      // Strict mode: the XML breaks its SEC schema (a *SchemaError)
+	ErrInvalidValue    = errors.New("invalid value")         // Strict mode: a value lenient parsing records as a ParseWarning
+
+	// A download ended early: fewer bytes than Content-Length, or an HTML/XML
+	// document whose root element is never closed. Retryable (CategoryNetwork).
+	ErrTruncatedDownload = errors.New("truncated download")
+)
+
+// errNotInStore is returned in offline mode for filings missing from the store
+var errNotInStore = errors.New("not in store (offline mode)")
+
+// ParseError is returned when a filing was downloaded (or read from the store)
+// but could not be parsed
+type ParseError struct {
+	AccessionNumber string
+	FormType        string // Form type from the filing index
+	Err             error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse %s: %v", e.AccessionNumber, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// FilingError is the failure of one filing in a batch (see BatchResult.Errors)
+type FilingError struct {
+	AccessionNumber string
+	URL             string
+	Category        string // One of the Category constants
+	Err             error
+}
+
+func (e *FilingError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FilingError) Unwrap() error {
+	return e.Err
+}
+
+// newFilingError classifies a batch failure; anything that isn't a fetch or
+// file error happened while parsing
+func newFilingError(filing Filing, err error) *FilingError {
+	category := fetchCategory(err)
+	if category == "" {
+		category = CategoryParse
+	}
+	return &FilingError{
+		AccessionNumber: filing.AccessionNumber,
+		URL:             filing.URL,
+		Category:        category,
+		Err:             err,
+	}
+}
+
+// ErrorCategory returns the category of an error from this package: the
+// FilingError category, the category of the SEC request or file access that
+// failed, or CategoryParse for a ParseError. Other errors are CategoryOther.
+func ErrorCategory(err error) string {
+	if err == nil {
+		return ""
+	}
+	var filingErr *FilingError
+	if errors.As(err, &filingErr) {
+		return filingErr.Category
+	}
+	if category := fetchCategory(err); category != "" {
+		return category
+	}
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) || errors.Is(err, ErrSchemaViolation) || errors.Is(err, ErrInvalidValue) {
+		return CategoryParse
+	}
+	return CategoryOther
+}
+
+// IsRetryable reports whether retrying later may succeed: network errors,
+// rate limiting and SEC server errors (5xx)
+func IsRetryable(err error) bool {
+	switch ErrorCategory(err) {
+	case CategoryNetwork, CategoryRateLimited:
+		return true
+	case CategoryHTTP:
+		var statusErr *HTTPStatusError
+		return errors.As(err, &statusErr) && statusErr.StatusCode >= 500
+	}
+	return false
+}
+
+// fetchCategory returns the category of a failed SEC request or file access,
+// or "" for other errors
+func fetchCategory(err error) string {
+	var statusErr *HTTPStatusError
+	var netErr net.Error
+	var pathErr *fs.PathError
+	switch {
+	case errors.As(err, &statusErr):
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return CategoryRateLimited
+		case http.StatusNotFound:
+			return CategoryNotFound
+		}
+		return CategoryHTTP
+	case errors.Is(err, ErrNotFound):
+		return CategoryNotFound
+	case errors.Is(err, errNotInStore):
+		return CategoryOffline
+	case errors.As(err, &pathErr):
+		// Before net.Error, which the syscall errors of file access also satisfy
+		return CategoryIO
+	case errors.As(err, &netErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, ErrTruncatedDownload):
+		return CategoryNetwork
+	}
+	return ""
+}
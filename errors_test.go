@@ -0,0 +1,113 @@
+package edgar
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestErrorCategory(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		category  string
+		retryable bool
+	}{
+		{"rate limited", fmt.Errorf("failed to fetch: %w", &HTTPStatusError{StatusCode: 429}), CategoryRateLimited, true},
+		{"not found", &HTTPStatusError{StatusCode: 404}, CategoryNotFound, false},
+		{"server error", &HTTPStatusError{StatusCode: 503}, CategoryHTTP, true},
+		{"forbidden", &HTTPStatusError{StatusCode: 403}, CategoryHTTP, false},
+		{"network", fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "https://www.sec.gov", Err: errors.New("connection refused")}), CategoryNetwork, true},
+		{"truncated stream", &ParseError{AccessionNumber: "0001", Err: fmt.Errorf("failed to parse XBRL: %w", ErrTruncatedDownload)}, CategoryNetwork, true},
+		{"offline", fmt.Errorf("filing 0001 %w", errNotInStore), CategoryOffline, false},
+		{"file", fmt.Errorf("failed to read file: %w", &os.PathError{Op: "open", Path: "x.xml", Err: os.ErrNotExist}), CategoryIO, false},
+		{"other", errors.New("CIK is required"), CategoryOther, false},
+		{"filing parse", newFilingError(Filing{AccessionNumber: "0001"}, errors.New("unknown form type")), CategoryParse, false},
+		{"filing fetch", fmt.Errorf("CIK 1: %w", newFilingError(Filing{}, &HTTPStatusError{StatusCode: 429})), CategoryRateLimited, true},
+	}
+	for _, tt := range tests {
+		if got := ErrorCategory(tt.err); got != tt.category {
+			t.Errorf("%s: ErrorCategory = %q, want %q", tt.name, got, tt.category)
+		}
+		if got := IsRetryable(tt.err); got != tt.retryable {
+			t.Errorf("%s: IsRetryable = %v, want %v", tt.name, got, tt.retryable)
+		}
+	}
+	if ErrorCategory(nil) != "" {
+		t.Error("Expected no category for a nil error")
+	}
+}
+
+func TestFetchAndParseBatch_FilingErrors(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	filing := Filing{
+		CIK:             "1640147",
+		AccessionNumber: "0001640147-25-000001",
+		Form:            "4",
+		FilingDate:      "2025-03-01",
+		URL:             "https://www.sec.gov/Archives/edgar/data/1640147/000164014725000001/ownership.xml",
+	}
+	if err := store.Put(filing, []byte("not a filing")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	result, err := FetchAndParseBatch(BatchOptions{CIK: "1640147", FormType: "4", Store: store, Offline: true})
+	if err != nil {
+		t.Fatalf("FetchAndParseBatch failed: %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", result.Errors)
+	}
+	var filingErr *FilingError
+	if !errors.As(result.Errors[0], &filingErr) {
+		t.Fatalf("Expected a *FilingError, got %T", result.Errors[0])
+	}
+	if filingErr.AccessionNumber != filing.AccessionNumber || filingErr.URL != filing.URL || filingErr.Category != CategoryParse {
+		t.Errorf("Unexpected filing error %+v", filingErr)
+	}
+	var parseErr *ParseError
+	if !errors.As(result.Errors[0], &parseErr) {
+		t.Fatalf("Expected a *ParseError, got %v", result.Errors[0])
+	}
+	if parseErr.AccessionNumber != filing.AccessionNumber || parseErr.FormType != "4" {
+		t.Errorf("Unexpected parse error %+v", parseErr)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	_, notForm := ParseAny(strings.NewReader(`<?xml version="1.0"?><invoice><total>1</total></invoice>`))
+	_, notXBRL := ParseXBRLAuto([]byte(`<?xml version="1.0"?><ownershipDocument/>`))
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"429", fmt.Errorf("failed to fetch: %w", &HTTPStatusError{StatusCode: 429}), ErrRateLimited, true},
+		{"429 not found", &HTTPStatusError{StatusCode: 429}, ErrNotFound, false},
+		{"404", &HTTPStatusError{StatusCode: 404}, ErrNotFound, true},
+		{"503", &HTTPStatusError{StatusCode: 503}, ErrRateLimited, false},
+		{"unsupported form", notForm, ErrUnsupportedForm, true},
+		{"not XBRL", notXBRL, ErrNotXBRL, true},
+		{"parse error", &ParseError{AccessionNumber: "0001", Err: fmt.Errorf("no facts: %w", ErrNotXBRL)}, ErrNotXBRL, true},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, tt.target); got != tt.want {
+			t.Errorf("%s: errors.Is(%v, %v) = %v, want %v", tt.name, tt.err, tt.target, got, tt.want)
+		}
+	}
+
+	if got := ErrorCategory(&ParseError{AccessionNumber: "0001", Err: errors.New("bad XML")}); got != CategoryParse {
+		t.Errorf("ParseError category = %q, want %q", got, CategoryParse)
+	}
+	if got := ErrorCategory(fmt.Errorf("filing 0001 %w for CIK 1", ErrNotFound)); got != CategoryNotFound {
+		t.Errorf("ErrNotFound category = %q, want %q", got, CategoryNotFound)
+	}
+}
@@ -0,0 +1,251 @@
+package edgar_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/RxDataLab/go-edgar"
+)
+
+// ExampleParse parses a Form 4 XML document and walks its open market sales.
+func ExampleParse() {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	form4, err := edgar.Parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(form4.Issuer.Name, form4.Issuer.TradingSymbol)
+	fmt.Println("Reporting owner:", form4.ReportingOwners[0].ID.Name)
+	fmt.Println("Open market sales:", len(form4.GetSales()))
+	// Output:
+	// Snowflake Inc. SNOW
+	// Reporting owner: Scarpelli Michael
+	// Open market sales: 5
+}
+
+// ExampleForm4_ToOutput converts a parsed Form 4 into the simplified JSON output structure.
+func ExampleForm4_ToOutput() {
+	data, err := os.ReadFile("testdata/form4/wave_derivatives/input.xml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	form4, err := edgar.Parse(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := form4.ToOutput()
+	txn := out.Transactions[0]
+	fmt.Println(out.Issuer.Ticker, txn.TransactionCode, *txn.Shares)
+	fmt.Println("10b5-1 plan:", txn.Is10b51Plan, *txn.Plan10b51AdoptionDate)
+	// Output:
+	// WVE M 60000
+	// 10b5-1 plan: true 2025-03-13
+}
+
+// ExampleParseAny auto-detects the form type before parsing.
+func ExampleParseAny() {
+	data, err := os.ReadFile("testdata/form4/arrowhead_footnotes/input.xml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	form, err := edgar.ParseAny(bytes.NewReader(data))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Form type:", form.FormType)
+	if f4, ok := form.Data.(*edgar.Form4Output); ok {
+		fmt.Println(f4.Issuer.Name)
+	}
+	// Output:
+	// Form type: 4
+	// ARROWHEAD PHARMACEUTICALS, INC.
+}
+
+// ExampleExtract10b51 analyzes footnote text for a Rule 10b5-1 trading plan.
+func ExampleExtract10b51() {
+	result := edgar.Extract10b51("The sales were effected pursuant to a Rule 10b5-1 trading plan adopted by the reporting person on March 13, 2025.")
+
+	fmt.Println(result.Is10b51Plan, *result.TenB51AdoptionDate)
+	// Output:
+	// true 2025-03-13
+}
+
+// ExampleParseSchedule13Auto parses an HTML Schedule 13D without knowing its format in advance.
+func ExampleParseSchedule13Auto() {
+	data, err := os.ReadFile("testdata/schedule13/vtv_13d_item4/input.htm")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filing, err := edgar.ParseSchedule13Auto(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(filing.FormType, filing.IsActivist())
+	fmt.Println("Has Item 4:", filing.Items13D != nil && filing.Items13D.Item4PurposeOfTransaction != "")
+	// Output:
+	// SC 13D/A true
+	// Has Item 4: true
+}
+
+// ExampleXBRL_GetSnapshot extracts key metrics from an inline XBRL 10-K.
+func ExampleXBRL_GetSnapshot() {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	xbrl, err := edgar.ParseXBRLAuto(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	snapshot, err := xbrl.GetSnapshot()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(snapshot.CompanyName, snapshot.FormType, snapshot.FiscalYearEnd)
+	fmt.Printf("Cash: $%.2fB\n", *snapshot.Cash/1e9)
+	// Output:
+	// Moderna, Inc. 10-K 2024-12-31
+	// Cash: $1.93B
+}
+
+// ExampleXBRL_Query uses the fluent query interface to find a single fact.
+func ExampleXBRL_Query() {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	xbrl, err := edgar.ParseInlineXBRL(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fact, err := xbrl.Query().
+		ByLabel("Research and Development Expense").
+		DurationOnly().
+		ForPeriodEndingOn("2024-12-31").
+		First()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	value, _ := fact.Float64()
+	fmt.Printf("%s: $%.2fB (%s)\n", fact.Concept, value/1e9, fact.GetPeriodLabel())
+	// Output:
+	// us-gaap:ResearchAndDevelopmentExpense: $4.54B (2024-01-01 to 2024-12-31)
+}
+
+// ExampleParseSubmissions lists a company's recent Form 4 filings from a submissions JSON file.
+func ExampleParseSubmissions() {
+	f, err := os.Open("testdata/cik/CIK0000078003.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	subs, err := edgar.ParseSubmissions(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	filings := edgar.FilterByForm(subs.GetRecentFilings(), "4")
+	filings = edgar.FilterByDateRange(filings, "2025-12-01", "2025-12-31")
+
+	fmt.Println(subs.Name)
+	fmt.Println(strings.HasPrefix(filings[0].URL, "https://www.sec.gov/Archives/edgar/data/78003/"))
+	// Output:
+	// PFIZER INC
+	// true
+}
+
+// ExampleFilterByForm shows how Schedule 13 filters include amendments while Form 4 filters do not.
+func ExampleFilterByForm() {
+	filings := []edgar.Filing{
+		{Form: "4"},
+		{Form: "4/A"},
+		{Form: "SC 13D"},
+		{Form: "SC 13D/A"},
+		{Form: "SC 13G"},
+	}
+
+	fmt.Println(len(edgar.FilterByForm(filings, "4")))
+	fmt.Println(len(edgar.FilterByForm(filings, "13D")))
+	fmt.Println(len(edgar.FilterByForm(filings, "13")))
+	// Output:
+	// 1
+	// 2
+	// 3
+}
+
+// ExampleExtractMetadataFromURL derives the CIK and accession number used for smart file naming.
+func ExampleExtractMetadataFromURL() {
+	meta, err := edgar.ExtractMetadataFromURL("https://www.sec.gov/Archives/edgar/data/1631574/000119312525314736/ownership.xml")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(meta.CIK, meta.Accession)
+	fmt.Println(edgar.GenerateFilename(meta, "json"))
+	// Output:
+	// 1631574 0001193125-25-314736
+	// 1631574-0001193125-25-314736_ownership.json
+}
+
+// ExampleFetchForm downloads a single filing. The SEC requires a real contact email.
+// This example is compile-checked only because it needs network access.
+func ExampleFetchForm() {
+	email, err := edgar.GetSecEmail()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := edgar.FetchForm("https://www.sec.gov/Archives/edgar/data/1631574/000119312525314736/ownership.xml", email)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	form, err := edgar.ParseAny(bytes.NewReader(data))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(form.FormType)
+}
+
+// ExampleFetchAndParseBatch downloads and parses every Form 4 filed for a CIK in a date range.
+// This example is compile-checked only because it needs network access.
+func ExampleFetchAndParseBatch() {
+	result, err := edgar.FetchAndParseBatch(edgar.BatchOptions{
+		CIK:      "1631574",
+		FormType: "4",
+		DateFrom: "2025-01-01",
+		DateTo:   "2025-06-30",
+		Email:    os.Getenv(edgar.SecEmailEnvVar),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, form := range result.Filings {
+		if f4, ok := form.Data.(*edgar.Form4Output); ok {
+			fmt.Println(f4.Metadata.AccessionNumber, len(f4.Transactions))
+		}
+	}
+	fmt.Printf("Parsed %d of %d filings (%d errors)\n", result.Fetched, result.TotalFound, len(result.Errors))
+}
@@ -0,0 +1,192 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Form4CSVHeader is the column layout used by WriteForm4CSV (one row per transaction)
+var Form4CSVHeader = []string{
+	"accession_number",
+	"filing_date",
+	"period_of_report",
+	"issuer_cik",
+	"issuer_name",
+	"ticker",
+	"owner_cik",
+	"owner_name",
+	"owner_relationship",
+	"table",
+	"security_title",
+	"transaction_date",
+	"transaction_code",
+	"transaction_description",
+	"acquired_disposed",
+	"shares",
+	"price_per_share",
+	"value",
+	"shares_owned_following",
+	"direct_indirect",
+	"is_10b5_1_plan",
+	"plan_10b5_1_adoption_date",
+	"footnotes",
+}
+
+// WriteForm4CSV writes Form 4 transactions (non-derivative and derivative) as CSV
+// rows with a header, one row per transaction per reporting owner (see
+// Form4Output.Rows).
+func WriteForm4CSV(w io.Writer, forms ...*Form4Output) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(Form4CSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, f := range forms {
+		for _, record := range form4CSVRecords(f) {
+			if err := cw.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteSnapshotCSV writes financial snapshots as CSV rows with a header, one
+// row per snapshot, so a batch of 10-K/10-Q filings becomes a table of
+// historical financials. Columns are the snapshot's JSON field names, as in
+// the Parquet export; missing values are empty.
+func WriteSnapshotCSV(w io.Writer, snaps ...*FinancialSnapshot) error {
+	columns := snapshotColumns()
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for j, col := range columns {
+		header[j] = col.name
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, snap := range snaps {
+		record := make([]string, len(columns))
+		for j, col := range columns {
+			switch v := col.value(snap).(type) {
+			case nil:
+			case float64:
+				record[j] = strconv.FormatFloat(v, 'f', -1, 64)
+			default:
+				record[j] = fmt.Sprint(v)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// FormatCSV returns CSV for a parsed form (Form 4 or XBRL)
+func FormatCSV(form *ParsedForm) ([]byte, error) {
+	return FormatCSVBatch([]*ParsedForm{form})
+}
+
+// FormatCSVBatch returns CSV rows for a batch of parsed forms: one row per
+// transaction for Form 4, one row per snapshot for XBRL. Other form types, or
+// a mix of the two, return an error.
+func FormatCSVBatch(filings []*ParsedForm) ([]byte, error) {
+	forms := make([]*Form4Output, 0, len(filings))
+	var snaps []*FinancialSnapshot
+	for _, f := range filings {
+		switch data := f.Data.(type) {
+		case *Form4Output:
+			forms = append(forms, data)
+		case *FinancialSnapshot:
+			snaps = append(snaps, data)
+		default:
+			return nil, fmt.Errorf("CSV output is only supported for Form 4 and XBRL (got %s)", f.FormType)
+		}
+	}
+	if len(forms) > 0 && len(snaps) > 0 {
+		return nil, fmt.Errorf("CSV output requires a single form type (got Form 4 and XBRL)")
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if len(snaps) > 0 {
+		err = WriteSnapshotCSV(&buf, snaps...)
+	} else {
+		err = WriteForm4CSV(&buf, forms...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// form4CSVRecords flattens one Form 4 into CSV records (see Form4Output.Rows)
+func form4CSVRecords(f *Form4Output) [][]string {
+	var records [][]string
+	for _, row := range f.Rows() {
+		records = append(records, []string{
+			row.AccessionNumber,
+			row.FilingDate,
+			row.PeriodOfReport,
+			row.IssuerCIK,
+			row.IssuerName,
+			row.Ticker,
+			row.OwnerCIK,
+			row.OwnerName,
+			row.OwnerRelationship,
+			row.Table,
+			row.SecurityTitle,
+			row.TransactionDate,
+			row.TransactionCode,
+			row.TransactionDescription,
+			row.AcquiredDisposed,
+			formatCSVFloat(row.Shares),
+			formatCSVFloat(row.PricePerShare),
+			formatCSVValue(row.Value),
+			formatCSVFloat(row.SharesOwnedFollowing),
+			row.DirectIndirect,
+			strconv.FormatBool(row.Is10b51Plan),
+			formatCSVString(row.Plan10b51AdoptionDate),
+			row.Footnotes,
+		})
+	}
+	return records
+}
+
+// formatCSVFloat formats a nullable number (empty cell for null)
+func formatCSVFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+// formatCSVValue formats a nullable dollar amount with cents
+func formatCSVValue(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 2, 64)
+}
+
+func formatCSVString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,105 @@
+package edgar
+
+import (
+	"encoding/csv"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatCSVBatch_Form4(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	form4, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse Form 4: %v", err)
+	}
+	out := form4.ToOutput()
+
+	csvData, err := FormatCSVBatch([]*ParsedForm{{FormType: "4", Data: out}})
+	if err != nil {
+		t.Fatalf("FormatCSVBatch failed: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(csvData))).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+
+	wantRows := len(out.Transactions) + len(out.Derivatives)
+	if len(records) != wantRows+1 {
+		t.Fatalf("Expected header + %d rows, got %d records", wantRows, len(records))
+	}
+
+	col := make(map[string]int)
+	for i, name := range records[0] {
+		col[name] = i
+	}
+
+	first := records[1]
+	if first[col["ticker"]] != "SNOW" {
+		t.Errorf("Expected ticker SNOW, got %s", first[col["ticker"]])
+	}
+	if first[col["transaction_code"]] != "M" || first[col["transaction_description"]] == "" {
+		t.Errorf("Unexpected code columns: %s / %s", first[col["transaction_code"]], first[col["transaction_description"]])
+	}
+	// value = shares * price
+	if first[col["value"]] != "1776000.00" {
+		t.Errorf("Expected value 1776000.00, got %s", first[col["value"]])
+	}
+	if !strings.HasPrefix(first[col["footnotes"]], "[F1] Includes shares") || strings.Contains(first[col["footnotes"]], "\n") {
+		t.Errorf("Expected single-line footnote text, got %q", first[col["footnotes"]])
+	}
+
+	// Schedule 13D/G can't be flattened, nor can Form 4 mixed with XBRL
+	if _, err := FormatCSVBatch([]*ParsedForm{{FormType: "SC 13D", Data: &Schedule13Filing{}}}); err == nil {
+		t.Error("Expected error for Schedule 13D input")
+	}
+	if _, err := FormatCSVBatch([]*ParsedForm{{FormType: "4", Data: out}, {FormType: "XBRL", Data: &FinancialSnapshot{}}}); err == nil {
+		t.Error("Expected error for mixed Form 4 and XBRL input")
+	}
+}
+
+func TestFormatCSVBatch_Snapshots(t *testing.T) {
+	revenue, margin := 1234.5, 0.25
+	snaps := []*ParsedForm{
+		{FormType: "XBRL", Data: &FinancialSnapshot{FiscalYearEnd: "2024-12-31", FiscalYear: 2024, FilingDate: "2025-02-14", Revenue: &revenue, Ratios: FinancialRatios{GrossMargin: &margin}}},
+		{FormType: "XBRL", Data: &FinancialSnapshot{FiscalYearEnd: "2023-12-31", FiscalYear: 2023}},
+	}
+
+	csvData, err := FormatCSVBatch(snaps)
+	if err != nil {
+		t.Fatalf("FormatCSVBatch failed: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(csvData))).ReadAll()
+	if err != nil {
+		t.Fatalf("Output is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected header + 2 rows, got %d records", len(records))
+	}
+
+	col := make(map[string]int)
+	for i, name := range records[0] {
+		col[name] = i
+	}
+	for _, name := range []string{"fiscalYearEnd", "fiscalYear", "filingDate", "revenue", "grossMargin"} {
+		if _, ok := col[name]; !ok {
+			t.Fatalf("Missing column %s in header %v", name, records[0])
+		}
+	}
+
+	first, second := records[1], records[2]
+	if first[col["fiscalYear"]] != "2024" || first[col["filingDate"]] != "2025-02-14" {
+		t.Errorf("Unexpected first row period: %v", first)
+	}
+	if first[col["revenue"]] != "1234.5" || first[col["grossMargin"]] != "0.25" {
+		t.Errorf("Expected revenue 1234.5 and gross margin 0.25, got %s and %s", first[col["revenue"]], first[col["grossMargin"]])
+	}
+	// Missing values are empty, not zero
+	if second[col["revenue"]] != "" || second[col["grossMargin"]] != "" {
+		t.Errorf("Expected empty missing values, got %q and %q", second[col["revenue"]], second[col["grossMargin"]])
+	}
+}
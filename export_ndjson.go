@@ -0,0 +1,69 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// NDJSONEncoder writes parsed forms as newline-delimited JSON (one filing per line)
+//
+// Each line holds the form's Data, matching the elements of FormatJSONBatch's
+// array. Forms are written as they are encoded, so memory stays constant no
+// matter how many filings pass through. Encode is safe for concurrent use.
+type NDJSONEncoder struct {
+	mu        sync.Mutex
+	w         io.Writer
+	enc       *json.Encoder
+	canonical bool
+}
+
+// NewNDJSONEncoder creates an encoder writing to w
+func NewNDJSONEncoder(w io.Writer) *NDJSONEncoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &NDJSONEncoder{w: w, enc: enc}
+}
+
+// SetCanonical makes the encoder write canonical JSON lines (see
+// FormatOptions.Canonical)
+func (e *NDJSONEncoder) SetCanonical(canonical bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.canonical = canonical
+}
+
+// Encode writes one parsed form as a single JSON line
+func (e *NDJSONEncoder) Encode(form *ParsedForm) error {
+	data := form.outputForm().Data
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.canonical {
+		var buf bytes.Buffer
+		if err := encodeCanonical(&buf, data, ""); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write NDJSON: %w", err)
+		}
+		return nil
+	}
+	if err := e.enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to write NDJSON: %w", err)
+	}
+	return nil
+}
+
+// FormatNDJSONBatch returns NDJSON for a batch of parsed forms
+func FormatNDJSONBatch(filings []*ParsedForm) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewNDJSONEncoder(&buf)
+	for _, f := range filings {
+		if err := enc.Encode(f); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,542 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Parquet output
+//
+// A minimal, dependency-free Parquet writer: one row group, one PLAIN-encoded,
+// uncompressed data page per column, all columns OPTIONAL (nulls allowed).
+// This is enough for pandas/pyarrow/DuckDB/Spark to read batch results.
+//
+// Each result type has a fixed column schema:
+//   - Form 4: one row per transaction per owner (same columns as the CSV export)
+//   - Schedule 13D/G: one row per reporting person
+//   - XBRL: one row per financial snapshot
+
+// parquetKind is the physical type of a column
+type parquetKind int
+
+const (
+	parquetString parquetKind = iota
+	parquetDouble
+	parquetInt64
+	parquetBool
+)
+
+// Parquet physical types, encodings and enums (from parquet.thrift)
+const (
+	pqTypeBoolean   = 0
+	pqTypeInt64     = 2
+	pqTypeDouble    = 5
+	pqTypeByteArray = 6
+
+	pqRepetitionOptional = 1
+	pqConvertedUTF8      = 0
+
+	pqEncodingPlain = 0
+	pqEncodingRLE   = 3
+
+	pqCodecUncompressed = 0
+	pqPageTypeData      = 0
+)
+
+// parquetColumn holds one column's values; nil entries are nulls
+type parquetColumn struct {
+	name   string
+	kind   parquetKind
+	values []interface{}
+}
+
+// parquetTable is a set of equal-length columns
+type parquetTable struct {
+	columns []*parquetColumn
+	rows    int
+}
+
+func newParquetTable(schema ...parquetColumn) *parquetTable {
+	t := &parquetTable{}
+	for i := range schema {
+		col := schema[i]
+		t.columns = append(t.columns, &col)
+	}
+	return t
+}
+
+// appendRow adds one row; values must match the column order
+func (t *parquetTable) appendRow(values ...interface{}) {
+	for i, col := range t.columns {
+		col.values = append(col.values, values[i])
+	}
+	t.rows++
+}
+
+// WriteParquet writes batch results as a Parquet file
+// All filings must be of the same kind (Form 4, Schedule 13D/G, or XBRL)
+func WriteParquet(w io.Writer, filings []*ParsedForm) error {
+	table, err := parquetTableFor(filings)
+	if err != nil {
+		return err
+	}
+	return table.write(w)
+}
+
+// FormatParquet returns batch results encoded as a Parquet file
+func FormatParquet(filings []*ParsedForm) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, filings); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parquetTableFor builds the table for a homogeneous batch
+func parquetTableFor(filings []*ParsedForm) (*parquetTable, error) {
+	if len(filings) == 0 {
+		return form4ParquetTable(nil), nil
+	}
+
+	switch filings[0].Data.(type) {
+	case *Form4Output:
+		forms := make([]*Form4Output, 0, len(filings))
+		for _, f := range filings {
+			f4, ok := f.AsForm4Output()
+			if !ok {
+				return nil, fmt.Errorf("parquet output requires a single form type (got Form 4 and %s)", f.FormType)
+			}
+			forms = append(forms, f4)
+		}
+		return form4ParquetTable(forms), nil
+
+	case *Schedule13Filing:
+		forms := make([]*Schedule13Filing, 0, len(filings))
+		for _, f := range filings {
+			sc, ok := f.AsSchedule13()
+			if !ok {
+				return nil, fmt.Errorf("parquet output requires a single form type (got Schedule 13 and %s)", f.FormType)
+			}
+			forms = append(forms, sc)
+		}
+		return schedule13ParquetTable(forms), nil
+
+	case *FinancialSnapshot:
+		snaps := make([]*FinancialSnapshot, 0, len(filings))
+		for _, f := range filings {
+			snap, ok := f.AsSnapshot()
+			if !ok {
+				return nil, fmt.Errorf("parquet output requires a single form type (got XBRL and %s)", f.FormType)
+			}
+			snaps = append(snaps, snap)
+		}
+		return snapshotParquetTable(snaps), nil
+
+	default:
+		return nil, fmt.Errorf("parquet output is not supported for %s", filings[0].FormType)
+	}
+}
+
+// form4ParquetTable flattens Form 4 transactions (one row per transaction per owner)
+func form4ParquetTable(forms []*Form4Output) *parquetTable {
+	t := newParquetTable(
+		parquetColumn{name: "accession_number"},
+		parquetColumn{name: "filing_date"},
+		parquetColumn{name: "period_of_report"},
+		parquetColumn{name: "issuer_cik"},
+		parquetColumn{name: "issuer_name"},
+		parquetColumn{name: "ticker"},
+		parquetColumn{name: "owner_cik"},
+		parquetColumn{name: "owner_name"},
+		parquetColumn{name: "owner_relationship"},
+		parquetColumn{name: "table"},
+		parquetColumn{name: "security_title"},
+		parquetColumn{name: "transaction_date"},
+		parquetColumn{name: "transaction_code"},
+		parquetColumn{name: "transaction_description"},
+		parquetColumn{name: "acquired_disposed"},
+		parquetColumn{name: "shares", kind: parquetDouble},
+		parquetColumn{name: "price_per_share", kind: parquetDouble},
+		parquetColumn{name: "value", kind: parquetDouble},
+		parquetColumn{name: "shares_owned_following", kind: parquetDouble},
+		parquetColumn{name: "direct_indirect"},
+		parquetColumn{name: "is_10b5_1_plan", kind: parquetBool},
+		parquetColumn{name: "plan_10b5_1_adoption_date"},
+		parquetColumn{name: "footnotes"},
+	)
+
+	for _, f := range forms {
+		for _, row := range f.Rows() {
+			t.appendRow(
+				row.AccessionNumber, row.FilingDate, row.PeriodOfReport,
+				row.IssuerCIK, row.IssuerName, row.Ticker,
+				row.OwnerCIK, row.OwnerName, row.OwnerRelationship,
+				row.Table, row.SecurityTitle, row.TransactionDate, row.TransactionCode,
+				row.TransactionDescription, row.AcquiredDisposed,
+				pqFloat(row.Shares), pqFloat(row.PricePerShare), pqFloat(row.Value), pqFloat(row.SharesOwnedFollowing),
+				row.DirectIndirect, row.Is10b51Plan, pqString(row.Plan10b51AdoptionDate), row.Footnotes,
+			)
+		}
+	}
+	return t
+}
+
+// schedule13ParquetTable flattens Schedule 13D/G filings (one row per reporting person)
+func schedule13ParquetTable(filings []*Schedule13Filing) *parquetTable {
+	t := newParquetTable(
+		parquetColumn{name: "form_type"},
+		parquetColumn{name: "is_amendment", kind: parquetBool},
+		parquetColumn{name: "amendment_number", kind: parquetInt64},
+		parquetColumn{name: "filing_date"},
+		parquetColumn{name: "event_date"},
+		parquetColumn{name: "issuer_cik"},
+		parquetColumn{name: "issuer_name"},
+		parquetColumn{name: "issuer_cusip"},
+		parquetColumn{name: "security_title"},
+		parquetColumn{name: "filer_cik"},
+		parquetColumn{name: "person_cik"},
+		parquetColumn{name: "person_name"},
+		parquetColumn{name: "aggregate_amount_owned", kind: parquetInt64},
+		parquetColumn{name: "percent_of_class", kind: parquetDouble},
+		parquetColumn{name: "sole_voting_power", kind: parquetInt64},
+		parquetColumn{name: "shared_voting_power", kind: parquetInt64},
+		parquetColumn{name: "sole_dispositive_power", kind: parquetInt64},
+		parquetColumn{name: "shared_dispositive_power", kind: parquetInt64},
+		parquetColumn{name: "member_of_group"},
+		parquetColumn{name: "type_of_reporting_person"},
+		parquetColumn{name: "citizenship"},
+	)
+
+	for _, f := range filings {
+		var amendment interface{}
+		if f.AmendmentNumber != nil {
+			amendment = int64(*f.AmendmentNumber)
+		}
+		eventDate := f.DateOfEvent
+		if eventDate == "" {
+			eventDate = f.EventDate
+		}
+
+		for _, p := range f.ReportingPersons {
+			t.appendRow(
+				f.FormType, f.IsAmendment, amendment, f.FilingDate, eventDate,
+				f.IssuerCIK, f.IssuerName, f.IssuerCUSIP, f.SecurityTitle, f.FilerCIK,
+				p.CIK, p.Name, p.AggregateAmountOwned, p.PercentOfClass,
+				p.SoleVotingPower, p.SharedVotingPower, p.SoleDispositivePower, p.SharedDispositivePower,
+				p.MemberOfGroup, p.TypeOfReportingPerson, p.Citizenship,
+			)
+		}
+	}
+	return t
+}
+
+// snapshotParquetTable writes one row per FinancialSnapshot, with the columns
+// of snapshotColumns
+func snapshotParquetTable(snaps []*FinancialSnapshot) *parquetTable {
+	columns := snapshotColumns()
+	schema := make([]parquetColumn, len(columns))
+	for j, col := range columns {
+		schema[j] = parquetColumn{name: col.name, kind: parquetString}
+		if col.numeric {
+			schema[j].kind = parquetDouble
+		}
+	}
+
+	t := newParquetTable(schema...)
+	for _, snap := range snaps {
+		row := make([]interface{}, len(columns))
+		for j, col := range columns {
+			row[j] = col.value(snap)
+		}
+		t.appendRow(row...)
+	}
+	return t
+}
+
+// pqFloat converts a nullable number to a column value
+func pqFloat(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func pqString(v *string) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// write encodes the table as a Parquet file
+func (t *parquetTable) write(w io.Writer) error {
+	var file bytes.Buffer
+	file.WriteString("PAR1")
+
+	type chunkInfo struct {
+		offset int64
+		size   int64
+	}
+	chunks := make([]chunkInfo, len(t.columns))
+
+	for i, col := range t.columns {
+		page, err := col.encodePage()
+		if err != nil {
+			return fmt.Errorf("failed to encode column %s: %w", col.name, err)
+		}
+
+		header := &thriftWriter{}
+		header.i32(1, pqPageTypeData)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5) // data_page_header
+		header.i32(1, int32(t.rows))
+		header.i32(2, pqEncodingPlain)
+		header.i32(3, pqEncodingRLE)
+		header.i32(4, pqEncodingRLE)
+		header.structEnd()
+		header.stop()
+
+		chunks[i] = chunkInfo{offset: int64(file.Len()), size: int64(header.buf.Len() + len(page))}
+		file.Write(header.buf.Bytes())
+		file.Write(page)
+	}
+
+	// File metadata (footer)
+	meta := &thriftWriter{}
+	meta.i32(1, 1) // version
+	meta.listBegin(2, thriftStruct, len(t.columns)+1)
+	meta.elemBegin() // root schema element
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(t.columns)))
+	meta.elemEnd()
+	for _, col := range t.columns {
+		meta.elemBegin()
+		meta.i32(1, col.physicalType())
+		meta.i32(3, pqRepetitionOptional)
+		meta.binary(4, col.name)
+		if col.kind == parquetString {
+			meta.i32(6, pqConvertedUTF8)
+		}
+		meta.elemEnd()
+	}
+	meta.i64(3, int64(t.rows))
+
+	var totalSize int64
+	for _, c := range chunks {
+		totalSize += c.size
+	}
+	meta.listBegin(4, thriftStruct, 1) // one row group
+	meta.elemBegin()
+	meta.listBegin(1, thriftStruct, len(t.columns))
+	for i, col := range t.columns {
+		meta.elemBegin() // ColumnChunk
+		meta.i64(2, chunks[i].offset)
+		meta.structBegin(3) // ColumnMetaData
+		meta.i32(1, col.physicalType())
+		meta.listBegin(2, thriftI32, 2)
+		meta.listI32(pqEncodingPlain)
+		meta.listI32(pqEncodingRLE)
+		meta.listBegin(3, thriftBinary, 1)
+		meta.listBinary(col.name)
+		meta.i32(4, pqCodecUncompressed)
+		meta.i64(5, int64(t.rows))
+		meta.i64(6, chunks[i].size)
+		meta.i64(7, chunks[i].size)
+		meta.i64(9, chunks[i].offset)
+		meta.structEnd()
+		meta.elemEnd()
+	}
+	meta.i64(2, totalSize)
+	meta.i64(3, int64(t.rows))
+	meta.elemEnd()
+	meta.binary(6, "go-edgar "+VERSION)
+	meta.stop()
+
+	file.Write(meta.buf.Bytes())
+	binary.Write(&file, binary.LittleEndian, uint32(meta.buf.Len()))
+	file.WriteString("PAR1")
+
+	_, err := w.Write(file.Bytes())
+	return err
+}
+
+func (c *parquetColumn) physicalType() int32 {
+	switch c.kind {
+	case parquetDouble:
+		return pqTypeDouble
+	case parquetInt64:
+		return pqTypeInt64
+	case parquetBool:
+		return pqTypeBoolean
+	default:
+		return pqTypeByteArray
+	}
+}
+
+// encodePage encodes definition levels followed by the PLAIN non-null values
+func (c *parquetColumn) encodePage() ([]byte, error) {
+	var page bytes.Buffer
+
+	// Definition levels: 1 = value present, 0 = null (RLE runs, bit width 1)
+	levels := encodeRLELevels(c.values)
+	binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+	page.Write(levels)
+
+	var bits []bool
+	for _, v := range c.values {
+		if v == nil {
+			continue
+		}
+		switch c.kind {
+		case parquetString:
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string, got %T", v)
+			}
+			binary.Write(&page, binary.LittleEndian, uint32(len(s)))
+			page.WriteString(s)
+		case parquetDouble:
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("expected float64, got %T", v)
+			}
+			binary.Write(&page, binary.LittleEndian, math.Float64bits(f))
+		case parquetInt64:
+			n, ok := v.(int64)
+			if !ok {
+				return nil, fmt.Errorf("expected int64, got %T", v)
+			}
+			binary.Write(&page, binary.LittleEndian, n)
+		case parquetBool:
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("expected bool, got %T", v)
+			}
+			bits = append(bits, b)
+		}
+	}
+
+	// Booleans are bit-packed, least significant bit first
+	if c.kind == parquetBool {
+		packed := make([]byte, (len(bits)+7)/8)
+		for i, b := range bits {
+			if b {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		page.Write(packed)
+	}
+
+	return page.Bytes(), nil
+}
+
+// encodeRLELevels encodes 0/1 definition levels as RLE runs of the hybrid encoding
+func encodeRLELevels(values []interface{}) []byte {
+	var out bytes.Buffer
+	for i := 0; i < len(values); {
+		level := byte(1)
+		if values[i] == nil {
+			level = 0
+		}
+		run := 1
+		for i+run < len(values) && (values[i+run] == nil) == (level == 0) {
+			run++
+		}
+		writeUvarint(&out, uint64(run)<<1) // LSB 0 = RLE run
+		out.WriteByte(level)
+		i += run
+	}
+	return out.Bytes()
+}
+
+// Thrift compact protocol (just enough for Parquet metadata)
+
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID int16
+	stack  []int16
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	if delta := id - t.lastID; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		writeUvarint(&t.buf, zigzag(int64(id)))
+	}
+	t.lastID = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	writeUvarint(&t.buf, zigzag(int64(v)))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	writeUvarint(&t.buf, zigzag(v))
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.fieldHeader(id, thriftBinary)
+	writeUvarint(&t.buf, uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+func (t *thriftWriter) structBegin(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.elemBegin()
+}
+
+func (t *thriftWriter) structEnd() { t.elemEnd() }
+
+// elemBegin starts a struct that is a list element (no field header)
+func (t *thriftWriter) elemBegin() {
+	t.stack = append(t.stack, t.lastID)
+	t.lastID = 0
+}
+
+func (t *thriftWriter) elemEnd() {
+	t.stop()
+	t.lastID = t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+func (t *thriftWriter) stop() { t.buf.WriteByte(0) }
+
+func (t *thriftWriter) listBegin(id int16, elemType byte, size int) {
+	t.fieldHeader(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xF0 | elemType)
+		writeUvarint(&t.buf, uint64(size))
+	}
+}
+
+func (t *thriftWriter) listI32(v int32) { writeUvarint(&t.buf, zigzag(int64(v))) }
+
+func (t *thriftWriter) listBinary(s string) {
+	writeUvarint(&t.buf, uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+func zigzag(n int64) uint64 { return uint64((n << 1) ^ (n >> 63)) }
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
@@ -0,0 +1,243 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestFormatParquet_Form4(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	form4, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse Form 4: %v", err)
+	}
+	out := form4.ToOutput()
+
+	file, err := FormatParquet([]*ParsedForm{{FormType: "4", Data: out}})
+	if err != nil {
+		t.Fatalf("FormatParquet failed: %v", err)
+	}
+
+	if !bytes.HasPrefix(file, []byte("PAR1")) || !bytes.HasSuffix(file, []byte("PAR1")) {
+		t.Fatalf("Missing PAR1 magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := file[len(file)-8-footerLen : len(file)-8]
+
+	meta, _ := readThriftStruct(t, footer)
+	wantRows := int64(len(out.Transactions) + len(out.Derivatives))
+	if got := meta[3].(int64); got != wantRows {
+		t.Errorf("num_rows = %d, want %d", got, wantRows)
+	}
+
+	// Schema: root + one element per column, in Form4CSVHeader order
+	schema := meta[2].([]interface{})
+	if len(schema) != len(Form4CSVHeader)+1 {
+		t.Fatalf("Expected %d schema elements, got %d", len(Form4CSVHeader)+1, len(schema))
+	}
+	for i, name := range Form4CSVHeader {
+		if got := string(schema[i+1].(map[int16]interface{})[4].([]byte)); got != name {
+			t.Errorf("Column %d = %q, want %q", i, got, name)
+		}
+	}
+
+	// Decode two columns from their data pages and compare with the parsed form
+	rowGroup := meta[4].([]interface{})[0].(map[int16]interface{})
+	chunks := rowGroup[1].([]interface{})
+	column := func(name string) []interface{} {
+		for i, h := range Form4CSVHeader {
+			if h == name {
+				offset := chunks[i].(map[int16]interface{})[2].(int64)
+				return readParquetPage(t, file[offset:], schema[i+1].(map[int16]interface{})[1].(int32))
+			}
+		}
+		t.Fatalf("Unknown column %s", name)
+		return nil
+	}
+
+	accessions := column("accession_number")
+	shares := column("shares")
+	if len(accessions) != int(wantRows) || len(shares) != int(wantRows) {
+		t.Fatalf("Expected %d values, got %d and %d", wantRows, len(accessions), len(shares))
+	}
+	for i, txn := range out.Transactions {
+		if accessions[i] != out.Metadata.AccessionNumber {
+			t.Errorf("Row %d accession = %v, want %q", i, accessions[i], out.Metadata.AccessionNumber)
+		}
+		if txn.Shares == nil {
+			if shares[i] != nil {
+				t.Errorf("Row %d shares = %v, want null", i, shares[i])
+			}
+		} else if shares[i] != *txn.Shares {
+			t.Errorf("Row %d shares = %v, want %v", i, shares[i], *txn.Shares)
+		}
+	}
+}
+
+func TestFormatParquet_MixedTypes(t *testing.T) {
+	_, err := FormatParquet([]*ParsedForm{
+		{FormType: "4", Data: &Form4Output{}},
+		{FormType: "XBRL", Data: &FinancialSnapshot{}},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for mixed form types")
+	}
+}
+
+// readParquetPage decodes a PLAIN data page of an OPTIONAL column (nil = null)
+func readParquetPage(t *testing.T, data []byte, physicalType int32) []interface{} {
+	header, n := readThriftStruct(t, data)
+	numValues := int(header[5].(map[int16]interface{})[1].(int32))
+	page := data[n : n+int(header[3].(int32))]
+
+	// Definition levels (RLE runs only)
+	levelsLen := int(binary.LittleEndian.Uint32(page))
+	levels := page[4 : 4+levelsLen]
+	page = page[4+levelsLen:]
+	var defined []bool
+	for len(levels) > 0 {
+		h, k := binary.Uvarint(levels)
+		if h&1 != 0 {
+			t.Fatalf("Unexpected bit-packed run")
+		}
+		for i := 0; i < int(h>>1); i++ {
+			defined = append(defined, levels[k] == 1)
+		}
+		levels = levels[k+1:]
+	}
+	if len(defined) != numValues {
+		t.Fatalf("Expected %d levels, got %d", numValues, len(defined))
+	}
+
+	values := make([]interface{}, numValues)
+	bit := 0
+	for i, ok := range defined {
+		if !ok {
+			continue
+		}
+		switch physicalType {
+		case pqTypeByteArray:
+			l := int(binary.LittleEndian.Uint32(page))
+			values[i] = string(page[4 : 4+l])
+			page = page[4+l:]
+		case pqTypeDouble:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case pqTypeInt64:
+			values[i] = int64(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case pqTypeBoolean:
+			values[i] = page[bit/8]&(1<<(bit%8)) != 0
+			bit++
+		}
+	}
+	return values
+}
+
+// readThriftStruct decodes a Thrift compact struct into field ID -> value,
+// returning the number of bytes consumed
+func readThriftStruct(t *testing.T, data []byte) (map[int16]interface{}, int) {
+	r := &thriftReader{t: t, data: data}
+	return r.readStruct(), r.pos
+}
+
+type thriftReader struct {
+	t    *testing.T
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("Bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var lastID int16
+	for {
+		b := r.data[r.pos]
+		r.pos++
+		if b == 0 {
+			return fields
+		}
+		typ := b & 0x0F
+		if delta := int16(b >> 4); delta != 0 {
+			lastID += delta
+		} else {
+			lastID = int16(r.zigzag())
+		}
+		fields[lastID] = r.readValue(typ)
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) interface{} {
+	switch typ {
+	case 1, 2: // Boolean true/false (encoded in the type)
+		return typ == 1
+	case thriftI32:
+		return int32(r.zigzag())
+	case thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		l := int(r.varint())
+		v := r.data[r.pos : r.pos+l]
+		r.pos += l
+		return v
+	case thriftList:
+		b := r.data[r.pos]
+		r.pos++
+		size := int(b >> 4)
+		if size == 15 {
+			size = int(r.varint())
+		}
+		items := make([]interface{}, size)
+		for i := range items {
+			items[i] = r.readValue(b & 0x0F)
+		}
+		return items
+	case thriftStruct:
+		return r.readStruct()
+	default:
+		r.t.Fatalf("Unsupported thrift type %d", typ)
+		return nil
+	}
+}
+
+func TestSnapshotParquetTable_Ratios(t *testing.T) {
+	margin := 0.25
+	table := snapshotParquetTable([]*FinancialSnapshot{{Ratios: FinancialRatios{GrossMargin: &margin}}})
+
+	columns := make(map[string]*parquetColumn)
+	for _, col := range table.columns {
+		columns[col.name] = col
+	}
+	if _, ok := columns["ratios"]; ok {
+		t.Error("Ratios written as a single column, want one column per ratio")
+	}
+	gross, ok := columns["grossMargin"]
+	if !ok || gross.kind != parquetDouble {
+		t.Fatalf("grossMargin column = %+v, want a double column", gross)
+	}
+	if gross.values[0] != 0.25 {
+		t.Errorf("grossMargin = %v, want 0.25", gross.values[0])
+	}
+	if runway := columns["cashRunwayMonths"]; runway == nil || runway.values[0] != nil {
+		t.Errorf("cashRunwayMonths column = %+v, want a null value", runway)
+	}
+}
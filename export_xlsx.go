@@ -0,0 +1,268 @@
+package edgar
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Excel (.xlsx) output
+//
+// Batch results are rendered as a workbook with one sheet per view:
+//   - Summary: one row per Form 4 filing (counts and net shares)
+//   - Transactions: one row per Form 4 transaction per owner (same columns as the CSV export)
+//   - Owners: one row per reporting owner per Form 4 filing
+//   - Financials: one row per FinancialSnapshot (10-K/10-Q)
+//
+// Sheets without data are omitted. The workbook is plain SpreadsheetML written
+// with archive/zip (inline strings, no shared string table).
+
+// xlsxSheet is a named table of cells (string, float64, int64, bool or nil)
+type xlsxSheet struct {
+	name   string
+	header []string
+	rows   [][]interface{}
+}
+
+// WriteXLSX writes Form 4 and XBRL batch results as an Excel workbook
+func WriteXLSX(w io.Writer, filings []*ParsedForm) error {
+	var forms []*Form4Output
+	var snaps []*FinancialSnapshot
+	for _, f := range filings {
+		switch data := f.Data.(type) {
+		case *Form4Output:
+			forms = append(forms, data)
+		case *FinancialSnapshot:
+			snaps = append(snaps, data)
+		default:
+			return fmt.Errorf("xlsx output is only supported for Form 4 and XBRL (got %s)", f.FormType)
+		}
+	}
+
+	var sheets []xlsxSheet
+	if len(forms) > 0 || len(snaps) == 0 {
+		sheets = append(sheets,
+			form4SummarySheet(forms),
+			tableSheet("Transactions", form4ParquetTable(forms)),
+			form4OwnersSheet(forms),
+		)
+	}
+	if len(snaps) > 0 {
+		sheets = append(sheets, tableSheet("Financials", snapshotParquetTable(snaps)))
+	}
+
+	return writeWorkbook(w, sheets)
+}
+
+// FormatXLSX returns batch results encoded as an Excel workbook
+func FormatXLSX(filings []*ParsedForm) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, filings); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tableSheet converts a typed column table into a sheet
+func tableSheet(name string, t *parquetTable) xlsxSheet {
+	sheet := xlsxSheet{name: name}
+	for _, col := range t.columns {
+		sheet.header = append(sheet.header, col.name)
+	}
+	for i := 0; i < t.rows; i++ {
+		row := make([]interface{}, len(t.columns))
+		for j, col := range t.columns {
+			row[j] = col.values[i]
+		}
+		sheet.rows = append(sheet.rows, row)
+	}
+	return sheet
+}
+
+// form4SummarySheet has one row per filing with transaction totals
+func form4SummarySheet(forms []*Form4Output) xlsxSheet {
+	sheet := xlsxSheet{
+		name: "Summary",
+		header: []string{
+			"accession_number", "filing_date", "period_of_report", "issuer_cik", "issuer_name", "ticker",
+			"owners", "transactions", "shares_acquired", "shares_disposed", "net_shares",
+			"value_acquired", "value_disposed", "has_10b5_1_plan",
+		},
+	}
+
+	for _, f := range forms {
+		var names []string
+		for _, owner := range f.ReportingOwners {
+			names = append(names, owner.Name)
+		}
+
+		sum := f.Summary()
+		sheet.rows = append(sheet.rows, []interface{}{
+			f.Metadata.AccessionNumber, f.Metadata.FilingDate, f.Metadata.PeriodOfReport,
+			f.Issuer.CIK, f.Issuer.Name, f.Issuer.Ticker,
+			strings.Join(names, "; "), int64(sum.Transactions),
+			sum.SharesAcquired, sum.SharesDisposed, sum.NetShares,
+			sum.ValueAcquired, sum.ValueDisposed, f.Has10b51Plan,
+		})
+	}
+	return sheet
+}
+
+// form4OwnersSheet has one row per reporting owner per filing
+func form4OwnersSheet(forms []*Form4Output) xlsxSheet {
+	sheet := xlsxSheet{
+		name: "Owners",
+		header: []string{
+			"accession_number", "filing_date", "issuer_cik", "issuer_name", "ticker",
+			"owner_cik", "owner_name", "is_director", "is_officer", "officer_title",
+			"is_ten_percent_owner", "is_other", "city", "state",
+		},
+	}
+
+	for _, f := range forms {
+		for _, owner := range f.ReportingOwners {
+			r := owner.Relationship
+			sheet.rows = append(sheet.rows, []interface{}{
+				f.Metadata.AccessionNumber, f.Metadata.FilingDate,
+				f.Issuer.CIK, f.Issuer.Name, f.Issuer.Ticker,
+				owner.CIK, owner.Name, r.IsDirector, r.IsOfficer, r.OfficerTitle,
+				r.IsTenPercentOwner, r.IsOther, owner.Address.City, owner.Address.State,
+			})
+		}
+	}
+	return sheet
+}
+
+// writeWorkbook writes the xlsx package (a zip of SpreadsheetML parts)
+func writeWorkbook(w io.Writer, sheets []xlsxSheet) error {
+	zw := zip.NewWriter(w)
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.name), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`, len(sheets)+1)
+
+	parts := []struct {
+		name string
+		data string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name string
+			data string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to write xlsx part %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(f, part.data); err != nil {
+			return fmt.Errorf("failed to write xlsx part %s: %w", part.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write xlsx: %w", err)
+	}
+	return nil
+}
+
+// xlsxStyles defines the default cell format plus a bold header format (s="1")
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// xml renders the worksheet part: a frozen, bold header row followed by the data
+func (s xlsxSheet) xml() string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+
+	b.WriteString(`<row r="1">`)
+	for j, name := range s.header {
+		fmt.Fprintf(&b, `<c r="%s1" s="1" t="inlineStr"><is><t>%s</t></is></c>`, xlsxColumn(j), xmlEscape(name))
+	}
+	b.WriteString(`</row>`)
+
+	for i, row := range s.rows {
+		r := i + 2
+		fmt.Fprintf(&b, `<row r="%d">`, r)
+		for j, v := range row {
+			ref := xlsxColumn(j) + strconv.Itoa(r)
+			switch v := v.(type) {
+			case nil:
+				continue // Empty cell
+			case string:
+				if v == "" {
+					continue
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(v))
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'g', -1, 64))
+			case int64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case bool:
+				n := 0
+				if v {
+					n = 1
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="b"><v>%d</v></c>`, ref, n)
+			default:
+				fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(fmt.Sprint(v)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// xlsxColumn converts a zero-based column index to its letter reference (0 -> A, 26 -> AA)
+func xlsxColumn(i int) string {
+	var name []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = append([]byte{byte('A' + (i-1)%26)}, name...)
+	}
+	return string(name)
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
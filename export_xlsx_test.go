@@ -0,0 +1,97 @@
+package edgar
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestFormatXLSX_Form4(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	form4, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse Form 4: %v", err)
+	}
+	out := form4.ToOutput()
+
+	file, err := FormatXLSX([]*ParsedForm{{FormType: "4", Data: out}})
+	if err != nil {
+		t.Fatalf("FormatXLSX failed: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(file), int64(len(file)))
+	if err != nil {
+		t.Fatalf("Output is not a zip archive: %v", err)
+	}
+	parts := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", f.Name, err)
+		}
+		parts[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+
+	// Every part must be well-formed XML
+	for name, part := range parts {
+		dec := xml.NewDecoder(bytes.NewReader(part))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed XML: %v", name, err)
+			}
+		}
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(parts["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatalf("Failed to read workbook: %v", err)
+	}
+	var names []string
+	for _, s := range workbook.Sheets {
+		names = append(names, s.Name)
+	}
+	want := []string{"Summary", "Transactions", "Owners"}
+	if len(names) != len(want) {
+		t.Fatalf("Expected sheets %v, got %v", want, names)
+	}
+
+	// Header + one row per transaction / owner / filing
+	rowCounts := map[string]int{
+		"xl/worksheets/sheet1.xml": 1 + 1,
+		"xl/worksheets/sheet2.xml": 1 + len(out.Transactions) + len(out.Derivatives),
+		"xl/worksheets/sheet3.xml": 1 + len(out.ReportingOwners),
+	}
+	for name, wantRows := range rowCounts {
+		var sheet struct {
+			Rows []struct{} `xml:"sheetData>row"`
+		}
+		if err := xml.Unmarshal(parts[name], &sheet); err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if len(sheet.Rows) != wantRows {
+			t.Errorf("%s: expected %d rows, got %d", name, wantRows, len(sheet.Rows))
+		}
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
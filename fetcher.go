@@ -3,7 +3,6 @@ package edgar
 import (
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"regexp"
 	"strings"
@@ -20,7 +19,19 @@ const (
 	SecEmailEnvVar = "SEC_EMAIL"
 )
 
-var lastRequestTime time.Time
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+
+// ValidateEmail checks the contact email sent in the SEC User-Agent, which the
+// SEC requires to identify who is making requests
+func ValidateEmail(email string) error {
+	if email == "" {
+		return fmt.Errorf("email is required for SEC requests")
+	}
+	if !emailRegex.MatchString(email) {
+		return fmt.Errorf("invalid email format: %s", email)
+	}
+	return nil
+}
 
 // GetSecEmail retrieves email from environment variable or returns error
 func GetSecEmail() (string, error) {
@@ -28,11 +39,8 @@ func GetSecEmail() (string, error) {
 	if email == "" {
 		return "", fmt.Errorf("SEC email required: set %s environment variable or use --email flag", SecEmailEnvVar)
 	}
-
-	// Basic email validation
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(email) {
-		return "", fmt.Errorf("invalid email format: %s", email)
+	if err := ValidateEmail(email); err != nil {
+		return "", err
 	}
 	if strings.HasSuffix(email, "example.com") {
 		return "", fmt.Errorf("Use a real email address, not example.com: %s", email)
@@ -47,49 +55,17 @@ func BuildUserAgent(email string) string {
 
 // FetchForm fetches a form XML from the SEC by URL
 // Implements rate limiting and proper User-Agent header
-// Email is required by SEC - must be a valid email address
+// Email is required by SEC - must be a valid email address (see ValidateEmail)
+// It is shorthand for NewClient(email).FetchForm(url), with the rate limit
+// shared by all package-level functions; use a Client to reuse settings across
+// requests
 func FetchForm(url string, email string) ([]byte, error) {
-	if email == "" {
-		return nil, fmt.Errorf("email is required for SEC requests")
-	}
-
-	// Rate limiting
-	if !lastRequestTime.IsZero() {
-		elapsed := time.Since(lastRequestTime)
-		if elapsed < RateLimit {
-			time.Sleep(RateLimit - elapsed)
-		}
-	}
-
-	// Create request
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set required User-Agent header with email
-	req.Header.Set("User-Agent", BuildUserAgent(email))
-
-	// Execute request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	lastRequestTime = time.Now()
-
-	// Check status
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("SEC returned status %d", resp.StatusCode)
-	}
-
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	return defaultClient(email).FetchForm(url)
+}
 
-	return body, nil
+// FetchFormStream is like FetchForm but returns the response body unread, so
+// large documents (e.g. 10-K iXBRL) can be parsed without buffering them in memory
+// The caller must close the returned reader
+func FetchFormStream(url string, email string) (io.ReadCloser, error) {
+	return defaultClient(email).FetchFormStream(url)
 }
@@ -1,6 +1,11 @@
 package edgar_test
 
 import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -50,3 +55,51 @@ func TestFetchForm_RateLimit(t *testing.T) {
 	// Should respect rate limit (at least 100ms between requests)
 	assert.GreaterOrEqual(t, elapsed.Milliseconds(), int64(100))
 }
+
+// TestFetchFormStream verifies the streaming fetch sends the User-Agent and returns the body unread
+func TestFetchFormStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("User-Agent"), "jane@acme.test") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, "<ownershipDocument/>")
+	}))
+	defer server.Close()
+
+	body, err := edgar.FetchFormStream(server.URL+"/ownership.xml", "jane@acme.test")
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "<ownershipDocument/>", string(data))
+
+	_, err = edgar.FetchFormStream(server.URL+"/missing", "jane@acme.test")
+	assert.ErrorContains(t, err, "status 404")
+
+	_, err = edgar.FetchFormStream(server.URL+"/ownership.xml", "")
+	assert.Error(t, err)
+}
+
+// TestFetchForm_Gzip verifies compressed transfer is requested and decoded transparently
+func TestFetchForm_Gzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			io.WriteString(w, "uncompressed")
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		io.WriteString(zw, "<ownershipDocument/>")
+		zw.Close()
+	}))
+	defer server.Close()
+
+	data, err := edgar.FetchForm(server.URL+"/ownership.xml", "jane@acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, "<ownershipDocument/>", string(data))
+}
@@ -0,0 +1,140 @@
+package edgar
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// FilingDocument is one document listed on a filing's index page
+type FilingDocument struct {
+	Sequence    string `json:"sequence"`
+	Description string `json:"description"`
+	Name        string `json:"name"` // File name, e.g. "ex99-1.htm"
+	Type        string `json:"type"` // e.g. "SC 13D", "EX-99.1"
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
+
+// FilingIndexURL returns the URL of a filing's index page
+// e.g. https://www.sec.gov/Archives/edgar/data/1263508/000110465924000001/0001104659-24-000001-index.htm
+func FilingIndexURL(cik, accessionNumber string) string {
+	return fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/%s/%s/%s-index.htm",
+		archiveCIK(cik),
+		archiveAccession(accessionNumber),
+		accessionNumber,
+	)
+}
+
+// FetchFilingIndex fetches and parses the document list of a filing
+func (c *Client) FetchFilingIndex(cik, accessionNumber string) ([]FilingDocument, error) {
+	body, err := c.get(FilingIndexURL(cik, accessionNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch filing index: %w", err)
+	}
+	defer body.Close()
+	return ParseFilingIndex(body)
+}
+
+// PrimaryDocumentURL returns the URL of a filing's main document, the first
+// one on its index page. Form 3/4/5 indexes link an XSL rendering
+// (xslF345X05/form4.xml); the raw document is returned instead, as in
+// Filing.BuildURL.
+func (c *Client) PrimaryDocumentURL(cik, accessionNumber string) (string, error) {
+	docs, err := c.FetchFilingIndex(cik, accessionNumber)
+	if err != nil {
+		return "", err
+	}
+	url := docs[0].URL
+	dir, name := path.Split(url)
+	if xsl := path.Base(dir); strings.HasPrefix(strings.ToLower(xsl), "xsl") {
+		url = strings.TrimSuffix(dir, xsl+"/") + name
+	}
+	return url, nil
+}
+
+// ParseFilingIndex reads the document table (class "tableFile") of a filing index page
+// Relative links are resolved against https://www.sec.gov; inline XBRL viewer
+// links ("/ix?doc=...") point at the underlying document.
+func ParseFilingIndex(r io.Reader) ([]FilingDocument, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse filing index: %w", err)
+	}
+
+	var docs []FilingDocument
+	for _, table := range findAllTablesInOrder(doc) {
+		if !strings.Contains(" "+attr(table, "class")+" ", " tableFile ") {
+			continue
+		}
+		for _, row := range childElements(table, "tr") {
+			cells := childElements(row, "td")
+			if len(cells) < 5 {
+				continue // Header row
+			}
+			link := findElement(cells[2], "a")
+			if link == nil {
+				continue
+			}
+			href := strings.TrimPrefix(attr(link, "href"), "/ix?doc=")
+			if strings.HasPrefix(href, "/") {
+				href = "https://www.sec.gov" + href
+			}
+			size, _ := strconv.ParseInt(strings.TrimSpace(extractText(cells[4])), 10, 64)
+			docs = append(docs, FilingDocument{
+				Sequence:    strings.TrimSpace(extractText(cells[0])),
+				Description: strings.Join(strings.Fields(extractText(cells[1])), " "),
+				Name:        strings.TrimSpace(extractText(link)),
+				Type:        strings.TrimSpace(extractText(cells[3])),
+				Size:        size,
+				URL:         href,
+			})
+		}
+	}
+
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("no documents found in filing index")
+	}
+	return docs, nil
+}
+
+// attr returns the value of an element attribute ("" if absent)
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// childElements returns the descendant elements with the given tag, without
+// descending into matches (so rows of nested tables are not included)
+func childElements(n *html.Node, tag string) []*html.Node {
+	var out []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == tag {
+			out = append(out, c)
+			continue
+		}
+		out = append(out, childElements(c, tag)...)
+	}
+	return out
+}
+
+// findElement returns the first descendant element with the given tag
+func findElement(n *html.Node, tag string) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == tag {
+			return c
+		}
+		if found := findElement(c, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package edgar_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/RxDataLab/go-edgar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilingIndex(t *testing.T) {
+	docs, err := edgar.ParseFilingIndex(strings.NewReader(testFilingIndex))
+	require.NoError(t, err)
+	require.Len(t, docs, 5)
+
+	assert.Equal(t, edgar.FilingDocument{
+		Sequence:    "2",
+		Description: "LETTER TO THE BOARD OF DIRECTORS",
+		Name:        "ex99-1.htm",
+		Type:        "EX-99.1",
+		Size:        4321,
+		URL:         "https://www.sec.gov/Archives/edgar/data/1263508/000090266424000123/ex99-1.htm",
+	}, docs[1])
+	assert.Equal(t, "", docs[4].Type)
+
+	_, err = edgar.ParseFilingIndex(strings.NewReader("<html><body>Not found</body></html>"))
+	assert.Error(t, err)
+}
+
+func TestClient_PrimaryDocumentURL(t *testing.T) {
+	const base = "https://www.sec.gov/Archives/edgar/data/1631574/000119312525314736/"
+	pages := map[string]string{
+		base + "0001193125-25-314736-index.htm": `<html><body><table class="tableFile">
+<tr><th>Seq</th><th>Description</th><th>Document</th><th>Type</th><th>Size</th></tr>
+<tr><td>1</td><td>FORM 4</td><td><a href="/Archives/edgar/data/1631574/000119312525314736/xslF345X05/ownership.xml">ownership.html</a></td><td>4</td><td>&nbsp;</td></tr>
+<tr><td>1</td><td>FORM 4</td><td><a href="/Archives/edgar/data/1631574/000119312525314736/ownership.xml">ownership.xml</a></td><td>4</td><td>5210</td></tr>
+</table></body></html>`,
+		"https://www.sec.gov/Archives/edgar/data/1263508/000090266424000123/0000902664-24-000123-index.htm": testFilingIndex,
+	}
+	client := edgar.NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, ok := pages[r.URL.String()]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusNotFound
+		}
+		return &http.Response{StatusCode: status, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})}
+
+	url, err := client.PrimaryDocumentURL("1631574", "0001193125-25-314736")
+	require.NoError(t, err)
+	assert.Equal(t, base+"ownership.xml", url, "the XSL rendering directory is dropped")
+
+	url, err = client.PrimaryDocumentURL("1263508", "0000902664-24-000123")
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.sec.gov/Archives/edgar/data/1263508/000090266424000123/tm2412345d1_sc13da.htm", url)
+
+	_, err = client.PrimaryDocumentURL("1631574", "0001193125-25-000000")
+	assert.Error(t, err)
+}
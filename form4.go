@@ -1,10 +1,13 @@
 package edgar
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 // Form4 represents an SEC Form 4 insider trading filing
@@ -13,7 +16,7 @@ type Form4 struct {
 	SchemaVersion      string              `xml:"schemaVersion"`
 	DocumentType       string              `xml:"documentType"`
 	PeriodOfReport     string              `xml:"periodOfReport"`
-	Aff10b5One         bool                `xml:"aff10b5One"` // 10b5-1 trading plan indicator
+	Aff10b5One         Bool                `xml:"aff10b5One"` // 10b5-1 trading plan indicator
 	Issuer             Issuer              `xml:"issuer"`
 	ReportingOwners    []ReportingOwner    `xml:"reportingOwner"`
 	NonDerivativeTable *NonDerivativeTable `xml:"nonDerivativeTable"`
@@ -21,6 +24,8 @@ type Form4 struct {
 	Footnotes          []Footnote          `xml:"footnotes>footnote"`
 	Signatures         []Signature         `xml:"ownerSignature"`
 	Remarks            string              `xml:"remarks"`
+
+	NotSubjectToSection16 Bool `xml:"notSubjectToSection16"` // Box 1: no longer subject to Section 16
 }
 
 // Issuer represents the company whose stock is being traded
@@ -51,10 +56,10 @@ type OwnerAddress struct {
 }
 
 type Relationship struct {
-	IsDirector        bool   `xml:"isDirector"`
-	IsOfficer         bool   `xml:"isOfficer"`
-	IsTenPercentOwner bool   `xml:"isTenPercentOwner"`
-	IsOther           bool   `xml:"isOther"`
+	IsDirector        Bool   `xml:"isDirector"`
+	IsOfficer         Bool   `xml:"isOfficer"`
+	IsTenPercentOwner Bool   `xml:"isTenPercentOwner"`
+	IsOther           Bool   `xml:"isOther"`
 	OfficerTitle      string `xml:"officerTitle"`
 }
 
@@ -77,7 +82,7 @@ type NonDerivativeTransaction struct {
 type TransactionCoding struct {
 	FormType           string     `xml:"transactionFormType"`
 	Code               string     `xml:"transactionCode"`
-	EquitySwapInvolved bool       `xml:"equitySwapInvolved"`
+	EquitySwapInvolved Bool       `xml:"equitySwapInvolved"`
 	FootnoteID         FootnoteID `xml:"footnoteId"`
 }
 
@@ -89,11 +94,16 @@ type TransactionAmounts struct {
 
 type PostTransactionAmounts struct {
 	SharesOwnedFollowing Value `xml:"sharesOwnedFollowingTransaction"`
+	ValueOwnedFollowing  Value `xml:"valueOwnedFollowingTransaction"` // Dollar value instead of shares
 }
 
 type OwnershipNature struct {
 	DirectOrIndirect  string `xml:"directOrIndirectOwnership>value"`
 	NatureOfOwnership string `xml:"natureOfOwnership>value"`
+
+	// Footnotes attached to the ownership fields (e.g. "By trust" explanations)
+	DirectOrIndirectFootnote FootnoteID `xml:"directOrIndirectOwnership>footnoteId"`
+	NatureFootnote           FootnoteID `xml:"natureOfOwnership>footnoteId"`
 }
 
 type Value struct {
@@ -101,6 +111,47 @@ type Value struct {
 	FootnoteID FootnoteID `xml:"footnoteId"`
 }
 
+// Bool is an ownership XML boolean flag
+//
+// Filers encode flags as "1"/"0", "true"/"false", "Y"/"N" or leave the
+// element empty, and some wrap the flag in a <value> child like other
+// ownership fields. Empty means false; anything unrecognized is an error
+// rather than a silent false.
+type Bool bool
+
+// UnmarshalXML accepts every observed encoding of an ownership boolean
+func (b *Bool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw struct {
+		Text  string `xml:",chardata"`
+		Value string `xml:"value"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	text := strings.TrimSpace(raw.Value)
+	if text == "" {
+		text = strings.TrimSpace(raw.Text)
+	}
+
+	v, err := parseBool(text)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", start.Name.Local, err)
+	}
+	*b = Bool(v)
+	return nil
+}
+
+// parseBool parses a filer-entered boolean ("" is false)
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "1", "true", "t", "y", "yes":
+		return true, nil
+	case "0", "false", "f", "n", "no", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("unrecognized boolean %q", s)
+}
+
 type FootnoteID struct {
 	ID string `xml:"id,attr"`
 }
@@ -156,14 +207,16 @@ type DerivativeHolding struct {
 }
 
 type NonDerivativeHolding struct {
-	SecurityTitle string `xml:"securityTitle>value"`
-	// Add more fields as needed
+	SecurityTitle   string                 `xml:"securityTitle>value"`
+	PostTransaction PostTransactionAmounts `xml:"postTransactionAmounts"`
+	OwnershipNature OwnershipNature        `xml:"ownershipNature"`
 }
 
 // UnderlyingSecurity represents the security underlying a derivative
 type UnderlyingSecurity struct {
 	SecurityTitle Value `xml:"underlyingSecurityTitle"`
 	Shares        Value `xml:"underlyingSecurityShares"`
+	Value         Value `xml:"underlyingSecurityValue"` // Dollar value instead of shares (e.g. convertible notes)
 }
 
 type Footnote struct {
@@ -177,14 +230,33 @@ type Signature struct {
 }
 
 // Parse unmarshals Form 4 XML into a Form4 struct
+//
+// Parsing doesn't depend on schemaVersion: elements used by older ownership
+// schemas (valueOwnedFollowingTransaction, underlyingSecurityValue) decode
+// alongside the current ones.
 func Parse(data []byte) (*Form4, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parses Form 4 XML from a reader as it is read
+func ParseReader(r io.Reader) (*Form4, error) {
 	var form4 Form4
-	if err := xml.Unmarshal(data, &form4); err != nil {
+	if err := xml.NewDecoder(r).Decode(&form4); err != nil {
 		return nil, err
 	}
+	form4.clearPlaceholderTicker()
 	return &form4, nil
 }
 
+// clearPlaceholderTicker drops the placeholder ticker ("NONE", "N/A") some
+// filing software writes when the issuer has no trading symbol
+func (f *Form4) clearPlaceholderTicker() {
+	switch strings.ToUpper(strings.TrimSpace(f.Issuer.TradingSymbol)) {
+	case "NONE", "N/A", "NA":
+		f.Issuer.TradingSymbol = ""
+	}
+}
+
 // TransactionCodeDescription returns human-readable transaction code
 func TransactionCodeDescription(code string) string {
 	descriptions := map[string]string{
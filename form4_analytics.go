@@ -0,0 +1,140 @@
+package edgar
+
+// Form4Summary totals a filing's Table I (non-derivative) transactions
+//
+// Acquired/disposed follow each transaction's A/D flag, so grants (A) and
+// tax withholding (F) are included alongside open-market purchases (P) and
+// sales (S). Value sums skip transactions without a price.
+type Form4Summary struct {
+	Transactions   int     `json:"transactions"` // Non-derivative + derivative transactions
+	SharesAcquired float64 `json:"sharesAcquired"`
+	SharesDisposed float64 `json:"sharesDisposed"`
+	NetShares      float64 `json:"netShares"` // SharesAcquired - SharesDisposed
+	ValueAcquired  float64 `json:"valueAcquired"`
+	ValueDisposed  float64 `json:"valueDisposed"`
+	NetValue       float64 `json:"netValue"` // ValueAcquired - ValueDisposed
+}
+
+// OwnerNetChange is the net non-derivative change attributed to one reporting owner
+type OwnerNetChange struct {
+	OwnerCIK  string `json:"ownerCik"`
+	OwnerName string `json:"ownerName"`
+	Form4Summary
+}
+
+// Value returns shares × price, or nil if either is missing
+func (t NonDerivativeTransactionOut) Value() *float64 {
+	return multiply(t.Shares, t.PricePerShare)
+}
+
+// Value returns shares × price, or nil if either is missing
+func (t DerivativeTransactionOut) Value() *float64 {
+	return multiply(t.Shares, t.PricePerShare)
+}
+
+// Summary totals the filing's bought/sold shares and value
+func (f *Form4Output) Summary() Form4Summary {
+	s := Form4Summary{Transactions: len(f.Transactions) + len(f.Derivatives)}
+	for _, txn := range f.Transactions {
+		if txn.Shares == nil {
+			continue
+		}
+		var value float64
+		if v := txn.Value(); v != nil {
+			value = *v
+		}
+		switch txn.AcquiredDisposed {
+		case "A":
+			s.SharesAcquired += *txn.Shares
+			s.ValueAcquired += value
+		case "D":
+			s.SharesDisposed += *txn.Shares
+			s.ValueDisposed += value
+		}
+	}
+	s.NetShares = s.SharesAcquired - s.SharesDisposed
+	s.NetValue = s.ValueAcquired - s.ValueDisposed
+	return s
+}
+
+// NetChangeByOwner returns the filing's net change for each reporting owner
+//
+// Form 4 does not split transactions between joint filers: every owner on the
+// filing reports the same transactions, so each owner gets the full totals.
+// De-duplicate on accession number before summing across owners.
+func (f *Form4Output) NetChangeByOwner() []OwnerNetChange {
+	summary := f.Summary()
+	changes := make([]OwnerNetChange, 0, len(f.ReportingOwners))
+	for _, owner := range f.ReportingOwners {
+		changes = append(changes, OwnerNetChange{
+			OwnerCIK:     owner.CIK,
+			OwnerName:    owner.Name,
+			Form4Summary: summary,
+		})
+	}
+	return changes
+}
+
+// ExerciseAndSale pairs a day's derivative exercises ("M") with same-day sales ("S")
+//
+// The classic exercise-and-sell: an insider exercises options and sells some or
+// all of the resulting shares the same day. SharesExercised counts underlying
+// shares received (falling back to the derivative share count when the
+// underlying amount is missing).
+type ExerciseAndSale struct {
+	Date            string                        `json:"date"`
+	SharesExercised float64                       `json:"sharesExercised"`
+	SharesSold      float64                       `json:"sharesSold"`
+	NetRetained     float64                       `json:"netRetained"` // SharesExercised - SharesSold
+	SaleValue       float64                       `json:"saleValue"`   // Sum of priced sales
+	Exercises       []DerivativeTransactionOut    `json:"exercises"`
+	Sales           []NonDerivativeTransactionOut `json:"sales"`
+}
+
+// ExerciseAndSales groups the filing's option exercises with same-day sales
+//
+// One entry is returned per transaction date with at least one exercise, in
+// the order the dates first appear. Days with exercises but no sales are
+// included (SharesSold = 0), so a held exercise is visible too.
+func (f *Form4Output) ExerciseAndSales() []ExerciseAndSale {
+	var pairs []ExerciseAndSale
+	byDate := make(map[string]int)
+	for _, txn := range f.Derivatives {
+		if txn.TransactionCode != "M" {
+			continue
+		}
+		i, ok := byDate[txn.TransactionDate]
+		if !ok {
+			i = len(pairs)
+			byDate[txn.TransactionDate] = i
+			pairs = append(pairs, ExerciseAndSale{Date: txn.TransactionDate})
+		}
+		shares := txn.UnderlyingShares
+		if shares == nil {
+			shares = txn.Shares
+		}
+		if shares != nil {
+			pairs[i].SharesExercised += *shares
+		}
+		pairs[i].Exercises = append(pairs[i].Exercises, txn)
+	}
+
+	for _, txn := range f.Transactions {
+		i, ok := byDate[txn.TransactionDate]
+		if !ok || txn.TransactionCode != "S" {
+			continue
+		}
+		if txn.Shares != nil {
+			pairs[i].SharesSold += *txn.Shares
+		}
+		if v := txn.Value(); v != nil {
+			pairs[i].SaleValue += *v
+		}
+		pairs[i].Sales = append(pairs[i].Sales, txn)
+	}
+
+	for i := range pairs {
+		pairs[i].NetRetained = pairs[i].SharesExercised - pairs[i].SharesSold
+	}
+	return pairs
+}
@@ -0,0 +1,77 @@
+package edgar
+
+import "testing"
+
+func TestForm4Output_Summary(t *testing.T) {
+	out := &Form4Output{
+		ReportingOwners: []ReportingOwnerOutput{{CIK: "111", Name: "Fund LP"}, {CIK: "222", Name: "Fund GP LLC"}},
+		Transactions: []NonDerivativeTransactionOut{
+			{TransactionCode: "P", AcquiredDisposed: "A", Shares: ptrFloat(1000), PricePerShare: ptrFloat(10)},
+			{TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(400), PricePerShare: ptrFloat(12.5)},
+			{TransactionCode: "F", AcquiredDisposed: "D", Shares: ptrFloat(100)}, // No price: shares only
+			{TransactionCode: "G", AcquiredDisposed: "D"},                        // No shares: skipped
+		},
+		Derivatives: []DerivativeTransactionOut{{TransactionCode: "M", AcquiredDisposed: "D", Shares: ptrFloat(500)}},
+	}
+
+	if v := out.Transactions[1].Value(); v == nil || *v != 5000 {
+		t.Errorf("Expected value 5000, got %v", v)
+	}
+	if v := out.Transactions[2].Value(); v != nil {
+		t.Errorf("Expected nil value without price, got %v", *v)
+	}
+
+	want := Form4Summary{
+		Transactions:   5,
+		SharesAcquired: 1000,
+		SharesDisposed: 500,
+		NetShares:      500,
+		ValueAcquired:  10000,
+		ValueDisposed:  5000,
+		NetValue:       5000,
+	}
+	if got := out.Summary(); got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+
+	changes := out.NetChangeByOwner()
+	if len(changes) != 2 || changes[1].OwnerName != "Fund GP LLC" || changes[1].NetShares != 500 {
+		t.Errorf("Unexpected per-owner changes: %+v", changes)
+	}
+}
+
+func TestForm4Output_ExerciseAndSales(t *testing.T) {
+	out := &Form4Output{
+		Derivatives: []DerivativeTransactionOut{
+			{TransactionDate: "2025-03-03", TransactionCode: "M", Shares: ptrFloat(5000), UnderlyingShares: ptrFloat(5000)},
+			{TransactionDate: "2025-03-03", TransactionCode: "M", Shares: ptrFloat(1000)}, // No underlying amount
+			{TransactionDate: "2025-03-05", TransactionCode: "M", UnderlyingShares: ptrFloat(200)},
+			{TransactionDate: "2025-03-04", TransactionCode: "A", Shares: ptrFloat(9000)}, // Grant, not an exercise
+		},
+		Transactions: []NonDerivativeTransactionOut{
+			{TransactionDate: "2025-03-03", TransactionCode: "M", AcquiredDisposed: "A", Shares: ptrFloat(6000)},
+			{TransactionDate: "2025-03-03", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(4000), PricePerShare: ptrFloat(10)},
+			{TransactionDate: "2025-03-03", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(500)},
+			{TransactionDate: "2025-03-04", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(100)}, // No exercise that day
+		},
+	}
+
+	pairs := out.ExerciseAndSales()
+	if len(pairs) != 2 {
+		t.Fatalf("Expected 2 exercise days, got %d: %+v", len(pairs), pairs)
+	}
+
+	first := pairs[0]
+	if first.Date != "2025-03-03" || first.SharesExercised != 6000 || first.SharesSold != 4500 ||
+		first.NetRetained != 1500 || first.SaleValue != 40000 {
+		t.Errorf("Unexpected first pair: %+v", first)
+	}
+	if len(first.Exercises) != 2 || len(first.Sales) != 2 {
+		t.Errorf("Expected 2 exercises and 2 sales, got %d and %d", len(first.Exercises), len(first.Sales))
+	}
+
+	second := pairs[1]
+	if second.Date != "2025-03-05" || second.SharesExercised != 200 || second.SharesSold != 0 || second.NetRetained != 200 {
+		t.Errorf("Unexpected second pair: %+v", second)
+	}
+}
@@ -0,0 +1,286 @@
+package edgar
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Form4BatchSummary aggregates a batch of Form 4 filings into a quick sanity
+// report: who bought and sold, which transaction codes dominate, the largest
+// trades and how much went through Rule 10b5-1 plans.
+//
+// Share and value totals cover Table I (non-derivative) transactions, as in
+// Form4Summary; transaction counts cover both tables.
+type Form4BatchSummary struct {
+	Filings       int            `json:"filings"`
+	Transactions  int            `json:"transactions"`
+	FirstFiled    string         `json:"firstFiled,omitempty"`
+	LastFiled     string         `json:"lastFiled,omitempty"`
+	Totals        Form4Summary   `json:"totals"`
+	Insiders      []InsiderTotal `json:"insiders"`      // By value traded, largest first
+	Codes         []CodeCount    `json:"codes"`         // By count, most first
+	LargestTrades []Form4Trade   `json:"largestTrades"` // By value, largest first
+	Plan10b51     Plan10b51Share `json:"plan10b51"`
+}
+
+// InsiderTotal sums one reporting owner's filings (see
+// Form4Output.NetChangeByOwner for how joint filings are attributed)
+type InsiderTotal struct {
+	OwnerCIK  string `json:"ownerCik"`
+	OwnerName string `json:"ownerName"`
+	Filings   int    `json:"filings"`
+	Form4Summary
+}
+
+// CodeCount is the number of transactions with one transaction code
+type CodeCount struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Count       int    `json:"count"`
+}
+
+// Form4Trade is one priced Table I transaction with its filing context
+type Form4Trade struct {
+	AccessionNumber  string  `json:"accessionNumber"`
+	FilingDate       string  `json:"filingDate"`
+	Issuer           string  `json:"issuer"`
+	Owners           string  `json:"owners"` // Reporting owner names, "; "-separated
+	TransactionDate  string  `json:"transactionDate"`
+	TransactionCode  string  `json:"transactionCode"`
+	AcquiredDisposed string  `json:"acquiredDisposed"`
+	Shares           float64 `json:"shares"`
+	PricePerShare    float64 `json:"pricePerShare"`
+	Value            float64 `json:"value"`
+	Is10b51Plan      bool    `json:"is10b51Plan"`
+}
+
+// Plan10b51Share is the part of a batch made under Rule 10b5-1 plans
+type Plan10b51Share struct {
+	Transactions     int     `json:"transactions"`
+	TransactionShare float64 `json:"transactionShare"` // Of all transactions, 0-1
+	Value            float64 `json:"value"`
+	ValueShare       float64 `json:"valueShare"` // Of all priced Table I value, 0-1
+}
+
+// SummarizeForm4Batch aggregates a batch of Form 4 filings, keeping the top
+// largest trades (all of them if top <= 0). Filings repeated under the same
+// accession number, e.g. from overlapping batches, are counted once.
+func SummarizeForm4Batch(forms []*Form4Output, top int) *Form4BatchSummary {
+	s := &Form4BatchSummary{
+		Insiders:      []InsiderTotal{},
+		Codes:         []CodeCount{},
+		LargestTrades: []Form4Trade{},
+	}
+	insiders := make(map[string]*InsiderTotal)
+	var insiderOrder []string
+	codes := make(map[string]int)
+	seen := make(map[string]bool)
+	var totalValue float64
+
+	for _, f := range forms {
+		if acc := f.Metadata.AccessionNumber; acc != "" {
+			if seen[acc] {
+				continue
+			}
+			seen[acc] = true
+		}
+		s.Filings++
+		if date := f.Metadata.FilingDate; date != "" {
+			if s.FirstFiled == "" || date < s.FirstFiled {
+				s.FirstFiled = date
+			}
+			if date > s.LastFiled {
+				s.LastFiled = date
+			}
+		}
+
+		summary := f.Summary()
+		s.Totals.add(summary)
+		for _, change := range f.NetChangeByOwner() {
+			key := change.OwnerCIK
+			if key == "" {
+				key = change.OwnerName
+			}
+			insider, ok := insiders[key]
+			if !ok {
+				insider = &InsiderTotal{OwnerCIK: change.OwnerCIK, OwnerName: change.OwnerName}
+				insiders[key] = insider
+				insiderOrder = append(insiderOrder, key)
+			}
+			insider.Filings++
+			insider.add(summary)
+		}
+
+		var names []string
+		for _, owner := range f.ReportingOwners {
+			names = append(names, owner.Name)
+		}
+		for _, txn := range f.Transactions {
+			codes[txn.TransactionCode]++
+			if txn.Is10b51Plan {
+				s.Plan10b51.Transactions++
+			}
+			v := txn.Value()
+			if v == nil {
+				continue
+			}
+			totalValue += *v
+			if txn.Is10b51Plan {
+				s.Plan10b51.Value += *v
+			}
+			s.LargestTrades = append(s.LargestTrades, Form4Trade{
+				AccessionNumber:  f.Metadata.AccessionNumber,
+				FilingDate:       f.Metadata.FilingDate,
+				Issuer:           f.Issuer.Name,
+				Owners:           strings.Join(names, "; "),
+				TransactionDate:  txn.TransactionDate,
+				TransactionCode:  txn.TransactionCode,
+				AcquiredDisposed: txn.AcquiredDisposed,
+				Shares:           *txn.Shares,
+				PricePerShare:    *txn.PricePerShare,
+				Value:            *v,
+				Is10b51Plan:      txn.Is10b51Plan,
+			})
+		}
+		for _, txn := range f.Derivatives {
+			codes[txn.TransactionCode]++
+			if txn.Is10b51Plan {
+				s.Plan10b51.Transactions++
+			}
+		}
+	}
+	s.Transactions = s.Totals.Transactions
+
+	for _, key := range insiderOrder {
+		s.Insiders = append(s.Insiders, *insiders[key])
+	}
+	sort.SliceStable(s.Insiders, func(i, j int) bool {
+		return s.Insiders[i].traded() > s.Insiders[j].traded()
+	})
+
+	for code, count := range codes {
+		s.Codes = append(s.Codes, CodeCount{Code: code, Description: TransactionCodeDescription(code), Count: count})
+	}
+	sortCodeCounts(s.Codes)
+
+	sort.SliceStable(s.LargestTrades, func(i, j int) bool {
+		return s.LargestTrades[i].Value > s.LargestTrades[j].Value
+	})
+	if top > 0 && len(s.LargestTrades) > top {
+		s.LargestTrades = s.LargestTrades[:top]
+	}
+
+	if s.Transactions > 0 {
+		s.Plan10b51.TransactionShare = float64(s.Plan10b51.Transactions) / float64(s.Transactions)
+	}
+	if totalValue > 0 {
+		s.Plan10b51.ValueShare = s.Plan10b51.Value / totalValue
+	}
+	return s
+}
+
+// add accumulates another summary
+func (s *Form4Summary) add(other Form4Summary) {
+	s.Transactions += other.Transactions
+	s.SharesAcquired += other.SharesAcquired
+	s.SharesDisposed += other.SharesDisposed
+	s.NetShares += other.NetShares
+	s.ValueAcquired += other.ValueAcquired
+	s.ValueDisposed += other.ValueDisposed
+	s.NetValue += other.NetValue
+}
+
+// traded is the total value bought and sold
+func (s Form4Summary) traded() float64 {
+	return s.ValueAcquired + s.ValueDisposed
+}
+
+// ReadForm4Batch reads Form 4 filings written by the CLI: a batch JSON array,
+// NDJSON (one filing per line), or a single-file JSON result ({"formType":
+// "4", "data": ...}). Other form types are an error.
+func ReadForm4Batch(r io.Reader) ([]*Form4Output, error) {
+	docs, err := readJSONRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	forms := make([]*Form4Output, 0, len(docs))
+	for i, doc := range docs {
+		var wrapped struct {
+			FormType string          `json:"formType"`
+			Data     json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(doc, &wrapped); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		if wrapped.Data != nil {
+			if wrapped.FormType != "4" {
+				return nil, fmt.Errorf("record %d: not a Form 4 (got %s)", i+1, wrapped.FormType)
+			}
+			doc = wrapped.Data
+		}
+
+		var f Form4Output
+		if err := json.Unmarshal(doc, &f); err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		if f.Issuer.CIK == "" && len(f.ReportingOwners) == 0 {
+			return nil, fmt.Errorf("record %d: not a Form 4 (no issuer or reporting owners)", i+1)
+		}
+		forms = append(forms, &f)
+	}
+	return forms, nil
+}
+
+// readJSONRecords splits CLI output into records: the elements of a JSON
+// array, or each value of an NDJSON stream (a single JSON object is a stream
+// of one)
+func readJSONRecords(r io.Reader) ([]json.RawMessage, error) {
+	br := bufio.NewReader(r)
+	dec := json.NewDecoder(br)
+
+	var docs []json.RawMessage
+	first, err := peekNonSpace(br)
+	if err != nil {
+		return nil, err
+	}
+	if first == '[' {
+		if err := dec.Decode(&docs); err != nil {
+			return nil, fmt.Errorf("failed to read batch JSON: %w", err)
+		}
+		return docs, nil
+	}
+	for {
+		var doc json.RawMessage
+		if err := dec.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read NDJSON record %d: %w", len(docs)+1, err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// peekNonSpace returns the first non-whitespace byte without consuming it
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err == io.EOF {
+			return 0, fmt.Errorf("empty input")
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
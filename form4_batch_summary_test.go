@@ -0,0 +1,147 @@
+package edgar
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// loadForm4Output parses a Form 4 test case
+func loadForm4Output(t *testing.T, name, accession string) *Form4Output {
+	t.Helper()
+	data, err := os.ReadFile("testdata/form4/" + name + "/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	form4, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", name, err)
+	}
+	out := form4.ToOutput()
+	out.SetFilingMetadata(accession, "2025-0"+accession[len(accession)-1:]+"-15", "")
+	return out
+}
+
+func TestSummarizeForm4Batch(t *testing.T) {
+	snow := loadForm4Output(t, "snow", "0000000000-25-000001")
+	arrowhead := loadForm4Output(t, "arrowhead_footnotes", "0000000000-25-000002")
+	duplicate := loadForm4Output(t, "snow", "0000000000-25-000001")
+
+	s := SummarizeForm4Batch([]*Form4Output{snow, arrowhead, duplicate}, 3)
+
+	if s.Filings != 2 {
+		t.Errorf("Expected 2 filings (duplicate accession counted once), got %d", s.Filings)
+	}
+	if s.FirstFiled != "2025-01-15" || s.LastFiled != "2025-02-15" {
+		t.Errorf("Unexpected filing range %s..%s", s.FirstFiled, s.LastFiled)
+	}
+
+	var want Form4Summary
+	want.add(snow.Summary())
+	want.add(arrowhead.Summary())
+	if s.Totals != want || s.Transactions != want.Transactions {
+		t.Errorf("Totals = %+v, want %+v", s.Totals, want)
+	}
+
+	if len(s.Insiders) != len(snow.ReportingOwners)+len(arrowhead.ReportingOwners) {
+		t.Fatalf("Expected one entry per reporting owner, got %d", len(s.Insiders))
+	}
+	for i := 1; i < len(s.Insiders); i++ {
+		if s.Insiders[i].traded() > s.Insiders[i-1].traded() {
+			t.Errorf("Insiders not sorted by value traded: %+v", s.Insiders)
+		}
+	}
+
+	counted := 0
+	for _, c := range s.Codes {
+		counted += c.Count
+		if c.Description == "" {
+			t.Errorf("Missing description for code %s", c.Code)
+		}
+	}
+	if counted != s.Transactions || s.Codes[0].Code != "S" {
+		t.Errorf("Codes = %+v, want S first and %d in total", s.Codes, s.Transactions)
+	}
+
+	if len(s.LargestTrades) != 3 {
+		t.Fatalf("Expected the top 3 trades, got %d", len(s.LargestTrades))
+	}
+	for i, trade := range s.LargestTrades {
+		if i > 0 && trade.Value > s.LargestTrades[i-1].Value {
+			t.Errorf("Trades not sorted by value: %+v", s.LargestTrades)
+		}
+		if trade.Value != trade.Shares*trade.PricePerShare || trade.Owners == "" {
+			t.Errorf("Unexpected trade %+v", trade)
+		}
+	}
+
+	plan := 0
+	for _, f := range []*Form4Output{snow, arrowhead} {
+		for _, txn := range f.Transactions {
+			if txn.Is10b51Plan {
+				plan++
+			}
+		}
+		for _, txn := range f.Derivatives {
+			if txn.Is10b51Plan {
+				plan++
+			}
+		}
+	}
+	if plan == 0 {
+		t.Fatal("Expected 10b5-1 transactions in the arrowhead test case")
+	}
+	if s.Plan10b51.Transactions != plan || s.Plan10b51.TransactionShare != float64(plan)/float64(s.Transactions) {
+		t.Errorf("Plan10b51 = %+v, want %d transactions", s.Plan10b51, plan)
+	}
+	if s.Plan10b51.ValueShare <= 0 || s.Plan10b51.ValueShare > 1 {
+		t.Errorf("Expected a 10b5-1 value share in (0, 1], got %f", s.Plan10b51.ValueShare)
+	}
+}
+
+func TestReadForm4Batch(t *testing.T) {
+	snow := loadForm4Output(t, "snow", "0000000000-25-000001")
+	arrowhead := loadForm4Output(t, "arrowhead_footnotes", "0000000000-25-000002")
+	batch := []*ParsedForm{{FormType: "4", Data: snow}, {FormType: "4", Data: arrowhead}}
+
+	jsonData, err := FormatJSONBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ndjsonData, err := FormatNDJSONBatch(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := FormatJSON(batch[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"json array", jsonData, 2},
+		{"ndjson", ndjsonData, 2},
+		{"single file", single, 1},
+	}
+	for _, tt := range tests {
+		forms, err := ReadForm4Batch(bytes.NewReader(tt.data))
+		if err != nil {
+			t.Fatalf("%s: ReadForm4Batch failed: %v", tt.name, err)
+		}
+		if len(forms) != tt.want {
+			t.Fatalf("%s: got %d filings, want %d", tt.name, len(forms), tt.want)
+		}
+		if forms[0].Metadata.AccessionNumber != snow.Metadata.AccessionNumber || len(forms[0].Transactions) != len(snow.Transactions) {
+			t.Errorf("%s: first filing not read back: %+v", tt.name, forms[0].Metadata)
+		}
+	}
+
+	for _, bad := range []string{"", "  \n", `{"formType":"XBRL","data":{}}`, `[{"fiscalYear":2024}]`} {
+		if _, err := ReadForm4Batch(bytes.NewReader([]byte(bad))); err == nil {
+			t.Errorf("ReadForm4Batch(%q) expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package edgar
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Form4Diff reports what an amendment (4/A) changed relative to the original Form 4
+type Form4Diff struct {
+	Fields  []FieldDiff         `json:"fields"`  // Filing-level differences (issuer, owners, footnotes, ...)
+	Added   []TransactionRef    `json:"added"`   // Transactions only in the amendment
+	Removed []TransactionRef    `json:"removed"` // Transactions only in the original
+	Changed []TransactionChange `json:"changed"` // Matched transactions with different values
+}
+
+// FieldDiff is a single differing value, formatted as text ("" for missing)
+type FieldDiff struct {
+	Field     string `json:"field"` // JSON field name, e.g. "shares" or "issuer.ticker"
+	Original  string `json:"original"`
+	Amendment string `json:"amendment"`
+}
+
+// TransactionRef identifies a transaction in one of the two filings
+type TransactionRef struct {
+	Table           string `json:"table"` // "non-derivative" or "derivative"
+	Index           int    `json:"index"` // Position in Transactions or Derivatives
+	SecurityTitle   string `json:"securityTitle"`
+	TransactionDate string `json:"transactionDate"`
+	TransactionCode string `json:"transactionCode"`
+}
+
+// TransactionChange is a transaction present in both filings with field-level differences
+type TransactionChange struct {
+	Original  TransactionRef `json:"original"`
+	Amendment TransactionRef `json:"amendment"`
+	Fields    []FieldDiff    `json:"fields"`
+}
+
+// Empty reports whether the amendment changed nothing
+func (d *Form4Diff) Empty() bool {
+	return len(d.Fields) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// CompareForm4 reports the differences between an original Form 4 and its amendment
+//
+// Transactions are matched by table, security title, transaction date and code
+// (the n-th occurrence of a key in one filing pairs with the n-th in the other),
+// so a corrected share count or price shows up as a change, while a corrected
+// date or code shows up as one removed and one added transaction.
+// Filing metadata that always differs between the two (accession number,
+// filing date, form type, source) is not compared.
+func CompareForm4(original, amendment *Form4Output) *Form4Diff {
+	diff := &Form4Diff{}
+
+	field := func(name, a, b string) {
+		if a != b {
+			diff.Fields = append(diff.Fields, FieldDiff{Field: name, Original: a, Amendment: b})
+		}
+	}
+	field("periodOfReport", original.Metadata.PeriodOfReport, amendment.Metadata.PeriodOfReport)
+	field("issuer.cik", original.Issuer.CIK, amendment.Issuer.CIK)
+	field("issuer.name", original.Issuer.Name, amendment.Issuer.Name)
+	field("issuer.ticker", original.Issuer.Ticker, amendment.Issuer.Ticker)
+	field("has10b51Plan", strconv.FormatBool(original.Has10b51Plan), strconv.FormatBool(amendment.Has10b51Plan))
+	field("reportingOwners", ownerCIKs(original.ReportingOwners), ownerCIKs(amendment.ReportingOwners))
+
+	origNotes := make(map[string]string, len(original.Footnotes))
+	for _, fn := range original.Footnotes {
+		origNotes[fn.ID] = fn.Text
+	}
+	amendNotes := make(map[string]string, len(amendment.Footnotes))
+	for _, fn := range amendment.Footnotes {
+		amendNotes[fn.ID] = fn.Text
+		field("footnotes."+fn.ID, origNotes[fn.ID], fn.Text)
+	}
+	for _, fn := range original.Footnotes {
+		if _, ok := amendNotes[fn.ID]; !ok {
+			field("footnotes."+fn.ID, fn.Text, "")
+		}
+	}
+
+	diff.compareTable("non-derivative", reflect.ValueOf(original.Transactions), reflect.ValueOf(amendment.Transactions))
+	diff.compareTable("derivative", reflect.ValueOf(original.Derivatives), reflect.ValueOf(amendment.Derivatives))
+	return diff
+}
+
+// compareTable matches two transaction slices by key and records the differences
+func (d *Form4Diff) compareTable(table string, original, amendment reflect.Value) {
+	ref := func(v reflect.Value, i int) TransactionRef {
+		txn := v.Index(i)
+		return TransactionRef{
+			Table:           table,
+			Index:           i,
+			SecurityTitle:   txn.FieldByName("SecurityTitle").String(),
+			TransactionDate: txn.FieldByName("TransactionDate").String(),
+			TransactionCode: txn.FieldByName("TransactionCode").String(),
+		}
+	}
+	key := func(r TransactionRef) string {
+		return r.SecurityTitle + "\x00" + r.TransactionDate + "\x00" + r.TransactionCode
+	}
+
+	// Queue the amendment's transactions per key, in order
+	pending := make(map[string][]int)
+	for i := 0; i < amendment.Len(); i++ {
+		k := key(ref(amendment, i))
+		pending[k] = append(pending[k], i)
+	}
+
+	matched := make([]bool, amendment.Len())
+	for i := 0; i < original.Len(); i++ {
+		orig := ref(original, i)
+		k := key(orig)
+		if len(pending[k]) == 0 {
+			d.Removed = append(d.Removed, orig)
+			continue
+		}
+		j := pending[k][0]
+		pending[k] = pending[k][1:]
+		matched[j] = true
+
+		if fields := diffStructFields(original.Index(i), amendment.Index(j)); len(fields) > 0 {
+			d.Changed = append(d.Changed, TransactionChange{Original: orig, Amendment: ref(amendment, j), Fields: fields})
+		}
+	}
+	for j := 0; j < amendment.Len(); j++ {
+		if !matched[j] {
+			d.Added = append(d.Added, ref(amendment, j))
+		}
+	}
+}
+
+// diffStructFields compares two structs of the same type field by field,
+// naming each difference by its JSON tag. Resolved footnote text is skipped
+// (the footnote IDs and the filing-level footnotes are compared instead).
+func diffStructFields(a, b reflect.Value) []FieldDiff {
+	var diffs []FieldDiff
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "footnoteTexts" {
+			continue
+		}
+		x, y := formatDiffValue(a.Field(i)), formatDiffValue(b.Field(i))
+		if x != y {
+			diffs = append(diffs, FieldDiff{Field: name, Original: x, Amendment: y})
+		}
+	}
+	return diffs
+}
+
+// formatDiffValue renders a field value as text for comparison and display
+func formatDiffValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return ""
+		}
+		return formatDiffValue(v.Elem())
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatDiffValue(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// ownerCIKs lists the reporting owners' CIKs for comparison
+func ownerCIKs(owners []ReportingOwnerOutput) string {
+	ciks := make([]string, len(owners))
+	for i, owner := range owners {
+		ciks[i] = owner.CIK
+	}
+	return strings.Join(ciks, ",")
+}
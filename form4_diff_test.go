@@ -0,0 +1,57 @@
+package edgar
+
+import "testing"
+
+func TestCompareForm4(t *testing.T) {
+	original := &Form4Output{
+		Issuer:          IssuerOutput{CIK: "1234567", Name: "ACME CORP", Ticker: "ACME"},
+		ReportingOwners: []ReportingOwnerOutput{{CIK: "111"}},
+		Transactions: []NonDerivativeTransactionOut{
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-03", TransactionCode: "S", Shares: ptrFloat(1000), PricePerShare: ptrFloat(10)},
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-03", TransactionCode: "S", Shares: ptrFloat(500), PricePerShare: ptrFloat(10.5)},
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-04", TransactionCode: "G", Shares: ptrFloat(50)},
+		},
+		Footnotes: []FootnoteOutput{{ID: "F1", Text: "Weighted average price."}},
+	}
+	amendment := &Form4Output{
+		Issuer:          IssuerOutput{CIK: "1234567", Name: "ACME CORP", Ticker: "ACME"},
+		ReportingOwners: []ReportingOwnerOutput{{CIK: "111"}},
+		Transactions: []NonDerivativeTransactionOut{
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-03", TransactionCode: "S", Shares: ptrFloat(1000), PricePerShare: ptrFloat(10)},
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-03", TransactionCode: "S", Shares: ptrFloat(750), PricePerShare: ptrFloat(10.5)},
+		},
+		Derivatives: []DerivativeTransactionOut{
+			{SecurityTitle: "Stock Option", TransactionDate: "2025-03-03", TransactionCode: "M", Shares: ptrFloat(750)},
+		},
+		Footnotes: []FootnoteOutput{{ID: "F1", Text: "Weighted average price ($10.00 to $10.75)."}},
+	}
+
+	diff := CompareForm4(original, amendment)
+
+	if len(diff.Fields) != 1 || diff.Fields[0].Field != "footnotes.F1" {
+		t.Errorf("Expected only footnote F1 to differ, got %+v", diff.Fields)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("Expected 1 changed transaction, got %+v", diff.Changed)
+	}
+	change := diff.Changed[0]
+	if change.Original.Index != 1 || len(change.Fields) != 1 {
+		t.Fatalf("Unexpected change: %+v", change)
+	}
+	if f := change.Fields[0]; f.Field != "shares" || f.Original != "500" || f.Amendment != "750" {
+		t.Errorf("Unexpected field diff: %+v", f)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].TransactionCode != "G" {
+		t.Errorf("Expected the gift to be removed, got %+v", diff.Removed)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Table != "derivative" {
+		t.Errorf("Expected the exercise to be added, got %+v", diff.Added)
+	}
+	if diff.Empty() {
+		t.Error("Expected a non-empty diff")
+	}
+
+	if d := CompareForm4(original, original); !d.Empty() {
+		t.Errorf("Expected no differences comparing a filing to itself, got %+v", d)
+	}
+}
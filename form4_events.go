@@ -0,0 +1,149 @@
+package edgar
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaterialEventForms are the issuer filings CorrelateTradeEvents looks for
+// around insider trades: current reports and periodic reports, with their
+// amendments
+var MaterialEventForms = []string{"8-K", "8-K/A", "10-Q", "10-Q/A", "10-K", "10-K/A", "6-K", "20-F", "40-F"}
+
+// NearbyFiling is an issuer filing within the window around an insider trade
+type NearbyFiling struct {
+	Form            string `json:"form"`
+	FilingDate      string `json:"filingDate"`
+	ReportDate      string `json:"reportDate,omitempty"`
+	AccessionNumber string `json:"accessionNumber"`
+	Items           string `json:"items,omitempty"` // 8-K items, e.g. "2.02,9.01"
+	URL             string `json:"url,omitempty"`
+	DaysFromTrade   int    `json:"daysFromTrade"` // Filing date - trade date: negative if filed before the trade
+	Earnings        bool   `json:"earnings"`      // A 10-Q/10-K (or 20-F/40-F), or an 8-K with Item 2.02 (results of operations)
+}
+
+// TradeEvents is one insider transaction with the issuer filings around it
+type TradeEvents struct {
+	AccessionNumber  string         `json:"accessionNumber"` // The Form 4
+	IssuerCIK        string         `json:"issuerCik"`
+	Issuer           string         `json:"issuer"`
+	Owners           string         `json:"owners"` // Reporting owner names, "; "-separated
+	SecurityTitle    string         `json:"securityTitle"`
+	Derivative       bool           `json:"derivative"` // Table II
+	TransactionDate  string         `json:"transactionDate"`
+	TransactionCode  string         `json:"transactionCode"`
+	AcquiredDisposed string         `json:"acquiredDisposed"`
+	Shares           *float64       `json:"shares"`
+	PricePerShare    *float64       `json:"pricePerShare"`
+	Is10b51Plan      bool           `json:"is10b51Plan"`
+	Events           []NearbyFiling `json:"events"` // Oldest first
+}
+
+// CorrelateTradeEvents annotates each insider transaction (both tables) with
+// the issuer's material filings (MaterialEventForms) filed within days of the
+// trade date, for blackout-window and pre-announcement trading reviews.
+//
+// filings is the issuer's filing index, e.g. Submissions.Filings.Recent
+// .GetFilings; filings of other forms are ignored. Transactions without a
+// valid date get no events. Filings repeated under the same accession number
+// are counted once.
+func CorrelateTradeEvents(forms []*Form4Output, filings []Filing, days int) []TradeEvents {
+	type dated struct {
+		filing Filing
+		date   time.Time
+	}
+	material := make(map[string]bool, len(MaterialEventForms))
+	for _, form := range MaterialEventForms {
+		material[form] = true
+	}
+	var index []dated
+	for _, f := range filings {
+		if !material[strings.ToUpper(f.Form)] {
+			continue
+		}
+		if date, err := time.Parse("2006-01-02", f.FilingDate); err == nil {
+			index = append(index, dated{f, date})
+		}
+	}
+	sort.SliceStable(index, func(i, j int) bool { return index[i].date.Before(index[j].date) })
+
+	nearby := func(tradeDate string) []NearbyFiling {
+		events := []NearbyFiling{}
+		trade, err := time.Parse("2006-01-02", tradeDate)
+		if err != nil {
+			return events
+		}
+		from := trade.AddDate(0, 0, -days)
+		i := sort.Search(len(index), func(i int) bool { return !index[i].date.Before(from) })
+		for ; i < len(index); i++ {
+			diff := int(index[i].date.Sub(trade).Hours() / 24)
+			if diff > days {
+				break
+			}
+			f := index[i].filing
+			events = append(events, NearbyFiling{
+				Form:            f.Form,
+				FilingDate:      f.FilingDate,
+				ReportDate:      f.ReportDate,
+				AccessionNumber: f.AccessionNumber,
+				Items:           f.Items,
+				URL:             f.URL,
+				DaysFromTrade:   diff,
+				Earnings:        isEarningsFiling(f),
+			})
+		}
+		return events
+	}
+
+	var trades []TradeEvents
+	seen := make(map[string]bool)
+	for _, f := range forms {
+		if acc := f.Metadata.AccessionNumber; acc != "" {
+			if seen[acc] {
+				continue
+			}
+			seen[acc] = true
+		}
+		var names []string
+		for _, owner := range f.ReportingOwners {
+			names = append(names, owner.Name)
+		}
+		base := TradeEvents{
+			AccessionNumber: f.Metadata.AccessionNumber,
+			IssuerCIK:       f.Issuer.CIK,
+			Issuer:          f.Issuer.Name,
+			Owners:          strings.Join(names, "; "),
+		}
+		for _, txn := range f.Transactions {
+			t := base
+			t.SecurityTitle, t.TransactionDate, t.TransactionCode = txn.SecurityTitle, txn.TransactionDate, txn.TransactionCode
+			t.AcquiredDisposed, t.Shares, t.PricePerShare = txn.AcquiredDisposed, txn.Shares, txn.PricePerShare
+			t.Is10b51Plan = txn.Is10b51Plan
+			t.Events = nearby(txn.TransactionDate)
+			trades = append(trades, t)
+		}
+		for _, txn := range f.Derivatives {
+			t := base
+			t.Derivative = true
+			t.SecurityTitle, t.TransactionDate, t.TransactionCode = txn.SecurityTitle, txn.TransactionDate, txn.TransactionCode
+			t.AcquiredDisposed, t.Shares, t.PricePerShare = txn.AcquiredDisposed, txn.Shares, txn.PricePerShare
+			t.Is10b51Plan = txn.Is10b51Plan
+			t.Events = nearby(txn.TransactionDate)
+			trades = append(trades, t)
+		}
+	}
+	return trades
+}
+
+// isEarningsFiling reports whether a filing discloses financial results
+func isEarningsFiling(f Filing) bool {
+	form := strings.TrimSuffix(strings.ToUpper(f.Form), "/A")
+	switch form {
+	case "10-Q", "10-K", "20-F", "40-F":
+		return true
+	case "8-K":
+		return hasItem(f.Items, "2.02")
+	}
+	return false
+}
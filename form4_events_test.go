@@ -0,0 +1,59 @@
+package edgar
+
+import (
+	"testing"
+)
+
+func TestCorrelateTradeEvents(t *testing.T) {
+	form := &Form4Output{
+		Metadata:        FormMetadata{AccessionNumber: "form4-1"},
+		Issuer:          IssuerOutput{CIK: "10", Name: "Acme"},
+		ReportingOwners: []ReportingOwnerOutput{{Name: "Doe Jane"}, {Name: "Doe Trust"}},
+		Transactions: []NonDerivativeTransactionOut{
+			{TransactionDate: "2025-02-10", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(100), PricePerShare: ptrFloat(10)},
+			{TransactionDate: "", TransactionCode: "G", AcquiredDisposed: "D", Shares: ptrFloat(5)},
+		},
+		Derivatives: []DerivativeTransactionOut{
+			{TransactionDate: "2025-06-30", TransactionCode: "M", AcquiredDisposed: "D", Shares: ptrFloat(50)},
+		},
+	}
+	filings := []Filing{
+		{Form: "10-Q", FilingDate: "2025-05-01", AccessionNumber: "q1"},
+		{Form: "8-K", FilingDate: "2025-02-05", AccessionNumber: "earnings", Items: "2.02,9.01"},
+		{Form: "8-K", FilingDate: "2025-02-20", AccessionNumber: "officer", Items: "5.02"},
+		{Form: "8-K", FilingDate: "2025-02-25", AccessionNumber: "late", Items: "8.01"}, // 15 days after
+		{Form: "4", FilingDate: "2025-02-11", AccessionNumber: "form4-1"},               // Not material
+		{Form: "10-K", FilingDate: "2025-01-27", AccessionNumber: "annual"},             // 14 days before
+	}
+
+	trades := CorrelateTradeEvents([]*Form4Output{form, form}, filings, 14)
+	if len(trades) != 3 {
+		t.Fatalf("Got %d trades, want 3 (repeated Form 4 counted once)", len(trades))
+	}
+
+	sale := trades[0]
+	if sale.Owners != "Doe Jane; Doe Trust" || sale.Issuer != "Acme" || sale.TransactionCode != "S" || sale.Derivative {
+		t.Errorf("Sale = %+v", sale)
+	}
+	want := []struct {
+		accession string
+		days      int
+		earnings  bool
+	}{{"annual", -14, true}, {"earnings", -5, true}, {"officer", 10, false}}
+	if len(sale.Events) != len(want) {
+		t.Fatalf("Sale events = %+v", sale.Events)
+	}
+	for i, w := range want {
+		e := sale.Events[i]
+		if e.AccessionNumber != w.accession || e.DaysFromTrade != w.days || e.Earnings != w.earnings {
+			t.Errorf("Events[%d] = %+v, want %+v", i, e, w)
+		}
+	}
+
+	if gift := trades[1]; len(gift.Events) != 0 || gift.Events == nil {
+		t.Errorf("Undated gift events = %#v, want empty", gift.Events)
+	}
+	if exercise := trades[2]; !exercise.Derivative || len(exercise.Events) != 0 {
+		t.Errorf("Exercise = %+v, want a derivative with no events", exercise)
+	}
+}
@@ -0,0 +1,76 @@
+package edgar
+
+import "strings"
+
+// TransactionFilter selects Form 4 transactions; the zero value keeps everything
+type TransactionFilter struct {
+	Codes        []string // Transaction codes to keep, e.g. "P", "S" (empty: all)
+	MinValue     float64  // Minimum shares × price; unpriced transactions are dropped when set
+	OfficersOnly bool     // Only filings with an officer among the reporting owners
+	Exclude10b51 bool     // Drop transactions made under a Rule 10b5-1 plan
+}
+
+// IsZero reports whether the filter keeps every transaction
+func (filter TransactionFilter) IsZero() bool {
+	return len(filter.Codes) == 0 && filter.MinValue == 0 && !filter.OfficersOnly && !filter.Exclude10b51
+}
+
+// keep reports whether one transaction passes the transaction-level criteria
+func (filter TransactionFilter) keep(code string, value *float64, is10b51 bool) bool {
+	if len(filter.Codes) > 0 && !containsFold(filter.Codes, code) {
+		return false
+	}
+	if filter.MinValue > 0 && (value == nil || *value < filter.MinValue) {
+		return false
+	}
+	return !(filter.Exclude10b51 && is10b51)
+}
+
+// FilterTransactions removes the non-derivative and derivative transactions
+// that don't match the filter, in place, and reports whether any are left.
+// Holdings are kept.
+func (f *Form4Output) FilterTransactions(filter TransactionFilter) bool {
+	if filter.OfficersOnly && !f.hasOfficer() {
+		f.Transactions = nil
+		f.Derivatives = nil
+		return false
+	}
+
+	transactions := f.Transactions[:0]
+	for _, txn := range f.Transactions {
+		if filter.keep(txn.TransactionCode, txn.Value(), txn.Is10b51Plan) {
+			transactions = append(transactions, txn)
+		}
+	}
+	f.Transactions = transactions
+
+	derivatives := f.Derivatives[:0]
+	for _, txn := range f.Derivatives {
+		if filter.keep(txn.TransactionCode, txn.Value(), txn.Is10b51Plan) {
+			derivatives = append(derivatives, txn)
+		}
+	}
+	f.Derivatives = derivatives
+
+	return len(f.Transactions)+len(f.Derivatives) > 0
+}
+
+// hasOfficer reports whether any reporting owner is an officer
+func (f *Form4Output) hasOfficer() bool {
+	for _, owner := range f.ReportingOwners {
+		if owner.Relationship.IsOfficer {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
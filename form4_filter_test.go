@@ -0,0 +1,111 @@
+package edgar
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestFilterTransactions(t *testing.T) {
+	newForm := func() *Form4Output {
+		return &Form4Output{
+			ReportingOwners: []ReportingOwnerOutput{{Name: "Jane Doe", Relationship: RelationshipOut{IsOfficer: true}}},
+			Transactions: []NonDerivativeTransactionOut{
+				{TransactionCode: "P", Shares: ptrFloat(1000), PricePerShare: ptrFloat(150)}, // $150,000
+				{TransactionCode: "S", Shares: ptrFloat(100), PricePerShare: ptrFloat(150), Is10b51Plan: true},
+				{TransactionCode: "S", Shares: ptrFloat(5000), PricePerShare: ptrFloat(20)}, // $100,000
+				{TransactionCode: "A", Shares: ptrFloat(2000)},                              // Grant, no price
+			},
+			Derivatives: []DerivativeTransactionOut{
+				{TransactionCode: "M", Shares: ptrFloat(2000), PricePerShare: ptrFloat(0)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter TransactionFilter
+		want   []string // Codes left, non-derivative then derivative
+	}{
+		{"zero filter", TransactionFilter{}, []string{"P", "S", "S", "A", "M"}},
+		{"codes", TransactionFilter{Codes: []string{"p", "S"}}, []string{"P", "S", "S"}},
+		{"min value", TransactionFilter{MinValue: 100000}, []string{"P", "S"}},
+		{"exclude 10b5-1", TransactionFilter{Codes: []string{"S"}, Exclude10b51: true}, []string{"S"}},
+		{"nothing left", TransactionFilter{Codes: []string{"G"}}, nil},
+	}
+	for _, tt := range tests {
+		f := newForm()
+		kept := f.FilterTransactions(tt.filter)
+		var got []string
+		for _, txn := range f.Transactions {
+			got = append(got, txn.TransactionCode)
+		}
+		for _, txn := range f.Derivatives {
+			got = append(got, txn.TransactionCode)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || kept != (len(tt.want) > 0) {
+			t.Errorf("%s: kept %v, codes %v, want %v", tt.name, kept, got, tt.want)
+		}
+	}
+
+	// Officers only applies to the filing's reporting owners
+	f := newForm()
+	f.ReportingOwners[0].Relationship = RelationshipOut{IsDirector: true}
+	if f.FilterTransactions(TransactionFilter{OfficersOnly: true}) || len(f.Transactions) != 0 {
+		t.Errorf("director-only filing kept %d transactions, want none", len(f.Transactions))
+	}
+}
+
+func TestFetchAndParseBatch_TransactionFilter(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	for day := 1; day <= 3; day++ {
+		filing := Filing{
+			CIK:             "1640147",
+			AccessionNumber: fmt.Sprintf("0001640147-25-%06d", day),
+			Form:            "4",
+			FilingDate:      fmt.Sprintf("2025-03-%02d", day),
+		}
+		if err := store.Put(filing, data); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	opts := BatchOptions{
+		CIK:               "1640147",
+		FormType:          "4",
+		Store:             store,
+		Offline:           true,
+		Logger:            DiscardLogger(),
+		TransactionFilter: TransactionFilter{Codes: []string{"S"}},
+	}
+	result, err := FetchAndParseBatch(opts)
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if len(result.Filings) != 3 || result.Filtered != 0 {
+		t.Fatalf("Expected 3 filings kept, got %d (filtered %d)", len(result.Filings), result.Filtered)
+	}
+	for _, parsed := range result.Filings {
+		f4 := parsed.Data.(*Form4Output)
+		if len(f4.Transactions) != 5 || len(f4.Derivatives) != 0 {
+			t.Errorf("Expected the 5 sales only, got %d transactions and %d derivatives", len(f4.Transactions), len(f4.Derivatives))
+		}
+	}
+
+	// Filings without a matching transaction are omitted
+	opts.TransactionFilter = TransactionFilter{Codes: []string{"P"}}
+	result, err = FetchAndParseBatch(opts)
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if len(result.Filings) != 0 || result.Filtered != 3 || result.Fetched != 3 {
+		t.Errorf("Expected all 3 filings filtered, got %d kept, %d filtered, %d fetched", len(result.Filings), result.Filtered, result.Fetched)
+	}
+}
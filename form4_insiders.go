@@ -0,0 +1,223 @@
+package edgar
+
+import (
+	"sort"
+	"strings"
+)
+
+// InsiderActivityReport aggregates a batch of Form 4 filings per insider over
+// time (see AggregateInsiderActivity)
+type InsiderActivityReport struct {
+	Filings  int               `json:"filings"`
+	Insiders []InsiderActivity `json:"insiders"` // By value traded, largest first
+}
+
+// InsiderActivity is one reporting owner's trading in one issuer's securities
+//
+// Share and value totals cover Table I (non-derivative) transactions, as in
+// Form4Summary; trade counts and dates cover both tables. Joint filings are
+// attributed in full to every owner on the filing (see
+// Form4Output.NetChangeByOwner).
+type InsiderActivity struct {
+	OwnerCIK       string            `json:"ownerCik"`
+	OwnerName      string            `json:"ownerName"`
+	IssuerCIK      string            `json:"issuerCik"`
+	IssuerName     string            `json:"issuerName"`
+	Ticker         string            `json:"ticker"`
+	Filings        int               `json:"filings"`
+	Trades         int               `json:"trades"`
+	FirstTradeDate string            `json:"firstTradeDate,omitempty"`
+	LastTradeDate  string            `json:"lastTradeDate,omitempty"`
+	SharesAcquired float64           `json:"sharesAcquired"`
+	SharesDisposed float64           `json:"sharesDisposed"`
+	NetShares      float64           `json:"netShares"` // SharesAcquired - SharesDisposed
+	Cost           float64           `json:"cost"`      // Priced acquisitions (shares × price)
+	Proceeds       float64           `json:"proceeds"`  // Priced dispositions (shares × price)
+	Codes          []CodeCount       `json:"codes"`     // By count, most first
+	Timeline       []InsiderPosition `json:"timeline"`  // One entry per trade date, oldest first
+}
+
+// InsiderPosition is an insider's net Table I change on one trade date
+type InsiderPosition struct {
+	Date                string   `json:"date"`
+	NetShares           float64  `json:"netShares"`           // The day's acquired - disposed
+	CumulativeNetShares float64  `json:"cumulativeNetShares"` // Running total since the first trade date
+	SharesOwned         *float64 `json:"sharesOwned"`         // Last reported holding of the common class (see OwnershipAsOf) after the day's transactions
+}
+
+// AggregateInsiderActivity aggregates Form 4 filings (e.g. a batch result) per
+// insider and issuer: cumulative net shares over time, cost and proceeds,
+// trade counts by code and first/last trade dates. Filings repeated under the
+// same accession number are counted once.
+//
+// Filings and their transactions are taken in date order, whatever the input
+// order, so each timeline day's SharesOwned is the latest holding reported.
+func AggregateInsiderActivity(forms []*Form4Output) *InsiderActivityReport {
+	type tally struct {
+		activity *InsiderActivity
+		codes    map[string]int
+		days     map[string]*InsiderPosition
+	}
+	report := &InsiderActivityReport{Insiders: []InsiderActivity{}}
+	insiders := make(map[string]*tally)
+	var order []string
+	seen := make(map[string]bool)
+
+	sorted := append([]*Form4Output(nil), forms...)
+	sort.SliceStable(sorted, func(i, j int) bool { return form4Date(sorted[i]) < form4Date(sorted[j]) })
+
+	for _, f := range sorted {
+		if acc := f.Metadata.AccessionNumber; acc != "" {
+			if seen[acc] {
+				continue
+			}
+			seen[acc] = true
+		}
+		report.Filings++
+
+		transactions := append([]NonDerivativeTransactionOut(nil), f.Transactions...)
+		sort.SliceStable(transactions, func(i, j int) bool {
+			return transactions[i].TransactionDate < transactions[j].TransactionDate
+		})
+		class := form4Class(f)
+
+		for _, owner := range f.ReportingOwners {
+			key := owner.CIK
+			if key == "" {
+				key = owner.Name
+			}
+			key += "|" + f.Issuer.CIK
+			t, ok := insiders[key]
+			if !ok {
+				t = &tally{
+					activity: &InsiderActivity{
+						OwnerCIK:   owner.CIK,
+						OwnerName:  owner.Name,
+						IssuerCIK:  f.Issuer.CIK,
+						IssuerName: f.Issuer.Name,
+						Ticker:     f.Issuer.Ticker,
+					},
+					codes: make(map[string]int),
+					days:  make(map[string]*InsiderPosition),
+				}
+				insiders[key] = t
+				order = append(order, key)
+			}
+			a := t.activity
+			a.Filings++
+
+			for _, txn := range f.Derivatives {
+				a.Trades++
+				t.codes[txn.TransactionCode]++
+				a.addTradeDate(txn.TransactionDate)
+			}
+			for _, txn := range transactions {
+				a.Trades++
+				t.codes[txn.TransactionCode]++
+				a.addTradeDate(txn.TransactionDate)
+
+				day, ok := t.days[txn.TransactionDate]
+				if !ok {
+					day = &InsiderPosition{Date: txn.TransactionDate}
+					t.days[txn.TransactionDate] = day
+				}
+				if txn.SharesOwnedFollowing != nil && strings.EqualFold(strings.TrimSpace(txn.SecurityTitle), class) {
+					day.SharesOwned = txn.SharesOwnedFollowing
+				}
+				if txn.Shares == nil {
+					continue
+				}
+				var value float64
+				if v := txn.Value(); v != nil {
+					value = *v
+				}
+				switch txn.AcquiredDisposed {
+				case "A":
+					a.SharesAcquired += *txn.Shares
+					a.Cost += value
+					day.NetShares += *txn.Shares
+				case "D":
+					a.SharesDisposed += *txn.Shares
+					a.Proceeds += value
+					day.NetShares -= *txn.Shares
+				}
+			}
+		}
+	}
+
+	for _, key := range order {
+		t := insiders[key]
+		a := t.activity
+		a.NetShares = a.SharesAcquired - a.SharesDisposed
+
+		a.Codes = make([]CodeCount, 0, len(t.codes))
+		for code, count := range t.codes {
+			a.Codes = append(a.Codes, CodeCount{Code: code, Description: TransactionCodeDescription(code), Count: count})
+		}
+		sortCodeCounts(a.Codes)
+
+		a.Timeline = make([]InsiderPosition, 0, len(t.days))
+		for _, day := range t.days {
+			a.Timeline = append(a.Timeline, *day)
+		}
+		sort.Slice(a.Timeline, func(i, j int) bool { return a.Timeline[i].Date < a.Timeline[j].Date })
+		var cumulative float64
+		for i := range a.Timeline {
+			cumulative += a.Timeline[i].NetShares
+			a.Timeline[i].CumulativeNetShares = cumulative
+		}
+		report.Insiders = append(report.Insiders, *a)
+	}
+	sort.SliceStable(report.Insiders, func(i, j int) bool {
+		return report.Insiders[i].Cost+report.Insiders[i].Proceeds > report.Insiders[j].Cost+report.Insiders[j].Proceeds
+	})
+	return report
+}
+
+// form4Date orders Form 4 filings: the period of report, else the filing date
+func form4Date(f *Form4Output) string {
+	if d, ok := normalizeFilingDate(f.Metadata.PeriodOfReport); ok {
+		return d
+	}
+	d, _ := normalizeFilingDate(f.Metadata.FilingDate)
+	return d
+}
+
+// form4Class returns the class of stock a Form 4's holdings are reported in
+// (see ownershipClass), or "" when Table I is empty
+func form4Class(f *Form4Output) string {
+	var titles []string
+	for _, txn := range f.Transactions {
+		titles = append(titles, strings.TrimSpace(txn.SecurityTitle))
+	}
+	for _, h := range f.Holdings {
+		titles = append(titles, strings.TrimSpace(h.SecurityTitle))
+	}
+	if len(titles) == 0 {
+		return ""
+	}
+	return ownershipClass(titles)
+}
+
+// addTradeDate widens the first/last trade dates to include date
+func (a *InsiderActivity) addTradeDate(date string) {
+	if date == "" {
+		return
+	}
+	if a.FirstTradeDate == "" || date < a.FirstTradeDate {
+		a.FirstTradeDate = date
+	}
+	if date > a.LastTradeDate {
+		a.LastTradeDate = date
+	}
+}
+
+// sortCodeCounts orders transaction codes by count, most first, then by code
+func sortCodeCounts(codes []CodeCount) {
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Count != codes[j].Count {
+			return codes[i].Count > codes[j].Count
+		}
+		return codes[i].Code < codes[j].Code
+	})
+}
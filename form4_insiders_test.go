@@ -0,0 +1,128 @@
+package edgar
+
+import (
+	"testing"
+)
+
+func TestAggregateInsiderActivity(t *testing.T) {
+	owner := ReportingOwnerOutput{CIK: "0001", Name: "Doe Jane"}
+	issuer := IssuerOutput{CIK: "0009", Name: "Acme Corp", Ticker: "ACME"}
+	first := &Form4Output{
+		Metadata:        FormMetadata{AccessionNumber: "0000000000-25-000001"},
+		Issuer:          issuer,
+		ReportingOwners: []ReportingOwnerOutput{owner},
+		Transactions: []NonDerivativeTransactionOut{
+			{TransactionDate: "2025-03-02", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(100), PricePerShare: ptrFloat(10), SharesOwnedFollowing: ptrFloat(900)},
+			{TransactionDate: "2025-03-01", TransactionCode: "M", AcquiredDisposed: "A", Shares: ptrFloat(1000), SharesOwnedFollowing: ptrFloat(1000)},
+		},
+		Derivatives: []DerivativeTransactionOut{
+			{TransactionDate: "2025-03-01", TransactionCode: "M", AcquiredDisposed: "D", Shares: ptrFloat(1000)},
+		},
+	}
+	second := &Form4Output{
+		Metadata:        FormMetadata{AccessionNumber: "0000000000-25-000002"},
+		Issuer:          issuer,
+		ReportingOwners: []ReportingOwnerOutput{owner, {CIK: "0002", Name: "Fund LP"}},
+		Transactions: []NonDerivativeTransactionOut{
+			{TransactionDate: "2025-04-10", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(400), PricePerShare: ptrFloat(12.5), SharesOwnedFollowing: ptrFloat(500)},
+			{TransactionDate: "2025-04-10", TransactionCode: "P", AcquiredDisposed: "A", Shares: ptrFloat(50), PricePerShare: ptrFloat(12)},
+		},
+	}
+
+	report := AggregateInsiderActivity([]*Form4Output{first, second, first})
+	if report.Filings != 2 || len(report.Insiders) != 2 {
+		t.Fatalf("Got %d filings, %d insiders; want 2 and 2 (duplicate counted once)", report.Filings, len(report.Insiders))
+	}
+
+	a := report.Insiders[0]
+	if a.OwnerName != "Doe Jane" || a.Ticker != "ACME" || a.Filings != 2 || a.Trades != 5 {
+		t.Errorf("Insider = %s/%s, %d filings, %d trades", a.OwnerName, a.Ticker, a.Filings, a.Trades)
+	}
+	if a.FirstTradeDate != "2025-03-01" || a.LastTradeDate != "2025-04-10" {
+		t.Errorf("Trade dates = %s..%s", a.FirstTradeDate, a.LastTradeDate)
+	}
+	if a.SharesAcquired != 1050 || a.SharesDisposed != 500 || a.NetShares != 550 {
+		t.Errorf("Shares acquired %v, disposed %v, net %v", a.SharesAcquired, a.SharesDisposed, a.NetShares)
+	}
+	if a.Proceeds != 6000 || a.Cost != 600 {
+		t.Errorf("Proceeds = %v, Cost = %v, want 6000 and 600", a.Proceeds, a.Cost)
+	}
+	if len(a.Codes) != 3 || a.Codes[0].Code != "M" || a.Codes[0].Count != 2 || a.Codes[1].Code != "S" || a.Codes[1].Count != 2 {
+		t.Errorf("Codes = %+v", a.Codes)
+	}
+
+	wantTimeline := []struct {
+		date            string
+		net, cumulative float64
+		owned           float64
+	}{
+		{"2025-03-01", 1000, 1000, 1000},
+		{"2025-03-02", -100, 900, 900},
+		{"2025-04-10", -350, 550, 500},
+	}
+	if len(a.Timeline) != len(wantTimeline) {
+		t.Fatalf("Timeline = %+v", a.Timeline)
+	}
+	for i, want := range wantTimeline {
+		got := a.Timeline[i]
+		if got.Date != want.date || got.NetShares != want.net || got.CumulativeNetShares != want.cumulative ||
+			got.SharesOwned == nil || *got.SharesOwned != want.owned {
+			t.Errorf("Timeline[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// The joint filer gets the second filing's trades in full
+	fund := report.Insiders[1]
+	if fund.OwnerName != "Fund LP" || fund.Filings != 1 || fund.NetShares != -350 || fund.FirstTradeDate != "2025-04-10" {
+		t.Errorf("Joint filer = %+v", fund)
+	}
+
+	// Holdings of the common class only, in date order whatever the input order
+	march := &Form4Output{
+		Metadata:        FormMetadata{AccessionNumber: "0000000000-25-000003", PeriodOfReport: "2025-03-05"},
+		Issuer:          issuer,
+		ReportingOwners: []ReportingOwnerOutput{owner},
+		Transactions: []NonDerivativeTransactionOut{
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-05", TransactionCode: "P", AcquiredDisposed: "A", Shares: ptrFloat(100), SharesOwnedFollowing: ptrFloat(1100)},
+			{SecurityTitle: "Series A Preferred Stock", TransactionDate: "2025-03-05", TransactionCode: "P", AcquiredDisposed: "A", Shares: ptrFloat(10), SharesOwnedFollowing: ptrFloat(10)},
+		},
+	}
+	amended := &Form4Output{
+		Metadata:        FormMetadata{AccessionNumber: "0000000000-25-000004", PeriodOfReport: "2025-03-06"},
+		Issuer:          issuer,
+		ReportingOwners: []ReportingOwnerOutput{owner},
+		Transactions: []NonDerivativeTransactionOut{
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-06", TransactionCode: "S", AcquiredDisposed: "D", Shares: ptrFloat(50), SharesOwnedFollowing: ptrFloat(1050)},
+			{SecurityTitle: "Common Stock", TransactionDate: "2025-03-05", TransactionCode: "P", AcquiredDisposed: "A", Shares: ptrFloat(20), SharesOwnedFollowing: ptrFloat(1120)},
+		},
+	}
+	report = AggregateInsiderActivity([]*Form4Output{amended, march})
+	timeline := report.Insiders[0].Timeline
+	if len(timeline) != 2 || *timeline[0].SharesOwned != 1120 || *timeline[1].SharesOwned != 1050 {
+		t.Errorf("Timeline = %+v, want 1120 then 1050 shares owned", timeline)
+	}
+}
+
+func TestAggregateInsiderActivity_Fixtures(t *testing.T) {
+	snow := loadForm4Output(t, "snow", "0000000000-25-000001")
+	arrowhead := loadForm4Output(t, "arrowhead_footnotes", "0000000000-25-000002")
+
+	report := AggregateInsiderActivity([]*Form4Output{snow, arrowhead})
+	summary := SummarizeForm4Batch([]*Form4Output{snow, arrowhead}, 0)
+	if len(report.Insiders) != len(summary.Insiders) {
+		t.Fatalf("Got %d insiders, batch summary has %d", len(report.Insiders), len(summary.Insiders))
+	}
+	for _, a := range report.Insiders {
+		if a.FirstTradeDate == "" || a.Trades == 0 || len(a.Timeline) == 0 {
+			t.Errorf("%s: no trades or dates: %+v", a.OwnerName, a)
+		}
+		if last := a.Timeline[len(a.Timeline)-1]; last.CumulativeNetShares != a.NetShares {
+			t.Errorf("%s: cumulative net shares %v, want NetShares %v", a.OwnerName, last.CumulativeNetShares, a.NetShares)
+		}
+		for _, s := range summary.Insiders {
+			if s.OwnerCIK == a.OwnerCIK && (s.ValueDisposed != a.Proceeds || s.ValueAcquired != a.Cost) {
+				t.Errorf("%s: proceeds/cost %v/%v, batch summary %v/%v", a.OwnerName, a.Proceeds, a.Cost, s.ValueDisposed, s.ValueAcquired)
+			}
+		}
+	}
+}
@@ -2,17 +2,19 @@ package edgar
 
 // Form4Output represents the simplified JSON output structure
 type Form4Output struct {
-	Metadata        FormMetadata                  `json:"metadata"`
-	SchemaVersion   string                        `json:"schemaVersion"`
-	Has10b51Plan    bool                          `json:"has10b51Plan"` // Document-level indicator
-	Issuer          IssuerOutput                  `json:"issuer"`
-	ReportingOwners []ReportingOwnerOutput        `json:"reportingOwners"`
-	Transactions    []NonDerivativeTransactionOut `json:"transactions"`
-	Derivatives     []DerivativeTransactionOut    `json:"derivatives"`
-	Holdings        []NonDerivativeHoldingOut     `json:"holdings,omitempty"`
-	DerivHoldings   []DerivativeHoldingOut        `json:"derivativeHoldings,omitempty"`
-	Footnotes       []FootnoteOutput              `json:"footnotes"`
-	Signatures      []SignatureOutput             `json:"signatures"`
+	Metadata              FormMetadata                  `json:"metadata"`
+	SchemaVersion         string                        `json:"schemaVersion"`
+	Has10b51Plan          bool                          `json:"has10b51Plan"`          // Document-level indicator
+	NotSubjectToSection16 bool                          `json:"notSubjectToSection16"` // Box 1: no longer subject to Section 16
+	Issuer                IssuerOutput                  `json:"issuer"`
+	ReportingOwners       []ReportingOwnerOutput        `json:"reportingOwners"`
+	Transactions          []NonDerivativeTransactionOut `json:"transactions"`
+	Derivatives           []DerivativeTransactionOut    `json:"derivatives"`
+	Holdings              []NonDerivativeHoldingOut     `json:"holdings,omitempty"`
+	DerivHoldings         []DerivativeHoldingOut        `json:"derivativeHoldings,omitempty"`
+	Footnotes             []FootnoteOutput              `json:"footnotes"`
+	Signatures            []SignatureOutput             `json:"signatures"`
+	Warnings              []ParseWarning                `json:"warnings,omitempty"` // Amounts that couldn't be read
 }
 
 // FormMetadata contains metadata about the filing
@@ -37,6 +39,7 @@ type ReportingOwnerOutput struct {
 	Name         string          `json:"name"`
 	Address      AddressOutput   `json:"address"`
 	Relationship RelationshipOut `json:"relationship"`
+	OfficerRoles []string        `json:"officerRoles,omitempty"` // Normalized officer title (e.g. ["CEO", "President"])
 }
 
 type AddressOutput struct {
@@ -57,47 +60,53 @@ type RelationshipOut struct {
 
 // NonDerivativeTransactionOut represents a single transaction row (table-like)
 type NonDerivativeTransactionOut struct {
-	SecurityTitle         string   `json:"securityTitle"`
-	TransactionDate       string   `json:"transactionDate"`
-	TransactionCode       string   `json:"transactionCode"`
-	Shares                *float64 `json:"shares"`               // Nullable for empty values
-	PricePerShare         *float64 `json:"pricePerShare"`        // Nullable for empty values
-	AcquiredDisposed      string   `json:"acquiredDisposed"`     // "A" or "D"
-	SharesOwnedFollowing  *float64 `json:"sharesOwnedFollowing"` // Nullable
-	DirectIndirect        string   `json:"directIndirect"`       // "D" or "I"
-	NatureOfOwnership     string   `json:"natureOfOwnership,omitempty"`
-	EquitySwapInvolved    bool     `json:"equitySwapInvolved"`
-	Is10b51Plan           bool     `json:"is10b51Plan"`           // Per-transaction 10b5-1 indicator (always present)
-	Plan10b51AdoptionDate *string  `json:"plan10b51AdoptionDate"` // ISO-8601 date (YYYY-MM-DD), null if not 10b5-1 or date unknown (always present)
-	Footnotes             []string `json:"footnotes"`             // Array of footnote IDs
+	SecurityTitle         string           `json:"securityTitle"`
+	TransactionDate       string           `json:"transactionDate"`
+	TransactionCode       string           `json:"transactionCode"`
+	Shares                *float64         `json:"shares"`                        // Nullable for empty values
+	PricePerShare         *float64         `json:"pricePerShare"`                 // Nullable for empty values
+	AcquiredDisposed      string           `json:"acquiredDisposed"`              // "A" or "D"
+	SharesOwnedFollowing  *float64         `json:"sharesOwnedFollowing"`          // Nullable
+	ValueOwnedFollowing   *float64         `json:"valueOwnedFollowing,omitempty"` // Reported as a dollar value instead of shares
+	DirectIndirect        string           `json:"directIndirect"`                // "D" or "I"
+	NatureOfOwnership     string           `json:"natureOfOwnership,omitempty"`
+	EquitySwapInvolved    bool             `json:"equitySwapInvolved"`
+	Is10b51Plan           bool             `json:"is10b51Plan"`             // Per-transaction 10b5-1 indicator (always present)
+	Plan10b51AdoptionDate *string          `json:"plan10b51AdoptionDate"`   // ISO-8601 date (YYYY-MM-DD), null if not 10b5-1 or date unknown (always present)
+	Footnotes             []string         `json:"footnotes"`               // Array of footnote IDs
+	FootnoteTexts         []FootnoteOutput `json:"footnoteTexts,omitempty"` // Resolved footnotes, set by ResolveFootnotes
 }
 
 // DerivativeTransactionOut represents a derivative transaction row
 type DerivativeTransactionOut struct {
-	SecurityTitle         string   `json:"securityTitle"`
-	TransactionDate       string   `json:"transactionDate"`
-	TransactionCode       string   `json:"transactionCode"`
-	Shares                *float64 `json:"shares"`
-	PricePerShare         *float64 `json:"pricePerShare"`
-	AcquiredDisposed      string   `json:"acquiredDisposed"`
-	ExercisePrice         *float64 `json:"exercisePrice,omitempty"`
-	ExerciseDate          string   `json:"exerciseDate,omitempty"`
-	ExpirationDate        string   `json:"expirationDate,omitempty"`
-	UnderlyingTitle       string   `json:"underlyingTitle,omitempty"`
-	UnderlyingShares      *float64 `json:"underlyingShares,omitempty"`
-	SharesOwnedFollowing  *float64 `json:"sharesOwnedFollowing"`
-	DirectIndirect        string   `json:"directIndirect"`
-	NatureOfOwnership     string   `json:"natureOfOwnership,omitempty"`
-	EquitySwapInvolved    bool     `json:"equitySwapInvolved"`
-	Is10b51Plan           bool     `json:"is10b51Plan"`           // Per-transaction 10b5-1 indicator (always present)
-	Plan10b51AdoptionDate *string  `json:"plan10b51AdoptionDate"` // ISO-8601 date (YYYY-MM-DD), null if not 10b5-1 or date unknown (always present)
-	Footnotes             []string `json:"footnotes"`             // Array of footnote IDs
+	SecurityTitle         string           `json:"securityTitle"`
+	TransactionDate       string           `json:"transactionDate"`
+	TransactionCode       string           `json:"transactionCode"`
+	Shares                *float64         `json:"shares"`
+	PricePerShare         *float64         `json:"pricePerShare"`
+	AcquiredDisposed      string           `json:"acquiredDisposed"`
+	ExercisePrice         *float64         `json:"exercisePrice,omitempty"`
+	ExerciseDate          string           `json:"exerciseDate,omitempty"`
+	ExpirationDate        string           `json:"expirationDate,omitempty"`
+	UnderlyingTitle       string           `json:"underlyingTitle,omitempty"`
+	UnderlyingShares      *float64         `json:"underlyingShares,omitempty"`
+	UnderlyingValue       *float64         `json:"underlyingValue,omitempty"` // Reported as a dollar value instead of shares
+	SharesOwnedFollowing  *float64         `json:"sharesOwnedFollowing"`
+	ValueOwnedFollowing   *float64         `json:"valueOwnedFollowing,omitempty"` // Reported as a dollar value instead of shares
+	DirectIndirect        string           `json:"directIndirect"`
+	NatureOfOwnership     string           `json:"natureOfOwnership,omitempty"`
+	EquitySwapInvolved    bool             `json:"equitySwapInvolved"`
+	Is10b51Plan           bool             `json:"is10b51Plan"`             // Per-transaction 10b5-1 indicator (always present)
+	Plan10b51AdoptionDate *string          `json:"plan10b51AdoptionDate"`   // ISO-8601 date (YYYY-MM-DD), null if not 10b5-1 or date unknown (always present)
+	Footnotes             []string         `json:"footnotes"`               // Array of footnote IDs
+	FootnoteTexts         []FootnoteOutput `json:"footnoteTexts,omitempty"` // Resolved footnotes, set by ResolveFootnotes
 }
 
 // NonDerivativeHoldingOut represents a holding row
 type NonDerivativeHoldingOut struct {
 	SecurityTitle        string   `json:"securityTitle"`
 	SharesOwnedFollowing *float64 `json:"sharesOwnedFollowing"`
+	ValueOwnedFollowing  *float64 `json:"valueOwnedFollowing,omitempty"` // Reported as a dollar value instead of shares
 	DirectIndirect       string   `json:"directIndirect"`
 	NatureOfOwnership    string   `json:"natureOfOwnership,omitempty"`
 	Footnotes            []string `json:"footnotes"`
@@ -111,7 +120,9 @@ type DerivativeHoldingOut struct {
 	ExpirationDate       string   `json:"expirationDate,omitempty"`
 	UnderlyingTitle      string   `json:"underlyingTitle,omitempty"`
 	UnderlyingShares     *float64 `json:"underlyingShares,omitempty"`
+	UnderlyingValue      *float64 `json:"underlyingValue,omitempty"` // Reported as a dollar value instead of shares
 	SharesOwnedFollowing *float64 `json:"sharesOwnedFollowing"`
+	ValueOwnedFollowing  *float64 `json:"valueOwnedFollowing,omitempty"` // Reported as a dollar value instead of shares
 	DirectIndirect       string   `json:"directIndirect"`
 	NatureOfOwnership    string   `json:"natureOfOwnership,omitempty"`
 	Footnotes            []string `json:"footnotes"`
@@ -168,7 +179,7 @@ func (f *Form4) ToOutput() *Form4Output {
 			break
 		}
 	}
-	useRemarksGlobal := f.Aff10b5One && !has10b51Footnotes && tenb51Map["__REMARKS__"] != ""
+	useRemarksGlobal := bool(f.Aff10b5One) && !has10b51Footnotes && tenb51Map["__REMARKS__"] != ""
 
 	out := &Form4Output{
 		Metadata: FormMetadata{
@@ -180,12 +191,14 @@ func (f *Form4) ToOutput() *Form4Output {
 			ReportDate:      "", // To be filled by caller if available
 			Source:          "", // To be filled by caller if available
 		},
-		SchemaVersion:   f.SchemaVersion,
-		Has10b51Plan:    f.Is10b51Plan(),
-		Issuer:          convertIssuer(f.Issuer),
-		ReportingOwners: convertReportingOwners(f.ReportingOwners),
-		Footnotes:       convertFootnotes(f.Footnotes, f.Remarks),
-		Signatures:      convertSignatures(f.Signatures),
+		SchemaVersion:         f.SchemaVersion,
+		Has10b51Plan:          f.Is10b51Plan(),
+		NotSubjectToSection16: bool(f.NotSubjectToSection16),
+		Issuer:                convertIssuer(f.Issuer),
+		ReportingOwners:       convertReportingOwners(f.ReportingOwners),
+		Footnotes:             convertFootnotes(f.Footnotes, f.Remarks),
+		Signatures:            convertSignatures(f.Signatures),
+		Warnings:              form4Warnings(f),
 	}
 
 	// Convert non-derivative transactions
@@ -233,12 +246,13 @@ func convertReportingOwners(owners []ReportingOwner) []ReportingOwnerOutput {
 				ZipCode: owner.Address.ZipCode,
 			},
 			Relationship: RelationshipOut{
-				IsDirector:        owner.Relationship.IsDirector,
-				IsOfficer:         owner.Relationship.IsOfficer,
-				IsTenPercentOwner: owner.Relationship.IsTenPercentOwner,
-				IsOther:           owner.Relationship.IsOther,
+				IsDirector:        bool(owner.Relationship.IsDirector),
+				IsOfficer:         bool(owner.Relationship.IsOfficer),
+				IsTenPercentOwner: bool(owner.Relationship.IsTenPercentOwner),
+				IsOther:           bool(owner.Relationship.IsOther),
 				OfficerTitle:      owner.Relationship.OfficerTitle,
 			},
+			OfficerRoles: NormalizeOfficerTitle(owner.Relationship.OfficerTitle),
 		})
 	}
 	return out
@@ -251,6 +265,7 @@ func convertNonDerivTransaction(txn NonDerivativeTransaction, tenb51Map map[stri
 		txn.Amounts.Shares.FootnoteID.ID,
 		txn.Amounts.PricePerShare.FootnoteID.ID,
 		txn.PostTransaction.SharesOwnedFollowing.FootnoteID.ID,
+		txn.PostTransaction.ValueOwnedFollowing.FootnoteID.ID,
 	)
 
 	// Check if any footnote indicates 10b5-1 plan
@@ -264,9 +279,10 @@ func convertNonDerivTransaction(txn NonDerivativeTransaction, tenb51Map map[stri
 		PricePerShare:         toFloat64Ptr(txn.Amounts.PricePerShare),
 		AcquiredDisposed:      txn.Amounts.AcquiredDisposed,
 		SharesOwnedFollowing:  toFloat64Ptr(txn.PostTransaction.SharesOwnedFollowing),
+		ValueOwnedFollowing:   toFloat64Ptr(txn.PostTransaction.ValueOwnedFollowing),
 		DirectIndirect:        txn.OwnershipNature.DirectOrIndirect,
 		NatureOfOwnership:     txn.OwnershipNature.NatureOfOwnership,
-		EquitySwapInvolved:    txn.Coding.EquitySwapInvolved,
+		EquitySwapInvolved:    bool(txn.Coding.EquitySwapInvolved),
 		Is10b51Plan:           is10b51,
 		Plan10b51AdoptionDate: adoptionDate,
 		Footnotes:             footnotes,
@@ -283,7 +299,9 @@ func convertDerivTransaction(txn DerivativeTransaction, tenb51Map map[string]str
 		txn.ExpirationDate.FootnoteID.ID,
 		txn.UnderlyingSecurity.SecurityTitle.FootnoteID.ID,
 		txn.UnderlyingSecurity.Shares.FootnoteID.ID,
+		txn.UnderlyingSecurity.Value.FootnoteID.ID,
 		txn.PostTransaction.SharesOwnedFollowing.FootnoteID.ID,
+		txn.PostTransaction.ValueOwnedFollowing.FootnoteID.ID,
 	)
 
 	// Check if any footnote indicates 10b5-1 plan
@@ -301,10 +319,12 @@ func convertDerivTransaction(txn DerivativeTransaction, tenb51Map map[string]str
 		ExpirationDate:        txn.ExpirationDate.Value,
 		UnderlyingTitle:       txn.UnderlyingSecurity.SecurityTitle.Value,
 		UnderlyingShares:      toFloat64Ptr(txn.UnderlyingSecurity.Shares),
+		UnderlyingValue:       toFloat64Ptr(txn.UnderlyingSecurity.Value),
 		SharesOwnedFollowing:  toFloat64Ptr(txn.PostTransaction.SharesOwnedFollowing),
+		ValueOwnedFollowing:   toFloat64Ptr(txn.PostTransaction.ValueOwnedFollowing),
 		DirectIndirect:        txn.OwnershipNature.DirectOrIndirect,
 		NatureOfOwnership:     txn.OwnershipNature.NatureOfOwnership,
-		EquitySwapInvolved:    txn.Coding.EquitySwapInvolved,
+		EquitySwapInvolved:    bool(txn.Coding.EquitySwapInvolved),
 		Is10b51Plan:           is10b51,
 		Plan10b51AdoptionDate: adoptionDate,
 		Footnotes:             footnotes,
@@ -312,10 +332,23 @@ func convertDerivTransaction(txn DerivativeTransaction, tenb51Map map[string]str
 }
 
 func convertNonDerivHolding(holding NonDerivativeHolding) NonDerivativeHoldingOut {
-	// TODO: Add fields when we have test data with holdings
+	footnotes := collectFootnotes(
+		holding.PostTransaction.SharesOwnedFollowing.FootnoteID.ID,
+		holding.PostTransaction.ValueOwnedFollowing.FootnoteID.ID,
+		holding.OwnershipNature.DirectOrIndirectFootnote.ID,
+		holding.OwnershipNature.NatureFootnote.ID,
+	)
+	if footnotes == nil {
+		footnotes = []string{}
+	}
+
 	return NonDerivativeHoldingOut{
-		SecurityTitle: holding.SecurityTitle,
-		Footnotes:     []string{},
+		SecurityTitle:        holding.SecurityTitle,
+		SharesOwnedFollowing: toFloat64Ptr(holding.PostTransaction.SharesOwnedFollowing),
+		ValueOwnedFollowing:  toFloat64Ptr(holding.PostTransaction.ValueOwnedFollowing),
+		DirectIndirect:       holding.OwnershipNature.DirectOrIndirect,
+		NatureOfOwnership:    holding.OwnershipNature.NatureOfOwnership,
+		Footnotes:            footnotes,
 	}
 }
 
@@ -326,7 +359,11 @@ func convertDerivHolding(holding DerivativeHolding) DerivativeHoldingOut {
 		holding.ExpirationDate.FootnoteID.ID,
 		holding.UnderlyingSecurity.SecurityTitle.FootnoteID.ID,
 		holding.UnderlyingSecurity.Shares.FootnoteID.ID,
+		holding.UnderlyingSecurity.Value.FootnoteID.ID,
 		holding.PostTransaction.SharesOwnedFollowing.FootnoteID.ID,
+		holding.PostTransaction.ValueOwnedFollowing.FootnoteID.ID,
+		holding.OwnershipNature.DirectOrIndirectFootnote.ID,
+		holding.OwnershipNature.NatureFootnote.ID,
 	)
 
 	return DerivativeHoldingOut{
@@ -336,7 +373,9 @@ func convertDerivHolding(holding DerivativeHolding) DerivativeHoldingOut {
 		ExpirationDate:       holding.ExpirationDate.Value,
 		UnderlyingTitle:      holding.UnderlyingSecurity.SecurityTitle.Value,
 		UnderlyingShares:     toFloat64Ptr(holding.UnderlyingSecurity.Shares),
+		UnderlyingValue:      toFloat64Ptr(holding.UnderlyingSecurity.Value),
 		SharesOwnedFollowing: toFloat64Ptr(holding.PostTransaction.SharesOwnedFollowing),
+		ValueOwnedFollowing:  toFloat64Ptr(holding.PostTransaction.ValueOwnedFollowing),
 		DirectIndirect:       holding.OwnershipNature.DirectOrIndirect,
 		NatureOfOwnership:    holding.OwnershipNature.NatureOfOwnership,
 		Footnotes:            footnotes,
@@ -0,0 +1,203 @@
+package edgar
+
+import "strings"
+
+// Form4Row is a denormalized Form 4 transaction: the transaction joined with
+// its filing, issuer and one reporting owner
+type Form4Row struct {
+	AccessionNumber string `json:"accessionNumber"`
+	FilingDate      string `json:"filingDate"`
+	PeriodOfReport  string `json:"periodOfReport"`
+
+	IssuerCIK  string `json:"issuerCik"`
+	IssuerName string `json:"issuerName"`
+	Ticker     string `json:"ticker"`
+
+	OwnerCIK          string `json:"ownerCik"`
+	OwnerName         string `json:"ownerName"`
+	OwnerRelationship string `json:"ownerRelationship"` // e.g. "Director; CEO"
+	IsDirector        bool   `json:"isDirector"`
+	IsOfficer         bool   `json:"isOfficer"`
+	IsTenPercentOwner bool   `json:"isTenPercentOwner"`
+	IsOther           bool   `json:"isOther"`
+	OfficerTitle      string `json:"officerTitle,omitempty"`
+
+	Table                  string   `json:"table"` // "non-derivative" or "derivative"
+	SecurityTitle          string   `json:"securityTitle"`
+	TransactionDate        string   `json:"transactionDate"`
+	TransactionCode        string   `json:"transactionCode"`
+	TransactionDescription string   `json:"transactionDescription"`
+	AcquiredDisposed       string   `json:"acquiredDisposed"`
+	Shares                 *float64 `json:"shares"`
+	PricePerShare          *float64 `json:"pricePerShare"`
+	Value                  *float64 `json:"value"` // Shares * PricePerShare (null if either is missing)
+	SharesOwnedFollowing   *float64 `json:"sharesOwnedFollowing"`
+	DirectIndirect         string   `json:"directIndirect"`
+	NatureOfOwnership      string   `json:"natureOfOwnership,omitempty"`
+	Is10b51Plan            bool     `json:"is10b51Plan"`
+	Plan10b51AdoptionDate  *string  `json:"plan10b51AdoptionDate"`
+
+	// Derivative transactions only
+	ExercisePrice    *float64 `json:"exercisePrice,omitempty"`
+	ExpirationDate   string   `json:"expirationDate,omitempty"`
+	UnderlyingTitle  string   `json:"underlyingTitle,omitempty"`
+	UnderlyingShares *float64 `json:"underlyingShares,omitempty"`
+
+	Footnotes string `json:"footnotes"` // Resolved footnote text, e.g. "[F1] ... | [F2] ..."
+}
+
+// Rows flattens the filing into one row per transaction per reporting owner
+//
+// Non-derivative transactions come first, then derivative transactions. A
+// filing with several (joint) reporting owners repeats each transaction once
+// per owner, so sum values per owner or de-duplicate on accession number when
+// aggregating. Filings without owners produce rows with empty owner fields.
+func (f *Form4Output) Rows() []Form4Row {
+	footnotes := make(map[string]string, len(f.Footnotes))
+	for _, fn := range f.Footnotes {
+		footnotes[fn.ID] = fn.Text
+	}
+
+	owners := f.ReportingOwners
+	if len(owners) == 0 {
+		owners = []ReportingOwnerOutput{{}}
+	}
+
+	base := func(owner ReportingOwnerOutput) Form4Row {
+		r := owner.Relationship
+		return Form4Row{
+			AccessionNumber:   f.Metadata.AccessionNumber,
+			FilingDate:        f.Metadata.FilingDate,
+			PeriodOfReport:    f.Metadata.PeriodOfReport,
+			IssuerCIK:         f.Issuer.CIK,
+			IssuerName:        f.Issuer.Name,
+			Ticker:            f.Issuer.Ticker,
+			OwnerCIK:          owner.CIK,
+			OwnerName:         owner.Name,
+			OwnerRelationship: describeRelationship(r),
+			IsDirector:        r.IsDirector,
+			IsOfficer:         r.IsOfficer,
+			IsTenPercentOwner: r.IsTenPercentOwner,
+			IsOther:           r.IsOther,
+			OfficerTitle:      r.OfficerTitle,
+		}
+	}
+
+	var rows []Form4Row
+	for _, txn := range f.Transactions {
+		for _, owner := range owners {
+			row := base(owner)
+			row.Table = "non-derivative"
+			row.SecurityTitle = txn.SecurityTitle
+			row.TransactionDate = txn.TransactionDate
+			row.TransactionCode = txn.TransactionCode
+			row.TransactionDescription = TransactionCodeDescription(txn.TransactionCode)
+			row.AcquiredDisposed = txn.AcquiredDisposed
+			row.Shares = txn.Shares
+			row.PricePerShare = txn.PricePerShare
+			row.Value = txn.Value()
+			row.SharesOwnedFollowing = txn.SharesOwnedFollowing
+			row.DirectIndirect = txn.DirectIndirect
+			row.NatureOfOwnership = txn.NatureOfOwnership
+			row.Is10b51Plan = txn.Is10b51Plan
+			row.Plan10b51AdoptionDate = txn.Plan10b51AdoptionDate
+			row.Footnotes = joinFootnotes(txn.Footnotes, footnotes)
+			rows = append(rows, row)
+		}
+	}
+	for _, txn := range f.Derivatives {
+		for _, owner := range owners {
+			row := base(owner)
+			row.Table = "derivative"
+			row.SecurityTitle = txn.SecurityTitle
+			row.TransactionDate = txn.TransactionDate
+			row.TransactionCode = txn.TransactionCode
+			row.TransactionDescription = TransactionCodeDescription(txn.TransactionCode)
+			row.AcquiredDisposed = txn.AcquiredDisposed
+			row.Shares = txn.Shares
+			row.PricePerShare = txn.PricePerShare
+			row.Value = txn.Value()
+			row.SharesOwnedFollowing = txn.SharesOwnedFollowing
+			row.DirectIndirect = txn.DirectIndirect
+			row.NatureOfOwnership = txn.NatureOfOwnership
+			row.Is10b51Plan = txn.Is10b51Plan
+			row.Plan10b51AdoptionDate = txn.Plan10b51AdoptionDate
+			row.ExercisePrice = txn.ExercisePrice
+			row.ExpirationDate = txn.ExpirationDate
+			row.UnderlyingTitle = txn.UnderlyingTitle
+			row.UnderlyingShares = txn.UnderlyingShares
+			row.Footnotes = joinFootnotes(txn.Footnotes, footnotes)
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// ResolveFootnotes fills FootnoteTexts on each transaction and derivative
+// transaction with the text of the footnotes it references, so consumers do
+// not have to join on footnote ID. IDs without a matching footnote are skipped.
+func (f *Form4Output) ResolveFootnotes() {
+	byID := make(map[string]FootnoteOutput, len(f.Footnotes))
+	for _, fn := range f.Footnotes {
+		byID[fn.ID] = fn
+	}
+	resolve := func(ids []string) []FootnoteOutput {
+		var out []FootnoteOutput
+		for _, id := range ids {
+			if fn, ok := byID[id]; ok {
+				out = append(out, fn)
+			}
+		}
+		return out
+	}
+
+	for i := range f.Transactions {
+		f.Transactions[i].FootnoteTexts = resolve(f.Transactions[i].Footnotes)
+	}
+	for i := range f.Derivatives {
+		f.Derivatives[i].FootnoteTexts = resolve(f.Derivatives[i].Footnotes)
+	}
+}
+
+// describeRelationship summarizes an owner's relationship flags, e.g. "Director; CEO"
+func describeRelationship(r RelationshipOut) string {
+	var parts []string
+	if r.IsDirector {
+		parts = append(parts, "Director")
+	}
+	if r.IsOfficer {
+		if r.OfficerTitle != "" {
+			parts = append(parts, r.OfficerTitle)
+		} else {
+			parts = append(parts, "Officer")
+		}
+	}
+	if r.IsTenPercentOwner {
+		parts = append(parts, "10% Owner")
+	}
+	if r.IsOther {
+		parts = append(parts, "Other")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// joinFootnotes resolves footnote IDs to their text
+func joinFootnotes(ids []string, text map[string]string) string {
+	var parts []string
+	for _, id := range ids {
+		if t, ok := text[id]; ok {
+			// Collapse the XML's line breaks/indentation so each row stays on one line
+			parts = append(parts, "["+id+"] "+strings.Join(strings.Fields(t), " "))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// multiply returns a * b, or nil if either is missing
+func multiply(a, b *float64) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	v := *a * *b
+	return &v
+}
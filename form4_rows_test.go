@@ -0,0 +1,74 @@
+package edgar
+
+import "testing"
+
+func TestForm4Output_Rows(t *testing.T) {
+	shares, price := 1000.0, 12.5
+	out := &Form4Output{
+		Metadata: FormMetadata{AccessionNumber: "0001234567-25-000001", FilingDate: "2025-03-01"},
+		Issuer:   IssuerOutput{CIK: "1234567", Name: "ACME CORP", Ticker: "ACME"},
+		ReportingOwners: []ReportingOwnerOutput{
+			{CIK: "111", Name: "Fund LP", Relationship: RelationshipOut{IsTenPercentOwner: true}},
+			{CIK: "222", Name: "Fund GP LLC", Relationship: RelationshipOut{IsTenPercentOwner: true, IsOther: true}},
+		},
+		Transactions: []NonDerivativeTransactionOut{
+			{SecurityTitle: "Common Stock", TransactionCode: "P", Shares: &shares, PricePerShare: &price, AcquiredDisposed: "A", Footnotes: []string{"F1"}},
+		},
+		Derivatives: []DerivativeTransactionOut{
+			{SecurityTitle: "Warrant", TransactionCode: "A", Shares: &shares, UnderlyingTitle: "Common Stock"},
+		},
+		Footnotes: []FootnoteOutput{{ID: "F1", Text: "Weighted average\n   price."}},
+	}
+
+	rows := out.Rows()
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows (2 transactions x 2 owners), got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.OwnerName != "Fund LP" || rows[1].OwnerName != "Fund GP LLC" {
+		t.Errorf("Expected one row per owner, got %q and %q", first.OwnerName, rows[1].OwnerName)
+	}
+	if first.Ticker != "ACME" || first.Table != "non-derivative" || first.TransactionDescription == "" {
+		t.Errorf("Unexpected row: %+v", first)
+	}
+	if first.Value == nil || *first.Value != 12500 {
+		t.Errorf("Expected value 12500, got %v", first.Value)
+	}
+	if first.Footnotes != "[F1] Weighted average price." {
+		t.Errorf("Unexpected footnotes %q", first.Footnotes)
+	}
+	if rows[1].OwnerRelationship != "10% Owner; Other" {
+		t.Errorf("Unexpected relationship %q", rows[1].OwnerRelationship)
+	}
+
+	deriv := rows[2]
+	if deriv.Table != "derivative" || deriv.UnderlyingTitle != "Common Stock" || deriv.Value != nil {
+		t.Errorf("Unexpected derivative row: %+v", deriv)
+	}
+}
+
+func TestForm4Output_ResolveFootnotes(t *testing.T) {
+	out := &Form4Output{
+		Transactions: []NonDerivativeTransactionOut{{Footnotes: []string{"F2", "F1", "F9"}}, {}},
+		Derivatives:  []DerivativeTransactionOut{{Footnotes: []string{"REMARKS"}}},
+		Footnotes: []FootnoteOutput{
+			{ID: "F1", Text: "Weighted average price."},
+			{ID: "F2", Text: "Sold under a 10b5-1 plan."},
+			{ID: "REMARKS", Text: "Exhibit 24 - Power of Attorney"},
+		},
+	}
+
+	out.ResolveFootnotes()
+
+	got := out.Transactions[0].FootnoteTexts
+	if len(got) != 2 || got[0].ID != "F2" || got[1].Text != "Weighted average price." {
+		t.Errorf("Expected F2 and F1 in reference order (unknown F9 skipped), got %+v", got)
+	}
+	if out.Transactions[1].FootnoteTexts != nil {
+		t.Errorf("Expected no footnotes, got %+v", out.Transactions[1].FootnoteTexts)
+	}
+	if got := out.Derivatives[0].FootnoteTexts; len(got) != 1 || got[0].ID != "REMARKS" {
+		t.Errorf("Unexpected derivative footnotes %+v", got)
+	}
+}
@@ -0,0 +1,161 @@
+package edgar
+
+import (
+	"sort"
+)
+
+// SentimentWeights weights insider trades in ScoreInsiderSentiment
+//
+// A trade's weight is its owner's largest applicable role weight (a CEO who
+// is also a director gets the CEO weight), times Plan10b51 if the trade was
+// made under a Rule 10b5-1 plan. Trades weighted 0 don't move the score.
+type SentimentWeights struct {
+	Roles           map[string]float64 `json:"roles"`   // Officer roles (RoleCEO, RoleCFO, ...)
+	Officer         float64            `json:"officer"` // Officers with none of Roles
+	Director        float64            `json:"director"`
+	TenPercentOwner float64            `json:"tenPercentOwner"`
+	Other           float64            `json:"other"`     // "Other" relationships, and owners with none flagged
+	Plan10b51       float64            `json:"plan10b51"` // Multiplier for prearranged (10b5-1) trades
+}
+
+// DefaultSentimentWeights count CEO and CFO trades double, ten percent owners
+// (often funds trading for their own reasons) at half, and prearranged 10b5-1
+// trades, decided months in advance, at a quarter
+var DefaultSentimentWeights = SentimentWeights{
+	Roles:           map[string]float64{RoleCEO: 2, RoleCFO: 2},
+	Officer:         1,
+	Director:        1,
+	TenPercentOwner: 0.5,
+	Other:           0.5,
+	Plan10b51:       0.25,
+}
+
+// IssuerSentiment is the insider sentiment of one issuer's Form 4s
+//
+// Score = (WeightedBuys - WeightedSells) / (WeightedBuys + WeightedSells),
+// from -1 (only selling) to +1 (only buying), 0 when there were no scored
+// trades. Only open-market purchases (P) and sales (S) with a price are
+// scored: grants, exercises, tax withholding and gifts don't signal a view.
+type IssuerSentiment struct {
+	IssuerCIK      string  `json:"issuerCik"`
+	IssuerName     string  `json:"issuerName"`
+	Ticker         string  `json:"ticker"`
+	Filings        int     `json:"filings"`
+	FirstTradeDate string  `json:"firstTradeDate,omitempty"`
+	LastTradeDate  string  `json:"lastTradeDate,omitempty"`
+	Buys           int     `json:"buys"`
+	Sells          int     `json:"sells"`
+	BuyValue       float64 `json:"buyValue"`  // Unweighted
+	SellValue      float64 `json:"sellValue"` // Unweighted
+	WeightedBuys   float64 `json:"weightedBuys"`
+	WeightedSells  float64 `json:"weightedSells"`
+	Score          float64 `json:"score"`
+}
+
+// ScoreInsiderSentiment scores each issuer's insider buying against selling
+// over a set of Form 4s, e.g. a year of batch results (see IssuerSentiment
+// and SentimentWeights). Issuers are returned by score, most bullish first.
+// Filings repeated under the same accession number are counted once.
+func ScoreInsiderSentiment(forms []*Form4Output, weights SentimentWeights) []IssuerSentiment {
+	issuers := make(map[string]*IssuerSentiment)
+	var order []string
+	seen := make(map[string]bool)
+
+	for _, f := range forms {
+		if acc := f.Metadata.AccessionNumber; acc != "" {
+			if seen[acc] {
+				continue
+			}
+			seen[acc] = true
+		}
+		key := f.Issuer.CIK
+		if key == "" {
+			key = f.Issuer.Name
+		}
+		s, ok := issuers[key]
+		if !ok {
+			s = &IssuerSentiment{IssuerCIK: f.Issuer.CIK, IssuerName: f.Issuer.Name, Ticker: f.Issuer.Ticker}
+			issuers[key] = s
+			order = append(order, key)
+		}
+		s.Filings++
+
+		// Joint filers report the same trades, so the filing counts once, at
+		// its most senior owner's weight
+		var ownerWeight float64
+		for _, owner := range f.ReportingOwners {
+			ownerWeight = max(ownerWeight, weights.owner(owner))
+		}
+
+		for _, txn := range f.Transactions {
+			if txn.TransactionCode != "P" && txn.TransactionCode != "S" {
+				continue
+			}
+			v := txn.Value()
+			if v == nil {
+				continue
+			}
+			w := ownerWeight
+			if txn.Is10b51Plan {
+				w *= weights.Plan10b51
+			}
+			if date := txn.TransactionDate; date != "" {
+				if s.FirstTradeDate == "" || date < s.FirstTradeDate {
+					s.FirstTradeDate = date
+				}
+				if date > s.LastTradeDate {
+					s.LastTradeDate = date
+				}
+			}
+			if txn.TransactionCode == "P" {
+				s.Buys++
+				s.BuyValue += *v
+				s.WeightedBuys += w * *v
+			} else {
+				s.Sells++
+				s.SellValue += *v
+				s.WeightedSells += w * *v
+			}
+		}
+	}
+
+	scores := make([]IssuerSentiment, 0, len(order))
+	for _, key := range order {
+		s := issuers[key]
+		if total := s.WeightedBuys + s.WeightedSells; total > 0 {
+			s.Score = (s.WeightedBuys - s.WeightedSells) / total
+		}
+		scores = append(scores, *s)
+	}
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+	return scores
+}
+
+// owner returns a reporting owner's weight: the largest of their roles'
+func (w SentimentWeights) owner(owner ReportingOwnerOutput) float64 {
+	rel := owner.Relationship
+	var weight float64
+	if rel.IsOfficer {
+		officer := w.Officer
+		roles := owner.OfficerRoles
+		if roles == nil {
+			roles = NormalizeOfficerTitle(rel.OfficerTitle)
+		}
+		for _, role := range roles {
+			if rw, ok := w.Roles[role]; ok {
+				officer = max(officer, rw)
+			}
+		}
+		weight = officer
+	}
+	if rel.IsDirector {
+		weight = max(weight, w.Director)
+	}
+	if rel.IsTenPercentOwner {
+		weight = max(weight, w.TenPercentOwner)
+	}
+	if rel.IsOther || !rel.IsOfficer && !rel.IsDirector && !rel.IsTenPercentOwner {
+		weight = max(weight, w.Other)
+	}
+	return weight
+}
@@ -0,0 +1,64 @@
+package edgar
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreInsiderSentiment(t *testing.T) {
+	trade := func(code, ad string, shares, price float64, plan bool) NonDerivativeTransactionOut {
+		return NonDerivativeTransactionOut{TransactionDate: "2025-05-01", TransactionCode: code, AcquiredDisposed: ad,
+			Shares: ptrFloat(shares), PricePerShare: ptrFloat(price), Is10b51Plan: plan}
+	}
+	ceo := ReportingOwnerOutput{CIK: "1", Name: "CEO", Relationship: RelationshipOut{IsOfficer: true, IsDirector: true, OfficerTitle: "President & CEO"}}
+	director := ReportingOwnerOutput{CIK: "2", Name: "Director", Relationship: RelationshipOut{IsDirector: true}}
+	fund := ReportingOwnerOutput{CIK: "3", Name: "Fund", Relationship: RelationshipOut{IsTenPercentOwner: true}}
+	acme := IssuerOutput{CIK: "10", Name: "Acme", Ticker: "ACME"}
+	beta := IssuerOutput{CIK: "20", Name: "Beta", Ticker: "BETA"}
+
+	forms := []*Form4Output{
+		// Acme: the CEO buys $10,000 (weight 2), a director sells $10,000 under a plan (weight 0.25)
+		{Metadata: FormMetadata{AccessionNumber: "a1"}, Issuer: acme, ReportingOwners: []ReportingOwnerOutput{ceo},
+			Transactions: []NonDerivativeTransactionOut{trade("P", "A", 1000, 10, false), trade("A", "A", 5000, 0, false)}},
+		{Metadata: FormMetadata{AccessionNumber: "a2"}, Issuer: acme, ReportingOwners: []ReportingOwnerOutput{director},
+			Transactions: []NonDerivativeTransactionOut{trade("S", "D", 500, 20, true), trade("F", "D", 100, 20, false)}},
+		// Beta: a fund sells $40,000 (weight 0.5) alongside a joint-filing director (weight 1)
+		{Metadata: FormMetadata{AccessionNumber: "b1"}, Issuer: beta, ReportingOwners: []ReportingOwnerOutput{fund, director},
+			Transactions: []NonDerivativeTransactionOut{trade("S", "D", 2000, 20, false)}},
+		// Repeated filing, counted once
+		{Metadata: FormMetadata{AccessionNumber: "b1"}, Issuer: beta, ReportingOwners: []ReportingOwnerOutput{fund, director},
+			Transactions: []NonDerivativeTransactionOut{trade("S", "D", 2000, 20, false)}},
+	}
+
+	scores := ScoreInsiderSentiment(forms, DefaultSentimentWeights)
+	if len(scores) != 2 || scores[0].Ticker != "ACME" || scores[1].Ticker != "BETA" {
+		t.Fatalf("Scores = %+v", scores)
+	}
+
+	acmeScore := scores[0]
+	if acmeScore.Filings != 2 || acmeScore.Buys != 1 || acmeScore.Sells != 1 || acmeScore.BuyValue != 10000 || acmeScore.SellValue != 10000 {
+		t.Errorf("Acme = %+v (grants and tax withholding aren't scored)", acmeScore)
+	}
+	if acmeScore.WeightedBuys != 20000 || acmeScore.WeightedSells != 2500 {
+		t.Errorf("Acme weighted buys/sells = %v/%v, want 20000/2500", acmeScore.WeightedBuys, acmeScore.WeightedSells)
+	}
+	if want := 17500.0 / 22500; math.Abs(acmeScore.Score-want) > 1e-9 {
+		t.Errorf("Acme score = %v, want %v", acmeScore.Score, want)
+	}
+
+	betaScore := scores[1]
+	if betaScore.Filings != 1 || betaScore.WeightedSells != 40000 || betaScore.Score != -1 {
+		t.Errorf("Beta = %+v, want one filing at the director's weight, score -1", betaScore)
+	}
+
+	// Custom weights: ignore plan trades entirely
+	weights := DefaultSentimentWeights
+	weights.Plan10b51 = 0
+	if s := ScoreInsiderSentiment(forms[:2], weights); s[0].Score != 1 {
+		t.Errorf("Score without plan trades = %v, want 1", s[0].Score)
+	}
+
+	if s := ScoreInsiderSentiment(nil, DefaultSentimentWeights); len(s) != 0 {
+		t.Errorf("Scores of no filings = %+v", s)
+	}
+}
@@ -358,3 +358,131 @@ func TestValueNumericConversions(t *testing.T) {
 		})
 	}
 }
+
+// TestBoolEncodings verifies every observed encoding of ownership boolean flags
+func TestBoolEncodings(t *testing.T) {
+	tests := []struct {
+		encoded string
+		want    bool
+	}{
+		{"1", true},
+		{"0", false},
+		{"true", true},
+		{"false", false},
+		{"TRUE", true},
+		{"Y", true},
+		{"N", false},
+		{"", false},
+		{" 1 ", true},
+		{"<value>1</value>", true},
+		{"<value>false</value>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoded, func(t *testing.T) {
+			xmlData := []byte(`
+				<ownershipDocument>
+					<aff10b5One>` + tt.encoded + `</aff10b5One>
+					<reportingOwner>
+						<reportingOwnerRelationship>
+							<isDirector>` + tt.encoded + `</isDirector>
+							<isOfficer>` + tt.encoded + `</isOfficer>
+							<isTenPercentOwner>` + tt.encoded + `</isTenPercentOwner>
+							<isOther>` + tt.encoded + `</isOther>
+						</reportingOwnerRelationship>
+					</reportingOwner>
+					<nonDerivativeTable>
+						<nonDerivativeTransaction>
+							<transactionCoding>
+								<transactionCode>S</transactionCode>
+								<equitySwapInvolved>` + tt.encoded + `</equitySwapInvolved>
+							</transactionCoding>
+						</nonDerivativeTransaction>
+					</nonDerivativeTable>
+				</ownershipDocument>
+			`)
+
+			f4, err := edgar.Parse(xmlData)
+			require.NoError(t, err)
+
+			rel := f4.ReportingOwners[0].Relationship
+			assert.Equal(t, tt.want, bool(f4.Aff10b5One), "aff10b5One")
+			assert.Equal(t, tt.want, bool(rel.IsDirector), "isDirector")
+			assert.Equal(t, tt.want, bool(rel.IsOfficer), "isOfficer")
+			assert.Equal(t, tt.want, bool(rel.IsTenPercentOwner), "isTenPercentOwner")
+			assert.Equal(t, tt.want, bool(rel.IsOther), "isOther")
+			assert.Equal(t, tt.want, bool(f4.NonDerivativeTable.Transactions[0].Coding.EquitySwapInvolved), "equitySwapInvolved")
+		})
+	}
+
+	// Unrecognized values are reported instead of silently read as false
+	_, err := edgar.Parse([]byte(`<ownershipDocument><aff10b5One>maybe</aff10b5One></ownershipDocument>`))
+	assert.Error(t, err)
+}
+
+// TestLegacySchemaVersion verifies value-based amounts and placeholder tickers from older filings
+func TestLegacySchemaVersion(t *testing.T) {
+	xmlData := []byte(`<?xml version="1.0"?>
+		<ownershipDocument>
+			<schemaVersion>X0201</schemaVersion>
+			<documentType>4</documentType>
+			<periodOfReport>2004-06-01</periodOfReport>
+			<notSubjectToSection16>true</notSubjectToSection16>
+			<issuer>
+				<issuerCik>0000123456</issuerCik>
+				<issuerName>Old Partners LP</issuerName>
+				<issuerTradingSymbol>NONE</issuerTradingSymbol>
+			</issuer>
+			<reportingOwner>
+				<reportingOwnerId>
+					<rptOwnerCik>0000654321</rptOwnerCik>
+					<rptOwnerName>Doe John</rptOwnerName>
+				</reportingOwnerId>
+				<reportingOwnerRelationship>
+					<isDirector>true</isDirector>
+					<isOfficer>false</isOfficer>
+				</reportingOwnerRelationship>
+			</reportingOwner>
+			<nonDerivativeTable>
+				<nonDerivativeHolding>
+					<securityTitle><value>Limited Partnership Interests</value></securityTitle>
+					<postTransactionAmounts>
+						<valueOwnedFollowingTransaction><value>250000</value></valueOwnedFollowingTransaction>
+					</postTransactionAmounts>
+					<ownershipNature><directOrIndirectOwnership><value>D</value></directOrIndirectOwnership></ownershipNature>
+				</nonDerivativeHolding>
+			</nonDerivativeTable>
+			<derivativeTable>
+				<derivativeTransaction>
+					<securityTitle><value>Convertible Note</value></securityTitle>
+					<transactionDate><value>2004-06-01</value></transactionDate>
+					<transactionCoding>
+						<transactionCode>P</transactionCode>
+						<equitySwapInvolved>false</equitySwapInvolved>
+					</transactionCoding>
+					<underlyingSecurity>
+						<underlyingSecurityTitle><value>Common Units</value></underlyingSecurityTitle>
+						<underlyingSecurityValue><value>100000</value></underlyingSecurityValue>
+					</underlyingSecurity>
+				</derivativeTransaction>
+			</derivativeTable>
+		</ownershipDocument>
+	`)
+
+	f4, err := edgar.Parse(xmlData)
+	require.NoError(t, err)
+
+	out := f4.ToOutput()
+	assert.Equal(t, "", out.Issuer.Ticker, "placeholder ticker should be cleared")
+	assert.True(t, out.NotSubjectToSection16)
+	assert.True(t, out.ReportingOwners[0].Relationship.IsDirector)
+
+	require.Len(t, out.Holdings, 1)
+	assert.Nil(t, out.Holdings[0].SharesOwnedFollowing)
+	require.NotNil(t, out.Holdings[0].ValueOwnedFollowing)
+	assert.Equal(t, 250000.0, *out.Holdings[0].ValueOwnedFollowing)
+
+	require.Len(t, out.Derivatives, 1)
+	require.NotNil(t, out.Derivatives[0].UnderlyingValue)
+	assert.Equal(t, 100000.0, *out.Derivatives[0].UnderlyingValue)
+}
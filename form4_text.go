@@ -0,0 +1,323 @@
+package edgar
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Legacy plain-text Form 4 support
+//
+// Before ownership filings moved to XML (mid-2003), electronic Form 4s were
+// ASCII documents: an SGML submission header followed by the form typed out as
+// fixed-width text, with tables drawn using "|" column separators. Layouts
+// vary by filing agent, so parsing is best-effort:
+//   - issuer and reporting owners come from the submission header
+//     (ISSUER: / REPORTING-OWNER: sections), which is machine-generated
+//   - relationship checkboxes are read from "(X) Director"-style marks
+//   - Table I transactions and holdings are read from "|"-separated lines;
+//     wrapped cells (e.g. a date split over two lines) are joined back together
+//
+// Table II (derivatives) is not parsed.
+
+// ParseForm4Text extracts issuer, owners and Table I rows from a legacy text Form 4
+func ParseForm4Text(data []byte) (*Form4Output, error) {
+	text := string(NormalizeText(data))
+
+	out := &Form4Output{
+		Metadata:     FormMetadata{FormType: "4"},
+		Transactions: []NonDerivativeTransactionOut{},
+		Derivatives:  []DerivativeTransactionOut{},
+	}
+	parseTextHeader(text, out)
+	if out.Issuer.Name == "" && len(out.ReportingOwners) == 0 {
+		return nil, fmt.Errorf("no issuer or reporting owner found in text filing")
+	}
+
+	rel := parseTextRelationship(text)
+	for i := range out.ReportingOwners {
+		out.ReportingOwners[i].Relationship = rel
+		out.ReportingOwners[i].OfficerRoles = NormalizeOfficerTitle(rel.OfficerTitle)
+	}
+
+	txns, holdings := parseTextTableI(text)
+	out.Transactions = append(out.Transactions, txns...)
+	out.Holdings = holdings
+	return out, nil
+}
+
+// isForm4Text reports whether data looks like a legacy (non-XML) Form 4
+func isForm4Text(data []byte) bool {
+	if bytes.Contains(data, []byte("<ownershipDocument")) {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := splitHeaderLine(line)
+		if ok && key == "CONFORMED SUBMISSION TYPE" {
+			return value == "4" || value == "4/A"
+		}
+	}
+	return false
+}
+
+var textHeaderField = regexp.MustCompile(`^\s*([A-Z][A-Z0-9 -]+):\s*(.*)$`)
+
+// splitHeaderLine splits an SGML header line such as "CENTRAL INDEX KEY:  0000123456"
+func splitHeaderLine(line string) (key, value string, ok bool) {
+	m := textHeaderField.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if m == nil {
+		return "", "", false
+	}
+	return strings.TrimSpace(m[1]), strings.TrimSpace(m[2]), true
+}
+
+// parseTextHeader reads filing metadata, the issuer and reporting owners from the SGML header
+func parseTextHeader(text string, out *Form4Output) {
+	section := ""
+	var owner *ReportingOwnerOutput
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "<DOCUMENT>") {
+			break // End of the header
+		}
+		key, value, ok := splitHeaderLine(line)
+		if !ok {
+			continue
+		}
+
+		// Unindented keys without a value open a section (ISSUER:, REPORTING-OWNER:)
+		if value == "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			section = key
+			if section == "REPORTING-OWNER" {
+				out.ReportingOwners = append(out.ReportingOwners, ReportingOwnerOutput{})
+				owner = &out.ReportingOwners[len(out.ReportingOwners)-1]
+			}
+			continue
+		}
+
+		switch key {
+		case "ACCESSION NUMBER":
+			out.Metadata.AccessionNumber = value
+		case "CONFORMED SUBMISSION TYPE":
+			out.Metadata.FormType = value
+		case "CONFORMED PERIOD OF REPORT":
+			out.Metadata.PeriodOfReport = headerDate(value)
+		case "FILED AS OF DATE":
+			out.Metadata.FilingDate = headerDate(value)
+		case "COMPANY CONFORMED NAME":
+			switch section {
+			case "ISSUER", "SUBJECT COMPANY":
+				out.Issuer.Name = value
+			case "REPORTING-OWNER":
+				owner.Name = value
+			}
+		case "CENTRAL INDEX KEY":
+			switch section {
+			case "ISSUER", "SUBJECT COMPANY":
+				out.Issuer.CIK = value
+				out.Metadata.CIK = value
+			case "REPORTING-OWNER":
+				owner.CIK = value
+			}
+		case "CITY", "STATE", "ZIP", "STREET 1", "STREET 2":
+			if section == "REPORTING-OWNER" {
+				switch key {
+				case "CITY":
+					owner.Address.City = value
+				case "STATE":
+					owner.Address.State = value
+				case "ZIP":
+					owner.Address.ZipCode = value
+				case "STREET 1":
+					owner.Address.Street1 = value
+				case "STREET 2":
+					owner.Address.Street2 = value
+				}
+			}
+		}
+	}
+}
+
+// headerDate converts an SGML header date (YYYYMMDD) to YYYY-MM-DD
+func headerDate(s string) string {
+	if len(s) == 8 {
+		return s[:4] + "-" + s[4:6] + "-" + s[6:]
+	}
+	return s
+}
+
+var (
+	textCheckedDirector  = regexp.MustCompile(`(?i)[(\[]\s*x\s*[)\]]\s*Director`)
+	textCheckedOfficer   = regexp.MustCompile(`(?i)[(\[]\s*x\s*[)\]]\s*Officer`)
+	textCheckedTenPct    = regexp.MustCompile(`(?i)[(\[]\s*x\s*[)\]]\s*10\s*%\s*Owner`)
+	textCheckedOther     = regexp.MustCompile(`(?i)[(\[]\s*x\s*[)\]]\s*Other`)
+	textOfficerTitleLine = regexp.MustCompile(`(?i)Officer\s*\(give\s+title\s+below\)[^\n]*\n\s*([^\n|]+)`)
+)
+
+// parseTextRelationship reads the "Relationship of Reporting Person to Issuer" checkboxes
+func parseTextRelationship(text string) RelationshipOut {
+	rel := RelationshipOut{
+		IsDirector:        textCheckedDirector.MatchString(text),
+		IsOfficer:         textCheckedOfficer.MatchString(text),
+		IsTenPercentOwner: textCheckedTenPct.MatchString(text),
+		IsOther:           textCheckedOther.MatchString(text),
+	}
+	if rel.IsOfficer {
+		if m := textOfficerTitleLine.FindStringSubmatch(text); m != nil {
+			title := strings.TrimSpace(m[1])
+			if !strings.Contains(strings.ToLower(title), "specify below") {
+				rel.OfficerTitle = title
+			}
+		}
+	}
+	return rel
+}
+
+var (
+	textTableIStart  = regexp.MustCompile(`(?i)Table\s+I\b`)
+	textTableIIStart = regexp.MustCompile(`(?i)Table\s+II\b`)
+	textRuleLine     = regexp.MustCompile(`^[\s|+=-]*-{3,}[\s|+=-]*$`)
+	textDate         = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{2}|\d{4})$`)
+	textFootnoteRef  = regexp.MustCompile(`\(\d+\)`)
+)
+
+// parseTextTableI reads Table I (non-derivative) transaction and holding rows
+//
+// Expected columns: title | date | code | V | amount | (A) or (D) | price |
+// owned at end of month | D or I | nature of indirect ownership. Filings that
+// omit the V column are also accepted. Rows with an owned amount but no
+// transaction date or code are holdings.
+func parseTextTableI(text string) ([]NonDerivativeTransactionOut, []NonDerivativeHoldingOut) {
+	start := textTableIStart.FindStringIndex(text)
+	if start == nil {
+		return nil, nil
+	}
+	table := text[start[1]:]
+	if end := textTableIIStart.FindStringIndex(table); end != nil {
+		table = table[:end[0]]
+	}
+
+	// Data starts after the rule line that closes the column headings
+	var rows [][]string
+	inData := false
+	for _, line := range strings.Split(table, "\n") {
+		if textRuleLine.MatchString(line) {
+			inData = true
+			continue
+		}
+		if !inData || !strings.Contains(line, "|") {
+			continue
+		}
+
+		cells := strings.Split(line, "|")
+		for i := range cells {
+			cells[i] = strings.TrimSpace(cells[i])
+		}
+		if len(cells) < 9 {
+			continue
+		}
+
+		// A line without a title, or with only a title, continues the previous row
+		if len(rows) > 0 && (cells[0] == "" || strings.Join(cells[1:], "") == "") {
+			prev := rows[len(rows)-1]
+			for i := 0; i < len(cells) && i < len(prev); i++ {
+				prev[i] = joinWrappedCell(prev[i], cells[i])
+			}
+			continue
+		}
+		rows = append(rows, cells)
+	}
+
+	var txns []NonDerivativeTransactionOut
+	var holdings []NonDerivativeHoldingOut
+	for _, cells := range rows {
+		// With the V column: 10+ cells; without it: 9
+		col := func(withV, withoutV int) string {
+			i := withoutV
+			if len(cells) >= 10 {
+				i = withV
+			}
+			if i < len(cells) {
+				return cells[i]
+			}
+			return ""
+		}
+		title := strings.TrimSpace(textFootnoteRef.ReplaceAllString(cells[0], ""))
+		owned := textNumber(col(7, 6))
+		directIndirect := strings.Trim(strings.ToUpper(col(8, 7)), "() ")
+
+		date, ok := textTransactionDate(cells[1])
+		code := strings.ToUpper(strings.TrimSpace(textFootnoteRef.ReplaceAllString(cells[2], "")))
+		if !ok || len(code) != 1 {
+			if cells[1] == "" && cells[2] == "" && owned != nil {
+				holdings = append(holdings, NonDerivativeHoldingOut{
+					SecurityTitle:        title,
+					SharesOwnedFollowing: owned,
+					DirectIndirect:       directIndirect,
+					NatureOfOwnership:    col(9, 8),
+					Footnotes:            []string{},
+				})
+			}
+			continue // Otherwise a heading or note line
+		}
+
+		txns = append(txns, NonDerivativeTransactionOut{
+			SecurityTitle:        title,
+			TransactionDate:      date,
+			TransactionCode:      code,
+			Shares:               textNumber(col(4, 3)),
+			AcquiredDisposed:     strings.Trim(strings.ToUpper(col(5, 4)), "() "),
+			PricePerShare:        textNumber(col(6, 5)),
+			SharesOwnedFollowing: owned,
+			DirectIndirect:       directIndirect,
+			NatureOfOwnership:    col(9, 8),
+			Footnotes:            []string{},
+		})
+	}
+	return txns, holdings
+}
+
+// joinWrappedCell rejoins a cell split across lines ("05/15/" + "02", "Common" + "Stock")
+func joinWrappedCell(prev, next string) string {
+	switch {
+	case next == "":
+		return prev
+	case prev == "", strings.HasSuffix(prev, "/"), strings.HasSuffix(prev, "-"):
+		return prev + next
+	default:
+		return prev + " " + next
+	}
+}
+
+// textTransactionDate converts MM/DD/YY or MM/DD/YYYY to YYYY-MM-DD
+func textTransactionDate(s string) (string, bool) {
+	m := textDate.FindStringSubmatch(strings.TrimSpace(textFootnoteRef.ReplaceAllString(s, "")))
+	if m == nil {
+		return "", false
+	}
+	year := m[3]
+	if len(year) == 2 {
+		// Text filings predate 2004; two-digit years before 50 are 20xx
+		if year < "50" {
+			year = "20" + year
+		} else {
+			year = "19" + year
+		}
+	}
+	return fmt.Sprintf("%s-%02s-%02s", year, m[1], m[2]), true
+}
+
+// textNumber parses an amount such as "$45.25", "2,000" or "10,500(1)", returning nil if empty
+func textNumber(s string) *float64 {
+	s = textFootnoteRef.ReplaceAllString(s, "")
+	s = strings.NewReplacer("$", "", ",", "", " ", "").Replace(s)
+	if s == "" {
+		return nil
+	}
+	return toFloat64Ptr(Value{Value: s})
+}
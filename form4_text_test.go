@@ -0,0 +1,130 @@
+package edgar
+
+import (
+	"strings"
+	"testing"
+)
+
+const legacyForm4Text = `<SEC-DOCUMENT>0000950123-02-005555.txt : 20020517
+<SEC-HEADER>0000950123-02-005555.hdr.sgml : 20020517
+ACCESSION NUMBER:		0000950123-02-005555
+CONFORMED SUBMISSION TYPE:	4
+PUBLIC DOCUMENT COUNT:		1
+CONFORMED PERIOD OF REPORT:	20020430
+FILED AS OF DATE:		20020517
+
+ISSUER:
+
+	COMPANY DATA:	
+		COMPANY CONFORMED NAME:			ACME CORP
+		CENTRAL INDEX KEY:			0000123456
+
+REPORTING-OWNER:	
+
+	OWNER DATA:	
+		COMPANY CONFORMED NAME:			DOE JOHN
+		CENTRAL INDEX KEY:			0000654321
+
+	BUSINESS ADDRESS:	
+		STREET 1:		1 MAIN ST
+		CITY:			SPRINGFIELD
+		STATE:			IL
+		ZIP:			62701
+</SEC-HEADER>
+<DOCUMENT>
+<TYPE>4
+<TEXT>
+                                    FORM 4
+6. Relationship of Reporting Person to Issuer
+   (X) Director              ( ) 10% Owner
+   (X) Officer (give title below)   ( ) Other (specify below)
+       President & CEO
+
+TABLE I -- NON-DERIVATIVE SECURITIES ACQUIRED, DISPOSED OF, OR BENEFICIALLY OWNED
+----------------------------------------------------------------------------------------------
+1.Title of Security |2.Trans- |3.Trans|  |4.Securities Acquired (A) |      |        |5.Amount  |6.|7.Nature
+                    |  action |  Code |V |  or Disposed of (D)      |(A) or|        |Owned at  |D |Indirect
+                    |  Date   |       |  |  Amount                  | (D)  | Price  |End of Mo |I |Ownership
+----------------------------------------------------------------------------------------------
+Common Stock        |04/15/   |S      |  |10,000                    |D     |$45.25  |          |D |
+                    |02       |       |  |                          |      |        |          |  |
+Common Stock        |04/22/2002|M(1)  |  |2,500                     |A     |$12.00  |112,500(2)|D |
+Common Stock        |         |       |  |                          |      |        |5,000     |I |By Trust
+----------------------------------------------------------------------------------------------
+TABLE II -- DERIVATIVE SECURITIES ACQUIRED, DISPOSED OF, OR BENEFICIALLY OWNED
+</TEXT>
+</DOCUMENT>
+</SEC-DOCUMENT>
+`
+
+func TestParseForm4Text(t *testing.T) {
+	if !isForm4Text([]byte(legacyForm4Text)) {
+		t.Fatal("Expected legacy text Form 4 to be detected")
+	}
+
+	parsed, err := ParseAny(strings.NewReader(legacyForm4Text))
+	if err != nil {
+		t.Fatalf("ParseAny failed: %v", err)
+	}
+	out, ok := parsed.Data.(*Form4Output)
+	if !ok || parsed.FormType != "4" {
+		t.Fatalf("Expected Form 4 output, got %s %T", parsed.FormType, parsed.Data)
+	}
+
+	if out.Metadata.AccessionNumber != "0000950123-02-005555" || out.Metadata.PeriodOfReport != "2002-04-30" || out.Metadata.FilingDate != "2002-05-17" {
+		t.Errorf("Unexpected metadata: %+v", out.Metadata)
+	}
+	if out.Issuer.CIK != "0000123456" || out.Issuer.Name != "ACME CORP" {
+		t.Errorf("Unexpected issuer: %+v", out.Issuer)
+	}
+	if len(out.ReportingOwners) != 1 {
+		t.Fatalf("Expected 1 reporting owner, got %d", len(out.ReportingOwners))
+	}
+	owner := out.ReportingOwners[0]
+	if owner.Name != "DOE JOHN" || owner.CIK != "0000654321" || owner.Address.City != "SPRINGFIELD" {
+		t.Errorf("Unexpected owner: %+v", owner)
+	}
+	rel := owner.Relationship
+	if !rel.IsDirector || !rel.IsOfficer || rel.IsTenPercentOwner || rel.IsOther || rel.OfficerTitle != "President & CEO" {
+		t.Errorf("Unexpected relationship: %+v", rel)
+	}
+
+	if len(out.Transactions) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d: %+v", len(out.Transactions), out.Transactions)
+	}
+	sale := out.Transactions[0]
+	if sale.TransactionDate != "2002-04-15" || sale.TransactionCode != "S" || sale.AcquiredDisposed != "D" ||
+		sale.Shares == nil || *sale.Shares != 10000 || sale.PricePerShare == nil || *sale.PricePerShare != 45.25 {
+		t.Errorf("Unexpected sale: %+v", sale)
+	}
+	exercise := out.Transactions[1]
+	if exercise.TransactionDate != "2002-04-22" || exercise.TransactionCode != "M" || exercise.AcquiredDisposed != "A" ||
+		exercise.SharesOwnedFollowing == nil || *exercise.SharesOwnedFollowing != 112500 {
+		t.Errorf("Unexpected exercise: %+v", exercise)
+	}
+
+	// The line with an owned amount but no transaction is a holding
+	if len(out.Holdings) != 1 {
+		t.Fatalf("Expected 1 holding, got %d: %+v", len(out.Holdings), out.Holdings)
+	}
+	if h := out.Holdings[0]; h.SharesOwnedFollowing == nil || *h.SharesOwnedFollowing != 5000 || h.DirectIndirect != "I" || h.NatureOfOwnership != "By Trust" {
+		t.Errorf("Unexpected holding: %+v", h)
+	}
+}
+
+func TestTextTransactionDate(t *testing.T) {
+	tests := map[string]string{
+		"04/15/02":    "2002-04-15",
+		"4/5/1999":    "1999-04-05",
+		"12/31/98":    "1998-12-31",
+		"04/15/02(1)": "2002-04-15",
+	}
+	for in, want := range tests {
+		if got, ok := textTransactionDate(in); !ok || got != want {
+			t.Errorf("textTransactionDate(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := textTransactionDate("Date"); ok {
+		t.Error("Expected heading text to be rejected")
+	}
+}
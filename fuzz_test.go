@@ -0,0 +1,129 @@
+package edgar
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The fuzz targets run their seeds as ordinary tests; to fuzz one, e.g.
+//
+//	go test -run '^$' -fuzz FuzzParseSchedule13Auto -fuzztime 1m
+//
+// Crashers go to testdata/fuzz/<target> and are replayed by go test.
+
+// addFuzzSeeds adds the files matching a testdata pattern to the corpus. Seeds
+// are kept to a few KB: the fuzzer slows to a crawl on large inputs.
+func addFuzzSeeds(f *testing.F, pattern string) {
+	f.Helper()
+	paths, err := filepath.Glob(pattern)
+	if err != nil || len(paths) == 0 {
+		f.Fatalf("no seeds match %s", pattern)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	addFuzzSeeds(f, "testdata/form4/[ab]*/input.xml")
+	f.Add([]byte(legacyForm4Text))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		form, err := Parse(data)
+		if err != nil {
+			return
+		}
+		form.ToOutput()
+		form.Parse10b51Footnotes()
+	})
+}
+
+func FuzzParseSchedule13Auto(f *testing.F) {
+	addFuzzSeeds(f, "testdata/schedule13/*_xml/input.xml")
+	f.Add([]byte(`<html><body><p>CUSIP No. 12345X109</p><p>(1) Names of Reporting Persons: Fund &amp; Co.</p>` +
+		`<p>(11) Aggregate Amount: 1,234,567</p><p>(13) Percent of Class: (5.2%)</p>` +
+		`<p>Item 4. Purpose of Transaction</p><p>&#9999999999; &#0; &#x110000;</p></body></html>`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s, err := ParseSchedule13Auto(data)
+		if err != nil {
+			return
+		}
+		s.ToOutput()
+		s.CalculateTotalShares()
+		s.CalculateTotalPercent()
+	})
+}
+
+func FuzzParseInlineXBRL(f *testing.F) {
+	f.Add([]byte(ifrsDoc))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		x, err := ParseInlineXBRL(data)
+		if err != nil {
+			return
+		}
+		x.GetSnapshots()
+		x.GetStatements()
+		x.GetTextBlocks()
+		x.ValidateCalculations()
+		x.ExportFacts(&bytes.Buffer{}, "csv")
+	})
+}
+
+func FuzzParseSubmissions(f *testing.F) {
+	f.Add([]byte(`{"cik":"78003","filings":{"recent":{"accessionNumber":["0000078003-25-000001","x"],"form":["4"]}}}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		subs, err := ParseSubmissions(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		subs.Filings.Recent.GetFilings(subs.CIK)
+	})
+}
+
+func FuzzNormalizeText(f *testing.F) {
+	for _, s := range []string{
+		"Fund&nbsp;&amp;&nbsp;Co.", "&amp;nbsp;", "&#160;&#x2014;&#8217;", "&#0;&#55296;&#1114112;&#99999999999999999999;",
+		"&#x;&#;&;&", "a  b​\r\nc",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		NormalizeText([]byte(s))
+	})
+}
+
+func TestNormalizeHTMLEntities(t *testing.T) {
+	tests := map[string]string{
+		"Fund&nbsp;&amp;&nbsp;Co.": "Fund & Co.",
+		"&amp;nbsp;":               "&nbsp;", // Decoded once
+		"&amp;amp;":                "&amp;",
+		"&#8212;&#x2014;&#X2014;":  "———",
+		"&#8220;Yes&#8221;":        "“Yes”",
+		"&#0; &#xD800; &#1114112;": "&#0; &#xD800; &#1114112;", // Not characters
+		"&#99999999999999999999;":  "&#99999999999999999999;",
+		"&#; &#x; &unknown; & ;":   "&#; &#x; &unknown; & ;",
+	}
+	for in, want := range tests {
+		if got := normalizeHTMLEntities(in); got != want {
+			t.Errorf("normalizeHTMLEntities(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGetFilings_ShortArrays(t *testing.T) {
+	subs, err := ParseSubmissions(strings.NewReader(`{"cik":"78003","filings":{"recent":{
+		"accessionNumber":["0000078003-25-000001","0000078003-25-000002"],"form":["4"],"filingDate":[]}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	filings := subs.Filings.Recent.GetFilings(subs.CIK)
+	if len(filings) != 2 || filings[0].Form != "4" || filings[1].Form != "" || filings[1].FilingDate != "" {
+		t.Errorf("GetFilings = %+v", filings)
+	}
+}
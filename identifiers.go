@@ -0,0 +1,85 @@
+package edgar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizeCIK returns a CIK without leading zeros ("0000320193" -> "320193"),
+// the form used in Archives URLs. A CIK is 1 to 10 digits and not all zeros.
+func NormalizeCIK(cik string) (string, error) {
+	s := strings.TrimSpace(cik)
+	if s == "" || len(s) > 10 || !allDigits(s) {
+		return "", fmt.Errorf("invalid CIK %q: want 1 to 10 digits", cik)
+	}
+	s = strings.TrimLeft(s, "0")
+	if s == "" {
+		return "", fmt.Errorf("invalid CIK %q: all zeros", cik)
+	}
+	return s, nil
+}
+
+// PadCIK returns a CIK zero-padded to 10 digits ("320193" -> "0000320193"),
+// the form used by data.sec.gov
+func PadCIK(cik string) (string, error) {
+	s, err := NormalizeCIK(cik)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%010s", s), nil
+}
+
+// NormalizeAccession returns an accession number in its dashed form
+// ("0001193125-25-314736"), accepting it with or without dashes. An accession
+// number is 18 digits: the filer agent's CIK (10), the year (2) and a
+// sequence number (6).
+func NormalizeAccession(accession string) (string, error) {
+	s, err := CompactAccession(accession)
+	if err != nil {
+		return "", err
+	}
+	return s[:10] + "-" + s[10:12] + "-" + s[12:], nil
+}
+
+// CompactAccession returns an accession number without dashes
+// ("000119312525314736"), the form used in Archives URLs
+func CompactAccession(accession string) (string, error) {
+	s := strings.TrimSpace(accession)
+	if len(s) == 20 && s[10] == '-' && s[13] == '-' {
+		s = s[:10] + s[11:13] + s[14:]
+	}
+	if len(s) != 18 || !allDigits(s) {
+		return "", fmt.Errorf("invalid accession number %q: want 18 digits, as 0001193125-25-314736 or 000119312525314736", accession)
+	}
+	return s, nil
+}
+
+// archiveCIK returns a CIK without leading zeros (see NormalizeCIK) for URLs,
+// store paths and matching. A value that isn't a valid CIK is returned as
+// given (trimmed), so a request built from it fails instead of silently
+// reaching another filer's documents.
+func archiveCIK(cik string) string {
+	if s, err := NormalizeCIK(cik); err == nil {
+		return s
+	}
+	return strings.TrimSpace(cik)
+}
+
+// archiveAccession returns an accession number without dashes (see
+// CompactAccession), or as given (trimmed) if it isn't valid
+func archiveAccession(accession string) string {
+	if s, err := CompactAccession(accession); err == nil {
+		return s
+	}
+	return strings.TrimSpace(accession)
+}
+
+// allDigits reports whether s is all ASCII digits
+func allDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package edgar
+
+import "testing"
+
+func TestNormalizeCIK(t *testing.T) {
+	tests := []struct {
+		in, want, padded string
+		ok               bool
+	}{
+		{"320193", "320193", "0000320193", true},
+		{"0000320193", "320193", "0000320193", true},
+		{" 78003 ", "78003", "0000078003", true},
+		{"", "", "", false},
+		{"0000000000", "", "", false},
+		{"12345678901", "", "", false},
+		{"AAPL", "", "", false},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeCIK(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("NormalizeCIK(%q) = %q, %v", tt.in, got, err)
+		}
+		padded, err := PadCIK(tt.in)
+		if (err == nil) != tt.ok || padded != tt.padded {
+			t.Errorf("PadCIK(%q) = %q, %v", tt.in, padded, err)
+		}
+	}
+}
+
+func TestNormalizeAccession(t *testing.T) {
+	tests := []struct {
+		in, want, compact string
+		ok                bool
+	}{
+		{"0001193125-25-314736", "0001193125-25-314736", "000119312525314736", true},
+		{"000119312525314736", "0001193125-25-314736", "000119312525314736", true},
+		{" 0001193125-25-314736\n", "0001193125-25-314736", "000119312525314736", true},
+		{"0001193125-25314736", "", "", false},
+		{"00011931252531473", "", "", false},
+		{"0001193125-25-31473X", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeAccession(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("NormalizeAccession(%q) = %q, %v", tt.in, got, err)
+		}
+		compact, err := CompactAccession(tt.in)
+		if (err == nil) != tt.ok || compact != tt.compact {
+			t.Errorf("CompactAccession(%q) = %q, %v", tt.in, compact, err)
+		}
+	}
+}
+
+func TestExtractMetadataFromURL(t *testing.T) {
+	meta, err := ExtractMetadataFromURL("https://www.sec.gov/Archives/edgar/data/0001631574/000119312525314736/ownership.xml")
+	if err != nil {
+		t.Fatalf("ExtractMetadataFromURL: %v", err)
+	}
+	if meta.CIK != "1631574" || meta.Accession != "0001193125-25-314736" {
+		t.Errorf("got CIK %q, accession %q", meta.CIK, meta.Accession)
+	}
+	if _, err := ExtractMetadataFromURL("https://www.sec.gov/Archives/edgar/data/1631574/12345/ownership.xml"); err == nil {
+		t.Error("expected an error for a malformed accession number")
+	}
+
+	// Padded and undashed values name files the same way
+	for _, meta := range []*FilingMetadata{
+		{CIK: "1631574", Accession: "0001193125-25-314736"},
+		{CIK: "0001631574", Accession: "000119312525314736"},
+	} {
+		if got := GenerateFilename(meta, "json"); got != "1631574-0001193125-25-314736_ownership.json" {
+			t.Errorf("GenerateFilename(%+v) = %s", *meta, got)
+		}
+	}
+}
+
+func TestArchiveURLs(t *testing.T) {
+	f := Filing{CIK: "0001631574", AccessionNumber: "0001193125-25-314736", PrimaryDocument: "xslF345X05/ownership.xml"}
+	if got := f.BuildURL(); got != "https://www.sec.gov/Archives/edgar/data/1631574/000119312525314736/ownership.xml" {
+		t.Errorf("BuildURL = %s", got)
+	}
+	if got := CompanyFactsURL("320193"); got != "https://data.sec.gov/api/xbrl/companyfacts/CIK0000320193.json" {
+		t.Errorf("CompanyFactsURL = %s", got)
+	}
+
+	// Invalid values are used as given rather than rewritten into another filer's path
+	f = Filing{CIK: "0000", AccessionNumber: "0001-25-1", PrimaryDocument: "doc.xml"}
+	if got := f.BuildURL(); got != "https://www.sec.gov/Archives/edgar/data/0000/0001-25-1/doc.xml" {
+		t.Errorf("BuildURL (invalid) = %s", got)
+	}
+}
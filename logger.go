@@ -0,0 +1,29 @@
+package edgar
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Logger receives progress and warning messages from long-running operations
+// such as FetchAndParseBatch. Arguments are alternating key/value pairs, as in
+// log/slog; *slog.Logger satisfies this interface.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// DiscardLogger returns a Logger that drops all messages
+func DiscardLogger() Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// loggerOrDefault returns l, or slog.Default() when l is nil
+func loggerOrDefault(l Logger) Logger {
+	if l == nil {
+		return slog.Default()
+	}
+	return l
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 )
 
 // FilingMetadata contains information extracted from SEC URLs or filings
@@ -26,15 +27,18 @@ func ExtractMetadataFromURL(url string) (*FilingMetadata, error) {
 		return nil, fmt.Errorf("could not extract CIK and accession from URL")
 	}
 
+	cik, err := NormalizeCIK(matches[1])
+	if err != nil {
+		return nil, err
+	}
 	// Format accession number: 0001193125-25-314736
-	accession := matches[2]
-	if len(accession) == 18 {
-		// Format: XXXXXXXXXX-XX-XXXXXX
-		accession = accession[:10] + "-" + accession[10:12] + "-" + accession[12:]
+	accession, err := NormalizeAccession(matches[2])
+	if err != nil {
+		return nil, err
 	}
 
 	return &FilingMetadata{
-		CIK:       matches[1],
+		CIK:       cik,
 		Accession: accession,
 	}, nil
 }
@@ -76,14 +80,19 @@ func MergeMetadata(urlMeta, formMeta *FilingMetadata) *FilingMetadata {
 }
 
 // GenerateFilename creates a smart filename based on metadata
-// Format: {CIK}-{accession}_ownership.{ext}
+// Format: {CIK}-{accession}_ownership.{ext}, with the CIK unpadded and the
+// accession number dashed (values that aren't valid are used as they are)
 // Falls back to ownership.{ext} if metadata is incomplete
 func GenerateFilename(meta *FilingMetadata, ext string) string {
-	if meta.CIK != "" && meta.Accession != "" {
-		return fmt.Sprintf("%s-%s_ownership.%s", meta.CIK, meta.Accession, ext)
+	cik, accession := archiveCIK(meta.CIK), meta.Accession
+	if s, err := NormalizeAccession(accession); err == nil {
+		accession = s
+	}
+	if cik != "" && accession != "" {
+		return fmt.Sprintf("%s-%s_ownership.%s", cik, accession, ext)
 	}
-	if meta.CIK != "" {
-		return fmt.Sprintf("%s_ownership.%s", meta.CIK, ext)
+	if cik != "" {
+		return fmt.Sprintf("%s_ownership.%s", cik, ext)
 	}
 	return fmt.Sprintf("ownership.%s", ext)
 }
@@ -94,6 +103,8 @@ type SaveOptions struct {
 	OriginalPath string // If empty, uses smart naming
 	OutputPath   string // If empty, uses smart naming or stdout
 	OutputDir    string // Directory for output files (default: current dir)
+	Format       string // Output format: "json" (default), "ndjson", "csv" (Form 4 and XBRL), "parquet" or "xlsx"
+	Canonical    bool   // Canonical JSON/NDJSON (see FormatOptions.Canonical)
 }
 
 // SaveResult contains paths to saved files
@@ -136,13 +147,13 @@ func SaveFiles(xmlData []byte, form *ParsedForm, meta *FilingMetadata, opts Save
 			outputPath = filepath.Join(opts.OutputDir, outputPath)
 		}
 
-		jsonData, err := json.MarshalIndent(form, "", "  ")
+		outputData, err := FormatFormWithOptions(opts.Format, form, FormatOptions{Canonical: opts.Canonical})
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+			return nil, err
 		}
 
-		if err := os.WriteFile(outputPath, jsonData, 0644); err != nil {
-			return nil, fmt.Errorf("failed to save JSON output: %w", err)
+		if err := os.WriteFile(outputPath, outputData, 0644); err != nil {
+			return nil, fmt.Errorf("failed to save %s output: %w", strings.ToUpper(formatOrDefault(opts.Format)), err)
 		}
 		result.OutputPath = outputPath
 	}
@@ -150,9 +161,30 @@ func SaveFiles(xmlData []byte, form *ParsedForm, meta *FilingMetadata, opts Save
 	return result, nil
 }
 
+// formatOrDefault returns the output format, defaulting to "json"
+func formatOrDefault(format string) string {
+	if format == "" {
+		return "json"
+	}
+	return format
+}
+
 // FormatJSON returns pretty-printed JSON for a parsed form
 func FormatJSON(form *ParsedForm) ([]byte, error) {
-	return json.MarshalIndent(form, "", "  ")
+	return json.MarshalIndent(form.outputForm(), "", "  ")
+}
+
+// FormatForm encodes one parsed form as "json" (default: the whole ParsedForm,
+// as FormatJSON) or in a batch format for a batch of one (see FormatBatch)
+func FormatForm(format string, form *ParsedForm) ([]byte, error) {
+	if format == "" || format == "json" {
+		data, err := FormatJSON(form)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return data, nil
+	}
+	return FormatBatch(format, []*ParsedForm{form})
 }
 
 // FormatJSONBatch returns pretty-printed JSON for an array of ParsedForms
@@ -160,11 +192,38 @@ func FormatJSONBatch(filings []*ParsedForm) ([]byte, error) {
 	// Extract just the data from each parsed form
 	data := make([]interface{}, len(filings))
 	for i, f := range filings {
-		data[i] = f.Data
+		data[i] = f.outputForm().Data
 	}
 	return json.MarshalIndent(data, "", "  ")
 }
 
+// FormatBatch encodes batch results as "json" (default), "ndjson", "csv" (Form 4 and XBRL), "parquet" or "xlsx"
+func FormatBatch(format string, filings []*ParsedForm) ([]byte, error) {
+	switch format {
+	case "", "json":
+		return FormatJSONBatch(filings)
+	case "ndjson":
+		return FormatNDJSONBatch(filings)
+	case "csv":
+		return FormatCSVBatch(filings)
+	case "parquet":
+		return FormatParquet(filings)
+	case "xlsx":
+		return FormatXLSX(filings)
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// isBatchFormat reports whether FormatBatch supports the format
+func isBatchFormat(format string) bool {
+	switch format {
+	case "", "json", "ndjson", "csv", "parquet", "xlsx":
+		return true
+	}
+	return false
+}
+
 // FormatFilingListJSON returns pretty-printed JSON for an array of Filing metadata
 func FormatFilingListJSON(filings []Filing) ([]byte, error) {
 	return json.MarshalIndent(filings, "", "  ")
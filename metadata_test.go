@@ -0,0 +1,55 @@
+package edgar
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveFiles_Formats(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	form, err := ParseAny(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseAny failed: %v", err)
+	}
+	meta := &FilingMetadata{CIK: "1640147", Accession: "0001640147-25-000001"}
+
+	// Each format is written under its own extension by the smart naming
+	prefixes := map[string]string{
+		"json":    "{",
+		"ndjson":  "{",
+		"csv":     "accession_number,",
+		"parquet": "PAR1",
+		"xlsx":    "PK",
+	}
+	dir := t.TempDir()
+	for format, prefix := range prefixes {
+		result, err := SaveFiles(data, form, meta, SaveOptions{
+			OutputDir:  dir,
+			OutputPath: GenerateFilename(meta, format),
+			Format:     format,
+		})
+		if err != nil {
+			t.Errorf("%s: SaveFiles failed: %v", format, err)
+			continue
+		}
+		if want := filepath.Join(dir, "1640147-0001640147-25-000001_ownership."+format); result.OutputPath != want {
+			t.Errorf("%s: saved to %s, want %s", format, result.OutputPath, want)
+		}
+		saved, err := os.ReadFile(result.OutputPath)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !bytes.HasPrefix(saved, []byte(prefix)) {
+			t.Errorf("%s: output starts with %q, want %q", format, saved[:min(len(saved), 20)], prefix)
+		}
+	}
+
+	if _, err := FormatForm("yaml", form); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
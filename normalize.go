@@ -1,8 +1,8 @@
 package edgar
 
 import (
-	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -36,70 +36,57 @@ func NormalizeText(data []byte) []byte {
 	return []byte(text)
 }
 
+// htmlEntities maps the entities common in SEC filings to their Unicode equivalents
+var htmlEntities = map[string]string{
+	"&nbsp;":   " ",      // Non-breaking space
+	"&mdash;":  "\u2014", // Em dash
+	"&ndash;":  "\u2013", // En dash
+	"&ldquo;":  "\u201c", // Left double quote
+	"&rdquo;":  "\u201d", // Right double quote
+	"&lsquo;":  "\u2018", // Left single quote
+	"&rsquo;":  "\u2019", // Right single quote
+	"&amp;":    "&",      // Ampersand
+	"&lt;":     "<",      // Less than
+	"&gt;":     ">",      // Greater than
+	"&quot;":   "\"",     // Quote
+	"&apos;":   "'",      // Apostrophe
+	"&hellip;": "...",    // Ellipsis
+	"&bull;":   "\u2022", // Bullet
+	"&trade;":  "\u2122", // Trademark
+	"&reg;":    "\u00ae", // Registered
+	"&copy;":   "\u00a9", // Copyright
+	"&sect;":   "\u00a7", // Section sign
+	"&para;":   "\u00b6", // Paragraph sign
+	"&#160;":   " ",      // Non-breaking space (numeric)
+}
+
+var entityPattern = regexp.MustCompile(`&(?:[a-zA-Z]+|#[0-9]+|#[xX][0-9a-fA-F]+);`)
+
 // normalizeHTMLEntities converts common HTML entities to their Unicode equivalents
+// The text is decoded in a single pass, so "&amp;nbsp;" becomes "&nbsp;" (never a
+// space), and numeric entities that aren't valid characters (&#0;, surrogates,
+// beyond U+10FFFF) are left as they are.
 func normalizeHTMLEntities(text string) string {
-	// Common entities found in SEC filings
-	replacements := map[string]string{
-		"&nbsp;":   " ",      // Non-breaking space
-		"&mdash;":  "\u2014", // Em dash
-		"&ndash;":  "\u2013", // En dash
-		"&ldquo;":  "\u201c", // Left double quote
-		"&rdquo;":  "\u201d", // Right double quote
-		"&lsquo;":  "\u2018", // Left single quote
-		"&rsquo;":  "\u2019", // Right single quote
-		"&amp;":    "&",      // Ampersand
-		"&lt;":     "<",      // Less than
-		"&gt;":     ">",      // Greater than
-		"&quot;":   "\"",     // Quote
-		"&apos;":   "'",      // Apostrophe
-		"&hellip;": "...",    // Ellipsis
-		"&bull;":   "\u2022", // Bullet
-		"&trade;":  "\u2122", // Trademark
-		"&reg;":    "\u00ae", // Registered
-		"&copy;":   "\u00a9", // Copyright
-		"&sect;":   "\u00a7", // Section sign
-		"&para;":   "\u00b6", // Paragraph sign
-		"&#160;":   " ",      // Non-breaking space (numeric)
-		"&#8211;":  "\u2013", // En dash (numeric)
-		"&#8212;":  "\u2014", // Em dash (numeric)
-		"&#8220;":  "\u201c", // Left double quote (numeric)
-		"&#8221;":  "\u201d", // Right double quote (numeric)
-		"&#8217;":  "\u2019", // Right single quote (numeric)
+	if !strings.Contains(text, "&") {
+		return text
 	}
-
-	for entity, replacement := range replacements {
-		text = strings.ReplaceAll(text, entity, replacement)
-	}
-
-	// Handle numeric entities (&#NNN;) - common pattern
-	numericEntityPattern := regexp.MustCompile(`&#(\d+);`)
-	text = numericEntityPattern.ReplaceAllStringFunc(text, func(match string) string {
-		// Extract the number
-		var code int
-		if _, err := fmt.Sscanf(match, "&#%d;", &code); err == nil {
-			// Convert common codes to their Unicode equivalents
-			switch code {
-			case 160: // nbsp
-				return " "
-			case 8211: // en dash
-				return "–"
-			case 8212: // em dash
-				return "—"
-			case 8220, 8221: // quotes
-				return "\""
-			case 8217: // apostrophe
-				return "'"
-			default:
-				// For other codes, try to convert to Unicode rune
-				if code < 0x110000 { // Valid Unicode range
-					return string(rune(code))
-				}
-			}
+	return entityPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if replacement, ok := htmlEntities[match]; ok {
+			return replacement
 		}
-		return match // Leave unchanged if we can't parse
+		if match[1] != '#' {
+			return match // Entities we don't know
+		}
+		digits, base := match[2:len(match)-1], 10
+		if digits[0] == 'x' || digits[0] == 'X' {
+			digits, base = digits[1:], 16
+		}
+		code, err := strconv.ParseInt(digits, base, 32)
+		if err != nil || code == 0 || code > unicode.MaxRune || code >= 0xD800 && code <= 0xDFFF {
+			return match
+		}
+		return string(rune(code))
 	})
-
-	return text
 }
 
 // normalizeWhitespace converts various Unicode whitespace characters to regular spaces
@@ -161,6 +148,55 @@ func removeInvisibleChars(text string) string {
 	return result.String()
 }
 
+// numberGroupSeparators are the thousands separators cleanNumberText drops
+// between digit groups besides commas: spaces (Unicode spaces such as the thin
+// space are normalized to ' ' first) and apostrophes
+const numberGroupSeparators = " '\u2019\u02bc\u2032"
+
+// cleanNumberText prepares a number for parsing: Unicode spaces become
+// regular spaces, invisible characters are removed, and space or apostrophe
+// thousands separators are dropped ("1 234 567" with thin spaces or
+// "1'234'567" -> "1234567"). A separator is only dropped before a group of
+// exactly three digits that isn't followed by a comma, so "1,874,978 6" and
+// "Item 7 100,000" keep their separate numbers.
+func cleanNumberText(s string) string {
+	s = removeInvisibleChars(normalizeWhitespace(s))
+	if !strings.ContainsAny(s, numberGroupSeparators) {
+		return s
+	}
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	run := 0 // Digits since the last separator or non-digit
+	for i, r := range runes {
+		if isASCIIDigit(r) {
+			run++
+			b.WriteRune(r)
+			continue
+		}
+		if strings.ContainsRune(numberGroupSeparators, r) && run >= 1 && run <= 3 && isDigitGroup(runes[i+1:]) {
+			run = 0
+			continue
+		}
+		run = 0
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isDigitGroup reports whether runes start with exactly three digits that
+// aren't followed by a comma group
+func isDigitGroup(runes []rune) bool {
+	if len(runes) < 3 || !isASCIIDigit(runes[0]) || !isASCIIDigit(runes[1]) || !isASCIIDigit(runes[2]) {
+		return false
+	}
+	return len(runes) == 3 || !isASCIIDigit(runes[3]) && runes[3] != ','
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
 // NormalizeXMLText is a lighter version for XML content that preserves more structure
 // but still handles the most common issues
 func NormalizeXMLText(data []byte) []byte {
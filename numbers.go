@@ -0,0 +1,40 @@
+package edgar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Dashes SEC tables use for blank and zero cells: hyphen-minus, hyphen,
+// non-breaking hyphen, figure dash, en dash, em dash, horizontal bar, minus sign
+const dashes = "-‐‑‒–—―−"
+
+var zeroPlaceholderPattern = regexp.MustCompile(`[` + dashes + `]\s*0\s*[` + dashes + `]`)
+
+// isPlaceholder reports whether a table cell is a dash standing for "none"
+// ("—", "–", "-", "--")
+func isPlaceholder(s string) bool {
+	s = strings.TrimSpace(s)
+	return s != "" && strings.Trim(s, dashes) == ""
+}
+
+// isZeroPlaceholder reports whether a value holds "-0-" (in any dash), the
+// SEC convention for zero
+func isZeroPlaceholder(s string) bool {
+	return zeroPlaceholderPattern.MatchString(s)
+}
+
+// isNegative reports whether the number at s[start:end] is negative: signed
+// with a minus ("-1,234", "$-1,234", "−1,234") or, as in financial tables,
+// the whole value in parentheses ("(1,234)", "$(1,234)", "(1.5%)")
+func isNegative(s string, start, end int) bool {
+	before := strings.TrimRight(s[:start], "$")
+	for _, minus := range []string{"-", "−"} {
+		if strings.HasSuffix(before, minus) {
+			return true
+		}
+	}
+	before = strings.Trim(s[:start], " \t$")
+	after := strings.TrimLeft(s[end:], " \t%")
+	return before == "(" && strings.HasPrefix(after, ")") && strings.Trim(after[1:], " \t%") == ""
+}
@@ -0,0 +1,148 @@
+package edgar
+
+import "testing"
+
+func TestParseInt64(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"1,874,978", 1874978},
+		{"1,874,978 6", 1874978},
+		{"text 123,456 more text", 123456},
+		{"5,000 (1)", 5000},
+		{"(1) 5,000", 1},
+		{"(1,234)", -1234},
+		{"$(1,234)", -1234},
+		{"($1,234)", -1234},
+		{" ( 1,234 ) ", -1234},
+		{"-1,234", -1234},
+		{"$-1,234", -1234},
+		{"−1,234", -1234},
+		{"-0-", 0},
+		{"—0—", 0},
+		{"–0–", 0},
+		{"- 0 -", 0},
+		{"—", 0},
+		{"–", 0},
+		{"-", 0},
+		{"--", 0},
+		{"", 0},
+		{"n/a", 0},
+	}
+	for _, tt := range tests {
+		if got := parseInt64(tt.in); got != tt.want {
+			t.Errorf("parseInt64(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseFloat64(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"5.1%", 5.1},
+		{"5.1% (1)", 5.1},
+		{"text 12.34 more text", 12.34},
+		{"(1.5)", -1.5},
+		{"(1.5)%", -1.5},
+		{"(1.5%)", -1.5},
+		{"$(2,500.25)", -2500.25},
+		{"-3.25", -3.25},
+		{"-0-", 0},
+		{"—", 0},
+		{"―", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parseFloat64(tt.in); got != tt.want {
+			t.Errorf("parseFloat64(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumericValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"1234000", 1234000, true},
+		{"1,234,000", 1234000, true},
+		{"-5.5", -5.5, true},
+		{"(1234)", -1234, true},
+		{"(1,234.5)", -1234.5, true},
+		{"-0-", 0, true},
+		{"—0—", 0, true},
+		{"0", 0, true},
+		{"", 0, false},
+		{"-", 0, false},
+		{"—", 0, false},
+		{"–", 0, false},
+		{"−", 0, false},
+		{"()", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseNumericValue(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseNumericValue(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestNoNumber(t *testing.T) {
+	for _, s := range []string{"n/a", "None", "abc"} {
+		if !noNumber(s) {
+			t.Errorf("noNumber(%q) = false, want true", s)
+		}
+	}
+	// Placeholders are blanks, not bad values
+	for _, s := range []string{"", "—", "-", "-0-", "—0—", "1,234", "(1,234)"} {
+		if noNumber(s) {
+			t.Errorf("noNumber(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestParseNumbers_Separators(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"1 234 567", 1234567}, // Thin space
+		{"1 234 567", 1234567}, // Narrow no-break space
+		{"1 234 567", 1234567}, // No-break space
+		{"1 234 567", 1234567},
+		{"1'234'567", 1234567},
+		{"1’234’567", 1234567}, // Right single quotation mark
+		{"1​234", 1234},        // Zero-width space
+		{"(1 234)", -1234},
+		{"1,874,978 6", 1874978},
+		{"Item 7 100,000", 7},
+		{"12 34", 12},
+		{"1234 567", 1234},
+	}
+	for _, tt := range tests {
+		if got := parseInt64(tt.in); got != tt.want {
+			t.Errorf("parseInt64(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	if got := parseFloat64("1 234.5"); got != 1234.5 {
+		t.Errorf("parseFloat64 = %v, want 1234.5", got)
+	}
+	if got, err := parseNumericValue("1 234 000"); err != nil || got != 1234000 {
+		t.Errorf("parseNumericValue = %v, %v, want 1234000", got, err)
+	}
+	for format, text := range map[string]string{
+		"ixt:num-dot-decimal":   "1 234.5",
+		"ixt:num-comma-decimal": "1 234,5",
+		"ixt:numdotdecimal":     "1’234.5",
+	} {
+		if got, _, err := transformInlineValue(format, text); err != nil || got != "1234.5" {
+			t.Errorf("%s %q = %q, %v, want 1234.5", format, text, got, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package edgar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Officer roles: the controlled vocabulary produced by NormalizeOfficerTitle
+const (
+	RoleCEO       = "CEO"
+	RoleCFO       = "CFO"
+	RoleCOO       = "COO"
+	RoleCTO       = "CTO"
+	RoleCAO       = "CAO" // Chief/principal accounting officer
+	RoleCSO       = "CSO" // Chief scientific officer
+	RoleCMO       = "CMO" // Chief medical officer
+	RoleCCO       = "CCO" // Chief commercial officer
+	RoleGC        = "GC"  // General counsel / chief legal officer
+	RolePresident = "President"
+	RoleChair     = "Chair"
+	RoleEVP       = "EVP"
+	RoleSVP       = "SVP"
+	RoleVP        = "VP"
+	RoleSecretary = "Secretary"
+	RoleTreasurer = "Treasurer"
+)
+
+// officerRolePatterns are matched in order against a normalized title
+// Vice-president patterns strip their match so "Vice President" is not also
+// read as President, and EVP/SVP are not also read as VP.
+var officerRolePatterns = []struct {
+	role  string
+	re    *regexp.Regexp
+	strip bool
+}{
+	{RoleCEO, regexp.MustCompile(`\bceo\b|\bchief executive\b|\bprincipal executive officer\b`), false},
+	{RoleCFO, regexp.MustCompile(`\bcfo\b|\bchief financial\b|\bprincipal financial officer\b`), false},
+	{RoleCOO, regexp.MustCompile(`\bcoo\b|\bchief operating\b`), false},
+	{RoleCTO, regexp.MustCompile(`\bcto\b|\bchief technology\b`), false},
+	{RoleCAO, regexp.MustCompile(`\bcao\b|\bchief accounting\b|\bprincipal accounting officer\b`), false},
+	{RoleCSO, regexp.MustCompile(`\bchief scientific\b|\bchief science\b`), false},
+	{RoleCMO, regexp.MustCompile(`\bchief medical\b`), false},
+	{RoleCCO, regexp.MustCompile(`\bchief commercial\b`), false},
+	{RoleGC, regexp.MustCompile(`\bgeneral counsel\b|\bgc\b|\bchief legal\b|\bclo\b`), false},
+	{RoleChair, regexp.MustCompile(`\bchair(man|woman|person)?\b`), false},
+	{RoleEVP, regexp.MustCompile(`\bexecutive vice president\b|\bexec(utive)? vp\b|\bevp\b`), true},
+	{RoleSVP, regexp.MustCompile(`\b(senior|sr) (vice president|vp)\b|\bsvp\b`), true},
+	{RoleVP, regexp.MustCompile(`\bvice president\b|\bvp\b`), true},
+	{RolePresident, regexp.MustCompile(`\bpresident\b|\bpres\b`), false},
+	{RoleSecretary, regexp.MustCompile(`\bsecretary\b`), false},
+	{RoleTreasurer, regexp.MustCompile(`\btreasurer\b`), false},
+}
+
+var officerTitleSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NormalizeOfficerTitle maps a free-text officerTitle to controlled-vocabulary
+// roles, e.g. "CEO & President" -> [CEO President], "EVP, CFO" -> [CFO EVP]
+// Roles are returned in vocabulary order; unrecognized titles return nil
+func NormalizeOfficerTitle(title string) []string {
+	// Lowercase and reduce punctuation to single spaces ("Pres." -> "pres", "Sr. VP" -> "sr vp")
+	normalized := " " + strings.TrimSpace(officerTitleSeparators.ReplaceAllString(strings.ToLower(title), " ")) + " "
+	if strings.TrimSpace(normalized) == "" {
+		return nil
+	}
+
+	var roles []string
+	for _, p := range officerRolePatterns {
+		if !p.re.MatchString(normalized) {
+			continue
+		}
+		roles = append(roles, p.role)
+		if p.strip {
+			normalized = p.re.ReplaceAllString(normalized, " ")
+		}
+	}
+	return roles
+}
@@ -0,0 +1,31 @@
+package edgar
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeOfficerTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  []string
+	}{
+		{"Chief Executive Officer", []string{RoleCEO}},
+		{"CEO & President", []string{RoleCEO, RolePresident}},
+		{"EVP, CFO", []string{RoleCFO, RoleEVP}},
+		{"Chief Financial Officer", []string{RoleCFO}},
+		{"EVP, CRO & Pres. Life Sciences", []string{RoleEVP, RolePresident}},
+		{"Sr. Vice President, General Counsel & Secretary", []string{RoleGC, RoleSVP, RoleSecretary}},
+		{"Vice President", []string{RoleVP}},
+		{"Executive Chairman", []string{RoleChair}},
+		{"Chief Medical Officer", []string{RoleCMO}},
+		{"See Remarks", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeOfficerTitle(tt.title); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("NormalizeOfficerTitle(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
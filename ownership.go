@@ -0,0 +1,465 @@
+package edgar
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OwnershipPosition is a holder's best-known beneficial ownership on a given date
+type OwnershipPosition struct {
+	HolderCIK       string   `json:"holderCik,omitempty"`
+	HolderName      string   `json:"holderName"`
+	JointFilers     []string `json:"jointFilers,omitempty"`   // Other reporting owners of a joint filing, sharing the position
+	SecurityTitle   string   `json:"securityTitle,omitempty"` // Class of stock the shares are in
+	Shares          float64  `json:"shares"`
+	PercentOfClass  *float64 `json:"percentOfClass"` // Only reported on Schedule 13D/G; null for Form 4
+	SourceForm      string   `json:"sourceForm"`     // Form that established the position, e.g. "4", "SC 13D/A"
+	AccessionNumber string   `json:"accessionNumber,omitempty"`
+	EffectiveDate   string   `json:"effectiveDate"` // Date the position became true (YYYY-MM-DD)
+	FilingDate      string   `json:"filingDate,omitempty"`
+}
+
+// ownershipCandidate is a position plus the keys used to order supersession
+type ownershipCandidate struct {
+	position  OwnershipPosition
+	amendment int               // Amendment number (0 for originals and Form 4)
+	holders   []ownershipHolder // Reporting owners sharing the position, the holder first
+}
+
+// ownershipHolder is a reporting owner as named in a filing
+type ownershipHolder struct {
+	cik  string
+	name string
+}
+
+// OwnershipAsOf reconstructs the ownership table for an issuer on a historical date
+// from already-parsed Form 4 and Schedule 13D/G filings.
+//
+// issuer matches the issuer CIK (leading zeros ignored), ticker, CUSIP or name
+// (case-insensitive). asOf is a YYYY-MM-DD date.
+//
+// Each filing takes effect on its event date: the latest transaction date for a
+// Form 4, the date of event for a Schedule 13D/G (falling back to the filing
+// date). For every holder, the most recent position effective on or before asOf
+// wins; ties are broken by filing date and then amendment number, so a later
+// amendment supersedes the filing it amends. Holders whose latest position is
+// zero shares (e.g. an exit amendment) are omitted.
+//
+// Holders are matched across filings by CIK, or by name where a filing has no
+// CIK (taking the CIK another filing reports for that name). Joint filers (the
+// reporting owners of one Form 4, or the group members of a Schedule 13D/G)
+// hold one position between them, reported once under the first of them with
+// the others in JointFilers, and are one holder in every filing.
+//
+// A Form 4 position counts one class of stock, so it compares with the single
+// class of a Schedule 13D/G: the first common stock (or ordinary shares) title
+// in Table I, or the first title when none is common. Holdings of other
+// classes, such as Class B or preferred stock, are left out. SecurityTitle
+// names the class.
+//
+// Results are sorted by shares held (largest first).
+func OwnershipAsOf(forms []*ParsedForm, issuer, asOf string) ([]OwnershipPosition, error) {
+	asOfDate, ok := normalizeFilingDate(asOf)
+	if !ok {
+		return nil, fmt.Errorf("invalid as-of date %q (expected YYYY-MM-DD)", asOf)
+	}
+
+	var candidates []ownershipCandidate
+	for _, form := range forms {
+		if form == nil {
+			continue
+		}
+
+		switch data := form.Data.(type) {
+		case *Form4Output:
+			if matchesIssuer(issuer, data.Issuer.CIK, data.Issuer.Name, data.Issuer.Ticker) {
+				candidates = append(candidates, form4Positions(data)...)
+			}
+		case *Schedule13Filing:
+			if matchesIssuer(issuer, data.IssuerCIK, data.IssuerName, data.IssuerCUSIP) {
+				candidates = append(candidates, schedule13Positions(data)...)
+			}
+		}
+	}
+
+	ids := newHolderIdentities(candidates)
+	latest := make(map[string]ownershipCandidate)
+	for _, c := range candidates {
+		if c.position.EffectiveDate == "" || c.position.EffectiveDate > asOfDate {
+			continue
+		}
+		key := ids.group(c.holders[0])
+		if prev, ok := latest[key]; !ok || supersedes(c, prev) {
+			latest[key] = c
+		}
+	}
+
+	var positions []OwnershipPosition
+	for _, c := range latest {
+		if c.position.Shares <= 0 {
+			continue
+		}
+		positions = append(positions, c.position)
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Shares != positions[j].Shares {
+			return positions[i].Shares > positions[j].Shares
+		}
+		return positions[i].HolderName < positions[j].HolderName
+	})
+
+	return positions, nil
+}
+
+// OwnershipAsOf parses every filing in the store and reconstructs the issuer's
+// ownership table on asOf (see the package-level OwnershipAsOf)
+// Stored filings that fail to parse are skipped
+func (s *FilingStore) OwnershipAsOf(issuer, asOf string) ([]OwnershipPosition, error) {
+	entries, err := s.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var forms []*ParsedForm
+	for _, e := range entries {
+		if !isOwnershipForm(e.Form) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.Root, filepath.FromSlash(e.Path)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stored filing %s: %w", e.AccessionNumber, err)
+		}
+
+		form, err := ParseAny(bytes.NewReader(data))
+		if err != nil {
+			continue
+		}
+
+		// Filing dates and accession numbers come from the manifest (not in the documents)
+		switch d := form.Data.(type) {
+		case *Form4Output:
+			d.SetFilingMetadata(e.AccessionNumber, e.FilingDate, e.ReportDate)
+		case *Schedule13Filing:
+			d.FilingDate = e.FilingDate
+			d.AccessionNumber = e.AccessionNumber
+		}
+		forms = append(forms, form)
+	}
+
+	return OwnershipAsOf(forms, issuer, asOf)
+}
+
+// isOwnershipForm reports whether a form type carries beneficial ownership data
+func isOwnershipForm(form string) bool {
+	base := strings.TrimSuffix(strings.ToUpper(form), "/A")
+	switch base {
+	case "4", "SC 13D", "SC 13G", "SCHEDULE 13D", "SCHEDULE 13G":
+		return true
+	}
+	return false
+}
+
+// form4Positions returns the post-filing holdings of the reporting owners in
+// the issuer's common class (see ownershipClass), as one position
+// The position is the sum of the final sharesOwnedFollowing for each ownership
+// line of that class (direct/indirect + nature), across transactions and holdings
+func form4Positions(f *Form4Output) []ownershipCandidate {
+	effective := ""
+	lines := make(map[string]float64)
+	var order []string
+	var titles []string
+
+	record := func(title, directIndirect, nature string, shares *float64) {
+		if shares == nil {
+			return
+		}
+		title = strings.TrimSpace(title)
+		key := strings.ToUpper(title) + "|" + directIndirect + "|" + nature
+		if _, ok := lines[key]; !ok {
+			order = append(order, key)
+			titles = append(titles, title)
+		}
+		lines[key] = *shares
+	}
+
+	for _, txn := range f.Transactions {
+		if d, ok := normalizeFilingDate(txn.TransactionDate); ok && d > effective {
+			effective = d
+		}
+		record(txn.SecurityTitle, txn.DirectIndirect, txn.NatureOfOwnership, txn.SharesOwnedFollowing)
+	}
+	for _, h := range f.Holdings {
+		record(h.SecurityTitle, h.DirectIndirect, h.NatureOfOwnership, h.SharesOwnedFollowing)
+	}
+
+	if effective == "" {
+		effective, _ = normalizeFilingDate(f.Metadata.PeriodOfReport)
+	}
+	if len(order) == 0 || effective == "" {
+		return nil
+	}
+
+	class := ownershipClass(titles)
+	var total float64
+	for i, key := range order {
+		if strings.EqualFold(titles[i], class) {
+			total += lines[key]
+		}
+	}
+
+	// Joint filers report the same holdings, so the filing is one position
+	var holders []ownershipHolder
+	for _, owner := range f.ReportingOwners {
+		holders = append(holders, ownershipHolder{cik: owner.CIK, name: owner.Name})
+	}
+	if len(holders) == 0 {
+		return nil
+	}
+	return []ownershipCandidate{{
+		position: OwnershipPosition{
+			HolderCIK:       strings.TrimLeft(holders[0].cik, "0"),
+			HolderName:      holders[0].name,
+			JointFilers:     jointFilers(holders),
+			SecurityTitle:   class,
+			Shares:          total,
+			SourceForm:      f.Metadata.FormType,
+			AccessionNumber: f.Metadata.AccessionNumber,
+			EffectiveDate:   effective,
+			FilingDate:      f.Metadata.FilingDate,
+		},
+		holders: holders,
+	}}
+}
+
+// ownershipClass picks the class of stock a Form 4 position counts, from its
+// Table I security titles in filing order: the first common stock or ordinary
+// shares title, else the first title
+func ownershipClass(titles []string) string {
+	for _, title := range titles {
+		upper := strings.ToUpper(title)
+		if (strings.Contains(upper, "COMMON") || strings.Contains(upper, "ORDINARY")) && !strings.Contains(upper, "PREFERRED") {
+			return title
+		}
+	}
+	return titles[0]
+}
+
+// schedule13Positions returns one position per reporting person on a Schedule 13D/G
+func schedule13Positions(f *Schedule13Filing) []ownershipCandidate {
+	effective, ok := normalizeFilingDate(f.DateOfEvent)
+	if !ok {
+		effective, ok = normalizeFilingDate(f.EventDate)
+	}
+	if !ok {
+		effective, ok = normalizeFilingDate(f.FilingDate)
+	}
+	if !ok {
+		return nil
+	}
+
+	amendment := 0
+	if f.AmendmentNumber != nil {
+		amendment = *f.AmendmentNumber
+	}
+
+	position := func(holders []ownershipHolder, shares int64, percent float64) ownershipCandidate {
+		return ownershipCandidate{
+			position: OwnershipPosition{
+				HolderCIK:       strings.TrimLeft(holders[0].cik, "0"),
+				HolderName:      holders[0].name,
+				JointFilers:     jointFilers(holders),
+				SecurityTitle:   f.SecurityTitle,
+				Shares:          float64(shares),
+				PercentOfClass:  &percent,
+				SourceForm:      f.FormType,
+				AccessionNumber: f.AccessionNumber,
+				EffectiveDate:   effective,
+				FilingDate:      f.FilingDate,
+			},
+			amendment: amendment,
+			holders:   holders,
+		}
+	}
+
+	// Group members all report the group's shares: one position, the largest
+	// amount, as in CalculateTotalShares
+	var candidates []ownershipCandidate
+	var group []ownershipHolder
+	var groupShares int64
+	var groupPercent float64
+	for _, person := range f.ReportingPersons {
+		cik := person.CIK
+		if cik == "" && len(f.ReportingPersons) == 1 {
+			cik = f.FilerCIK
+		}
+		holder := ownershipHolder{cik: cik, name: person.Name}
+		if person.MemberOfGroup == "a" && !person.IsAggregateExclude {
+			group = append(group, holder)
+			groupShares = max(groupShares, person.AggregateAmountOwned)
+			groupPercent = max(groupPercent, person.PercentOfClass)
+			continue
+		}
+		candidates = append(candidates, position([]ownershipHolder{holder}, person.AggregateAmountOwned, person.PercentOfClass))
+	}
+	if len(group) > 0 {
+		candidates = append(candidates, position(group, groupShares, groupPercent))
+	}
+	return candidates
+}
+
+// jointFilers returns the names of the holders after the first
+func jointFilers(holders []ownershipHolder) []string {
+	var names []string
+	for _, h := range holders[1:] {
+		names = append(names, h.name)
+	}
+	return names
+}
+
+// holderIdentities resolves reporting owners across filings to one key per
+// beneficial holder
+type holderIdentities struct {
+	cikByName map[string]string // Normalized name -> CIK, from filings that report both
+	parent    map[string]string // Union-find over holder keys, joining joint filers
+}
+
+// newHolderIdentities learns the holders' CIKs by name and joins the joint
+// filers of every candidate into one group
+func newHolderIdentities(candidates []ownershipCandidate) *holderIdentities {
+	ids := &holderIdentities{cikByName: make(map[string]string), parent: make(map[string]string)}
+	for _, c := range candidates {
+		for _, h := range c.holders {
+			if cik := strings.TrimLeft(h.cik, "0"); cik != "" {
+				ids.cikByName[normalizeHolderName(h.name)] = cik
+			}
+		}
+	}
+	for _, c := range candidates {
+		for _, h := range c.holders[1:] {
+			a, b := ids.find(ids.key(c.holders[0])), ids.find(ids.key(h))
+			// The smaller key is the root, so groups don't depend on filing order
+			if a < b {
+				ids.parent[b] = a
+			} else if b < a {
+				ids.parent[a] = b
+			}
+		}
+	}
+	return ids
+}
+
+// key identifies a holder by CIK, looked up by name when the filing has none
+func (ids *holderIdentities) key(h ownershipHolder) string {
+	cik := strings.TrimLeft(h.cik, "0")
+	if cik == "" {
+		cik = ids.cikByName[normalizeHolderName(h.name)]
+	}
+	return holderKey(cik, h.name)
+}
+
+// find returns the root key of a holder key's group
+func (ids *holderIdentities) find(key string) string {
+	for {
+		parent, ok := ids.parent[key]
+		if !ok {
+			return key
+		}
+		key = parent
+	}
+}
+
+// group returns the key of the group a holder belongs to
+func (ids *holderIdentities) group(h ownershipHolder) string {
+	return ids.find(ids.key(h))
+}
+
+// supersedes reports whether candidate a replaces b as the holder's best-known position
+func supersedes(a, b ownershipCandidate) bool {
+	if a.position.EffectiveDate != b.position.EffectiveDate {
+		return a.position.EffectiveDate > b.position.EffectiveDate
+	}
+	af, _ := normalizeFilingDate(a.position.FilingDate)
+	bf, _ := normalizeFilingDate(b.position.FilingDate)
+	if af != bf {
+		return af > bf
+	}
+	if a.amendment != b.amendment {
+		return a.amendment > b.amendment
+	}
+	return a.position.AccessionNumber > b.position.AccessionNumber
+}
+
+// matchesIssuer compares an issuer query against CIK (ignoring leading zeros) and
+// case-insensitive names/identifiers
+func matchesIssuer(query, cik string, names ...string) bool {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return false
+	}
+
+	trimmed := strings.TrimLeft(query, "0")
+	if trimmed != "" && trimmed == strings.TrimLeft(cik, "0") {
+		return true
+	}
+
+	for _, name := range names {
+		if name != "" && strings.EqualFold(strings.TrimSpace(name), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// holderKey identifies a holder across filings (CIK when known, else name)
+func holderKey(cik, name string) string {
+	if cik = strings.TrimLeft(cik, "0"); cik != "" {
+		return "cik:" + cik
+	}
+	return "name:" + normalizeHolderName(name)
+}
+
+// normalizeHolderName reduces a holder name to uppercase words in sorted order,
+// so "Doe Jane" (Form 4) and "Jane Doe" (Schedule 13D/G) compare equal
+func normalizeHolderName(name string) string {
+	words := strings.FieldsFunc(strings.ToUpper(name), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '.' || r == '\t' || r == '\n'
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// normalizeFilingDate converts the date formats seen in filings to YYYY-MM-DD
+func normalizeFilingDate(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false
+	}
+
+	layouts := []string{
+		"2006-01-02",
+		"01/02/2006",
+		"1/2/2006",
+		"January 2, 2006",
+		"Jan 2, 2006",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+
+	// Dates with a time/offset suffix (e.g. "2025-03-01-05:00")
+	if len(raw) > 10 {
+		if t, err := time.Parse("2006-01-02", raw[:10]); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,153 @@
+package edgar
+
+import "testing"
+
+func TestOwnershipAsOf(t *testing.T) {
+	one := 1
+
+	forms := []*ParsedForm{
+		// Insider buys on 2024-03-01, then sells down on 2024-09-15
+		{FormType: "4", Data: &Form4Output{
+			Metadata:        FormMetadata{FormType: "4", AccessionNumber: "0000000001-24-000001", FilingDate: "2024-03-04"},
+			Issuer:          IssuerOutput{CIK: "0001234567", Name: "Acme Corp", Ticker: "ACME"},
+			ReportingOwners: []ReportingOwnerOutput{{CIK: "0000111111", Name: "Doe Jane"}},
+			Transactions: []NonDerivativeTransactionOut{
+				{SecurityTitle: "Common Stock", TransactionDate: "2024-03-01", SharesOwnedFollowing: ptrFloat(1000), DirectIndirect: "D"},
+				{SecurityTitle: "Common Stock", TransactionDate: "2024-03-01", SharesOwnedFollowing: ptrFloat(500), DirectIndirect: "I", NatureOfOwnership: "By Trust"},
+			},
+		}},
+		{FormType: "4", Data: &Form4Output{
+			Metadata:        FormMetadata{FormType: "4", AccessionNumber: "0000000001-24-000002", FilingDate: "2024-09-17"},
+			Issuer:          IssuerOutput{CIK: "1234567", Name: "Acme Corp", Ticker: "ACME"},
+			ReportingOwners: []ReportingOwnerOutput{{CIK: "111111", Name: "Doe Jane"}},
+			Transactions: []NonDerivativeTransactionOut{
+				{SecurityTitle: "Common Stock", TransactionDate: "2024-09-15", SharesOwnedFollowing: ptrFloat(200), DirectIndirect: "D"},
+			},
+		}},
+		// Activist files a 13D, then an amendment reporting the same event date
+		{FormType: "SC 13D", Data: &Schedule13Filing{
+			FormType: "SC 13D", AccessionNumber: "0000000002-24-000001", FilingDate: "2024-05-10", DateOfEvent: "05/01/2024",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp",
+			ReportingPersons: []ReportingPerson13{{CIK: "0000222222", Name: "Fund LP", AggregateAmountOwned: 5000000, PercentOfClass: 6.1}},
+		}},
+		{FormType: "SC 13D", Data: &Schedule13Filing{
+			FormType: "SC 13D/A", AccessionNumber: "0000000002-24-000002", IsAmendment: true, AmendmentNumber: &one, FilingDate: "2024-05-20", DateOfEvent: "05/01/2024",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp",
+			ReportingPersons: []ReportingPerson13{{CIK: "0000222222", Name: "Fund LP", AggregateAmountOwned: 5100000, PercentOfClass: 6.2}},
+		}},
+		// Different issuer must be ignored
+		{FormType: "SC 13G", Data: &Schedule13Filing{
+			FormType: "SC 13G", FilingDate: "2024-02-01", EventDate: "01/31/2024",
+			IssuerCIK: "0009999999", IssuerName: "Other Inc",
+			ReportingPersons: []ReportingPerson13{{CIK: "0000333333", Name: "Index Fund", AggregateAmountOwned: 9000000}},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		issuer string
+		asOf   string
+		want   map[string]float64 // holder name -> shares
+	}{
+		{"before any filing", "1234567", "2024-01-15", map[string]float64{}},
+		{"after first Form 4", "ACME", "2024-04-01", map[string]float64{"Doe Jane": 1500}},
+		{"amendment supersedes original", "acme corp", "2024-06-30", map[string]float64{"Doe Jane": 1500, "Fund LP": 5100000}},
+		{"later Form 4 supersedes", "0001234567", "2024-12-31", map[string]float64{"Doe Jane": 200, "Fund LP": 5100000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions, err := OwnershipAsOf(forms, tt.issuer, tt.asOf)
+			if err != nil {
+				t.Fatalf("OwnershipAsOf failed: %v", err)
+			}
+			if len(positions) != len(tt.want) {
+				t.Fatalf("Expected %d positions, got %d: %+v", len(tt.want), len(positions), positions)
+			}
+			for _, p := range positions {
+				if want, ok := tt.want[p.HolderName]; !ok || want != p.Shares {
+					t.Errorf("Unexpected position %s: %.0f shares", p.HolderName, p.Shares)
+				}
+			}
+		})
+	}
+
+	// Largest holder first, with the 13D percent carried through
+	positions, _ := OwnershipAsOf(forms, "1234567", "2024-12-31")
+	if positions[0].HolderName != "Fund LP" || positions[0].SourceForm != "SC 13D/A" || positions[0].AccessionNumber != "0000000002-24-000002" {
+		t.Errorf("Expected Fund LP from SC 13D/A first, got %+v", positions[0])
+	}
+	if positions[0].PercentOfClass == nil || *positions[0].PercentOfClass != 6.2 {
+		t.Errorf("Expected percent 6.2, got %v", positions[0].PercentOfClass)
+	}
+	if positions[0].EffectiveDate != "2024-05-01" {
+		t.Errorf("Expected effective date 2024-05-01, got %s", positions[0].EffectiveDate)
+	}
+
+	// Holdings of several classes: only the common stock lines are summed
+	founder := &ParsedForm{FormType: "4", Data: &Form4Output{
+		Metadata:        FormMetadata{FormType: "4", AccessionNumber: "0000000004-24-000001", FilingDate: "2024-10-03"},
+		Issuer:          IssuerOutput{CIK: "1234567", Name: "Acme Corp", Ticker: "ACME"},
+		ReportingOwners: []ReportingOwnerOutput{{CIK: "444444", Name: "Founder Sam"}},
+		Transactions: []NonDerivativeTransactionOut{
+			{SecurityTitle: "Series A Preferred Stock", TransactionDate: "2024-10-01", SharesOwnedFollowing: ptrFloat(50), DirectIndirect: "D"},
+			{SecurityTitle: "Common Stock", TransactionDate: "2024-10-01", SharesOwnedFollowing: ptrFloat(300), DirectIndirect: "D"},
+		},
+		Holdings: []NonDerivativeHoldingOut{
+			{SecurityTitle: "Class B Common Stock", SharesOwnedFollowing: ptrFloat(700), DirectIndirect: "D"},
+			{SecurityTitle: "Common Stock", SharesOwnedFollowing: ptrFloat(25), DirectIndirect: "I", NatureOfOwnership: "By Spouse"},
+		},
+	}}
+	positions, _ = OwnershipAsOf(append(forms, founder), "1234567", "2024-12-31")
+	if len(positions) != 3 || positions[1].HolderName != "Founder Sam" {
+		t.Fatalf("Expected Founder Sam second of 3 positions, got %+v", positions)
+	}
+	if positions[1].Shares != 325 || positions[1].SecurityTitle != "Common Stock" {
+		t.Errorf("Expected 325 shares of Common Stock, got %.0f of %q", positions[1].Shares, positions[1].SecurityTitle)
+	}
+
+	// A joint Form 4 by the fund and its GP, then a joint 13D/A naming the GP
+	// without a CIK: one holder throughout, its shares counted once
+	joint := append(forms[:len(forms):len(forms)],
+		&ParsedForm{FormType: "4", Data: &Form4Output{
+			Metadata:        FormMetadata{FormType: "4", AccessionNumber: "0000000006-24-000001", FilingDate: "2024-07-03"},
+			Issuer:          IssuerOutput{CIK: "1234567", Name: "Acme Corp", Ticker: "ACME"},
+			ReportingOwners: []ReportingOwnerOutput{{CIK: "0000222222", Name: "Fund LP"}, {CIK: "0000666666", Name: "Fund GP LLC"}},
+			Transactions: []NonDerivativeTransactionOut{
+				{SecurityTitle: "Common Stock", TransactionDate: "2024-07-01", SharesOwnedFollowing: ptrFloat(5300000), DirectIndirect: "D"},
+			},
+		}},
+		&ParsedForm{FormType: "SC 13D", Data: &Schedule13Filing{
+			FormType: "SC 13D/A", AccessionNumber: "0000000006-24-000002", FilingDate: "2024-08-05", DateOfEvent: "08/01/2024",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp",
+			ReportingPersons: []ReportingPerson13{
+				{Name: "FUND GP, LLC", MemberOfGroup: "a", AggregateAmountOwned: 5400000, PercentOfClass: 6.5},
+				{CIK: "0000777777", Name: "Manager Pat", MemberOfGroup: "a", AggregateAmountOwned: 5400000, PercentOfClass: 6.5},
+			},
+		}},
+	)
+	positions, _ = OwnershipAsOf(joint, "1234567", "2024-07-31")
+	if len(positions) != 2 || positions[0].HolderName != "Fund LP" || positions[0].Shares != 5300000 || len(positions[0].JointFilers) != 1 {
+		t.Errorf("Expected one joint Fund LP position of 5300000 and Doe Jane, got %+v", positions)
+	}
+	positions, _ = OwnershipAsOf(joint, "1234567", "2024-12-31")
+	if len(positions) != 2 || positions[0].Shares != 5400000 || positions[0].AccessionNumber != "0000000006-24-000002" {
+		t.Errorf("Expected the 13D/A group's 5400000 once and Doe Jane, got %+v", positions)
+	}
+
+	// Same-day 13Gs without amendment numbers: the later accession wins
+	sameDay := []*ParsedForm{
+		{FormType: "SC 13G", Data: &Schedule13Filing{FormType: "SC 13G", AccessionNumber: "0000000005-24-000002", FilingDate: "2024-02-14", IssuerCIK: "1234567",
+			ReportingPersons: []ReportingPerson13{{CIK: "555555", Name: "Index Fund", AggregateAmountOwned: 800}}}},
+		{FormType: "SC 13G", Data: &Schedule13Filing{FormType: "SC 13G", AccessionNumber: "0000000005-24-000001", FilingDate: "2024-02-14", IssuerCIK: "1234567",
+			ReportingPersons: []ReportingPerson13{{CIK: "555555", Name: "Index Fund", AggregateAmountOwned: 700}}}},
+	}
+	positions, _ = OwnershipAsOf(sameDay, "1234567", "2024-12-31")
+	if len(positions) != 1 || positions[0].Shares != 800 || positions[0].AccessionNumber != "0000000005-24-000002" {
+		t.Errorf("Expected 800 shares from 0000000005-24-000002, got %+v", positions)
+	}
+
+	if _, err := OwnershipAsOf(forms, "ACME", "12/31/24"); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+}
@@ -0,0 +1,132 @@
+package edgar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseWarning is a data quality issue the parsers worked around instead of
+// failing: a number that couldn't be read (and was left empty or read as 0),
+// a malformed XBRL context or fact that was skipped, or a fact whose context
+// is missing. With ParseOptions.Strict they are errors instead.
+type ParseWarning struct {
+	Field   string `json:"field"` // e.g. "transactions[2].shares", "reportingPersons[0].soleVotingPower", "us-gaap:Revenues"
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+func (w ParseWarning) String() string {
+	if w.Value == "" {
+		return fmt.Sprintf("%s: %s", w.Field, w.Message)
+	}
+	return fmt.Sprintf("%s: %s (%q)", w.Field, w.Message, w.Value)
+}
+
+// Warnings returns the data quality issues found while parsing the form
+func (p *ParsedForm) Warnings() []ParseWarning {
+	if f, ok := p.AsForm4Output(); ok {
+		return f.Warnings
+	}
+	if s, ok := p.AsSchedule13(); ok {
+		return s.Warnings
+	}
+	if s, ok := p.AsSnapshot(); ok {
+		return s.Warnings
+	}
+	return nil
+}
+
+// strictWarnings fails strict parsing of a form with warnings
+func strictWarnings(form *ParsedForm) error {
+	warnings := form.Warnings()
+	if len(warnings) == 0 {
+		return nil
+	}
+	msg := warnings[0].String()
+	if n := len(warnings) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidValue, msg)
+}
+
+// parseWarnings collects warnings while a form is parsed
+type parseWarnings []ParseWarning
+
+func (w *parseWarnings) add(field, value, message string) {
+	*w = append(*w, ParseWarning{Field: field, Value: value, Message: message})
+}
+
+// int64 reads a share count with parseInt64, warning when the text holds no
+// number at all and is read as 0
+func (w *parseWarnings) int64(field, s string) int64 {
+	if noNumber(s) {
+		w.add(field, strings.TrimSpace(s), "not a number, read as 0")
+	}
+	return parseInt64(s)
+}
+
+// float64 reads a percentage with parseFloat64, warning like int64
+func (w *parseWarnings) float64(field, s string) float64 {
+	if noNumber(s) {
+		w.add(field, strings.TrimSpace(s), "not a number, read as 0")
+	}
+	return parseFloat64(s)
+}
+
+// noNumber reports whether text that isn't blank or a placeholder ("—",
+// "-0-") has no digits, so parseInt64 and parseFloat64 silently read it as 0
+func noNumber(s string) bool {
+	s = strings.TrimSpace(s)
+	return s != "" && !isPlaceholder(s) && !isZeroPlaceholder(s) && strings.IndexFunc(s, unicode.IsDigit) < 0
+}
+
+// form4Warnings reports Form 4 amounts that aren't numbers; ToOutput leaves
+// them null
+func form4Warnings(f *Form4) []ParseWarning {
+	var w parseWarnings
+	check := func(field string, v Value) {
+		if v.Value == "" {
+			return
+		}
+		if _, err := strconv.ParseFloat(v.Value, 64); err != nil {
+			w.add(field, v.Value, "not a number, left empty")
+		}
+	}
+	if t := f.NonDerivativeTable; t != nil {
+		for i, txn := range t.Transactions {
+			field := fmt.Sprintf("transactions[%d].", i)
+			check(field+"shares", txn.Amounts.Shares)
+			check(field+"pricePerShare", txn.Amounts.PricePerShare)
+			check(field+"sharesOwnedFollowing", txn.PostTransaction.SharesOwnedFollowing)
+			check(field+"valueOwnedFollowing", txn.PostTransaction.ValueOwnedFollowing)
+		}
+		for i, h := range t.Holdings {
+			field := fmt.Sprintf("holdings[%d].", i)
+			check(field+"sharesOwnedFollowing", h.PostTransaction.SharesOwnedFollowing)
+			check(field+"valueOwnedFollowing", h.PostTransaction.ValueOwnedFollowing)
+		}
+	}
+	if t := f.DerivativeTable; t != nil {
+		for i, txn := range t.Transactions {
+			field := fmt.Sprintf("derivatives[%d].", i)
+			check(field+"shares", txn.Amounts.Shares)
+			check(field+"pricePerShare", txn.Amounts.PricePerShare)
+			check(field+"exercisePrice", txn.ConversionOrExercisePrice)
+			check(field+"underlyingShares", txn.UnderlyingSecurity.Shares)
+			check(field+"underlyingValue", txn.UnderlyingSecurity.Value)
+			check(field+"sharesOwnedFollowing", txn.PostTransaction.SharesOwnedFollowing)
+			check(field+"valueOwnedFollowing", txn.PostTransaction.ValueOwnedFollowing)
+		}
+		for i, h := range t.Holdings {
+			field := fmt.Sprintf("derivativeHoldings[%d].", i)
+			check(field+"exercisePrice", h.ConversionOrExercisePrice)
+			check(field+"underlyingShares", h.UnderlyingSecurity.Shares)
+			check(field+"underlyingValue", h.UnderlyingSecurity.Value)
+			check(field+"sharesOwnedFollowing", h.PostTransaction.SharesOwnedFollowing)
+			check(field+"valueOwnedFollowing", h.PostTransaction.ValueOwnedFollowing)
+		}
+	}
+	return w
+}
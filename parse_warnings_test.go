@@ -0,0 +1,108 @@
+package edgar
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseWarnings_Schedule13(t *testing.T) {
+	data, err := os.ReadFile("testdata/schedule13/jushi_13g_xml/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	data = bytes.Replace(data, []byte("<soleVotingPower>10000000.00<"), []byte("<soleVotingPower>n/a<"), 1)
+
+	form, err := ParseAny(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseAny: %v", err)
+	}
+	want := []ParseWarning{{Field: "reportingPersons[0].soleVotingPower", Value: "n/a", Message: "not a number, read as 0"}}
+	if got := form.Warnings(); len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("Warnings = %v, want %v", got, want)
+	}
+	s, _ := form.AsSchedule13()
+	if out := s.ToOutput(); len(out.Warnings) != 1 {
+		t.Errorf("Output warnings = %v, want 1", out.Warnings)
+	}
+
+	// Strict mode rejects the value before parsing
+	if _, err := ParseAnyWithOptions(bytes.NewReader(data), ParseOptions{Strict: true}); !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("Strict parse error = %v, want ErrSchemaViolation", err)
+	}
+}
+
+func TestParseWarnings_Form4(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	data = bytes.Replace(data, []byte("<value>200000</value>"), []byte("<value>abc</value>"), 1)
+
+	form, err := ParseAny(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseAny: %v", err)
+	}
+	out, _ := form.AsForm4Output()
+	if len(out.Warnings) != 1 || out.Warnings[0].Field != "transactions[0].shares" || out.Warnings[0].Value != "abc" {
+		t.Fatalf("Warnings = %v, want transactions[0].shares", out.Warnings)
+	}
+	if out.Transactions[0].Shares != nil {
+		t.Errorf("Shares = %v, want nil", *out.Transactions[0].Shares)
+	}
+	if _, err := ParseAnyWithOptions(bytes.NewReader(data), ParseOptions{Strict: true}); !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("Strict parse error = %v, want ErrSchemaViolation", err)
+	}
+}
+
+func TestParseWarnings_XBRL(t *testing.T) {
+	doc := strings.Replace(ifrsDoc, "</body>", `<ix:nonFraction name="ifrs-full:OtherIncome" contextRef="Missing" unitRef="eur" decimals="-6" scale="6">5</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:Revenue" unitRef="eur" decimals="-6">7</ix:nonFraction>
+</body>`, 1)
+
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	want := []string{
+		`ix:nonFraction: fact without a name or contextRef skipped ("ifrs-full:Revenue")`,
+		`ifrs-full:OtherIncome: context not found, fact has no period ("Missing")`,
+	}
+	var got []string
+	for _, w := range x.Warnings {
+		got = append(got, w.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("Warnings =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if len(snapshot.Warnings) != 2 {
+		t.Errorf("Snapshot warnings = %v, want 2", snapshot.Warnings)
+	}
+
+	// XBRL has no schema check, so strict mode fails on the warnings
+	_, err = ParseAnyWithOptions(strings.NewReader(doc), ParseOptions{Strict: true})
+	if !errors.Is(err, ErrInvalidValue) || !strings.Contains(err.Error(), "(and 1 more)") {
+		t.Errorf("Strict parse error = %v, want ErrInvalidValue", err)
+	}
+
+	// The fixtures are clean
+	for _, file := range []string{"testdata/xbrl/moderna_10k/input.htm", "testdata/form4/wave_derivatives/input.xml", "testdata/schedule13/aadi_13d_xml/input.xml"} {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file, err)
+		}
+		form, err := ParseAnyWithOptions(f, ParseOptions{Strict: true})
+		f.Close()
+		if err != nil {
+			t.Errorf("%s: %v", file, err)
+		} else if w := form.Warnings(); len(w) > 0 {
+			t.Errorf("%s: unexpected warnings %v", file, w)
+		}
+	}
+}
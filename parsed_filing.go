@@ -0,0 +1,74 @@
+package edgar
+
+// ParsedFiling identifies a parsed filing whatever its form type. It is
+// implemented by Form4Output, Schedule13Filing and FinancialSnapshot, and by
+// ParsedForm (for its Data), so pipelines can handle mixed-form batches
+// without a type switch.
+//
+// Filing date and accession number come from the SEC index: they are set in
+// batch mode and empty for documents parsed on their own.
+type ParsedFiling interface {
+	GetFormType() string  // "4", "SC 13D/A", "10-K", ...
+	GetIssuerCIK() string // The company the filing is about
+	GetFilingDate() string
+	GetAccession() string
+}
+
+var (
+	_ ParsedFiling = (*Form4Output)(nil)
+	_ ParsedFiling = (*Schedule13Filing)(nil)
+	_ ParsedFiling = (*FinancialSnapshot)(nil)
+	_ ParsedFiling = (*ParsedForm)(nil)
+)
+
+func (f *Form4Output) GetFormType() string   { return f.Metadata.FormType }
+func (f *Form4Output) GetIssuerCIK() string  { return f.Issuer.CIK }
+func (f *Form4Output) GetFilingDate() string { return f.Metadata.FilingDate }
+func (f *Form4Output) GetAccession() string  { return f.Metadata.AccessionNumber }
+
+func (s *Schedule13Filing) GetFormType() string   { return s.FormType }
+func (s *Schedule13Filing) GetIssuerCIK() string  { return s.IssuerCIK }
+func (s *Schedule13Filing) GetFilingDate() string { return s.FilingDate }
+func (s *Schedule13Filing) GetAccession() string  { return s.AccessionNumber }
+
+func (s *FinancialSnapshot) GetFormType() string   { return s.FormType }
+func (s *FinancialSnapshot) GetIssuerCIK() string  { return s.CIK }
+func (s *FinancialSnapshot) GetFilingDate() string { return s.FilingDate }
+func (s *FinancialSnapshot) GetAccession() string  { return s.AccessionNumber }
+
+// Filing returns the form's data as a ParsedFiling, or nil for data of another
+// type
+func (p *ParsedForm) Filing() ParsedFiling {
+	filing, _ := p.Data.(ParsedFiling)
+	return filing
+}
+
+// GetFormType returns the filing's own form type (e.g. "10-K" rather than
+// ParsedForm.FormType "XBRL"), falling back to ParsedForm.FormType
+func (p *ParsedForm) GetFormType() string {
+	if f := p.Filing(); f != nil && f.GetFormType() != "" {
+		return f.GetFormType()
+	}
+	return p.FormType
+}
+
+func (p *ParsedForm) GetIssuerCIK() string {
+	if f := p.Filing(); f != nil {
+		return f.GetIssuerCIK()
+	}
+	return ""
+}
+
+func (p *ParsedForm) GetFilingDate() string {
+	if f := p.Filing(); f != nil {
+		return f.GetFilingDate()
+	}
+	return ""
+}
+
+func (p *ParsedForm) GetAccession() string {
+	if f := p.Filing(); f != nil {
+		return f.GetAccession()
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package edgar
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParsedFiling_MixedBatch(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	filings := []struct {
+		filing Filing
+		path   string
+	}{
+		{Filing{CIK: "1640147", AccessionNumber: "0001640147-25-000001", Form: "4", FilingDate: "2025-03-01"}, "testdata/form4/snow/input.xml"},
+		{Filing{CIK: "1640147", AccessionNumber: "0001104659-24-130001", Form: "SC 13D", FilingDate: "2024-12-20"}, "testdata/schedule13/aadi_13d_xml/input.xml"},
+	}
+	var accessions []string
+	for _, f := range filings {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", f.path, err)
+		}
+		if err := store.Put(f.filing, data); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+		accessions = append(accessions, f.filing.AccessionNumber)
+	}
+
+	result, err := FetchAndParseBatch(BatchOptions{CIK: "1640147", Accessions: accessions, Store: store, Offline: true, Logger: DiscardLogger()})
+	if err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+	if len(result.Filings) != 2 {
+		t.Fatalf("Expected 2 filings, got %d (errors: %v)", len(result.Filings), result.Errors)
+	}
+
+	// Newest first, as listed in the store
+	want := []struct{ form, date, accession string }{
+		{"4", "2025-03-01", "0001640147-25-000001"},
+		{"SC 13D", "2024-12-20", "0001104659-24-130001"},
+	}
+	for i, parsed := range result.Filings {
+		for _, f := range []ParsedFiling{parsed, parsed.Filing()} {
+			if f.GetFormType() != want[i].form || f.GetFilingDate() != want[i].date || f.GetAccession() != want[i].accession {
+				t.Errorf("Filing %d (%T) = %s %s %s, want %s %s %s", i, f, f.GetFormType(), f.GetFilingDate(), f.GetAccession(),
+					want[i].form, want[i].date, want[i].accession)
+			}
+			if f.GetIssuerCIK() == "" {
+				t.Errorf("Filing %d (%T): empty issuer CIK", i, f)
+			}
+		}
+	}
+
+	snapshot := &FinancialSnapshot{FormType: "10-K", CIK: "0001682852", FilingDate: "2025-02-21", AccessionNumber: "0001682852-25-000011"}
+	parsed := &ParsedForm{FormType: "XBRL", Data: snapshot}
+	if parsed.GetFormType() != "10-K" || parsed.GetIssuerCIK() != "0001682852" || parsed.GetAccession() != "0001682852-25-000011" {
+		t.Errorf("Unexpected snapshot identity %s %s %s", parsed.GetFormType(), parsed.GetIssuerCIK(), parsed.GetAccession())
+	}
+	if other := (&ParsedForm{FormType: "13F", Data: "table"}); other.Filing() != nil || other.GetFormType() != "13F" {
+		t.Errorf("Expected no ParsedFiling for unknown data, got %v", other.Filing())
+	}
+}
@@ -1,6 +1,7 @@
 package edgar
 
 import (
+	"bufio"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -13,38 +14,127 @@ type ParsedForm struct {
 	Data     interface{} `json:"data"`
 }
 
+// AsForm4Output returns the data of a Form 4 (including text Form 4s)
+func (p *ParsedForm) AsForm4Output() (*Form4Output, bool) {
+	f4, ok := p.Data.(*Form4Output)
+	return f4, ok
+}
+
+// AsSchedule13 returns the data of a Schedule 13D/G or its amendment
+func (p *ParsedForm) AsSchedule13() (*Schedule13Filing, bool) {
+	sc13, ok := p.Data.(*Schedule13Filing)
+	return sc13, ok
+}
+
+// outputForm returns the form as it is written out: a Schedule 13D/G filing
+// becomes its Schedule13Output, as a Form 4 is already a Form4Output
+func (p *ParsedForm) outputForm() *ParsedForm {
+	if sc13, ok := p.Data.(*Schedule13Filing); ok {
+		return &ParsedForm{FormType: p.FormType, Data: sc13.ToOutput()}
+	}
+	return p
+}
+
+// AsSnapshot returns the financial snapshot of a 10-K/10-Q (form type "XBRL")
+func (p *ParsedForm) AsSnapshot() (*FinancialSnapshot, bool) {
+	snapshot, ok := p.Data.(*FinancialSnapshot)
+	return snapshot, ok
+}
+
+// detectWindow is how much of a document is inspected before deciding to stream
+// it: the ix namespace is declared on the root html element
+const detectWindow = 64 << 10
+
 // ParseAny auto-detects the form type and parses accordingly
 func ParseAny(r io.Reader) (*ParsedForm, error) {
+	return ParseAnyWithProfile(r, "")
+}
+
+// ParseAnyWithProfile is ParseAny with 10-K/10-Q facts labeled by an industry
+// concept mapping profile (see XBRL.ApplyProfile); "" uses the default mappings
+//
+// Inline XBRL documents (10-K, 10-Q) are recognized from their first bytes and
+// parsed as they are read, so large filings are never held in memory; other
+// forms are read in full first.
+func ParseAnyWithProfile(r io.Reader, profile string) (*ParsedForm, error) {
+	return ParseAnyWithOptions(r, ParseOptions{Profile: profile})
+}
+
+// ParseOptions configures ParseAnyWithOptions
+type ParseOptions struct {
+	Profile string // Industry concept mapping profile for 10-K/10-Q facts ("" for the defaults)
+
+	// Strict validates ownership (Form 3, 4, 5) and Schedule 13D/G XML against
+	// the SEC schema rules before parsing (see ValidateXML), failing with a
+	// *SchemaError instead of returning a partially empty form, and fails
+	// forms with warnings (ParsedForm.Warnings) with ErrInvalidValue.
+	// Otherwise (lenient mode) such values are coerced or skipped and
+	// recorded as warnings.
+	Strict bool
+}
+
+// ParseAnyWithOptions is ParseAny with options (see ParseOptions)
+func ParseAnyWithOptions(r io.Reader, opts ParseOptions) (*ParsedForm, error) {
+	form, err := parseAny(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Strict {
+		if err := strictWarnings(form); err != nil {
+			return nil, err
+		}
+	}
+	return form, nil
+}
+
+func parseAny(r io.Reader, opts ParseOptions) (*ParsedForm, error) {
+	profile := opts.Profile
+	br := bufio.NewReaderSize(r, detectWindow)
+	head, err := br.Peek(detectWindow)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if !isZip(head) && DetectXBRLType(head) == "inline" {
+		xbrl, err := ParseInlineXBRLReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse XBRL: %w", err)
+		}
+		return xbrlSnapshotForm(xbrl, profile)
+	}
+
 	// Read all data
-	data, err := io.ReadAll(r)
+	data, err := io.ReadAll(br)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read input: %w", err)
 	}
 
 	// First check if it's XBRL (10-K, 10-Q, etc.)
 	// IMPORTANT: Check XBRL BEFORE normalization because XML entities should be handled by XML parser
+	// An XBRL archive (-xbrl.zip) needs random access, so it is read first too
 	xbrlType := DetectXBRLType(data)
-	if xbrlType == "inline" || xbrlType == "standalone" {
-		// Parse XBRL
-		xbrl, err := ParseXBRLAuto(data)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse XBRL: %w", err)
-		}
+	if isZip(data) || xbrlType == "inline" || xbrlType == "standalone" {
+		return parseXBRLForm(data, profile)
+	}
 
-		// Extract snapshot
-		snapshot, err := xbrl.GetSnapshot()
+	// Legacy (pre-XML) text Form 4
+	if isForm4Text(data) {
+		form4, err := ParseForm4Text(data)
 		if err != nil {
-			return nil, fmt.Errorf("failed to extract financial snapshot: %w", err)
+			return nil, fmt.Errorf("failed to parse text Form 4: %w", err)
 		}
-
-		// Determine form type from XBRL (10-K, 10-Q, etc.)
-		// For now, just return as "10-K/10-Q" - we could extract this from DEI facts
 		return &ParsedForm{
-			FormType: "XBRL",
-			Data:     snapshot,
+			FormType: "4",
+			Data:     form4,
 		}, nil
 	}
 
+	// Strict mode rejects XML that breaks its schema before parsing
+	if opts.Strict {
+		if err := validateStrict(data); err != nil {
+			return nil, err
+		}
+	}
+
 	// Not XBRL, try ownership forms (Form 4, etc.)
 	formType, err := detectFormType(data)
 	if err != nil {
@@ -78,10 +168,6 @@ func ParseAny(r io.Reader) (*ParsedForm, error) {
 			Data:     form4.ToOutput(),
 		}, nil
 	case "SC 13D", "SC 13D/A", "SC 13G", "SC 13G/A":
-		// Normalize text for Schedule 13 forms (handles non-breaking spaces, HTML entities)
-		// This is critical for HTML parsing where &nbsp; appears in item headings
-		data = NormalizeText(data)
-
 		// Use auto-detection for 13D/G (handles both XML and HTML)
 		sc13, err := ParseSchedule13Auto(data)
 		if err != nil {
@@ -92,7 +178,7 @@ func ParseAny(r io.Reader) (*ParsedForm, error) {
 			Data:     sc13,
 		}, nil
 	default:
-		return nil, fmt.Errorf("form type %s not yet supported", formType)
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedForm, formType)
 	}
 }
 
@@ -115,7 +201,7 @@ func detectFormType(data []byte) (string, error) {
 			}
 			return "SC 13G", nil
 		}
-		return "", fmt.Errorf("HTML form type not recognized")
+		return "", fmt.Errorf("%w: HTML form type not recognized", ErrUnsupportedForm)
 	}
 
 	// Try XML parsing for pure XML forms
@@ -157,7 +243,7 @@ func detectFormType(data []byte) (string, error) {
 		} else if check.XMLName.Space == "http://www.sec.gov/edgar/schedule13g" {
 			return check.SubmissionType, nil // "SCHEDULE 13G" or "SCHEDULE 13G/A"
 		}
-		return "", fmt.Errorf("edgarSubmission forms with namespace '%s' not yet supported", check.XMLName.Space)
+		return "", fmt.Errorf("%w: edgarSubmission with namespace '%s'", ErrUnsupportedForm, check.XMLName.Space)
 	case "html":
 		// XHTML rendered forms (Schedule 13D/G, etc.)
 		// Check for namespace declarations to identify form type
@@ -173,8 +259,36 @@ func detectFormType(data []byte) (string, error) {
 			}
 			return "SC 13G", nil
 		}
-		return "", fmt.Errorf("HTML form type not recognized")
+		return "", fmt.Errorf("%w: HTML form type not recognized", ErrUnsupportedForm)
 	default:
-		return "", fmt.Errorf("unknown form type with root element: %s", check.XMLName.Local)
+		return "", fmt.Errorf("%w: root element %s", ErrUnsupportedForm, check.XMLName.Local)
+	}
+}
+
+// parseXBRLForm parses a 10-K/10-Q document into a financial snapshot, labeling
+// facts with the given concept mapping profile ("" for the default mappings)
+func parseXBRLForm(data []byte, profile string) (*ParsedForm, error) {
+	xbrl, err := ParseXBRLAuto(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse XBRL: %w", err)
+	}
+	return xbrlSnapshotForm(xbrl, profile)
+}
+
+// xbrlSnapshotForm extracts the financial snapshot of a parsed XBRL document
+func xbrlSnapshotForm(xbrl *XBRL, profile string) (*ParsedForm, error) {
+	if profile != "" {
+		if err := xbrl.ApplyProfile(profile); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshot, err := xbrl.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract financial snapshot: %w", err)
 	}
+	return &ParsedForm{
+		FormType: "XBRL",
+		Data:     snapshot,
+	}, nil
 }
@@ -1,8 +1,13 @@
 package edgar
 
 import (
+	"bytes"
+	"io"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestDetectFormType(t *testing.T) {
@@ -13,12 +18,12 @@ func TestDetectFormType(t *testing.T) {
 	}{
 		{
 			name:     "Schedule 13D",
-			filepath: "/home/nick/projects/port-edgartools/edgartools/tests/data/beneficial_ownership/schedule13d.xml",
+			filepath: "testdata/schedule13/aadi_13d_xml/input.xml",
 			want:     "SCHEDULE 13D",
 		},
 		{
 			name:     "Schedule 13G",
-			filepath: "/home/nick/projects/port-edgartools/edgartools/tests/data/beneficial_ownership/schedule13g.xml",
+			filepath: "testdata/schedule13/jushi_13g_xml/input.xml",
 			want:     "SCHEDULE 13G",
 		},
 	}
@@ -41,3 +46,174 @@ func TestDetectFormType(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAnyStreamsInlineXBRL(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	want, err := parseXBRLForm(data, "")
+	if err != nil {
+		t.Fatalf("parseXBRLForm: %v", err)
+	}
+
+	// One byte at a time, as a slow network body would deliver it
+	got, err := ParseAny(&oneByteReader{r: bytes.NewReader(data)})
+	if err != nil {
+		t.Fatalf("ParseAny: %v", err)
+	}
+	gotSnap, _ := got.AsSnapshot()
+	wantSnap, _ := want.AsSnapshot()
+	if got.FormType != "XBRL" || value(gotSnap.Revenue) != value(wantSnap.Revenue) || value(gotSnap.Cash) != value(wantSnap.Cash) {
+		t.Errorf("streamed parse = %s revenue %v cash %v, want %s revenue %v cash %v",
+			got.FormType, value(gotSnap.Revenue), value(gotSnap.Cash), want.FormType, value(wantSnap.Revenue), value(wantSnap.Cash))
+	}
+
+	// Inline markers past the detection window are still found in the full read
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml"><body>` + strings.Repeat("<p>filler</p>", detectWindow/10) +
+		`<div xmlns:ix="http://www.xbrl.org/2013/inlineXBRL" xmlns:us-gaap="http://fasb.org/us-gaap/2024">` +
+		`<ix:nonFraction name="us-gaap:Cash" contextRef="c-1" unitRef="usd" decimals="0">5</ix:nonFraction></div></body></html>`
+	form, err := ParseAny(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ParseAny with late inline markers: %v", err)
+	}
+	if form.FormType != "XBRL" {
+		t.Errorf("FormType = %q, want XBRL", form.FormType)
+	}
+}
+
+func TestParsedFormAccessors(t *testing.T) {
+	tests := []struct {
+		path     string
+		form4    bool
+		sc13     bool
+		snapshot bool
+	}{
+		{"testdata/form4/snow/input.xml", true, false, false},
+		{"testdata/schedule13/aadi_13d_xml/input.xml", false, true, false},
+		{"testdata/xbrl/moderna_10k/input.htm", false, false, true},
+	}
+	for _, tt := range tests {
+		f, err := os.Open(tt.path)
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", tt.path, err)
+		}
+		form, err := ParseAny(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("ParseAny(%s): %v", tt.path, err)
+		}
+
+		f4, ok := form.AsForm4Output()
+		if ok != tt.form4 || (f4 != nil) != tt.form4 {
+			t.Errorf("%s: AsForm4Output ok = %v, want %v", tt.path, ok, tt.form4)
+		}
+		sc13, ok := form.AsSchedule13()
+		if ok != tt.sc13 || (sc13 != nil) != tt.sc13 {
+			t.Errorf("%s: AsSchedule13 ok = %v, want %v", tt.path, ok, tt.sc13)
+		}
+		snapshot, ok := form.AsSnapshot()
+		if ok != tt.snapshot || (snapshot != nil) != tt.snapshot {
+			t.Errorf("%s: AsSnapshot ok = %v, want %v", tt.path, ok, tt.snapshot)
+		}
+	}
+}
+
+func TestReaderParsers(t *testing.T) {
+	read := func(path string) []byte {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return data
+	}
+	// Each reader variant must match its []byte counterpart, one byte at a time
+	slow := func(data []byte) io.Reader { return &oneByteReader{r: bytes.NewReader(data)} }
+
+	form4 := read("testdata/form4/snow/input.xml")
+	want4, err := Parse(form4)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	got4, err := ParseReader(slow(form4))
+	if err != nil {
+		t.Fatalf("ParseReader: %v", err)
+	}
+	if diff := cmp.Diff(want4, got4); diff != "" {
+		t.Errorf("ParseReader mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, path := range []string{
+		"testdata/schedule13/aadi_13d_xml/input.xml",
+		"testdata/schedule13/jushi_13g_xml/input.xml",
+		"testdata/schedule13/13d_2024_1/input.htm",
+	} {
+		data := read(path)
+		want, err := ParseSchedule13Auto(data)
+		if err != nil {
+			t.Fatalf("ParseSchedule13Auto(%s): %v", path, err)
+		}
+		got, err := ParseSchedule13Reader(slow(data))
+		if err != nil {
+			t.Fatalf("ParseSchedule13Reader(%s): %v", path, err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ParseSchedule13Reader(%s) mismatch (-want +got):\n%s", path, diff)
+		}
+	}
+
+	standalone := []byte(`<?xml version="1.0"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:us-gaap="http://fasb.org/us-gaap/2024">
+  <xbrli:context id="FY2024"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0001682852</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <us-gaap:Revenues contextRef="FY2024" unitRef="usd" decimals="-6">3236000000</us-gaap:Revenues>
+</xbrli:xbrl>`)
+	for _, data := range [][]byte{standalone, read("testdata/xbrl/moderna_10k/input.htm")} {
+		want, err := ParseXBRLAuto(data)
+		if err != nil {
+			t.Fatalf("ParseXBRLAuto: %v", err)
+		}
+		got, err := ParseXBRLAutoReader(slow(data))
+		if err != nil {
+			t.Fatalf("ParseXBRLAutoReader: %v", err)
+		}
+		if len(got.Facts) == 0 || len(got.Contexts) != len(want.Contexts) || len(got.Units) != len(want.Units) {
+			t.Errorf("ParseXBRLAutoReader: %d facts, %d contexts, %d units; want %d, %d, %d",
+				len(got.Facts), len(got.Contexts), len(got.Units), len(want.Facts), len(want.Contexts), len(want.Units))
+		}
+		if diff := cmp.Diff(want.Facts, got.Facts); diff != "" {
+			t.Errorf("ParseXBRLAutoReader facts mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	if _, err := ParseXBRLReader(strings.NewReader(`<?xml version="1.0"?><ownershipDocument/>`)); err == nil {
+		t.Error("Expected an error for a document without an xbrl root")
+	}
+}
+
+// oneByteReader returns at most one byte per Read
+type oneByteReader struct{ r io.Reader }
+
+func (o *oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return o.r.Read(p[:1])
+}
+
+// BenchmarkParseAnyInlineXBRL benchmarks 10-K parsing from a reader
+func BenchmarkParseAnyInlineXBRL(b *testing.B) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		b.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseAny(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
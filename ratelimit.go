@@ -0,0 +1,47 @@
+package edgar
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces request starts at least an interval apart; it is safe
+// for concurrent use
+//
+// Each call reserves the next free slot under the lock and sleeps outside
+// it, so concurrent callers queue up in order without holding the lock.
+type rateLimiter struct {
+	mu   sync.Mutex
+	next time.Time // Earliest start of the next request
+
+	now   func() time.Time    // nil uses time.Now (tests use a fake clock)
+	sleep func(time.Duration) // nil uses time.Sleep
+}
+
+// defaultLimiter is shared by the package-level fetch functions, so
+// successive calls such as FetchForm(url, email) stay within the SEC limit
+var defaultLimiter = &rateLimiter{}
+
+// wait blocks until a request may start, at least interval after the
+// previously reserved one
+func (l *rateLimiter) wait(interval time.Duration) {
+	l.mu.Lock()
+	now := time.Now()
+	if l.now != nil {
+		now = l.now()
+	}
+	start := now
+	if l.next.After(now) {
+		start = l.next
+	}
+	l.next = start.Add(interval)
+	l.mu.Unlock()
+
+	if d := start.Sub(now); d > 0 {
+		if l.sleep != nil {
+			l.sleep(d)
+		} else {
+			time.Sleep(d)
+		}
+	}
+}
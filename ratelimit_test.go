@@ -0,0 +1,100 @@
+package edgar
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manual clock that records sleeps instead of blocking
+type fakeClock struct {
+	mu     sync.Mutex
+	t      time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func TestRateLimiter(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	l := &rateLimiter{now: clock.now, sleep: clock.sleep}
+
+	l.wait(RateLimit) // First request starts immediately
+	clock.advance(30 * time.Millisecond)
+	l.wait(RateLimit) // 70ms left of the interval
+	clock.advance(70 * time.Millisecond)
+	clock.advance(time.Second)
+	l.wait(RateLimit) // Idle long enough: no wait
+	l.wait(250 * time.Millisecond)
+	clock.advance(100 * time.Millisecond)
+	l.wait(RateLimit) // The previous request asked for a longer gap
+
+	want := []time.Duration{70 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond}
+	if len(clock.sleeps) != len(want) {
+		t.Fatalf("Sleeps = %v, want %v", clock.sleeps, want)
+	}
+	for i := range want {
+		if clock.sleeps[i] != want[i] {
+			t.Errorf("Sleep %d = %v, want %v", i, clock.sleeps[i], want[i])
+		}
+	}
+}
+
+func TestRateLimiter_Concurrent(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	l := &rateLimiter{now: clock.now, sleep: clock.sleep}
+
+	// Requests made at the same instant are queued one interval apart
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.wait(RateLimit)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(clock.sleeps, func(i, j int) bool { return clock.sleeps[i] < clock.sleeps[j] })
+	if len(clock.sleeps) != 9 {
+		t.Fatalf("Expected 9 of 10 requests to wait, got %v", clock.sleeps)
+	}
+	for i, d := range clock.sleeps {
+		if want := time.Duration(i+1) * RateLimit; d != want {
+			t.Errorf("Wait %d = %v, want %v", i, d, want)
+		}
+	}
+}
+
+func TestClient_RateLimiterOwnership(t *testing.T) {
+	a, b := NewClient("jane@acme.test"), NewClient("jane@acme.test")
+	if a.rateLimiter() == nil || a.rateLimiter() != a.rateLimiter() {
+		t.Fatal("Expected a Client to keep one limiter")
+	}
+	if a.rateLimiter() == b.rateLimiter() {
+		t.Error("Expected separate Clients to have separate limiters")
+	}
+	if defaultClient("a@acme.test").rateLimiter() != defaultClient("b@acme.test").rateLimiter() {
+		t.Error("Expected the package-level functions to share one limiter")
+	}
+	if (&Client{Email: "jane@acme.test"}).rateLimiter() == nil {
+		t.Error("Expected a Client literal to get a limiter")
+	}
+}
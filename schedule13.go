@@ -1,8 +1,10 @@
 package edgar
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -19,11 +21,13 @@ type Schedule13Filing struct {
 	IsAmendment     bool   // true if contains "/A"
 	AmendmentNumber *int   // nil for original, 1, 2, 3... for numbered amendments
 	FilingDate      string // From filing metadata (not in XML)
+	AccessionNumber string // From filing metadata (not in XML)
 
 	// Issuer (company being reported on)
-	IssuerCIK   string
-	IssuerName  string
-	IssuerCUSIP string
+	IssuerCIK        string
+	IssuerName       string
+	IssuerCUSIP      string
+	IssuerCUSIPValid bool // false when the CUSIP is malformed or fails its check digit
 
 	// Security information
 	SecurityTitle string
@@ -45,6 +49,18 @@ type Schedule13Filing struct {
 
 	// Filer CIK from header (fallback when reportingPersonCIK is missing)
 	FilerCIK string
+
+	// How each field was extracted from an HTML filing (nil for XML filings)
+	Extraction *ExtractionConfidence
+
+	// Exhibits attached with AttachExhibits (not part of the primary document)
+	Exhibits []Exhibit
+
+	// Stated vs recomputed percent of class, set by EnrichPercentOfClass
+	PercentCheck *PercentOfClassCheck
+
+	// Cover page numbers that couldn't be read and were taken as 0
+	Warnings []ParseWarning
 }
 
 // ReportingPerson13 represents an individual or entity reporting beneficial ownership.
@@ -80,79 +96,79 @@ type ReportingPerson13 struct {
 // Item 4 (Purpose of Transaction) is the most important for activist analysis.
 type Schedule13DItems struct {
 	// Item 1: Security and Issuer
-	Item1SecurityTitle string
-	Item1IssuerName    string
-	Item1IssuerAddress string
+	Item1SecurityTitle string `json:"item1SecurityTitle,omitempty"`
+	Item1IssuerName    string `json:"item1IssuerName,omitempty"`
+	Item1IssuerAddress string `json:"item1IssuerAddress,omitempty"`
 
 	// Item 2: Identity and Background
-	Item2FilingPersons       string
-	Item2BusinessAddress     string
-	Item2PrincipalOccupation string
-	Item2Convictions         string
-	Item2Citizenship         string
+	Item2FilingPersons       string `json:"item2FilingPersons,omitempty"`
+	Item2BusinessAddress     string `json:"item2BusinessAddress,omitempty"`
+	Item2PrincipalOccupation string `json:"item2PrincipalOccupation,omitempty"`
+	Item2Convictions         string `json:"item2Convictions,omitempty"`
+	Item2Citizenship         string `json:"item2Citizenship,omitempty"`
 
 	// Item 3: Source and Amount of Funds
-	Item3SourceOfFunds string
+	Item3SourceOfFunds string `json:"item3SourceOfFunds,omitempty"`
 
 	// Item 4: Purpose of Transaction (MOST IMPORTANT)
 	// Contains activist intent, board letters, future plans, etc.
-	Item4PurposeOfTransaction string
+	Item4PurposeOfTransaction string `json:"item4PurposeOfTransaction,omitempty"`
 
 	// Item 5: Interest in Securities of the Issuer
-	Item5PercentageOfClass string
-	Item5NumberOfShares    string
-	Item5Transactions      string
-	Item5Shareholders      string
-	Item5Date5PctOwnership string
+	Item5PercentageOfClass string `json:"item5PercentageOfClass,omitempty"`
+	Item5NumberOfShares    string `json:"item5NumberOfShares,omitempty"`
+	Item5Transactions      string `json:"item5Transactions,omitempty"`
+	Item5Shareholders      string `json:"item5Shareholders,omitempty"`
+	Item5Date5PctOwnership string `json:"item5Date5PctOwnership,omitempty"`
 
 	// Item 6: Contracts, Arrangements, Understandings
-	Item6Contracts string
+	Item6Contracts string `json:"item6Contracts,omitempty"`
 
 	// Item 7: Material to be Filed as Exhibits
-	Item7Exhibits string
+	Item7Exhibits string `json:"item7Exhibits,omitempty"`
 }
 
 // Schedule13GItems contains Items 1-10 from Schedule 13G.
 // Item 10 (Certification) is key - certifies passive investor status.
 type Schedule13GItems struct {
 	// Item 1: Name and address of issuer
-	Item1IssuerName    string
-	Item1IssuerAddress string
+	Item1IssuerName    string `json:"item1IssuerName,omitempty"`
+	Item1IssuerAddress string `json:"item1IssuerAddress,omitempty"`
 
 	// Item 2: Name and address of person filing
-	Item2FilerNames     string
-	Item2FilerAddresses string
-	Item2Citizenship    string
+	Item2FilerNames     string `json:"item2FilerNames,omitempty"`
+	Item2FilerAddresses string `json:"item2FilerAddresses,omitempty"`
+	Item2Citizenship    string `json:"item2Citizenship,omitempty"`
 
 	// Item 3: If applicable (usually N/A)
-	Item3NotApplicable bool
+	Item3NotApplicable bool `json:"item3NotApplicable,omitempty"`
 
 	// Item 4: Ownership
-	Item4AmountBeneficiallyOwned string
-	Item4PercentOfClass          string
-	Item4SoleVoting              string
-	Item4SharedVoting            string
-	Item4SoleDispositive         string
-	Item4SharedDispositive       string
+	Item4AmountBeneficiallyOwned string `json:"item4AmountBeneficiallyOwned,omitempty"`
+	Item4PercentOfClass          string `json:"item4PercentOfClass,omitempty"`
+	Item4SoleVoting              string `json:"item4SoleVoting,omitempty"`
+	Item4SharedVoting            string `json:"item4SharedVoting,omitempty"`
+	Item4SoleDispositive         string `json:"item4SoleDispositive,omitempty"`
+	Item4SharedDispositive       string `json:"item4SharedDispositive,omitempty"`
 
 	// Item 5: Ownership of 5% or less
-	Item5NotApplicable       bool
-	Item5Ownership5PctOrLess string
+	Item5NotApplicable       bool   `json:"item5NotApplicable,omitempty"`
+	Item5Ownership5PctOrLess string `json:"item5Ownership5PctOrLess,omitempty"`
 
 	// Item 6: Ownership of more than 5%
-	Item6NotApplicable bool
+	Item6NotApplicable bool `json:"item6NotApplicable,omitempty"`
 
 	// Item 7: Identification and classification
-	Item7NotApplicable bool
+	Item7NotApplicable bool `json:"item7NotApplicable,omitempty"`
 
 	// Item 8: Identification and classification of members
-	Item8NotApplicable bool
+	Item8NotApplicable bool `json:"item8NotApplicable,omitempty"`
 
 	// Item 9: Notice pursuant to Rule 13d-1(k)
-	Item9NotApplicable bool
+	Item9NotApplicable bool `json:"item9NotApplicable,omitempty"`
 
 	// Item 10: Certification (important - passive investor cert)
-	Item10Certification string
+	Item10Certification string `json:"item10Certification,omitempty"`
 }
 
 // TotalVotingPower returns total voting power (sole + shared).
@@ -352,8 +368,14 @@ type schedule13DItems1To7 struct {
 
 // ParseSchedule13D parses a Schedule 13D XML filing.
 func ParseSchedule13D(data []byte) (*Schedule13Filing, error) {
+	return ParseSchedule13DReader(bytes.NewReader(data))
+}
+
+// ParseSchedule13DReader parses a Schedule 13D XML filing from a reader as it
+// is read
+func ParseSchedule13DReader(r io.Reader) (*Schedule13Filing, error) {
 	var xmlDoc schedule13DXML
-	if err := xml.Unmarshal(data, &xmlDoc); err != nil {
+	if err := xml.NewDecoder(r).Decode(&xmlDoc); err != nil {
 		return nil, fmt.Errorf("failed to parse Schedule 13D XML: %w", err)
 	}
 
@@ -362,17 +384,19 @@ func ParseSchedule13D(data []byte) (*Schedule13Filing, error) {
 		FilerCIK:        xmlDoc.HeaderData.FilerInfo.Filer.FilerCredentials.CIK,
 		IssuerCIK:       xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerCIK,
 		IssuerName:      xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerName,
-		IssuerCUSIP:     xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerCUSIP,
 		SecurityTitle:   xmlDoc.FormData.CoverPageHeader.SecuritiesClassTitle,
 		DateOfEvent:     xmlDoc.FormData.CoverPageHeader.DateOfEvent,
 		PreviouslyFiled: strings.ToUpper(xmlDoc.FormData.CoverPageHeader.PreviouslyFiledFlag) == "TRUE",
 	}
 
+	filing.setIssuerCUSIP(xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerCUSIP)
+
 	// Extract amendment info
 	filing.IsAmendment, filing.AmendmentNumber = ExtractAmendmentInfo(filing.FormType)
 
 	// Parse reporting persons
-	for _, personXML := range xmlDoc.FormData.ReportingPersons.ReportingPersonInfo {
+	var warnings parseWarnings
+	for i, personXML := range xmlDoc.FormData.ReportingPersons.ReportingPersonInfo {
 		person := ReportingPerson13{
 			CIK:                   personXML.ReportingPersonCIK,
 			Name:                  personXML.ReportingPersonName,
@@ -386,12 +410,13 @@ func ParseSchedule13D(data []byte) (*Schedule13Filing, error) {
 		}
 
 		// Parse numeric fields
-		person.SoleVotingPower = parseInt64(personXML.SoleVotingPower)
-		person.SharedVotingPower = parseInt64(personXML.SharedVotingPower)
-		person.SoleDispositivePower = parseInt64(personXML.SoleDispositivePower)
-		person.SharedDispositivePower = parseInt64(personXML.SharedDispositivePower)
-		person.AggregateAmountOwned = parseInt64(personXML.AggregateAmountOwned)
-		person.PercentOfClass = parseFloat64(personXML.PercentOfClass)
+		field := fmt.Sprintf("reportingPersons[%d].", i)
+		person.SoleVotingPower = warnings.int64(field+"soleVotingPower", personXML.SoleVotingPower)
+		person.SharedVotingPower = warnings.int64(field+"sharedVotingPower", personXML.SharedVotingPower)
+		person.SoleDispositivePower = warnings.int64(field+"soleDispositivePower", personXML.SoleDispositivePower)
+		person.SharedDispositivePower = warnings.int64(field+"sharedDispositivePower", personXML.SharedDispositivePower)
+		person.AggregateAmountOwned = warnings.int64(field+"aggregateAmountOwned", personXML.AggregateAmountOwned)
+		person.PercentOfClass = warnings.float64(field+"percentOfClass", personXML.PercentOfClass)
 
 		// Fallback to filer CIK if reporting person CIK is empty
 		if person.CIK == "" && !person.NoCIK {
@@ -400,6 +425,7 @@ func ParseSchedule13D(data []byte) (*Schedule13Filing, error) {
 
 		filing.ReportingPersons = append(filing.ReportingPersons, person)
 	}
+	filing.Warnings = warnings
 
 	// Parse Items 1-7
 	items := &Schedule13DItems{
@@ -428,66 +454,78 @@ func ParseSchedule13D(data []byte) (*Schedule13Filing, error) {
 
 // Helper functions for parsing numeric values
 
+var (
+	intPattern   = regexp.MustCompile(`[0-9,]+`)
+	floatPattern = regexp.MustCompile(`[0-9,]+\.?[0-9]*`)
+)
+
 func parseInt64(s string) int64 {
-	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(cleanNumberText(s))
 	if s == "" {
 		return 0
 	}
 
-	// Handle "-0-" as zero
-	if strings.Contains(s, "-0-") {
+	// Handle "-0-" and dash placeholders ("—") as zero
+	if isZeroPlaceholder(s) || isPlaceholder(s) {
 		return 0
 	}
 
 	// Extract first number from string (handles cases like "1,874,978 6" or "text 123,456 more text")
-	re := regexp.MustCompile(`[0-9,]+`)
-	match := re.FindString(s)
-	if match == "" {
+	loc := intPattern.FindStringIndex(s)
+	if loc == nil {
 		return 0
 	}
 
 	// Remove commas
-	match = strings.ReplaceAll(match, ",", "")
-
-	// Parse as int
-	if val, err := strconv.ParseInt(match, 10, 64); err == nil {
-		return val
+	match := strings.ReplaceAll(s[loc[0]:loc[1]], ",", "")
+
+	// Parse as int, falling back to float
+	val, err := strconv.ParseInt(match, 10, 64)
+	if err != nil {
+		f, err := strconv.ParseFloat(match, 64)
+		if err != nil {
+			return 0
+		}
+		val = int64(f)
 	}
 
-	// Fallback: try parsing as float and convert
-	if f, err := strconv.ParseFloat(match, 64); err == nil {
-		return int64(f)
+	// "(1,234)" and "-1,234" are negative
+	if isNegative(s, loc[0], loc[1]) {
+		return -val
 	}
-
-	return 0
+	return val
 }
 
 func parseFloat64(s string) float64 {
-	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(cleanNumberText(s))
 	if s == "" {
 		return 0.0
 	}
 
-	// Handle "-0-" as zero
-	if strings.Contains(s, "-0-") {
+	// Handle "-0-" and dash placeholders ("—") as zero
+	if isZeroPlaceholder(s) || isPlaceholder(s) {
 		return 0.0
 	}
 
 	// Extract first number from string (handles "5.1% (1)" or "text 12.34 more text")
-	re := regexp.MustCompile(`[0-9,]+\.?[0-9]*`)
-	match := re.FindString(s)
-	if match == "" {
+	loc := floatPattern.FindStringIndex(s)
+	if loc == nil {
 		return 0.0
 	}
 
 	// Remove commas
-	match = strings.ReplaceAll(match, ",", "")
+	match := strings.ReplaceAll(s[loc[0]:loc[1]], ",", "")
 
-	if f, err := strconv.ParseFloat(match, 64); err == nil {
-		return f
+	f, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0.0
 	}
 
-	return 0.0
+	// "(1.5)%" and "-1.5" are negative
+	if isNegative(s, loc[0], loc[1]) {
+		return -f
+	}
+	return f
 }
 
 // XML parsing structures for Schedule 13G
@@ -595,8 +633,14 @@ type schedule13GItems struct {
 
 // ParseSchedule13G parses a Schedule 13G XML filing.
 func ParseSchedule13G(data []byte) (*Schedule13Filing, error) {
+	return ParseSchedule13GReader(bytes.NewReader(data))
+}
+
+// ParseSchedule13GReader parses a Schedule 13G XML filing from a reader as it
+// is read
+func ParseSchedule13GReader(r io.Reader) (*Schedule13Filing, error) {
 	var xmlDoc schedule13GXML
-	if err := xml.Unmarshal(data, &xmlDoc); err != nil {
+	if err := xml.NewDecoder(r).Decode(&xmlDoc); err != nil {
 		return nil, fmt.Errorf("failed to parse Schedule 13G XML: %w", err)
 	}
 
@@ -605,17 +649,19 @@ func ParseSchedule13G(data []byte) (*Schedule13Filing, error) {
 		FilerCIK:         xmlDoc.HeaderData.FilerInfo.Filer.FilerCredentials.CIK,
 		IssuerCIK:        xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerCik,
 		IssuerName:       xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerName,
-		IssuerCUSIP:      xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerCusip,
 		SecurityTitle:    xmlDoc.FormData.CoverPageHeader.SecuritiesClassTitle,
 		EventDate:        xmlDoc.FormData.CoverPageHeader.EventDateRequiresFilingThisStatement,
 		RuleDesignations: xmlDoc.FormData.CoverPageHeader.DesignateRulesPursuantThisScheduleFiled.DesignateRulePursuantThisScheduleFiled,
 	}
 
+	filing.setIssuerCUSIP(xmlDoc.FormData.CoverPageHeader.IssuerInfo.IssuerCusip)
+
 	// Extract amendment info
 	filing.IsAmendment, filing.AmendmentNumber = ExtractAmendmentInfo(filing.FormType)
 
 	// Parse reporting persons
-	for _, personXML := range xmlDoc.FormData.CoverPageHeaderReportingPersonDetails {
+	var warnings parseWarnings
+	for i, personXML := range xmlDoc.FormData.CoverPageHeaderReportingPersonDetails {
 		person := ReportingPerson13{
 			Name:                  personXML.ReportingPersonName,
 			NoCIK:                 strings.ToUpper(personXML.ReportingPersonNoCIK) == "Y",
@@ -626,12 +672,14 @@ func ParseSchedule13G(data []byte) (*Schedule13Filing, error) {
 		}
 
 		// Parse numeric fields
-		person.SoleVotingPower = parseInt64(personXML.ReportingPersonBeneficiallyOwnedNumberOfShares.SoleVotingPower)
-		person.SharedVotingPower = parseInt64(personXML.ReportingPersonBeneficiallyOwnedNumberOfShares.SharedVotingPower)
-		person.SoleDispositivePower = parseInt64(personXML.ReportingPersonBeneficiallyOwnedNumberOfShares.SoleDispositivePower)
-		person.SharedDispositivePower = parseInt64(personXML.ReportingPersonBeneficiallyOwnedNumberOfShares.SharedDispositivePower)
-		person.AggregateAmountOwned = parseInt64(personXML.ReportingPersonBeneficiallyOwnedAggregateNumberOfShares)
-		person.PercentOfClass = parseFloat64(personXML.ClassPercent)
+		shares := personXML.ReportingPersonBeneficiallyOwnedNumberOfShares
+		field := fmt.Sprintf("reportingPersons[%d].", i)
+		person.SoleVotingPower = warnings.int64(field+"soleVotingPower", shares.SoleVotingPower)
+		person.SharedVotingPower = warnings.int64(field+"sharedVotingPower", shares.SharedVotingPower)
+		person.SoleDispositivePower = warnings.int64(field+"soleDispositivePower", shares.SoleDispositivePower)
+		person.SharedDispositivePower = warnings.int64(field+"sharedDispositivePower", shares.SharedDispositivePower)
+		person.AggregateAmountOwned = warnings.int64(field+"aggregateAmountOwned", personXML.ReportingPersonBeneficiallyOwnedAggregateNumberOfShares)
+		person.PercentOfClass = warnings.float64(field+"percentOfClass", personXML.ClassPercent)
 
 		// Fallback to filer CIK (13G often doesn't have CIK in person details)
 		if person.CIK == "" && !person.NoCIK {
@@ -640,6 +688,7 @@ func ParseSchedule13G(data []byte) (*Schedule13Filing, error) {
 
 		filing.ReportingPersons = append(filing.ReportingPersons, person)
 	}
+	filing.Warnings = warnings
 
 	// Parse Items 1-10
 	items := &Schedule13GItems{
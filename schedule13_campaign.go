@@ -0,0 +1,208 @@
+package edgar
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Campaign event kinds
+const (
+	CampaignStake       = "stake"        // Original 13D: an activist discloses a 5%+ stake
+	CampaignStakeChange = "stake_change" // 13D/A reporting a different position
+	CampaignDemand      = "demand"       // 13D/A whose Item 4 raises new demands
+	CampaignProxy       = "proxy"        // Contested proxy material (PREC14A, DEFC14A, DFAN14A, ...)
+	CampaignBoardChange = "board_change" // 8-K Item 5.02: director or officer departures and appointments
+	CampaignExit        = "exit"         // 13D/A reporting less than 5%
+)
+
+// contestedProxyForms are the proxy forms filed in a proxy contest, by either side
+var contestedProxyForms = map[string]bool{
+	"PREC14A": true, "PREC14C": true, "PRRN14A": true, "PREN14A": true,
+	"DEFC14A": true, "DEFC14C": true, "DEFN14A": true, "DFAN14A": true, "DFRN14A": true,
+}
+
+// campaignDemandPatterns map Item 4 language to the demands they signal
+var campaignDemandPatterns = []struct {
+	demand string
+	re     *regexp.Regexp
+}{
+	{"board representation", regexp.MustCompile(`(?i)\b(nominat\w*|board (seat|representation)|appoint\w* .{0,40}director|elect\w* .{0,40}director)`)},
+	{"proxy contest", regexp.MustCompile(`(?i)\b(proxy (contest|fight|solicitation)|solicit\w* (of )?proxies|withhold (votes|authority))`)},
+	{"special meeting", regexp.MustCompile(`(?i)\bspecial meeting\b|\bwritten consent\b`)},
+	{"sale of the company", regexp.MustCompile(`(?i)\b(sale of the (company|issuer)|strategic alternatives|acquisition proposal|take[- ]private|tender offer)\b`)},
+	{"capital return", regexp.MustCompile(`(?i)\b(share (repurchase|buyback)|stock (repurchase|buyback)|special dividend|return (of )?capital)\b`)},
+	{"breakup", regexp.MustCompile(`(?i)\b(spin[- ]?off|divest\w*|separation of)\b`)},
+	{"management change", regexp.MustCompile(`(?i)\b(replace\w* .{0,30}(ceo|chief executive|management)|management change)\b`)},
+}
+
+// CampaignEvent is one step of an activist campaign
+type CampaignEvent struct {
+	Date            string   `json:"date"` // Event date (YYYY-MM-DD), falling back to the filing date
+	Kind            string   `json:"kind"` // CampaignStake, CampaignDemand, ...
+	FormType        string   `json:"formType"`
+	AccessionNumber string   `json:"accessionNumber,omitempty"`
+	URL             string   `json:"url,omitempty"`
+	Filer           string   `json:"filer,omitempty"`          // 13D filer
+	Shares          int64    `json:"shares,omitempty"`         // 13D position (see CalculateTotalShares)
+	PercentOfClass  float64  `json:"percentOfClass,omitempty"` // 13D position (see CalculateTotalPercent)
+	Demands         []string `json:"demands,omitempty"`        // New demands (CampaignDemand)
+	Items           string   `json:"items,omitempty"`          // 8-K items
+}
+
+// CampaignTimeline is the ordered activist campaign history of one issuer
+type CampaignTimeline struct {
+	IssuerCIK  string          `json:"issuerCik"`
+	IssuerName string          `json:"issuerName"`
+	Activists  []string        `json:"activists"`     // 13D filers, in order of their first filing
+	Start      string          `json:"start"`         // First stake
+	End        string          `json:"end,omitempty"` // Last activist exit, empty while any activist holds 5%+
+	Events     []CampaignEvent `json:"events"`        // By date; same-day events in kind order
+}
+
+// BuildCampaignTimeline combines an issuer's Schedule 13D filings and
+// amendments with its proxy and 8-K filings (e.g. Submissions.Filings.Recent
+// .GetFilings) into one activist campaign timeline: stakes, position changes
+// and exits from the 13D chains, new demands from Item 4, contested proxy
+// material (PREC14A, DEFC14A, DFAN14A, ...) and 8-K Item 5.02 board changes.
+//
+// 13G filings are ignored, as are filings other than contested proxies and
+// 8-Ks with Item 5.02. Once there is a 13D, proxy and 8-K events are kept only
+// from the first stake through the last exit, so unrelated board changes years
+// earlier don't appear. Demands are reported when first raised: an amendment
+// repeating an earlier Item 4 adds no demand event.
+func BuildCampaignTimeline(schedules []*Schedule13Filing, filings []Filing) *CampaignTimeline {
+	var activist []*Schedule13Filing
+	for _, s := range schedules {
+		if s != nil && s.IsActivist() {
+			activist = append(activist, s)
+		}
+	}
+
+	t := &CampaignTimeline{Activists: []string{}, Events: []CampaignEvent{}}
+	holding := 0 // Activists currently above 5%
+	for _, chain := range Schedule13Chains(activist) {
+		if t.IssuerName == "" {
+			t.IssuerCIK, t.IssuerName = chain.IssuerCIK, chain.IssuerName
+		}
+		t.Activists = append(t.Activists, chain.FilerName)
+
+		seenDemands := make(map[string]bool)
+		var previous *CampaignEvent
+		exited := false
+		for _, f := range chain.Filings {
+			date, _ := schedule13EffectiveDate(f)
+			event := CampaignEvent{
+				Date:            date,
+				FormType:        f.FormType,
+				AccessionNumber: f.AccessionNumber,
+				Filer:           chain.FilerName,
+				Shares:          f.CalculateTotalShares(),
+				PercentOfClass:  f.CalculateTotalPercent(),
+			}
+			// Below 5%, or down to nothing; a share count without a percentage
+			// (one the cover page didn't give) says nothing
+			below := event.PercentOfClass > 0 && event.PercentOfClass < 5 ||
+				event.PercentOfClass == 0 && event.Shares == 0 && len(f.ReportingPersons) > 0
+			switch {
+			case previous == nil && !below:
+				event.Kind = CampaignStake
+			case below && (previous == nil || !exited):
+				event.Kind = CampaignExit
+			case previous != nil && exited && !below:
+				event.Kind = CampaignStake // Back above 5%
+			case previous != nil && (event.Shares != previous.Shares || event.PercentOfClass != previous.PercentOfClass):
+				event.Kind = CampaignStakeChange
+			}
+			if event.Kind != "" {
+				t.Events = append(t.Events, event)
+			}
+			exited = below
+			previous = &event
+
+			if f.Items13D == nil {
+				continue
+			}
+			var demands []string
+			for _, d := range itemDemands(f.Items13D.Item4PurposeOfTransaction) {
+				if !seenDemands[d] {
+					seenDemands[d] = true
+					demands = append(demands, d)
+				}
+			}
+			if len(demands) > 0 {
+				demand := event
+				demand.Kind, demand.Demands = CampaignDemand, demands
+				demand.Shares, demand.PercentOfClass = 0, 0
+				t.Events = append(t.Events, demand)
+			}
+		}
+		if previous != nil && !exited {
+			holding++
+		}
+	}
+
+	for _, e := range t.Events {
+		if e.Kind == CampaignStake && (t.Start == "" || e.Date < t.Start) {
+			t.Start = e.Date
+		}
+		if e.Kind == CampaignExit && holding == 0 && e.Date > t.End {
+			t.End = e.Date
+		}
+	}
+
+	for _, f := range filings {
+		event := CampaignEvent{
+			Date:            f.FilingDate,
+			FormType:        f.Form,
+			AccessionNumber: f.AccessionNumber,
+			URL:             f.URL,
+		}
+		switch {
+		case contestedProxyForms[strings.ToUpper(f.Form)]:
+			event.Kind = CampaignProxy
+		case strings.HasPrefix(f.Form, "8-K") && hasItem(f.Items, "5.02"):
+			event.Kind = CampaignBoardChange
+			event.Items = f.Items
+			if f.ReportDate != "" {
+				event.Date = f.ReportDate
+			}
+		default:
+			continue
+		}
+		if t.Start != "" && event.Date < t.Start || t.End != "" && event.Date > t.End {
+			continue
+		}
+		t.Events = append(t.Events, event)
+	}
+
+	kindOrder := map[string]int{CampaignStake: 0, CampaignStakeChange: 1, CampaignDemand: 2, CampaignProxy: 3, CampaignBoardChange: 4, CampaignExit: 5}
+	sort.SliceStable(t.Events, func(i, j int) bool {
+		if t.Events[i].Date != t.Events[j].Date {
+			return t.Events[i].Date < t.Events[j].Date
+		}
+		return kindOrder[t.Events[i].Kind] < kindOrder[t.Events[j].Kind]
+	})
+	return t
+}
+
+// itemDemands returns the demands an Item 4 text raises, in pattern order
+func itemDemands(item4 string) []string {
+	var demands []string
+	for _, p := range campaignDemandPatterns {
+		if p.re.MatchString(item4) {
+			demands = append(demands, p.demand)
+		}
+	}
+	return demands
+}
+
+// hasItem reports whether a comma-separated 8-K item list ("5.02,9.01") includes item
+func hasItem(items, item string) bool {
+	for _, i := range strings.Split(items, ",") {
+		if strings.TrimSpace(i) == item {
+			return true
+		}
+	}
+	return false
+}
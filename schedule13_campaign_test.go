@@ -0,0 +1,84 @@
+package edgar
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildCampaignTimeline(t *testing.T) {
+	filing := func(form, date string, shares int64, percent float64, item4 string) *Schedule13Filing {
+		s := &Schedule13Filing{
+			FormType:         form,
+			AccessionNumber:  "acc-" + date,
+			IssuerCIK:        "1234",
+			IssuerName:       "Target Inc.",
+			DateOfEvent:      date,
+			FilerCIK:         "999",
+			ReportingPersons: []ReportingPerson13{{Name: "Activist Partners LP", AggregateAmountOwned: shares, PercentOfClass: percent}},
+		}
+		if form == "SC 13G" {
+			s.EventDate, s.DateOfEvent = date, ""
+			return s
+		}
+		s.Items13D = &Schedule13DItems{Item4PurposeOfTransaction: item4}
+		return s
+	}
+	schedules := []*Schedule13Filing{
+		filing("SC 13D/A", "2024-09-01", 300000, 3.0, "The Reporting Persons sold shares. They may nominate directors."),
+		filing("SC 13D", "2024-01-10", 600000, 6.0, "The Reporting Persons intend to nominate candidates for election as directors."),
+		filing("SC 13D/A", "2024-03-01", 800000, 8.0, "The Reporting Persons continue to nominate directors and urge the Board to explore strategic alternatives, including a sale of the Company."),
+		filing("SC 13G", "2023-06-01", 550000, 5.5, ""),
+	}
+	filings := []Filing{
+		{Form: "8-K", FilingDate: "2020-05-01", Items: "5.02"}, // Before the campaign
+		{Form: "PREC14A", FilingDate: "2024-03-20", AccessionNumber: "proxy"},
+		{Form: "8-K", FilingDate: "2024-04-17", ReportDate: "2024-04-15", Items: "5.02,9.01"},
+		{Form: "8-K", FilingDate: "2024-05-01", Items: "2.02,9.01"}, // Earnings
+		{Form: "10-K", FilingDate: "2024-02-28"},
+		{Form: "DEFC14A", FilingDate: "2025-02-01"}, // After the exit
+	}
+
+	timeline := BuildCampaignTimeline(schedules, filings)
+	if timeline.IssuerName != "Target Inc." || !reflect.DeepEqual(timeline.Activists, []string{"Activist Partners LP"}) {
+		t.Errorf("Issuer %q, activists %v", timeline.IssuerName, timeline.Activists)
+	}
+	if timeline.Start != "2024-01-10" || timeline.End != "2024-09-01" {
+		t.Errorf("Campaign %s to %s, want 2024-01-10 to 2024-09-01", timeline.Start, timeline.End)
+	}
+
+	type step struct{ date, kind string }
+	var got []step
+	for _, e := range timeline.Events {
+		got = append(got, step{e.Date, e.Kind})
+	}
+	want := []step{
+		{"2024-01-10", CampaignStake},
+		{"2024-01-10", CampaignDemand},
+		{"2024-03-01", CampaignStakeChange},
+		{"2024-03-01", CampaignDemand},
+		{"2024-03-20", CampaignProxy},
+		{"2024-04-15", CampaignBoardChange},
+		{"2024-09-01", CampaignExit},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Events = %v\nwant %v", got, want)
+	}
+
+	events := timeline.Events
+	if !reflect.DeepEqual(events[1].Demands, []string{"board representation"}) ||
+		!reflect.DeepEqual(events[3].Demands, []string{"sale of the company"}) {
+		t.Errorf("Demands = %v then %v, want only new demands", events[1].Demands, events[3].Demands)
+	}
+	if events[2].Shares != 800000 || events[2].PercentOfClass != 8 || events[6].PercentOfClass != 3 {
+		t.Errorf("Positions = %+v, %+v", events[2], events[6])
+	}
+	if events[5].Items != "5.02,9.01" {
+		t.Errorf("Board change items = %q", events[5].Items)
+	}
+
+	// Without a 13D, every proxy and board change is kept
+	timeline = BuildCampaignTimeline(nil, filings)
+	if len(timeline.Events) != 4 || timeline.Start != "" {
+		t.Errorf("Events without a 13D = %+v", timeline.Events)
+	}
+}
@@ -0,0 +1,131 @@
+package edgar
+
+import "fmt"
+
+// Confidence levels for fields extracted from HTML Schedule 13 filings
+const (
+	ConfidenceHigh   = "high"   // Read from a labeled location (cover page marker, item heading, labeled row)
+	ConfidenceMedium = "medium" // Read by a fallback strategy, or values that do not cross-check
+	ConfidenceLow    = "low"    // Missing, malformed, or assigned by position from ambiguous input
+)
+
+// HTML extraction strategies recorded in FieldConfidence.Strategy
+const (
+	StrategyItem1a        = "item1a"        // Item 1(a) "Name of Issuer" section
+	StrategyCoverPage     = "coverPage"     // Bold text before a cover page marker such as "(CUSIP Number)"
+	StrategyLabeledText   = "labeledText"   // Text between two cover page labels
+	StrategyLabeledTables = "labeledTables" // Older HTML: values found under row labels ("SOLE VOTING POWER")
+	StrategyRowNumbers    = "rowNumbers"    // Values read from numbered cover page rows (7-11, 13, 14 on a 13D)
+	StrategyPositional    = "positional"    // Modern XHTML: numeric values assigned in table order
+	StrategyNone          = "none"          // Nothing matched
+)
+
+// FieldConfidence describes how one field of an HTML filing was extracted
+type FieldConfidence struct {
+	Field      string `json:"field"` // e.g. "issuerCusip", "reportingPersons[0].powers"
+	Strategy   string `json:"strategy"`
+	Confidence string `json:"confidence"`
+	Ambiguous  bool   `json:"ambiguous,omitempty"` // The input allowed more than one reading
+	Note       string `json:"note,omitempty"`
+}
+
+// ExtractionConfidence is the per-field confidence of an HTML Schedule 13 parse
+// XML filings are structured and carry no confidence metadata (nil).
+type ExtractionConfidence struct {
+	Overall string            `json:"overall"` // Lowest confidence of any field
+	Fields  []FieldConfidence `json:"fields"`
+}
+
+// NeedsReview reports whether any field was extracted with low confidence
+func (c *ExtractionConfidence) NeedsReview() bool {
+	return c != nil && c.Overall == ConfidenceLow
+}
+
+// add records a field's confidence and lowers Overall if needed
+func (c *ExtractionConfidence) add(fc FieldConfidence) {
+	c.Fields = append(c.Fields, fc)
+	if c.Overall == "" || confidenceRank(fc.Confidence) < confidenceRank(c.Overall) {
+		c.Overall = fc.Confidence
+	}
+}
+
+// addText records a text field: high for the primary strategy, medium for a
+// fallback, low when nothing was found
+func (c *ExtractionConfidence) addText(field, value, strategy string, fallback bool) {
+	switch {
+	case value == "":
+		c.add(FieldConfidence{Field: field, Strategy: StrategyNone, Confidence: ConfidenceLow, Note: "not found"})
+	case fallback:
+		c.add(FieldConfidence{Field: field, Strategy: strategy, Confidence: ConfidenceMedium})
+	default:
+		c.add(FieldConfidence{Field: field, Strategy: strategy, Confidence: ConfidenceHigh})
+	}
+}
+
+func confidenceRank(level string) int {
+	switch level {
+	case ConfidenceHigh:
+		return 2
+	case ConfidenceMedium:
+		return 1
+	}
+	return 0
+}
+
+// addPerson records the confidence of a reporting person's share amounts and percent
+// Labeled values start high and positional values medium; either drops when the
+// table was ambiguous or the amounts do not cross-check.
+func (c *ExtractionConfidence) addPerson(i int, p ReportingPerson13, strategy, ambiguity string) {
+	powers := FieldConfidence{
+		Field:      fmt.Sprintf("reportingPersons[%d].powers", i),
+		Strategy:   strategy,
+		Confidence: ConfidenceHigh,
+	}
+	if strategy == StrategyPositional {
+		powers.Confidence = ConfidenceMedium
+	}
+	if ambiguity != "" {
+		powers.Confidence = ConfidenceLow
+		powers.Ambiguous = true
+		powers.Note = ambiguity
+	} else if note := checkPersonAmounts(p); note != "" {
+		// A missing aggregate makes the filing totals wrong; other mismatches
+		// are only suspicious when the values were read from labeled rows
+		powers.Confidence = ConfidenceLow
+		if strategy != StrategyPositional && p.AggregateAmountOwned > 0 {
+			powers.Confidence = ConfidenceMedium
+		}
+		powers.Note = note
+	}
+	c.add(powers)
+
+	percent := FieldConfidence{
+		Field:      fmt.Sprintf("reportingPersons[%d].percentOfClass", i),
+		Strategy:   strategy,
+		Confidence: ConfidenceHigh,
+	}
+	if (p.PercentOfClass <= 0 && p.AggregateAmountOwned > 0) || p.PercentOfClass > 100 {
+		percent.Confidence = ConfidenceLow
+		percent.Note = "missing or out of range"
+	}
+	c.add(percent)
+}
+
+// checkPersonAmounts cross-checks the cover page amounts of a reporting person
+// The aggregate amount should equal sole + shared dispositive power and no
+// power can exceed it.
+func checkPersonAmounts(p ReportingPerson13) string {
+	if p.AggregateAmountOwned == 0 {
+		if p.TotalVotingPower() > 0 || p.TotalDispositivePower() > 0 {
+			return "aggregate amount missing"
+		}
+		return "" // Reported zero (e.g. an exit filing)
+	}
+	if p.TotalVotingPower() > p.AggregateAmountOwned {
+		return "voting power exceeds aggregate amount"
+	}
+	if p.TotalDispositivePower() != p.AggregateAmountOwned {
+		return "dispositive powers do not sum to aggregate amount"
+	}
+	return ""
+}
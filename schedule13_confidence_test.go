@@ -0,0 +1,99 @@
+package edgar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSchedule13HTMLConfidence_Positional(t *testing.T) {
+	row := func(values ...string) string {
+		var b strings.Builder
+		b.WriteString(`<table id="reportingPersonDetails">`)
+		for _, v := range values {
+			b.WriteString(`<tr><td><div class="text">` + v + `</div></td></tr>`)
+		}
+		b.WriteString(`</table>`)
+		return b.String()
+	}
+	page := `<html><body><p>SCHEDULE 13G</p>` +
+		// All five amounts present and consistent
+		row("Alpha Capital LP", "1,000", "2,000", "1,000", "2,000", "3,000", "5.1%") +
+		// Zero shared powers shift the remaining values into the wrong slots
+		row("Beta Partners LLC", "3,000", "0", "3,000", "0", "3,000", "5.1%") +
+		`</body></html>`
+
+	filing, err := ParseSchedule13HTML([]byte(page))
+	if err != nil {
+		t.Fatalf("ParseSchedule13HTML: %v", err)
+	}
+	if len(filing.ReportingPersons) != 2 || filing.Extraction == nil {
+		t.Fatalf("got %d persons, extraction %v", len(filing.ReportingPersons), filing.Extraction)
+	}
+
+	fields := make(map[string]FieldConfidence)
+	for _, fc := range filing.Extraction.Fields {
+		fields[fc.Field] = fc
+	}
+
+	alpha := fields["reportingPersons[0].powers"]
+	if alpha.Strategy != StrategyPositional || alpha.Confidence != ConfidenceMedium || alpha.Ambiguous {
+		t.Errorf("clean positional row = %+v, want medium and unambiguous", alpha)
+	}
+	beta := fields["reportingPersons[1].powers"]
+	if beta.Confidence != ConfidenceLow || !beta.Ambiguous || beta.Note == "" {
+		t.Errorf("row with zero cells = %+v, want low and ambiguous", beta)
+	}
+	if !filing.Extraction.NeedsReview() {
+		t.Error("filing with an ambiguous row should need review")
+	}
+	if fields["issuerCusip"].Confidence != ConfidenceLow {
+		t.Errorf("missing CUSIP = %+v, want low", fields["issuerCusip"])
+	}
+}
+
+func TestSchedule13HTMLConfidence_Fixtures(t *testing.T) {
+	tests := []struct {
+		file        string
+		overall     string
+		needsReview bool
+	}{
+		// Numbered rows with every amount cross-checking; event date not found
+		{"testdata/schedule13/invitae_13g_2021/input.htm", ConfidenceMedium, false},
+		// Percent of class row holds only a footnote reference
+		{"testdata/schedule13/13d_2024_2/input.htm", ConfidenceLow, true},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(tt.file)
+		if err != nil {
+			t.Fatalf("read %s: %v", tt.file, err)
+		}
+		filing, err := ParseSchedule13HTML(data)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.file, err)
+		}
+		if filing.Extraction.Overall != tt.overall || filing.Extraction.NeedsReview() != tt.needsReview {
+			t.Errorf("%s: overall %q needsReview %v, want %q %v",
+				tt.file, filing.Extraction.Overall, filing.Extraction.NeedsReview(), tt.overall, tt.needsReview)
+		}
+	}
+}
+
+func TestCheckPersonAmounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		person ReportingPerson13
+		ok     bool
+	}{
+		{"consistent", ReportingPerson13{SoleVotingPower: 100, SoleDispositivePower: 60, SharedDispositivePower: 40, AggregateAmountOwned: 100}, true},
+		{"exit filing", ReportingPerson13{}, true},
+		{"aggregate missing", ReportingPerson13{SoleVotingPower: 100, SoleDispositivePower: 100}, false},
+		{"voting exceeds aggregate", ReportingPerson13{SoleVotingPower: 200, SoleDispositivePower: 100, AggregateAmountOwned: 100}, false},
+		{"dispositive mismatch", ReportingPerson13{SoleDispositivePower: 50, AggregateAmountOwned: 100}, false},
+	}
+	for _, tt := range tests {
+		if got := checkPersonAmounts(tt.person) == ""; got != tt.ok {
+			t.Errorf("%s: consistent = %v, want %v", tt.name, got, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package edgar
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Row-number anchored cover page extraction
+//
+// Every Schedule 13D/G cover page repeats the same numbered rows for each
+// reporting person, and both old HTML and modern XHTML renderings print the
+// row numbers. Reading amounts by row number instead of by the order numbers
+// are encountered keeps zero-valued rows ("0", "-0-", "None") in their slot.
+//
+//	                      13D   13G
+//	Sole voting power       7     5
+//	Shared voting power     8     6
+//	Sole dispositive        9     7
+//	Shared dispositive     10     8
+//	Aggregate amount       11     9
+//	Percent of class       13    11
+//	Type of person         14    12
+
+// coverRowScheme maps cover page fields to their row numbers
+type coverRowScheme struct {
+	soleVoting, sharedVoting, soleDispositive, sharedDispositive, aggregate, percent, personType int
+}
+
+var (
+	schedule13DRows = coverRowScheme{7, 8, 9, 10, 11, 13, 14}
+	schedule13GRows = coverRowScheme{5, 6, 7, 8, 9, 11, 12}
+)
+
+var (
+	coverRowToken      = regexp.MustCompile(`^\(?(\d{1,2})\)?\.?$`)
+	coverRowTokenLabel = regexp.MustCompile(`^\(?(\d{1,2})\)?\.?\s+([A-Z].*)$`)
+	coverPreface       = regexp.MustCompile(`(?i)number\s+of\s+shares\s+beneficially\s+owned\s+by\s+each\s+reporting\s+person\s+with`)
+	coverAmountRow     = regexp.MustCompile(`(?i)^((sole|shared)\s+(voting|dispositive)\s+power|aggregate\s+amount)`)
+	coverAmountLabel   = regexp.MustCompile(`(?i)^.*?(voting power|dispositive power|beneficially owned( by each reporting person)?)\s*:?`)
+	coverPercentLabel  = regexp.MustCompile(`(?i)^.*?represented by amount in row\s*\(?\d*\)?\s*:?`)
+	coverTypeLabel     = regexp.MustCompile(`(?i)^.*?type of reporting person\s*(\(see instructions\))?\s*:?`)
+	coverFootnoteRef   = regexp.MustCompile(`\(\s*(\d{1,2}|[a-z])\s*\)|\*+`)
+	coverZeroAmount    = regexp.MustCompile(`(?i)^(-0-|none\b|nil\b|—|-+$)`)
+	coverLeadingAmount = regexp.MustCompile(`^\$?(\d[\d,]*)`)
+	coverPercentValue  = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+	coverTypeCodes     = regexp.MustCompile(`^[A-Z]{2}(\s*(,|;|/|&|and)?\s*[A-Z]{2}\b)*`)
+	coverTypeCode      = regexp.MustCompile(`[A-Z]{2}`)
+)
+
+// coverPageTexts returns the trimmed, non-empty text nodes under the given nodes, in order
+func coverPageTexts(nodes ...*html.Node) []string {
+	var texts []string
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			if text := strings.TrimSpace(n.Data); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+	}
+	for _, n := range nodes {
+		f(n)
+	}
+	return texts
+}
+
+// coverPageRows groups a reporting person's cover page text by row number
+//
+// A row number is a text node holding just the number ("7", "7.", "(7)") or
+// the number followed by the row label ("7. SOLE VOTING POWER"). Numbers must
+// increase by at most three, and a number directly after a power or aggregate
+// label that has no value yet is read as that value, so amounts such as "10"
+// are not mistaken for row numbers.
+func coverPageRows(texts []string) map[int]string {
+	rows := make(map[int]string)
+	current := 0
+	var content []string
+
+	flush := func() {
+		if current > 0 {
+			rows[current] = cleanCoverRow(strings.Join(content, " "))
+		}
+		content = content[:0]
+	}
+
+	for _, text := range texts {
+		number, rest := 0, ""
+		if m := coverRowToken.FindStringSubmatch(text); m != nil {
+			number, _ = strconv.Atoi(m[1])
+		} else if m := coverRowTokenLabel.FindStringSubmatch(text); m != nil {
+			number, _ = strconv.Atoi(m[1])
+			rest = m[2]
+		}
+
+		isRow := number > current && number <= 14 && (current == 0 || number <= current+3)
+		if isRow && current > 0 && rest == "" && awaitingAmount(cleanCoverRow(strings.Join(content, " "))) {
+			isRow = false
+		}
+		if !isRow {
+			if current > 0 {
+				content = append(content, text)
+			}
+			continue
+		}
+
+		flush()
+		current = number
+		if rest != "" {
+			content = append(content, rest)
+		}
+	}
+	flush()
+	return rows
+}
+
+// cleanCoverRow collapses whitespace and drops the "NUMBER OF SHARES BENEFICIALLY
+// OWNED BY EACH REPORTING PERSON WITH" side heading that precedes the power rows
+func cleanCoverRow(content string) string {
+	return strings.Join(strings.Fields(coverPreface.ReplaceAllString(content, " ")), " ")
+}
+
+// awaitingAmount reports whether row content is an amount label still missing its value
+func awaitingAmount(content string) bool {
+	if !coverAmountRow.MatchString(content) {
+		return false
+	}
+	_, ok := coverRowAmount(content)
+	return !ok
+}
+
+// coverRowAmount reads the share amount from a row ("SOLE VOTING POWER: 10,885,357 (1)")
+func coverRowAmount(content string) (int64, bool) {
+	value := coverAmountLabel.ReplaceAllString(content, "")
+	value = strings.TrimSpace(coverFootnoteRef.ReplaceAllString(value, ""))
+	if m := coverLeadingAmount.FindStringSubmatch(value); m != nil {
+		n, err := strconv.ParseInt(strings.ReplaceAll(m[1], ",", ""), 10, 64)
+		return n, err == nil
+	}
+	if coverZeroAmount.MatchString(value) {
+		return 0, true
+	}
+	return 0, false
+}
+
+// coverRowPercent reads the percent of class from its row ("... IN ROW (11) 22.9% (1)(2)")
+func coverRowPercent(content string) (float64, bool) {
+	m := coverPercentValue.FindStringSubmatch(coverPercentLabel.ReplaceAllString(content, ""))
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	return v, err == nil
+}
+
+// coverRowType reads the type-of-reporting-person codes ("IA, PN") from its row
+func coverRowType(content string) string {
+	value := strings.TrimSpace(coverTypeLabel.ReplaceAllString(content, ""))
+	codes := coverTypeCodes.FindString(value)
+	if codes == "" {
+		return ""
+	}
+	return strings.Join(coverTypeCode.FindAllString(codes, -1), ", ")
+}
+
+// coverRowSchemeFor picks the row numbering from the row holding the sole voting
+// power label, falling back to the form type
+func coverRowSchemeFor(rows map[int]string, formType string) coverRowScheme {
+	for n, content := range rows {
+		if strings.Contains(strings.ToUpper(content), "SOLE VOTING POWER") {
+			switch n {
+			case schedule13DRows.soleVoting:
+				return schedule13DRows
+			case schedule13GRows.soleVoting:
+				return schedule13GRows
+			}
+		}
+	}
+	if strings.Contains(formType, "13G") {
+		return schedule13GRows
+	}
+	return schedule13DRows
+}
+
+// applyCoverRows fills a reporting person's amounts from numbered cover page rows
+// It returns false, leaving the person unchanged, unless all five amount rows
+// (voting, dispositive and aggregate) are present and readable. Percent and
+// type are set when their rows can be read.
+func applyCoverRows(p *ReportingPerson13, rows map[int]string, formType string) bool {
+	scheme := coverRowSchemeFor(rows, formType)
+
+	rowNumbers := []int{scheme.soleVoting, scheme.sharedVoting, scheme.soleDispositive, scheme.sharedDispositive, scheme.aggregate}
+	amounts := make([]int64, len(rowNumbers))
+	for i, n := range rowNumbers {
+		content, ok := rows[n]
+		if !ok {
+			return false
+		}
+		if amounts[i], ok = coverRowAmount(content); !ok {
+			return false
+		}
+	}
+
+	p.SoleVotingPower = amounts[0]
+	p.SharedVotingPower = amounts[1]
+	p.SoleDispositivePower = amounts[2]
+	p.SharedDispositivePower = amounts[3]
+	p.AggregateAmountOwned = amounts[4]
+
+	if percent, ok := coverRowPercent(rows[scheme.percent]); ok {
+		p.PercentOfClass = percent
+	}
+	if personType := coverRowType(rows[scheme.personType]); personType != "" {
+		p.TypeOfReportingPerson = personType
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package edgar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCoverPageRows(t *testing.T) {
+	texts := []string{
+		"CUSIP No. 088786108", "1.", "NAMES OF REPORTING PERSONS", "Fund LP",
+		"2.", "CHECK THE APPROPRIATE BOX IF A MEMBER OF A GROUP", "(a) ¨", "(b) ¨",
+		"3.", "SEC USE ONLY",
+		"4.", "SOURCE OF FUNDS", "WC",
+		"5.", "CHECK BOX IF DISCLOSURE OF LEGAL PROCEEDINGS IS REQUIRED", "¨",
+		"6.", "CITIZENSHIP OR PLACE OF ORGANIZATION", "Delaware",
+		"NUMBER OF SHARES BENEFICIALLY OWNED BY EACH REPORTING PERSON WITH",
+		"7.", "SOLE VOTING POWER", "-0-",
+		"8.", "SHARED VOTING POWER", "10", // A value that looks like the next row number
+		"9", "SOLE DISPOSITIVE POWER", "None",
+		"10", "SHARED DISPOSITIVE POWER: 1,250,000 (1)",
+		"11. AGGREGATE AMOUNT BENEFICIALLY OWNED BY EACH REPORTING PERSON", "1,250,000",
+		"12.", "CHECK BOX IF THE AGGREGATE AMOUNT IN ROW (11) EXCLUDES CERTAIN SHARES", "¨",
+		"13.", "PERCENT OF CLASS REPRESENTED BY AMOUNT IN ROW (11)", "6.2% (2)",
+		"14.", "TYPE OF REPORTING PERSON (See Instructions)", "PN,", "IA",
+	}
+
+	rows := coverPageRows(texts)
+	if rows[6] != "CITIZENSHIP OR PLACE OF ORGANIZATION Delaware" {
+		t.Errorf("row 6 = %q", rows[6])
+	}
+	if rows[8] != "SHARED VOTING POWER 10" {
+		t.Errorf("row 8 = %q, want the amount 10 kept in the row", rows[8])
+	}
+
+	var p ReportingPerson13
+	if !applyCoverRows(&p, rows, "SC 13D") {
+		t.Fatal("applyCoverRows returned false")
+	}
+	want := ReportingPerson13{
+		SoleVotingPower:        0,
+		SharedVotingPower:      10,
+		SoleDispositivePower:   0,
+		SharedDispositivePower: 1250000,
+		AggregateAmountOwned:   1250000,
+		PercentOfClass:         6.2,
+		TypeOfReportingPerson:  "PN, IA",
+	}
+	if p != want {
+		t.Errorf("got %+v\nwant %+v", p, want)
+	}
+}
+
+func TestApplyCoverRows_Incomplete(t *testing.T) {
+	rows := map[int]string{7: "SOLE VOTING POWER 100", 8: "SHARED VOTING POWER 0"}
+	p := ReportingPerson13{SoleVotingPower: 5}
+	if applyCoverRows(&p, rows, "SC 13D") || p.SoleVotingPower != 5 {
+		t.Errorf("missing rows should leave the person unchanged, got %+v", p)
+	}
+}
+
+// Modern XHTML with zero sole voting power: positional assignment shifts every
+// later amount, row numbers keep them in place
+func TestExtractModernXHTMLPersons_ZeroSoleVotingPower(t *testing.T) {
+	// Row numbers and labels are plain cells; values are text divs
+	row := func(n, label, value string) string {
+		return "<tr><td>" + n + "</td><td>" + label + `</td><td><div class="text">` + value + "</div></td></tr>"
+	}
+	page := `<html><body><p>SCHEDULE 13G</p><table id="reportingPersonDetails">` +
+		row("1", "Names of Reporting Persons", "Index Fund Trust") +
+		row("4", "Citizenship or Place of Organization", "Massachusetts") +
+		row("5", "Sole Voting Power", "0.00") +
+		row("6", "Shared Voting Power", "2,400,000.00") +
+		row("7", "Sole Dispositive Power", "0.00") +
+		row("8", "Shared Dispositive Power", "2,500,000.00") +
+		row("9", "Aggregate Amount Beneficially Owned by Each Reporting Person", "2,500,000.00") +
+		row("11", "Percent of class represented by amount in row (9)", "5.3 %") +
+		row("12", "Type of Reporting Person (See Instructions)", "IV") +
+		`</table></body></html>`
+
+	filing, err := ParseSchedule13HTML([]byte(page))
+	if err != nil {
+		t.Fatalf("ParseSchedule13HTML: %v", err)
+	}
+	if len(filing.ReportingPersons) != 1 {
+		t.Fatalf("got %d persons, want 1", len(filing.ReportingPersons))
+	}
+	p := filing.ReportingPersons[0]
+	if p.SoleVotingPower != 0 || p.SharedVotingPower != 2400000 || p.SoleDispositivePower != 0 ||
+		p.SharedDispositivePower != 2500000 || p.AggregateAmountOwned != 2500000 || p.PercentOfClass != 5.3 {
+		t.Errorf("amounts misassigned: %+v", p)
+	}
+
+	for _, fc := range filing.Extraction.Fields {
+		if fc.Field == "reportingPersons[0].powers" && (fc.Strategy != StrategyRowNumbers || fc.Confidence != ConfidenceHigh) {
+			t.Errorf("powers confidence = %+v, want rowNumbers/high", fc)
+		}
+	}
+}
+
+func TestParseSchedule13HTML_RowNumbers(t *testing.T) {
+	data, err := os.ReadFile("testdata/schedule13/13d_2024_1/input.htm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filing, err := ParseSchedule13HTML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filing.ReportingPersons) != 4 {
+		t.Fatalf("got %d persons, want 4", len(filing.ReportingPersons))
+	}
+	// The aggregate label wraps across lines ("CHECK BOX\n IF"), which the
+	// label-to-label extraction missed
+	for _, p := range filing.ReportingPersons {
+		if p.AggregateAmountOwned != 10885357 || p.PercentOfClass != 22.9 || !strings.Contains(p.TypeOfReportingPerson, ",") {
+			t.Errorf("%s: %+v", p.Name, p)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package edgar
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Exhibit is an exhibit attached to a filing (letters to the board, agreements, ...)
+type Exhibit struct {
+	FilingDocument
+	Number        string `json:"number"`         // From the type, e.g. "99.1" for EX-99.1
+	Text          string `json:"text,omitempty"` // Empty for images and PDFs
+	ListedInItem7 bool   `json:"listedInItem7"`  // Referenced by number or description in Item 7
+}
+
+// FetchExhibits fetches the exhibits of a filing and their text
+func FetchExhibits(cik, accessionNumber, email string) ([]Exhibit, error) {
+	return defaultClient(email).FetchExhibits(cik, accessionNumber)
+}
+
+// FetchExhibits fetches the exhibits of a filing (documents of type EX-*) and their text
+// Text is extracted from HTML and plain-text exhibits; images and PDFs are
+// listed without text. Use (*Schedule13Filing).AttachExhibits to link them to Item 7.
+func (c *Client) FetchExhibits(cik, accessionNumber string) ([]Exhibit, error) {
+	docs, err := c.FetchFilingIndex(cik, accessionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var exhibits []Exhibit
+	for _, doc := range docs {
+		if !strings.HasPrefix(strings.ToUpper(doc.Type), "EX-") {
+			continue
+		}
+		exhibit := Exhibit{FilingDocument: doc, Number: exhibitNumber(doc.Type)}
+		if isTextDocument(doc.Name) {
+			data, err := c.FetchForm(doc.URL)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch exhibit %s: %w", doc.Name, err)
+			}
+			exhibit.Text = ExhibitText(data)
+		}
+		exhibits = append(exhibits, exhibit)
+	}
+	return exhibits, nil
+}
+
+// ExhibitText returns the readable text of an exhibit (HTML or plain text),
+// one paragraph per line
+func ExhibitText(data []byte) string {
+	text := string(NormalizeText(data))
+	if strings.Contains(strings.ToLower(text[:min(len(text), 1024)]), "<html") {
+		if doc, err := html.Parse(strings.NewReader(text)); err == nil {
+			text = blockText(doc)
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AttachExhibits stores exhibits on the filing and marks those listed in Item 7
+// An exhibit counts as listed when Item 7 mentions its number ("99.1") or its
+// description. Only 13D filings have an Item 7 exhibit list.
+func (s *Schedule13Filing) AttachExhibits(exhibits []Exhibit) {
+	item7 := ""
+	if s.Items13D != nil {
+		item7 = strings.ToLower(s.Items13D.Item7Exhibits)
+	}
+
+	s.Exhibits = make([]Exhibit, len(exhibits))
+	for i, ex := range exhibits {
+		ex.ListedInItem7 = item7 != "" && mentionsExhibit(item7, ex)
+		s.Exhibits[i] = ex
+	}
+}
+
+// mentionsExhibit reports whether lowercase Item 7 text refers to the exhibit
+func mentionsExhibit(item7 string, ex Exhibit) bool {
+	if ex.Number != "" {
+		re := regexp.MustCompile(`(^|[^\d.])` + regexp.QuoteMeta(strings.ToLower(ex.Number)) + `($|[^\d]|\.[^\d])`)
+		if re.MatchString(item7) {
+			return true
+		}
+	}
+	desc := strings.ToLower(ex.Description)
+	return len(desc) > 8 && strings.Contains(item7, desc)
+}
+
+// exhibitNumber extracts the exhibit number from a document type ("EX-99.1" -> "99.1")
+func exhibitNumber(docType string) string {
+	return strings.TrimSpace(docType[strings.Index(docType, "-")+1:])
+}
+
+// isTextDocument reports whether a document has extractable text (not an image or PDF)
+func isTextDocument(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".htm", ".html", ".txt", ".xml":
+		return true
+	}
+	return false
+}
+
+// blockText renders the text of an HTML document with a line break after each block element
+func blockText(n *html.Node) string {
+	var buf strings.Builder
+	var f func(*html.Node)
+	f = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			buf.WriteString(strings.ReplaceAll(n.Data, "\n", " "))
+		case n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style"):
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			f(c)
+		}
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "p", "div", "br", "tr", "li", "h1", "h2", "h3", "h4", "h5", "h6", "table":
+				buf.WriteString("\n")
+			}
+		}
+	}
+	f(n)
+	return buf.String()
+}
@@ -0,0 +1,65 @@
+package edgar_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/RxDataLab/go-edgar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFilingIndex = `<html><body>
+<table class="tableFile" summary="Document Format Files">
+<tr><th scope="col">Seq</th><th scope="col">Description</th><th scope="col">Document</th><th scope="col">Type</th><th scope="col">Size</th></tr>
+<tr><td scope="row">1</td><td scope="row">SC 13D/A</td><td scope="row"><a href="/Archives/edgar/data/1263508/000090266424000123/tm2412345d1_sc13da.htm">tm2412345d1_sc13da.htm</a></td><td scope="row">SC 13D/A</td><td scope="row">98765</td></tr>
+<tr class="blueRow"><td scope="row">2</td><td scope="row">LETTER TO THE BOARD OF DIRECTORS</td><td scope="row"><a href="/Archives/edgar/data/1263508/000090266424000123/ex99-1.htm">ex99-1.htm</a></td><td scope="row">EX-99.1</td><td scope="row">4321</td></tr>
+<tr><td scope="row">3</td><td scope="row">JOINT FILING AGREEMENT</td><td scope="row"><a href="/Archives/edgar/data/1263508/000090266424000123/ex99-2.txt">ex99-2.txt</a></td><td scope="row">EX-99.2</td><td scope="row">1200</td></tr>
+<tr class="blueRow"><td scope="row">4</td><td scope="row">CHART</td><td scope="row"><a href="/Archives/edgar/data/1263508/000090266424000123/chart.jpg">chart.jpg</a></td><td scope="row">EX-99.3</td><td scope="row">55000</td></tr>
+<tr><td scope="row">&nbsp;</td><td scope="row">Complete submission text file</td><td scope="row"><a href="/Archives/edgar/data/1263508/000090266424000123/0000902664-24-000123.txt">0000902664-24-000123.txt</a></td><td scope="row">&nbsp;</td><td scope="row">160000</td></tr>
+</table>
+</body></html>`
+
+func TestClient_FetchExhibits(t *testing.T) {
+	const base = "https://www.sec.gov/Archives/edgar/data/1263508/000090266424000123/"
+	pages := map[string]string{
+		base + "0000902664-24-000123-index.htm": testFilingIndex,
+		base + "ex99-1.htm":                     `<html><body><p>Dear Members of the Board,</p><p>We urge   the Board to explore a sale.</p><script>x()</script></body></html>`,
+		base + "ex99-2.txt":                     "JOINT FILING AGREEMENT\n\nThe undersigned agree to file jointly.\n",
+	}
+
+	var urls []string
+	client := edgar.NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		urls = append(urls, r.URL.String())
+		body, ok := pages[r.URL.String()]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusNotFound
+		}
+		return &http.Response{StatusCode: status, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})}
+
+	exhibits, err := client.FetchExhibits("0001263508", "0000902664-24-000123")
+	require.NoError(t, err)
+	require.Len(t, exhibits, 3)
+	assert.NotContains(t, urls, base+"chart.jpg", "images are listed but not downloaded")
+
+	assert.Equal(t, "99.1", exhibits[0].Number)
+	assert.Equal(t, "Dear Members of the Board,\nWe urge the Board to explore a sale.", exhibits[0].Text)
+	assert.Equal(t, "JOINT FILING AGREEMENT\nThe undersigned agree to file jointly.", exhibits[1].Text)
+	assert.Equal(t, "", exhibits[2].Text)
+
+	filing := &edgar.Schedule13Filing{
+		FormType: "SC 13D/A",
+		Items13D: &edgar.Schedule13DItems{Item7Exhibits: "Exhibit 99.1 - Letter to the Board of Directors, dated May 1, 2024"},
+	}
+	filing.AttachExhibits(exhibits)
+	require.Len(t, filing.Exhibits, 3)
+	assert.True(t, filing.Exhibits[0].ListedInItem7)
+	assert.False(t, filing.Exhibits[1].ListedInItem7)
+	assert.False(t, filing.Exhibits[2].ListedInItem7)
+	assert.Len(t, filing.ToOutput().Exhibits, 3)
+}
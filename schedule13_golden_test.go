@@ -0,0 +1,104 @@
+package edgar_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RxDataLab/go-edgar"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+// Schedule13TestCase represents a Schedule 13D/G test case with metadata and expected output
+type Schedule13TestCase struct {
+	Metadata TestCaseMetadata        `json:"metadata"`
+	Expected *edgar.Schedule13Output `json:"expected"`
+}
+
+// TestSchedule13Parser is a data-driven test that discovers and tests all Schedule 13D/G test cases
+// Test cases are stored in testdata/schedule13/<case_name>/ with:
+//   - input.xml or input.htm: The XML or HTML filing
+//   - expected.json: The expected parsed output with metadata
+func TestSchedule13Parser(t *testing.T) {
+	testCasesDir := "testdata/schedule13"
+
+	entries, err := os.ReadDir(testCasesDir)
+	require.NoError(t, err, "failed to read test cases directory")
+
+	var testCases []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			testCases = append(testCases, entry.Name())
+		}
+	}
+
+	require.NotEmpty(t, testCases, "no test cases found in %s", testCasesDir)
+
+	for _, testCase := range testCases {
+		t.Run(testCase, func(t *testing.T) {
+			casePath := filepath.Join(testCasesDir, testCase)
+			expectedPath := filepath.Join(casePath, "expected.json")
+
+			// XML and HTML filings share the layout; exactly one input file per case
+			inputs, err := filepath.Glob(filepath.Join(casePath, "input.*"))
+			require.NoError(t, err)
+			require.Len(t, inputs, 1, "expected one input.xml or input.htm")
+
+			inputData, err := os.ReadFile(inputs[0])
+			require.NoError(t, err, "failed to read %s", inputs[0])
+
+			expectedData, err := os.ReadFile(expectedPath)
+			require.NoError(t, err, "failed to read expected.json")
+
+			var tc Schedule13TestCase
+			err = json.Unmarshal(expectedData, &tc)
+			require.NoError(t, err, "failed to parse expected.json")
+
+			t.Logf("Source: %s", tc.Metadata.SourceURL)
+			t.Logf("Notes: %s", tc.Metadata.Notes)
+
+			// Parse the way the CLI does (form detection, text normalization, XML/HTML auto-detection)
+			parsed, err := edgar.ParseAny(bytes.NewReader(inputData))
+			require.NoError(t, err, "failed to parse Schedule 13")
+
+			filing, ok := parsed.Data.(*edgar.Schedule13Filing)
+			require.True(t, ok, "expected *Schedule13Filing, got %T", parsed.Data)
+
+			freshOutput := filing.ToOutput()
+
+			newPath := expectedPath + ".new"
+			if diff := cmp.Diff(tc.Expected, freshOutput); diff != "" {
+				tc.Expected = freshOutput
+				newData, err := json.MarshalIndent(tc, "", "  ")
+				require.NoError(t, err, "failed to marshal new output")
+
+				err = os.WriteFile(newPath, newData, 0o644)
+				require.NoError(t, err, "failed to write .new file")
+
+				if *updateGolden {
+					err = os.WriteFile(expectedPath, newData, 0o644)
+					require.NoError(t, err, "failed to update golden file")
+					os.Remove(newPath)
+
+					t.Logf("✓ Accepted new snapshot: %s", expectedPath)
+				} else {
+					t.Errorf("Snapshot mismatch!\n\n"+
+						"DIFF (-committed +fresh):\n%s\n\n"+
+						"A new snapshot has been written to:\n  %s\n\n"+
+						"To review the change:\n"+
+						"  diff %s %s\n\n"+
+						"If the new output is CORRECT, accept it with:\n"+
+						"  go test -v -run TestSchedule13Parser/%s -update\n\n"+
+						"If the new output is WRONG, fix the parser and re-run tests.",
+						diff, newPath, expectedPath, newPath, testCase)
+				}
+			} else if _, err := os.Stat(newPath); err == nil {
+				// Output matches golden file - clean up stale .new files
+				os.Remove(newPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package edgar
+
+import (
+	"sort"
+	"strings"
+)
+
+// Schedule13HistoryPoint is a filer's reported position at one point in a 13D/G amendment chain
+type Schedule13HistoryPoint struct {
+	Date            string  `json:"date"` // Event date (YYYY-MM-DD), falling back to the filing date
+	FilingDate      string  `json:"filingDate,omitempty"`
+	FormType        string  `json:"formType"`
+	AmendmentNumber *int    `json:"amendmentNumber"`
+	Shares          int64   `json:"shares"`         // See CalculateTotalShares
+	PercentOfClass  float64 `json:"percentOfClass"` // See CalculateTotalPercent
+	SharesChange    int64   `json:"sharesChange"`   // Relative to the previous point (0 for the first)
+	PercentChange   float64 `json:"percentChange"`
+}
+
+// Schedule13Chain is the ordered 13D/G filing chain of one filer for one issuer
+type Schedule13Chain struct {
+	IssuerCIK   string                   `json:"issuerCik"`
+	IssuerName  string                   `json:"issuerName"`
+	IssuerCUSIP string                   `json:"issuerCusip,omitempty"`
+	FilerCIK    string                   `json:"filerCik,omitempty"`
+	FilerName   string                   `json:"filerName"`
+	Filings     []*Schedule13Filing      `json:"-"` // Original first, then amendments in order
+	History     []Schedule13HistoryPoint `json:"history"`
+}
+
+// Schedule13Chains groups 13D/G filings by issuer and filer and reconstructs
+// each group's amendment chain and ownership history (see Schedule13History)
+//
+// The issuer is identified by CIK, else CUSIP, else name; the filer by the
+// header filer CIK, else the first reporting person. A CIK or CUSIP missing
+// from some filings is taken from the others with the same CUSIP or name, so
+// a chain doesn't split when only some amendments carry it. A filer that
+// moves from 13G to 13D (or back) for the same issuer stays in one chain.
+// Chains are sorted by issuer name, then filer name.
+func Schedule13Chains(filings []*Schedule13Filing) []Schedule13Chain {
+	ids := newSchedule13Identities(filings)
+	index := make(map[string]int)
+	var chains []Schedule13Chain
+	for _, f := range filings {
+		if f == nil {
+			continue
+		}
+		filerCIK, filerName := schedule13Filer(f)
+		if filerCIK == "" {
+			filerCIK = ids.filerCIKs[normalizeHolderName(filerName)]
+		}
+		key := ids.issuerKey(f) + "|" + holderKey(filerCIK, filerName)
+
+		i, ok := index[key]
+		if !ok {
+			i = len(chains)
+			index[key] = i
+			chains = append(chains, Schedule13Chain{
+				IssuerCIK:   f.IssuerCIK,
+				IssuerName:  f.IssuerName,
+				IssuerCUSIP: f.IssuerCUSIP,
+				FilerCIK:    filerCIK,
+				FilerName:   filerName,
+			})
+		}
+		chains[i].Filings = append(chains[i].Filings, f)
+		if chains[i].IssuerCIK == "" {
+			chains[i].IssuerCIK = f.IssuerCIK
+		}
+		if chains[i].IssuerCUSIP == "" {
+			chains[i].IssuerCUSIP = f.IssuerCUSIP
+		}
+	}
+
+	for i := range chains {
+		chains[i].Filings = orderSchedule13Chain(chains[i].Filings)
+		chains[i].History = schedule13Series(chains[i].Filings)
+	}
+
+	sort.SliceStable(chains, func(i, j int) bool {
+		if chains[i].IssuerName != chains[j].IssuerName {
+			return chains[i].IssuerName < chains[j].IssuerName
+		}
+		return chains[i].FilerName < chains[j].FilerName
+	})
+	return chains
+}
+
+// Schedule13History orders one filer's 13D/G filings for one issuer (original
+// and amendments) and returns the reported position over time
+//
+// Filings are ordered like OwnershipAsOf supersession: by event date, then
+// filing date, then amendment number. When several filings report the same
+// event date (e.g. an amendment correcting the original), the last one wins
+// and the series has one point for that date. Filings without any usable
+// date are skipped.
+func Schedule13History(filings []*Schedule13Filing) []Schedule13HistoryPoint {
+	return schedule13Series(orderSchedule13Chain(filings))
+}
+
+// orderSchedule13Chain sorts filings into chain order, dropping undated ones
+func orderSchedule13Chain(filings []*Schedule13Filing) []*Schedule13Filing {
+	type entry struct {
+		filing    *Schedule13Filing
+		candidate ownershipCandidate
+	}
+
+	var entries []entry
+	for _, f := range filings {
+		if f == nil {
+			continue
+		}
+		date, ok := schedule13EffectiveDate(f)
+		if !ok {
+			continue
+		}
+		filingDate, _ := normalizeFilingDate(f.FilingDate)
+		amendment := 0
+		if f.AmendmentNumber != nil {
+			amendment = *f.AmendmentNumber
+		}
+		entries = append(entries, entry{
+			filing: f,
+			candidate: ownershipCandidate{
+				position:  OwnershipPosition{EffectiveDate: date, FilingDate: filingDate},
+				amendment: amendment,
+			},
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return supersedes(entries[j].candidate, entries[i].candidate)
+	})
+
+	ordered := make([]*Schedule13Filing, len(entries))
+	for i, e := range entries {
+		ordered[i] = e.filing
+	}
+	return ordered
+}
+
+// schedule13Series builds the history from filings already in chain order
+func schedule13Series(filings []*Schedule13Filing) []Schedule13HistoryPoint {
+	var series []Schedule13HistoryPoint
+	for _, f := range filings {
+		date, _ := schedule13EffectiveDate(f)
+		filingDate, _ := normalizeFilingDate(f.FilingDate)
+		point := Schedule13HistoryPoint{
+			Date:            date,
+			FilingDate:      filingDate,
+			FormType:        f.FormType,
+			AmendmentNumber: f.AmendmentNumber,
+			Shares:          f.CalculateTotalShares(),
+			PercentOfClass:  f.CalculateTotalPercent(),
+		}
+
+		// A later filing for the same event date replaces the earlier point
+		if n := len(series); n > 0 && series[n-1].Date == date {
+			series = series[:n-1]
+		}
+		if n := len(series); n > 0 {
+			point.SharesChange = point.Shares - series[n-1].Shares
+			point.PercentChange = point.PercentOfClass - series[n-1].PercentOfClass
+		}
+		series = append(series, point)
+	}
+	return series
+}
+
+// schedule13EffectiveDate is the date a filing's position became true:
+// the 13D date of event, the 13G event date, else the filing date
+func schedule13EffectiveDate(f *Schedule13Filing) (string, bool) {
+	for _, raw := range []string{f.DateOfEvent, f.EventDate, f.FilingDate} {
+		if date, ok := normalizeFilingDate(raw); ok {
+			return date, true
+		}
+	}
+	return "", false
+}
+
+// schedule13Filer identifies the filer of a 13D/G (header CIK, else first reporting person)
+func schedule13Filer(f *Schedule13Filing) (cik, name string) {
+	cik = strings.TrimLeft(f.FilerCIK, "0")
+	if len(f.ReportingPersons) > 0 {
+		name = f.ReportingPersons[0].Name
+		if cik == "" {
+			cik = strings.TrimLeft(f.ReportingPersons[0].CIK, "0")
+		}
+	}
+	return cik, name
+}
+
+// schedule13Identities fills in the issuer and filer identifiers a filing
+// leaves out from other filings in the same set
+type schedule13Identities struct {
+	issuerCIKs   map[string]string // "cusip:"/"name:" key -> issuer CIK
+	issuerCUSIPs map[string]string // Normalized issuer name -> CUSIP
+	filerCIKs    map[string]string // Normalized filer name -> filer CIK
+}
+
+func newSchedule13Identities(filings []*Schedule13Filing) *schedule13Identities {
+	ids := &schedule13Identities{
+		issuerCIKs:   make(map[string]string),
+		issuerCUSIPs: make(map[string]string),
+		filerCIKs:    make(map[string]string),
+	}
+	for _, f := range filings {
+		if f == nil {
+			continue
+		}
+		cik, cusip, name := schedule13IssuerIDs(f)
+		if cik != "" && cusip != "" {
+			ids.issuerCIKs["cusip:"+cusip] = cik
+		}
+		if cik != "" && name != "" {
+			ids.issuerCIKs["name:"+name] = cik
+		}
+		if cusip != "" && name != "" {
+			ids.issuerCUSIPs[name] = cusip
+		}
+		if filerCIK, filerName := schedule13Filer(f); filerCIK != "" && filerName != "" {
+			ids.filerCIKs[normalizeHolderName(filerName)] = filerCIK
+		}
+	}
+	return ids
+}
+
+// issuerKey identifies the subject company: CIK, else CUSIP, else name, each
+// looked up from the other filings when the filing has none
+func (ids *schedule13Identities) issuerKey(f *Schedule13Filing) string {
+	cik, cusip, name := schedule13IssuerIDs(f)
+	if cik == "" && cusip != "" {
+		cik = ids.issuerCIKs["cusip:"+cusip]
+	}
+	if cik == "" && name != "" {
+		cik = ids.issuerCIKs["name:"+name]
+	}
+	if cik != "" {
+		return "cik:" + cik
+	}
+	if cusip == "" {
+		cusip = ids.issuerCUSIPs[name]
+	}
+	if cusip != "" {
+		return "cusip:" + cusip
+	}
+	return "name:" + name
+}
+
+// schedule13IssuerIDs returns a filing's normalized issuer CIK, CUSIP and name
+func schedule13IssuerIDs(f *Schedule13Filing) (cik, cusip, name string) {
+	return strings.TrimLeft(f.IssuerCIK, "0"),
+		strings.ToUpper(strings.TrimSpace(f.IssuerCUSIP)),
+		strings.ToUpper(strings.Join(strings.Fields(f.IssuerName), " "))
+}
@@ -0,0 +1,78 @@
+package edgar
+
+import "testing"
+
+func TestSchedule13Chains(t *testing.T) {
+	one, two := 1, 2
+	person := func(shares int64, percent float64) []ReportingPerson13 {
+		return []ReportingPerson13{{CIK: "0000222222", Name: "Fund LP", AggregateAmountOwned: shares, PercentOfClass: percent}}
+	}
+
+	filings := []*Schedule13Filing{
+		// Out of order on purpose; amendment 1 corrects the original's event date figures
+		{FormType: "SC 13D/A", IsAmendment: true, AmendmentNumber: &two, FilingDate: "2024-09-03", DateOfEvent: "08/30/2024",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp", FilerCIK: "0000222222", ReportingPersons: person(3000000, 3.6)},
+		{FormType: "SC 13D", FilingDate: "2024-05-10", DateOfEvent: "05/01/2024",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp", FilerCIK: "0000222222", ReportingPersons: person(5000000, 6.1)},
+		{FormType: "SC 13D/A", IsAmendment: true, AmendmentNumber: &one, FilingDate: "2024-05-20", DateOfEvent: "05/01/2024",
+			IssuerCIK: "1234567", IssuerName: "Acme Corp", FilerCIK: "222222", ReportingPersons: person(5100000, 6.2)},
+		// Earlier passive position by the same filer joins the chain
+		{FormType: "SC 13G", FilingDate: "2024-02-14", EventDate: "12/31/2023",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp", FilerCIK: "0000222222", ReportingPersons: person(4000000, 4.9)},
+		// Different filer, same issuer
+		{FormType: "SC 13G", FilingDate: "2024-02-01", EventDate: "01/31/2024",
+			IssuerCIK: "0001234567", IssuerName: "Acme Corp",
+			ReportingPersons: []ReportingPerson13{{CIK: "0000333333", Name: "Index Fund", AggregateAmountOwned: 9000000, PercentOfClass: 10.8}}},
+	}
+
+	chains := Schedule13Chains(filings)
+	if len(chains) != 2 {
+		t.Fatalf("got %d chains, want 2", len(chains))
+	}
+
+	fund := chains[0]
+	if fund.FilerName != "Fund LP" || fund.FilerCIK != "222222" {
+		t.Fatalf("first chain filer = %q (%s), want Fund LP (222222)", fund.FilerName, fund.FilerCIK)
+	}
+	if len(fund.Filings) != 4 || fund.Filings[0].FormType != "SC 13G" || fund.Filings[3].AmendmentNumber != &two {
+		t.Errorf("filings not in chain order: %+v", fund.Filings)
+	}
+
+	want := []Schedule13HistoryPoint{
+		{Date: "2023-12-31", FormType: "SC 13G", Shares: 4000000, PercentOfClass: 4.9},
+		{Date: "2024-05-01", FormType: "SC 13D/A", Shares: 5100000, PercentOfClass: 6.2, SharesChange: 1100000},
+		{Date: "2024-08-30", FormType: "SC 13D/A", Shares: 3000000, PercentOfClass: 3.6, SharesChange: -2100000},
+	}
+	if len(fund.History) != len(want) {
+		t.Fatalf("got %d history points, want %d: %+v", len(fund.History), len(want), fund.History)
+	}
+	for i, w := range want {
+		got := fund.History[i]
+		if got.Date != w.Date || got.FormType != w.FormType || got.Shares != w.Shares ||
+			got.PercentOfClass != w.PercentOfClass || got.SharesChange != w.SharesChange {
+			t.Errorf("point %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if fund.History[1].AmendmentNumber == nil || *fund.History[1].AmendmentNumber != 1 {
+		t.Errorf("same-date amendment should replace the original, got %+v", fund.History[1])
+	}
+
+	if chains[1].FilerName != "Index Fund" || len(chains[1].History) != 1 {
+		t.Errorf("second chain = %+v", chains[1])
+	}
+
+	// Amendments carrying only the CUSIP, or only the issuer and filer names,
+	// stay in the chain of the original that carries all of them
+	partial := []*Schedule13Filing{
+		{FormType: "SC 13D", FilingDate: "2024-05-10", DateOfEvent: "05/01/2024", IssuerCIK: "0001234567", IssuerCUSIP: "00123Q104",
+			IssuerName: "Acme Corp", FilerCIK: "0000222222", ReportingPersons: person(5000000, 6.1)},
+		{FormType: "SC 13D/A", IsAmendment: true, AmendmentNumber: &one, FilingDate: "2024-06-10", DateOfEvent: "06/05/2024",
+			IssuerCUSIP: "00123q104", IssuerName: "ACME CORPORATION", FilerCIK: "222222", ReportingPersons: person(5500000, 6.7)},
+		{FormType: "SC 13D/A", IsAmendment: true, AmendmentNumber: &two, FilingDate: "2024-07-10", DateOfEvent: "07/05/2024",
+			IssuerName: "Acme  Corp", ReportingPersons: []ReportingPerson13{{Name: "Fund LP", AggregateAmountOwned: 6000000, PercentOfClass: 7.3}}},
+	}
+	chains = Schedule13Chains(partial)
+	if len(chains) != 1 || len(chains[0].History) != 3 || chains[0].IssuerCUSIP != "00123Q104" {
+		t.Errorf("Expected one chain of 3 points, got %+v", chains)
+	}
+}
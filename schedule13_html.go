@@ -1,6 +1,8 @@
 package edgar
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"regexp"
@@ -13,10 +15,20 @@ import (
 // ParseSchedule13HTML parses HTML/XHTML rendered Schedule 13D or 13G filings.
 // This handles the modern SEC filing format where data is in HTML tables.
 func ParseSchedule13HTML(data []byte) (*Schedule13Filing, error) {
-	doc, err := html.Parse(strings.NewReader(string(data)))
+	return ParseSchedule13HTMLReader(bytes.NewReader(data))
+}
+
+// ParseSchedule13HTMLReader parses an HTML/XHTML Schedule 13D or 13G filing
+// from a reader, without first copying the document into a string
+func ParseSchedule13HTMLReader(r io.Reader) (*Schedule13Filing, error) {
+	doc, err := html.Parse(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
+	// Non-breaking and other Unicode spaces, invisible characters and
+	// double-escaped entities are normalized once here, so markers match as
+	// plain text everywhere below
+	normalizeTextNodes(doc)
 
 	filing := &Schedule13Filing{}
 
@@ -37,21 +49,20 @@ func ParseSchedule13HTML(data []byte) (*Schedule13Filing, error) {
 	// Extract issuer information from HTML
 	// Try multiple extraction strategies in order of reliability:
 
+	conf := &ExtractionConfidence{}
+
 	// 1. Try extracting from Item 1(a) (most reliable when present)
 	filing.IssuerName = extractFromItem1a(doc, pageText)
+	nameStrategy, nameFallback := StrategyItem1a, false
 
 	// 2. If Item 1(a) not found, extract from cover page <B> tags before markers
 	if filing.IssuerName == "" {
 		filing.IssuerName = extractBoldBeforeMarker(doc, "(Name of Issuer)")
+		nameStrategy, nameFallback = StrategyCoverPage, true
 	}
 
 	// Security title and CUSIP always from cover page
-	// Note: &nbsp; in HTML is converted to \u00a0 (non-breaking space) by the parser
 	filing.SecurityTitle = extractBoldBeforeMarker(doc, "(Title of Class of Securities)")
-	if filing.SecurityTitle == "" {
-		// Try with non-breaking space
-		filing.SecurityTitle = extractBoldBeforeMarker(doc, "(Title of Class\u00a0of Securities)")
-	}
 
 	filing.IssuerCUSIP = extractBoldBeforeMarker(doc, "(CUSIP Number)")
 	if filing.IssuerCUSIP == "" {
@@ -62,10 +73,17 @@ func ParseSchedule13HTML(data []byte) (*Schedule13Filing, error) {
 	// Clean up extracted values
 	filing.IssuerName = strings.TrimSpace(filing.IssuerName)
 	filing.SecurityTitle = strings.TrimSpace(filing.SecurityTitle)
-	filing.IssuerCUSIP = strings.TrimSpace(filing.IssuerCUSIP)
 
-	// Remove footnote markers from CUSIP (e.g., "088786108**" -> "088786108")
-	filing.IssuerCUSIP = regexp.MustCompile(`[*†‡§]+$`).ReplaceAllString(filing.IssuerCUSIP, "")
+	// Remove footnote markers and spacing from CUSIP (e.g., "088786108**" -> "088786108")
+	filing.setIssuerCUSIP(filing.IssuerCUSIP)
+
+	conf.addText("issuerName", filing.IssuerName, nameStrategy, nameFallback)
+	conf.addText("securityTitle", filing.SecurityTitle, StrategyCoverPage, false)
+	if filing.IssuerCUSIP != "" && !filing.IssuerCUSIPValid {
+		conf.add(FieldConfidence{Field: "issuerCusip", Strategy: StrategyCoverPage, Confidence: ConfidenceLow, Note: "fails CUSIP validation"})
+	} else {
+		conf.addText("issuerCusip", filing.IssuerCUSIP, StrategyCoverPage, false)
+	}
 
 	// Extract event date
 	eventDate := extractBetween(pageText, "(Date of Event Which Requires Filing of this Statement)", "Check the appropriate box")
@@ -73,9 +91,16 @@ func ParseSchedule13HTML(data []byte) (*Schedule13Filing, error) {
 		eventDate = extractBetween(pageText, "(Date of Event Which Requires Filing of This Statement)", "Check the appropriate box")
 	}
 	filing.EventDate = strings.TrimSpace(eventDate)
+	if filing.EventDate == "" {
+		// Not needed for ownership figures; missing dates fall back to the filing date
+		conf.add(FieldConfidence{Field: "eventDate", Strategy: StrategyNone, Confidence: ConfidenceMedium, Note: "not found"})
+	} else {
+		conf.addText("eventDate", filing.EventDate, StrategyLabeledText, false)
+	}
 
 	// Extract reporting persons from HTML tables
-	filing.ReportingPersons = extractReportingPersonsHTML(doc)
+	filing.ReportingPersons = extractReportingPersonsHTML(doc, filing.FormType, conf)
+	filing.Extraction = conf
 
 	// Extract rule designations for 13G
 	if strings.Contains(filing.FormType, "13G") {
@@ -100,24 +125,45 @@ func ParseSchedule13HTML(data []byte) (*Schedule13Filing, error) {
 	return filing, nil
 }
 
-// extractReportingPersonsHTML extracts reporting person data from HTML tables.
-func extractReportingPersonsHTML(doc *html.Node) []ReportingPerson13 {
+// extractReportingPersonsHTML extracts reporting person data from HTML tables
+// and records the confidence of each person's amounts
+func extractReportingPersonsHTML(doc *html.Node, formType string, conf *ExtractionConfidence) []ReportingPerson13 {
+	var persons []ReportingPerson13
+	var strategies, ambiguity []string
+
 	// Try modern XHTML format first (with id="reportingPersonDetails")
-	modernTables := findAllTables(doc, "reportingPersonDetails")
-	if len(modernTables) > 0 {
-		return extractModernXHTMLPersons(modernTables)
+	if modernTables := findAllTables(doc, "reportingPersonDetails"); len(modernTables) > 0 {
+		persons, strategies, ambiguity = extractModernXHTMLPersons(modernTables, formType)
+	} else {
+		// Fall back to old HTML format (tables with "NAMES OF REPORTING PERSONS")
+		persons, strategies = extractOldHTMLPersons(doc, formType)
+		ambiguity = make([]string, len(persons))
 	}
 
-	// Fall back to old HTML format (tables with "NAMES OF REPORTING PERSONS")
-	return extractOldHTMLPersons(doc)
+	if len(persons) == 0 {
+		conf.add(FieldConfidence{Field: "reportingPersons", Strategy: StrategyNone, Confidence: ConfidenceLow, Note: "no reporting persons found"})
+	}
+	for i, p := range persons {
+		conf.addPerson(i, p, strategies[i], ambiguity[i])
+	}
+	return persons
 }
 
+// positionalZero matches cells that report a zero amount ("0", "-0-", "None")
+var positionalZero = regexp.MustCompile(`(?i)^(0|-0-|none)$`)
+
 // extractModernXHTMLPersons handles modern XHTML format with styled divs
-func extractModernXHTMLPersons(tables []*html.Node) []ReportingPerson13 {
+// Amounts are read by cover page row number when the rows can be located;
+// otherwise numbers are assigned in the order they appear. It also returns,
+// per person, the strategy used and why a positional assignment was
+// ambiguous ("" when every amount had a clear slot).
+func extractModernXHTMLPersons(tables []*html.Node, formType string) ([]ReportingPerson13, []string, []string) {
 	var persons []ReportingPerson13
+	var strategies, ambiguity []string
 
 	for _, table := range tables {
 		person := ReportingPerson13{}
+		assigned, skipped, zeros := 0, 0, 0
 
 		// Extract all text divs from this specific table
 		tableDivs := findAllTextDivsInNode(table)
@@ -134,6 +180,7 @@ func extractModernXHTMLPersons(tables []*html.Node) []ReportingPerson13 {
 			// Look for numeric values and assign based on position
 			if val := parseInt64(text); val > 0 {
 				// Assign based on which numeric field we haven't filled yet
+				assigned++
 				if person.SoleVotingPower == 0 {
 					person.SoleVotingPower = val
 				} else if person.SharedVotingPower == 0 && val != person.SoleVotingPower {
@@ -144,7 +191,14 @@ func extractModernXHTMLPersons(tables []*html.Node) []ReportingPerson13 {
 					person.SharedDispositivePower = val
 				} else if person.AggregateAmountOwned == 0 && val != person.SharedDispositivePower {
 					person.AggregateAmountOwned = val
+				} else {
+					assigned--
+					if !strings.Contains(text, "%") {
+						skipped++ // Percent of class is read separately below
+					}
 				}
+			} else if positionalZero.MatchString(text) {
+				zeros++
 			}
 
 			// Look for percentage
@@ -172,18 +226,43 @@ func extractModernXHTMLPersons(tables []*html.Node) []ReportingPerson13 {
 			person.Name = cleanReportingPersonName(person.Name)
 		}
 
+		// Row numbers pin each amount to its field; prefer them over positions
+		strategy, note := StrategyPositional, positionalAmbiguity(assigned, skipped, zeros)
+		if applyCoverRows(&person, coverPageRows(coverPageTexts(table)), formType) {
+			strategy, note = StrategyRowNumbers, ""
+		}
+
 		// Only add if we got meaningful data
 		if person.Name != "" && len(person.Name) > 3 {
 			persons = append(persons, person)
+			strategies = append(strategies, strategy)
+			ambiguity = append(ambiguity, note)
 		}
 	}
 
-	return persons
+	return persons, strategies, ambiguity
+}
+
+// positionalAmbiguity explains why positional amounts may be misassigned
+// Zero cells are skipped, so any zero or unplaced value shifts later amounts.
+func positionalAmbiguity(assigned, skipped, zeros int) string {
+	switch {
+	case zeros > 0:
+		return fmt.Sprintf("%d zero-valued cells shift positional assignment", zeros)
+	case skipped > 0:
+		return fmt.Sprintf("%d numeric values had no positional slot", skipped)
+	case assigned < 5:
+		return fmt.Sprintf("only %d of 5 amounts found", assigned)
+	}
+	return ""
 }
 
 // extractOldHTMLPersons handles old HTML format with multiple tables per person
-func extractOldHTMLPersons(doc *html.Node) []ReportingPerson13 {
+// Amounts are read by cover page row number when possible, falling back to the
+// text between row labels. The strategy used for each person is also returned.
+func extractOldHTMLPersons(doc *html.Node, formType string) ([]ReportingPerson13, []string) {
 	var persons []ReportingPerson13
+	var strategies []string
 
 	// Get all tables in the document
 	allTables := findAllTablesInOrder(doc)
@@ -198,7 +277,7 @@ func extractOldHTMLPersons(doc *html.Node) []ReportingPerson13 {
 	}
 
 	// For each "NAMES" table, combine data from it and the next 2 tables
-	for _, idx := range nameTableIndices {
+	for n, idx := range nameTableIndices {
 		person := ReportingPerson13{}
 
 		// Table 1: Name and citizenship
@@ -287,6 +366,16 @@ func extractOldHTMLPersons(doc *html.Node) []ReportingPerson13 {
 			}
 		}
 
+		// Prefer amounts read by row number over the text between labels
+		end := min(idx+3, len(allTables))
+		if n+1 < len(nameTableIndices) {
+			end = min(end, nameTableIndices[n+1])
+		}
+		strategy := StrategyLabeledTables
+		if applyCoverRows(&person, coverPageRows(coverPageTexts(allTables[idx:end]...)), formType) {
+			strategy = StrategyRowNumbers
+		}
+
 		// Clean up person name (remove trailing row numbers like "2.", "3.", etc.)
 		if person.Name != "" {
 			person.Name = cleanReportingPersonName(person.Name)
@@ -295,10 +384,11 @@ func extractOldHTMLPersons(doc *html.Node) []ReportingPerson13 {
 		// Only add if we got meaningful data
 		if person.Name != "" && len(person.Name) > 3 {
 			persons = append(persons, person)
+			strategies = append(strategies, strategy)
 		}
 	}
 
-	return persons
+	return persons, strategies
 }
 
 // findAllTextDivs finds all <div class="text"> elements
@@ -451,6 +541,17 @@ func renderNode(w io.Writer, n *html.Node) {
 	html.Render(w, n)
 }
 
+// normalizeTextNodes applies NormalizeText to every text node of a parsed
+// document; the entities of the markup itself were decoded by the parser
+func normalizeTextNodes(n *html.Node) {
+	if n.Type == html.TextNode {
+		n.Data = string(NormalizeText([]byte(n.Data)))
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		normalizeTextNodes(c)
+	}
+}
+
 // extractText extracts all text content from HTML
 func extractText(n *html.Node) string {
 	var buf strings.Builder
@@ -534,8 +635,8 @@ func extractFromItem1a(doc *html.Node, pageText string) string {
 	lines := strings.Split(chunk, "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-		// Skip empty lines, nbsp, and lines that are too short
-		if line == "" || line == "&nbsp;" || len(line) < 3 {
+		// Skip empty lines and lines that are too short
+		if line == "" || len(line) < 3 {
 			continue
 		}
 		// Skip lines that look like labels or markers
@@ -558,11 +659,7 @@ func extractBoldBeforeMarker(doc *html.Node, marker string) string {
 	// Find the paragraph containing the marker
 	var markerParagraphIdx = -1
 	for i, p := range paragraphs {
-		text := extractText(p)
-		// Check for marker with and without HTML entities
-		if strings.Contains(text, marker) ||
-			strings.Contains(text, strings.ReplaceAll(marker, " ", "\u00a0")) || // nbsp
-			strings.Contains(text, strings.ReplaceAll(marker, " ", "&nbsp;")) {
+		if strings.Contains(extractText(p), marker) {
 			markerParagraphIdx = i
 			break
 		}
@@ -584,12 +681,12 @@ func extractBoldBeforeMarker(doc *html.Node, marker string) string {
 		// (handles cases where value is in <FONT> or other tags)
 		paraText := extractText(paragraphs[i])
 		paraText = strings.TrimSpace(paraText)
-		// Clean up whitespace and remove nbsp
+		// Clean up whitespace
 		re := regexp.MustCompile(`\s+`)
 		paraText = re.ReplaceAllString(paraText, " ")
 
-		// Skip empty paragraphs and nbsp-only paragraphs
-		if paraText == "" || paraText == " " || strings.TrimSpace(strings.ReplaceAll(paraText, "\u00a0", "")) == "" {
+		// Skip empty paragraphs
+		if paraText == "" {
 			continue
 		}
 
@@ -757,6 +854,29 @@ func ParseSchedule13Auto(data []byte) (*Schedule13Filing, error) {
 	return ParseSchedule13HTML(data)
 }
 
+// ParseSchedule13Reader is ParseSchedule13Auto for a reader: the format is
+// detected from the start of the document, which is then parsed as it is read
+func ParseSchedule13Reader(r io.Reader) (*Schedule13Filing, error) {
+	br := bufio.NewReaderSize(r, detectWindow)
+	head, err := br.Peek(detectWindow)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	// The root element and its namespace come first in pure XML filings
+	if bytes.HasPrefix(bytes.TrimSpace(head), []byte("<?xml")) &&
+		bytes.Contains(head, []byte("<edgarSubmission")) &&
+		!bytes.Contains(head, []byte("<!DOCTYPE html")) {
+		if bytes.Contains(head, []byte("schedule13D")) {
+			return ParseSchedule13DReader(br)
+		} else if bytes.Contains(head, []byte("schedule13g")) {
+			return ParseSchedule13GReader(br)
+		}
+	}
+
+	return ParseSchedule13HTMLReader(br)
+}
+
 // extractSchedule13DItems extracts narrative Items 1-7 from Schedule 13D HTML
 func extractSchedule13DItems(doc *html.Node) *Schedule13DItems {
 	items := &Schedule13DItems{}
@@ -1030,9 +1150,6 @@ func findItemParagraphs(doc *html.Node) map[int]*html.Node {
 			searchText = searchText[:300]
 		}
 
-		// Normalize non-breaking spaces to regular spaces (Go's \s doesn't match \u00a0)
-		searchText = strings.ReplaceAll(searchText, "\u00a0", " ")
-
 		// Check if it matches the Item pattern
 		if matches := itemPattern.FindStringSubmatch(searchText); len(matches) >= 2 {
 			itemNum, err := strconv.Atoi(matches[1])
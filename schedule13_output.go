@@ -0,0 +1,120 @@
+package edgar
+
+// Schedule13Output represents the simplified JSON output structure for SC 13D/G
+type Schedule13Output struct {
+	Metadata         Schedule13Metadata        `json:"metadata"`
+	Issuer           Schedule13IssuerOutput    `json:"issuer"`
+	SecurityTitle    string                    `json:"securityTitle"`
+	ReportingPersons []ReportingPerson13Output `json:"reportingPersons"`
+	Totals           Schedule13Totals          `json:"totals"`
+	Items13D         *Schedule13DItems         `json:"items13D,omitempty"`
+	Items13G         *Schedule13GItems         `json:"items13G,omitempty"`
+	Extraction       *ExtractionConfidence     `json:"extraction,omitempty"`   // HTML filings only
+	Exhibits         []Exhibit                 `json:"exhibits,omitempty"`     // See AttachExhibits
+	PercentCheck     *PercentOfClassCheck      `json:"percentCheck,omitempty"` // See EnrichPercentOfClass
+	Warnings         []ParseWarning            `json:"warnings,omitempty"`
+}
+
+// Schedule13Metadata contains metadata about the filing
+type Schedule13Metadata struct {
+	FormType         string   `json:"formType"` // "SC 13D", "SC 13D/A", "SC 13G", "SC 13G/A"
+	IsAmendment      bool     `json:"isAmendment"`
+	AmendmentNumber  *int     `json:"amendmentNumber"` // null for originals and unnumbered amendments
+	FilingDate       string   `json:"filingDate"`      // From SEC index, empty if not available
+	AccessionNumber  string   `json:"accessionNumber,omitempty"`
+	FilerCIK         string   `json:"filerCik,omitempty"`
+	DateOfEvent      string   `json:"dateOfEvent,omitempty"` // 13D only
+	PreviouslyFiled  bool     `json:"previouslyFiled"`       // 13D only
+	EventDate        string   `json:"eventDate,omitempty"`   // 13G only
+	RuleDesignations []string `json:"ruleDesignations,omitempty"`
+}
+
+type Schedule13IssuerOutput struct {
+	CIK        string `json:"cik"`
+	Name       string `json:"name"`
+	CUSIP      string `json:"cusip"`
+	CUSIPValid bool   `json:"cusipValid"` // Check digit verified (see ValidateCUSIP)
+}
+
+type ReportingPerson13Output struct {
+	CIK                    string  `json:"cik"`
+	Name                   string  `json:"name"`
+	NoCIK                  bool    `json:"noCik,omitempty"`
+	AggregateAmountOwned   int64   `json:"aggregateAmountOwned"`
+	PercentOfClass         float64 `json:"percentOfClass"`
+	SoleVotingPower        int64   `json:"soleVotingPower"`
+	SharedVotingPower      int64   `json:"sharedVotingPower"`
+	SoleDispositivePower   int64   `json:"soleDispositivePower"`
+	SharedDispositivePower int64   `json:"sharedDispositivePower"`
+	MemberOfGroup          string  `json:"memberOfGroup,omitempty"` // "a" = joint filer, "b" = separate filer
+	IsAggregateExclude     bool    `json:"isAggregateExclude"`
+	TypeOfReportingPerson  string  `json:"typeOfReportingPerson,omitempty"`
+	FundType               string  `json:"fundType,omitempty"`
+	Citizenship            string  `json:"citizenship,omitempty"`
+	Comment                string  `json:"comment,omitempty"`
+}
+
+// Schedule13Totals are the filing-level ownership figures
+type Schedule13Totals struct {
+	TotalShares  int64   `json:"totalShares"`  // Joint filers counted once (see CalculateTotalShares)
+	TotalPercent float64 `json:"totalPercent"` // Largest reported percent of class
+	IsActivist   bool    `json:"isActivist"`   // 13D (true) vs 13G (false)
+}
+
+// ToOutput converts a Schedule13Filing to the simplified output structure
+func (s *Schedule13Filing) ToOutput() *Schedule13Output {
+	out := &Schedule13Output{
+		Metadata: Schedule13Metadata{
+			FormType:         s.FormType,
+			IsAmendment:      s.IsAmendment,
+			AmendmentNumber:  s.AmendmentNumber,
+			FilingDate:       s.FilingDate,
+			AccessionNumber:  s.AccessionNumber,
+			FilerCIK:         s.FilerCIK,
+			DateOfEvent:      s.DateOfEvent,
+			PreviouslyFiled:  s.PreviouslyFiled,
+			EventDate:        s.EventDate,
+			RuleDesignations: s.RuleDesignations,
+		},
+		Issuer: Schedule13IssuerOutput{
+			CIK:        s.IssuerCIK,
+			Name:       s.IssuerName,
+			CUSIP:      s.IssuerCUSIP,
+			CUSIPValid: s.IssuerCUSIPValid,
+		},
+		SecurityTitle:    s.SecurityTitle,
+		ReportingPersons: make([]ReportingPerson13Output, 0, len(s.ReportingPersons)),
+		Totals: Schedule13Totals{
+			TotalShares:  s.CalculateTotalShares(),
+			TotalPercent: s.CalculateTotalPercent(),
+			IsActivist:   s.IsActivist(),
+		},
+		Items13D:     s.Items13D,
+		Items13G:     s.Items13G,
+		Extraction:   s.Extraction,
+		Exhibits:     s.Exhibits,
+		PercentCheck: s.PercentCheck,
+		Warnings:     s.Warnings,
+	}
+
+	for _, p := range s.ReportingPersons {
+		out.ReportingPersons = append(out.ReportingPersons, ReportingPerson13Output{
+			CIK:                    p.CIK,
+			Name:                   p.Name,
+			NoCIK:                  p.NoCIK,
+			AggregateAmountOwned:   p.AggregateAmountOwned,
+			PercentOfClass:         p.PercentOfClass,
+			SoleVotingPower:        p.SoleVotingPower,
+			SharedVotingPower:      p.SharedVotingPower,
+			SoleDispositivePower:   p.SoleDispositivePower,
+			SharedDispositivePower: p.SharedDispositivePower,
+			MemberOfGroup:          p.MemberOfGroup,
+			IsAggregateExclude:     p.IsAggregateExclude,
+			TypeOfReportingPerson:  p.TypeOfReportingPerson,
+			FundType:               p.FundType,
+			Citizenship:            p.Citizenship,
+			Comment:                p.Comment,
+		})
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package edgar
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchedule13Filing_ToOutput(t *testing.T) {
+	filing := &Schedule13Filing{
+		FormType: "SC 13D/A", IsAmendment: true, AmendmentNumber: ptrInt(2), FilingDate: "2024-05-20",
+		IssuerCIK: "1234567", IssuerName: "Acme Corp", IssuerCUSIP: "000000000",
+		DateOfEvent: "05/01/2024",
+		ReportingPersons: []ReportingPerson13{
+			{CIK: "111", Name: "Fund LP", AggregateAmountOwned: 5000000, PercentOfClass: 6.1, MemberOfGroup: "a"},
+			{CIK: "222", Name: "Fund GP LLC", AggregateAmountOwned: 5000000, PercentOfClass: 6.1, MemberOfGroup: "a"},
+		},
+		Items13D: &Schedule13DItems{Item4PurposeOfTransaction: "Seek board representation."},
+	}
+
+	out := filing.ToOutput()
+	if out.Metadata.FormType != "SC 13D/A" || *out.Metadata.AmendmentNumber != 2 || out.Issuer.CUSIP != "000000000" {
+		t.Errorf("Unexpected metadata/issuer: %+v %+v", out.Metadata, out.Issuer)
+	}
+	if len(out.ReportingPersons) != 2 || out.ReportingPersons[1].Name != "Fund GP LLC" {
+		t.Errorf("Unexpected reporting persons: %+v", out.ReportingPersons)
+	}
+	want := Schedule13Totals{TotalShares: 5000000, TotalPercent: 6.1, IsActivist: true}
+	if out.Totals != want {
+		t.Errorf("Totals = %+v, want %+v (joint filers counted once)", out.Totals, want)
+	}
+
+	// JSON output encodes the simplified output
+	data, err := FormatJSON(&ParsedForm{FormType: filing.FormType, Data: filing})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded struct{ Data Schedule13Output }
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Data.Issuer.Name != "Acme Corp" || decoded.Data.Items13D == nil || decoded.Data.Items13D.Item4PurposeOfTransaction == "" {
+		t.Errorf("Unexpected JSON output: %s", data)
+	}
+
+	// Checkpoints keep the full filing
+	raw, err := json.Marshal(filing)
+	if err != nil {
+		t.Fatalf("Marshal record failed: %v", err)
+	}
+	parsed, err := decodeParsedForm("SC 13D", raw)
+	if err != nil {
+		t.Fatalf("decodeParsedForm failed: %v", err)
+	}
+	restored, ok := parsed.Data.(*Schedule13Filing)
+	if !ok || restored.IssuerCUSIP != "000000000" || len(restored.ReportingPersons) != 2 || restored.ReportingPersons[0].MemberOfGroup != "a" {
+		t.Errorf("Checkpoint round trip lost data: %+v", parsed.Data)
+	}
+}
@@ -0,0 +1,84 @@
+package edgar
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultPercentTolerance is how far (in percentage points) a stated percent of
+// class may differ from the recomputed one before it is flagged
+const DefaultPercentTolerance = 1.0
+
+// PercentOfClassCheck compares the stated percent of class of each reporting
+// person with the percent recomputed from the issuer's shares outstanding
+//
+// Divergence is not necessarily an error: under Rule 13d-3(d)(1) a holder of
+// warrants or convertibles adds the underlying shares to the denominator, and
+// filers may use a share count from a different date.
+type PercentOfClassCheck struct {
+	SharesOutstanding SharesOutstanding    `json:"sharesOutstanding"`
+	Tolerance         float64              `json:"tolerance"` // Percentage points
+	Persons           []PersonPercentCheck `json:"persons"`
+	Diverges          bool                 `json:"diverges"` // Any person diverges
+}
+
+// PersonPercentCheck is the recomputed percent of class for one reporting person
+type PersonPercentCheck struct {
+	Name            string  `json:"name"`
+	StatedPercent   float64 `json:"statedPercent"`
+	ComputedPercent float64 `json:"computedPercent"` // Aggregate amount / shares outstanding, 2 decimals
+	Difference      float64 `json:"difference"`      // Stated - computed, in percentage points
+	Diverges        bool    `json:"diverges"`
+}
+
+// EnrichPercentOfClass fetches the issuer's shares outstanding and recomputes
+// each reporting person's percent of class (see RecomputePercentOfClass)
+func EnrichPercentOfClass(s *Schedule13Filing, email string) error {
+	return defaultClient(email).EnrichPercentOfClass(s)
+}
+
+// EnrichPercentOfClass fetches the issuer's company facts, takes the latest shares
+// outstanding reported on or before the event date and stores the comparison in
+// s.PercentCheck using DefaultPercentTolerance
+func (c *Client) EnrichPercentOfClass(s *Schedule13Filing) error {
+	if s.IssuerCIK == "" {
+		return fmt.Errorf("cannot check percent of class: issuer CIK unknown")
+	}
+
+	facts, err := c.FetchCompanyFacts(s.IssuerCIK)
+	if err != nil {
+		return err
+	}
+
+	asOf, _ := schedule13EffectiveDate(s)
+	shares, ok := facts.SharesOutstanding(asOf)
+	if !ok {
+		return fmt.Errorf("no shares outstanding reported for issuer CIK %s", s.IssuerCIK)
+	}
+
+	s.RecomputePercentOfClass(shares, DefaultPercentTolerance)
+	return nil
+}
+
+// RecomputePercentOfClass recomputes each reporting person's percent of class from
+// shares, flags persons whose stated percent differs by more than tolerance
+// percentage points, and stores the result in s.PercentCheck
+// Nothing is flagged when the issuer reports several share classes, since the
+// count for the filing's class cannot be told apart.
+func (s *Schedule13Filing) RecomputePercentOfClass(shares SharesOutstanding, tolerance float64) *PercentOfClassCheck {
+	check := &PercentOfClassCheck{SharesOutstanding: shares, Tolerance: tolerance}
+
+	for _, p := range s.ReportingPersons {
+		pc := PersonPercentCheck{Name: p.Name, StatedPercent: p.PercentOfClass}
+		if shares.Shares > 0 {
+			pc.ComputedPercent = math.Round(float64(p.AggregateAmountOwned)/float64(shares.Shares)*10000) / 100
+		}
+		pc.Difference = math.Round((pc.StatedPercent-pc.ComputedPercent)*100) / 100
+		pc.Diverges = shares.Shares > 0 && !shares.MultipleClasses && math.Abs(pc.Difference) > tolerance
+		check.Diverges = check.Diverges || pc.Diverges
+		check.Persons = append(check.Persons, pc)
+	}
+
+	s.PercentCheck = check
+	return check
+}
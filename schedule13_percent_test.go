@@ -0,0 +1,92 @@
+package edgar
+
+import (
+	"strings"
+	"testing"
+)
+
+const testCompanyFacts = `{
+  "cik": 1422142,
+  "entityName": "Aadi Bioscience, Inc.",
+  "facts": {
+    "dei": {
+      "EntityCommonStockSharesOutstanding": {
+        "label": "Entity Common Stock, Shares Outstanding",
+        "units": {
+          "shares": [
+            {"end": "2024-05-03", "val": 24522484, "accn": "0001422142-24-000020", "fy": 2024, "fp": "Q1", "form": "10-Q", "filed": "2024-05-08"},
+            {"end": "2024-11-01", "val": 24596578, "accn": "0001422142-24-000045", "fy": 2024, "fp": "Q3", "form": "10-Q", "filed": "2024-11-07"},
+            {"end": "2025-03-07", "val": 24720000, "accn": "0001422142-25-000010", "fy": 2024, "fp": "FY", "form": "10-K", "filed": "2025-03-12"}
+          ]
+        }
+      }
+    }
+  }
+}`
+
+func TestCompanyFactsSharesOutstanding(t *testing.T) {
+	facts, err := ParseCompanyFacts(strings.NewReader(testCompanyFacts))
+	if err != nil {
+		t.Fatalf("ParseCompanyFacts: %v", err)
+	}
+
+	tests := []struct {
+		asOf       string
+		wantShares int64
+		wantAsOf   string
+	}{
+		{"2024-12-31", 24596578, "2024-11-01"}, // Latest before the event date
+		{"", 24720000, "2025-03-07"},           // Latest overall
+		{"2020-01-01", 24720000, "2025-03-07"}, // Nothing that early: latest overall
+	}
+	for _, tt := range tests {
+		got, ok := facts.SharesOutstanding(tt.asOf)
+		if !ok {
+			t.Fatalf("SharesOutstanding(%q) found nothing", tt.asOf)
+		}
+		if got.Shares != tt.wantShares || got.AsOf != tt.wantAsOf {
+			t.Errorf("SharesOutstanding(%q) = %d as of %s, want %d as of %s", tt.asOf, got.Shares, got.AsOf, tt.wantShares, tt.wantAsOf)
+		}
+		if got.Concept != "dei:EntityCommonStockSharesOutstanding" {
+			t.Errorf("Concept = %q", got.Concept)
+		}
+	}
+
+	if CompanyFactsURL("1422142") != "https://data.sec.gov/api/xbrl/companyfacts/CIK0001422142.json" {
+		t.Errorf("CompanyFactsURL = %s", CompanyFactsURL("1422142"))
+	}
+}
+
+func TestRecomputePercentOfClass(t *testing.T) {
+	filing := &Schedule13Filing{
+		ReportingPersons: []ReportingPerson13{
+			{Name: "BML Investment Partners, L.P.", AggregateAmountOwned: 2100000, PercentOfClass: 8.5},
+			{Name: "Leonard Braden Michael", AggregateAmountOwned: 2435000, PercentOfClass: 12.4},
+		},
+	}
+	shares := SharesOutstanding{Shares: 24596578, AsOf: "2024-11-01"}
+
+	check := filing.RecomputePercentOfClass(shares, DefaultPercentTolerance)
+	if filing.PercentCheck != check || filing.ToOutput().PercentCheck != check {
+		t.Fatal("check not stored on the filing and its output")
+	}
+	if len(check.Persons) != 2 {
+		t.Fatalf("got %d persons, want 2", len(check.Persons))
+	}
+
+	if p := check.Persons[0]; p.ComputedPercent != 8.54 || p.Diverges {
+		t.Errorf("person 0 = %+v, want 8.54%% and no divergence", p)
+	}
+	if p := check.Persons[1]; p.ComputedPercent != 9.9 || p.Difference != 2.5 || !p.Diverges {
+		t.Errorf("person 1 = %+v, want 9.9%% diverging by 2.5 points", p)
+	}
+	if !check.Diverges {
+		t.Error("filing should be flagged")
+	}
+
+	// Per-class counts cannot be matched to the filing's class: compute but do not flag
+	shares.MultipleClasses = true
+	if filing.RecomputePercentOfClass(shares, DefaultPercentTolerance).Diverges {
+		t.Error("multi-class issuer should not be flagged")
+	}
+}
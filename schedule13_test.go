@@ -1,13 +1,16 @@
 package edgar
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 )
 
 func TestParseSchedule13D(t *testing.T) {
-	// Test with edgartools reference data
-	data, err := os.ReadFile("/home/nick/projects/port-edgartools/edgartools/tests/data/beneficial_ownership/schedule13d.xml")
+	// Test with the XML golden test case (see testdata/schedule13)
+	data, err := os.ReadFile("testdata/schedule13/aadi_13d_xml/input.xml")
 	if err != nil {
 		t.Fatalf("Failed to read test file: %v", err)
 	}
@@ -170,9 +173,13 @@ func ptrInt(i int) *int {
 	return &i
 }
 
+func ptrFloat(f float64) *float64 {
+	return &f
+}
+
 func TestParseSchedule13G(t *testing.T) {
-	// Test with edgartools reference data
-	data, err := os.ReadFile("/home/nick/projects/port-edgartools/edgartools/tests/data/beneficial_ownership/schedule13g.xml")
+	// Test with the XML golden test case (see testdata/schedule13)
+	data, err := os.ReadFile("testdata/schedule13/jushi_13g_xml/input.xml")
 	if err != nil {
 		t.Fatalf("Failed to read test file: %v", err)
 	}
@@ -297,3 +304,71 @@ func TestParseSchedule13G(t *testing.T) {
 		t.Error("Expected IsPassive() to return true for 13G")
 	}
 }
+
+// TestParseSchedule13HTML_Entities checks that entity-heavy variants of the HTML
+// fixtures (numeric, hex and double-escaped non-breaking spaces, thin spaces,
+// zero-width characters inside markers) parse exactly like the originals
+func TestParseSchedule13HTML_Entities(t *testing.T) {
+	spaces := []string{"&#160;", "&#xA0;", "&#8239;", "&#8201;", "&amp;nbsp;", "&nbsp;&#8203;"}
+	for _, name := range []string{"13d_2024_1", "13d_2024_2", "invitae_13g_2021", "vtv_13d_item4"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/schedule13/" + name + "/input.htm")
+			if err != nil {
+				t.Fatalf("Failed to read test file: %v", err)
+			}
+
+			// Swap every &nbsp; for another spelling, and the spaces of the
+			// cover page markers and Item headings for non-breaking ones
+			var variant bytes.Buffer
+			for i, part := range bytes.Split(data, []byte("&nbsp;")) {
+				if i > 0 {
+					variant.WriteString(spaces[i%len(spaces)])
+				}
+				variant.Write(part)
+			}
+			text := variant.String()
+			for _, marker := range []string{"(Title of Class of Securities)", "(CUSIP number)", "(CUSIP Number)", "(Name of Issuer)", "Item 4.", "Item 5."} {
+				text = strings.ReplaceAll(text, marker, strings.ReplaceAll(marker, " ", "&#160;"))
+			}
+			if text == string(data) {
+				t.Fatal("Variant is the same as the fixture")
+			}
+
+			want, err := ParseSchedule13HTML(data)
+			if err != nil {
+				t.Fatalf("ParseSchedule13HTML: %v", err)
+			}
+			got, err := ParseSchedule13HTML([]byte(text))
+			if err != nil {
+				t.Fatalf("ParseSchedule13HTML (variant): %v", err)
+			}
+			wantJSON, _ := json.MarshalIndent(want.ToOutput(), "", "  ")
+			gotJSON, _ := json.MarshalIndent(got.ToOutput(), "", "  ")
+			if !bytes.Equal(gotJSON, wantJSON) {
+				t.Errorf("Variant parsed differently:\n%s\nwant\n%s", gotJSON, wantJSON)
+			}
+			if want.IssuerName == "" || want.SecurityTitle == "" || want.IssuerCUSIP == "" {
+				t.Errorf("Fixture is missing cover page fields: %q, %q, %q", want.IssuerName, want.SecurityTitle, want.IssuerCUSIP)
+			}
+		})
+	}
+}
+
+// TestParseAny_Schedule13XMLEntities checks that escaped characters in XML
+// filings reach the XML decoder intact
+func TestParseAny_Schedule13XMLEntities(t *testing.T) {
+	data, err := os.ReadFile("testdata/schedule13/jushi_13g_xml/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	data = bytes.Replace(data, []byte("Marex Group plc<"), []byte("Marex Group &amp; Co &lt;UK&gt;<"), 1)
+
+	form, err := ParseAny(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseAny: %v", err)
+	}
+	filing, _ := form.AsSchedule13()
+	if got := filing.ReportingPersons[1].Name; got != "Marex Group & Co <UK>" {
+		t.Errorf("Name = %q, want %q", got, "Marex Group & Co <UK>")
+	}
+}
@@ -0,0 +1,267 @@
+package edgar
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// manifestFile is the append-only index of stored filings (one JSON entry per line)
+const manifestFile = "manifest.jsonl"
+
+// DefaultStoreLayout is the FilingStore path template used when Layout is empty
+const DefaultStoreLayout = "{cik}/{year}/{accession}/{doc}"
+
+// FilingStore persists raw filings on disk so batches can be re-parsed offline.
+//
+// Layout: {root}/{cik}/{year}/{accession}/{document} (see DefaultStoreLayout)
+// Example: store/78003/2025/0001225208-25-010078/ownership.xml
+//
+// A manifest (manifest.jsonl) in the root directory records the SEC metadata
+// for every stored document, so filings can be listed without hitting the SEC.
+type FilingStore struct {
+	Root string
+
+	// Layout is the path template for stored documents, relative to Root.
+	// Placeholders: {cik} (no leading zeros), {year} (of the filing date),
+	// {form} (slashes replaced by "-"), {accession} and {doc}. {doc} is
+	// appended when the template doesn't end with it, since Filings reads the
+	// document name from the last path element. Keep the same layout for the
+	// life of a store: Has and Get only look under the current one.
+	Layout string
+
+	mu sync.Mutex
+}
+
+// StoreEntry is one manifest record describing a stored filing
+type StoreEntry struct {
+	CIK             string `json:"cik"`
+	AccessionNumber string `json:"accessionNumber"`
+	Form            string `json:"form"`
+	FilingDate      string `json:"filingDate"`
+	ReportDate      string `json:"reportDate,omitempty"`
+	URL             string `json:"url"`
+	Path            string `json:"path"` // Relative to the store root
+	Size            int    `json:"size"`
+	StoredAt        string `json:"storedAt"` // RFC 3339 timestamp
+}
+
+// NewFilingStore opens (and creates if needed) a filing store rooted at dir
+func NewFilingStore(dir string) (*FilingStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("store directory is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	return &FilingStore{Root: dir}, nil
+}
+
+// RelPath returns the store-relative path for a filing's primary document,
+// following the store's Layout
+func (s *FilingStore) RelPath(f Filing) string {
+	cik := archiveCIK(f.CIK)
+	if cik == "" {
+		cik = "unknown"
+	}
+
+	year := "unknown"
+	if len(f.FilingDate) >= 4 {
+		year = f.FilingDate[:4]
+	}
+
+	// Use the document name from the URL (xsl rendering prefixes are already stripped by BuildURL)
+	doc := f.PrimaryDocument
+	if f.URL != "" {
+		doc = path.Base(f.URL)
+	} else if strings.Contains(doc, "/") {
+		doc = path.Base(doc)
+	}
+	if doc == "" || doc == "." || doc == "/" {
+		doc = "filing.txt"
+	}
+
+	form := strings.ReplaceAll(strings.TrimSpace(f.Form), "/", "-")
+	if form == "" {
+		form = "unknown"
+	}
+
+	layout := s.Layout
+	if layout == "" {
+		layout = DefaultStoreLayout
+	}
+	if !strings.HasSuffix(layout, "{doc}") {
+		layout = strings.TrimSuffix(layout, "/") + "/{doc}"
+	}
+	rel := strings.NewReplacer(
+		"{cik}", cik,
+		"{year}", year,
+		"{form}", form,
+		"{accession}", f.AccessionNumber,
+		"{doc}", doc,
+	).Replace(layout)
+
+	return filepath.Join(strings.Split(rel, "/")...)
+}
+
+// Has reports whether the filing's document is already in the store
+func (s *FilingStore) Has(f Filing) bool {
+	_, err := os.Stat(filepath.Join(s.Root, s.RelPath(f)))
+	return err == nil
+}
+
+// Get returns the stored document for a filing
+// The boolean is false (with a nil error) when the filing is not in the store
+func (s *FilingStore) Get(f Filing) ([]byte, bool, error) {
+	data, err := os.ReadFile(filepath.Join(s.Root, s.RelPath(f)))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read stored filing %s: %w", f.AccessionNumber, err)
+	}
+	return data, true, nil
+}
+
+// Put writes a filing's document to the store and records it in the manifest
+func (s *FilingStore) Put(f Filing, data []byte) error {
+	rel := s.RelPath(f)
+	full := filepath.Join(s.Root, rel)
+
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+	// Written to a temporary file and renamed into place, so a crash never
+	// leaves a partial document that Get would return as stored
+	tmp, err := os.CreateTemp(filepath.Dir(full), "."+filepath.Base(full)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write stored filing %s: %w", f.AccessionNumber, err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), full)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write stored filing %s: %w", f.AccessionNumber, err)
+	}
+
+	entry := StoreEntry{
+		CIK:             archiveCIK(f.CIK),
+		AccessionNumber: f.AccessionNumber,
+		Form:            f.Form,
+		FilingDate:      f.FilingDate,
+		ReportDate:      f.ReportDate,
+		URL:             f.URL,
+		Path:            filepath.ToSlash(rel),
+		Size:            len(data),
+		StoredAt:        time.Now().UTC().Format(time.RFC3339),
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest entry: %w", err)
+	}
+
+	// Append to the manifest (serialized so concurrent writers don't interleave lines)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mf, err := os.OpenFile(filepath.Join(s.Root, manifestFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer mf.Close()
+
+	if _, err := mf.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// Manifest returns all stored filings, sorted by filing date (newest first)
+// When a filing was stored more than once, the latest entry wins
+func (s *FilingStore) Manifest() ([]StoreEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(filepath.Join(s.Root, manifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer f.Close()
+
+	byKey := make(map[string]StoreEntry)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry StoreEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("corrupt manifest line: %w", err)
+		}
+		byKey[entry.Path] = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	entries := make([]StoreEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].FilingDate != entries[j].FilingDate {
+			return entries[i].FilingDate > entries[j].FilingDate
+		}
+		return entries[i].AccessionNumber > entries[j].AccessionNumber
+	})
+
+	return entries, nil
+}
+
+// Filings returns the stored filings for a CIK as Filing structs, so they can be
+// filtered with FilterByForm/FilterByDateRange exactly like live submissions
+func (s *FilingStore) Filings(cik string) ([]Filing, error) {
+	entries, err := s.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	cik = archiveCIK(cik)
+	var filings []Filing
+	for _, e := range entries {
+		if e.CIK != cik {
+			continue
+		}
+		filings = append(filings, Filing{
+			CIK:             e.CIK,
+			AccessionNumber: e.AccessionNumber,
+			Form:            e.Form,
+			FilingDate:      e.FilingDate,
+			ReportDate:      e.ReportDate,
+			PrimaryDocument: path.Base(e.Path),
+			URL:             e.URL,
+		})
+	}
+	return filings, nil
+}
@@ -0,0 +1,213 @@
+package edgar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilingStore(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	filing := Filing{
+		CIK:             "0000078003",
+		AccessionNumber: "0001225208-25-010078",
+		Form:            "4",
+		FilingDate:      "2025-12-02",
+		PrimaryDocument: "xslF345X05/ownership.xml",
+		URL:             "https://www.sec.gov/Archives/edgar/data/78003/000122520825010078/ownership.xml",
+	}
+
+	// Layout: {cik}/{year}/{accession}/{doc}
+	want := filepath.Join("78003", "2025", "0001225208-25-010078", "ownership.xml")
+	if got := store.RelPath(filing); got != want {
+		t.Errorf("Expected path %s, got %s", want, got)
+	}
+
+	if store.Has(filing) {
+		t.Error("Expected empty store")
+	}
+	if _, ok, err := store.Get(filing); ok || err != nil {
+		t.Errorf("Expected miss without error, got ok=%v err=%v", ok, err)
+	}
+
+	data := []byte("<ownershipDocument></ownershipDocument>")
+	if err := store.Put(filing, data); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	// Storing again must not duplicate the manifest entry
+	if err := store.Put(filing, data); err != nil {
+		t.Fatalf("Second put failed: %v", err)
+	}
+
+	got, ok, err := store.Get(filing)
+	if err != nil || !ok {
+		t.Fatalf("Expected stored filing, got ok=%v err=%v", ok, err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Stored data mismatch: %q", got)
+	}
+	// Written through a temporary file that is renamed into place
+	files, _ := os.ReadDir(filepath.Join(store.Root, filepath.Dir(want)))
+	if len(files) != 1 || files[0].Name() != "ownership.xml" {
+		t.Errorf("Expected only ownership.xml in the accession directory, got %v", files)
+	}
+
+	entries, err := store.Manifest()
+	if err != nil {
+		t.Fatalf("Manifest failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 manifest entry, got %d", len(entries))
+	}
+	if entries[0].Size != len(data) {
+		t.Errorf("Expected size %d, got %d", len(data), entries[0].Size)
+	}
+
+	// Stored filings come back as Filing structs usable with the normal filters
+	filings, err := store.Filings("78003")
+	if err != nil {
+		t.Fatalf("Filings failed: %v", err)
+	}
+	if len(FilterByForm(filings, "4")) != 1 {
+		t.Errorf("Expected 1 stored Form 4, got %d", len(filings))
+	}
+	if filings[0].URL != filing.URL {
+		t.Errorf("Expected URL %s, got %s", filing.URL, filings[0].URL)
+	}
+
+	if other, _ := store.Filings("320193"); len(other) != 0 {
+		t.Errorf("Expected no filings for another CIK, got %d", len(other))
+	}
+}
+
+func TestFilingStore_Layout(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	store.Layout = "{form}/{cik}/{accession}"
+
+	filing := Filing{
+		CIK:             "0000078003",
+		AccessionNumber: "0001225208-25-010078",
+		Form:            "4/A",
+		FilingDate:      "2025-12-02",
+		URL:             "https://www.sec.gov/Archives/edgar/data/78003/000122520825010078/ownership.xml",
+	}
+
+	// {doc} is appended when the template leaves it out
+	want := filepath.Join("4-A", "78003", "0001225208-25-010078", "ownership.xml")
+	if got := store.RelPath(filing); got != want {
+		t.Errorf("Expected path %s, got %s", want, got)
+	}
+
+	if err := store.Put(filing, []byte("<ownershipDocument></ownershipDocument>")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if !store.Has(filing) {
+		t.Error("Expected stored filing under the custom layout")
+	}
+	filings, err := store.Filings("78003")
+	if err != nil || len(filings) != 1 {
+		t.Fatalf("Expected 1 stored filing, got %d (%v)", len(filings), err)
+	}
+	if filings[0].PrimaryDocument != "ownership.xml" {
+		t.Errorf("Expected primary document ownership.xml, got %s", filings[0].PrimaryDocument)
+	}
+}
+
+func TestFetchAndParseBatch_Offline(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+
+	filing := Filing{
+		CIK:             "1640147",
+		AccessionNumber: "0001640147-25-000001",
+		Form:            "4",
+		FilingDate:      "2025-03-01",
+		URL:             "https://www.sec.gov/Archives/edgar/data/1640147/000164014725000001/ownership.xml",
+	}
+	if err := store.Put(filing, data); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	// No email and no network: everything must come from the store
+	result, err := FetchAndParseBatch(BatchOptions{
+		CIK:      "0001640147",
+		FormType: "4",
+		Store:    store,
+		Offline:  true,
+	})
+	if err != nil {
+		t.Fatalf("Offline batch failed: %v", err)
+	}
+	if result.Fetched != 1 || len(result.Errors) != 0 {
+		t.Fatalf("Expected 1 parsed filing and no errors, got %d and %v", result.Fetched, result.Errors)
+	}
+
+	f4, ok := result.Filings[0].Data.(*Form4Output)
+	if !ok {
+		t.Fatalf("Expected *Form4Output, got %T", result.Filings[0].Data)
+	}
+	if f4.Metadata.AccessionNumber != filing.AccessionNumber {
+		t.Errorf("Expected accession %s, got %s", filing.AccessionNumber, f4.Metadata.AccessionNumber)
+	}
+
+	if _, err := FetchAndParseBatch(BatchOptions{CIK: "1640147", FormType: "4", Offline: true}); err == nil {
+		t.Error("Expected error for offline mode without a store")
+	}
+}
+
+func TestFetchAndParseBatch_OfflineSchedule13(t *testing.T) {
+	store, err := NewFilingStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	data, err := os.ReadFile("testdata/schedule13/13d_2024_1/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	filing := Filing{
+		CIK:             "1761612",
+		AccessionNumber: "0001104659-24-130001",
+		Form:            "SC 13D/A",
+		FilingDate:      "2024-12-20",
+		URL:             "https://www.sec.gov/Archives/edgar/data/1761612/000110465924130001/tm2431383d3_sc13da.htm",
+	}
+	if err := store.Put(filing, data); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	result, err := FetchAndParseBatch(BatchOptions{CIK: "1761612", FormType: "13D", Store: store, Offline: true})
+	if err != nil {
+		t.Fatalf("Offline batch failed: %v", err)
+	}
+	if result.Fetched != 1 || len(result.Errors) != 0 {
+		t.Fatalf("Expected 1 parsed filing and no errors, got %d and %v", result.Fetched, result.Errors)
+	}
+
+	parsed := result.Filings[0]
+	sc13, ok := parsed.Data.(*Schedule13Filing)
+	if !ok {
+		t.Fatalf("Expected *Schedule13Filing, got %T", parsed.Data)
+	}
+	// Form type and filing date come from the index
+	if parsed.FormType != "SC 13D/A" || sc13.FormType != "SC 13D/A" || !sc13.IsAmendment || sc13.FilingDate != "2024-12-20" {
+		t.Errorf("Unexpected filing metadata: %s %s amendment=%v %s", parsed.FormType, sc13.FormType, sc13.IsAmendment, sc13.FilingDate)
+	}
+	if sc13.IssuerName == "" || len(sc13.ReportingPersons) == 0 {
+		t.Errorf("Expected issuer and reporting persons, got %+v", sc13)
+	}
+}
@@ -4,9 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strings"
-	"time"
 )
 
 // Submissions represents the complete SEC submissions data for a CIK
@@ -83,36 +81,29 @@ type Filing struct {
 
 // FetchSubmissions fetches and parses the CIK submissions JSON from SEC
 func FetchSubmissions(cik string, email string) (*Submissions, error) {
-	// Pad CIK to 10 digits
-	paddedCIK := fmt.Sprintf("%010s", cik)
-
-	// Construct URL
-	url := fmt.Sprintf("https://data.sec.gov/submissions/CIK%s.json", paddedCIK)
+	return defaultClient(email).FetchSubmissions(cik)
+}
 
-	// Create request with User-Agent header
-	req, err := http.NewRequest("GET", url, nil)
+// FetchSubmissions fetches and parses the CIK submissions JSON from SEC
+func (c *Client) FetchSubmissions(cik string) (*Submissions, error) {
+	// Pad CIK to 10 digits
+	paddedCIK, err := PadCIK(cik)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	userAgent := fmt.Sprintf("go-edgar %s", email)
-	req.Header.Set("User-Agent", userAgent)
+	// Construct URL
+	url := fmt.Sprintf("https://data.sec.gov/submissions/CIK%s.json", paddedCIK)
 
-	// Execute request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	body, err := c.get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch submissions: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("SEC returned status %d", resp.StatusCode)
-	}
+	defer body.Close()
 
 	// Parse JSON
 	var subs Submissions
-	if err := json.NewDecoder(resp.Body).Decode(&subs); err != nil {
+	if err := json.NewDecoder(body).Decode(&subs); err != nil {
 		return nil, fmt.Errorf("failed to parse submissions JSON: %w", err)
 	}
 
@@ -129,6 +120,7 @@ func ParseSubmissions(r io.Reader) (*Submissions, error) {
 }
 
 // GetFilings converts the parallel arrays in FilingArrays into a slice of Filing structs
+// Arrays shorter than accessionNumber (malformed data) leave the missing fields empty
 func (fa *FilingArrays) GetFilings(cik string) []Filing {
 	count := len(fa.AccessionNumber)
 	filings := make([]Filing, count)
@@ -137,12 +129,18 @@ func (fa *FilingArrays) GetFilings(cik string) []Filing {
 		filing := Filing{
 			CIK:             cik,
 			AccessionNumber: fa.AccessionNumber[i],
-			FilingDate:      fa.FilingDate[i],
-			Form:            fa.Form[i],
-			PrimaryDocument: fa.PrimaryDocument[i],
 		}
 
-		// Handle optional fields with bounds checking
+		// Handle fields with bounds checking
+		if i < len(fa.FilingDate) {
+			filing.FilingDate = fa.FilingDate[i]
+		}
+		if i < len(fa.Form) {
+			filing.Form = fa.Form[i]
+		}
+		if i < len(fa.PrimaryDocument) {
+			filing.PrimaryDocument = fa.PrimaryDocument[i]
+		}
 		if i < len(fa.ReportDate) {
 			filing.ReportDate = fa.ReportDate[i]
 		}
@@ -185,8 +183,10 @@ func (fa *FilingArrays) GetFilings(cik string) []Filing {
 
 // BuildURL constructs the full SEC EDGAR URL for this filing
 func (f *Filing) BuildURL() string {
-	// Remove hyphens from accession number for URL path
-	accessionPath := strings.ReplaceAll(f.AccessionNumber, "-", "")
+	// The URL path takes the CIK without leading zeros and the accession
+	// number without dashes (invalid values are used as they are)
+	cik := archiveCIK(f.CIK)
+	accessionPath := archiveAccession(f.AccessionNumber)
 
 	// For Form 4, the primaryDocument often points to HTML rendering (xslF345X05/doc4.xml)
 	// Strip the xsl path prefix to get the actual document name
@@ -199,7 +199,7 @@ func (f *Filing) BuildURL() string {
 
 	// https://www.sec.gov/Archives/edgar/data/{CIK}/{ACCESSION}/{PRIMARY_DOCUMENT}
 	return fmt.Sprintf("https://www.sec.gov/Archives/edgar/data/%s/%s/%s",
-		strings.TrimLeft(f.CIK, "0"), // Remove leading zeros from CIK
+		cik,
 		accessionPath,
 		doc,
 	)
@@ -237,8 +237,10 @@ func FilterByForm(filings []Filing, formType string) []Filing {
 // Note: Form 4/3/5 do NOT include amendments by default (use "4/A" explicitly to match amendments).
 // Schedule 13 forms DO include amendments when filtering by base type.
 func matchesFormType(filingForm, requestedForm string) bool {
-	// Normalize requested form: add "SC" prefix for Schedule 13 forms
+	// Normalize both forms: add "SC" prefix for Schedule 13 forms, and map the
+	// "SCHEDULE 13D" names EDGAR has used since the December 2024 XML transition
 	normalizedRequest := normalizeFormType(requestedForm)
+	filingForm = normalizeFormType(filingForm)
 
 	// Special case: "13" as wildcard for all Schedule 13 forms
 	if requestedForm == "13" {
@@ -270,10 +272,16 @@ func matchesFormType(filingForm, requestedForm string) bool {
 //   - "13G" → "SC 13G"
 //   - "4" → "4" (unchanged)
 //   - "SC 13D" → "SC 13D" (already normalized)
+//   - "SCHEDULE 13D/A" → "SC 13D/A"
 func normalizeFormType(formType string) string {
 	// Trim whitespace
 	formType = strings.TrimSpace(formType)
 
+	// Structured (XML) Schedule 13 filings are indexed as "SCHEDULE 13D", "SCHEDULE 13G/A", ...
+	if strings.HasPrefix(formType, "SCHEDULE 13") {
+		return "SC " + strings.TrimPrefix(formType, "SCHEDULE ")
+	}
+
 	// If already has "SC" prefix, return as-is
 	if strings.HasPrefix(formType, "SC ") {
 		return formType
@@ -311,33 +319,24 @@ func FilterByDateRange(filings []Filing, from, to string) []Filing {
 
 // FetchPaginatedFilings fetches and parses a paginated filings file
 func FetchPaginatedFilings(cik string, filename string, email string) (*FilingArrays, error) {
+	return defaultClient(email).FetchPaginatedFilings(cik, filename)
+}
+
+// FetchPaginatedFilings fetches and parses a paginated filings file
+func (c *Client) FetchPaginatedFilings(cik string, filename string) (*FilingArrays, error) {
 	// Construct URL
 	url := fmt.Sprintf("https://data.sec.gov/submissions/%s", filename)
 
-	// Create request with User-Agent header
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	userAgent := fmt.Sprintf("go-edgar %s", email)
-	req.Header.Set("User-Agent", userAgent)
-
 	// Execute request with rate limiting
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	body, err := c.get(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch paginated filings: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("SEC returned status %d for %s", resp.StatusCode, filename)
+		return nil, fmt.Errorf("failed to fetch paginated filings %s: %w", filename, err)
 	}
+	defer body.Close()
 
 	// Parse JSON - paginated files only contain the FilingArrays
 	var filings FilingArrays
-	if err := json.NewDecoder(resp.Body).Decode(&filings); err != nil {
+	if err := json.NewDecoder(body).Decode(&filings); err != nil {
 		return nil, fmt.Errorf("failed to parse paginated filings JSON: %w", err)
 	}
 
@@ -347,12 +346,17 @@ func FetchPaginatedFilings(cik string, filename string, email string) (*FilingAr
 // GetAllFilings returns all filings including paginated results
 // This fetches all paginated files if they exist
 func (s *Submissions) GetAllFilings(email string) ([]Filing, error) {
+	return defaultClient(email).GetAllFilings(s)
+}
+
+// GetAllFilings returns all filings for the submissions including paginated results
+func (c *Client) GetAllFilings(s *Submissions) ([]Filing, error) {
 	// Start with recent filings
 	allFilings := s.GetRecentFilings()
 
 	// Fetch paginated files if they exist
 	for _, fileInfo := range s.Filings.Files {
-		filings, err := FetchPaginatedFilings(s.CIK, fileInfo.Name, email)
+		filings, err := c.FetchPaginatedFilings(s.CIK, fileInfo.Name)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch %s: %w", fileInfo.Name, err)
 		}
@@ -360,9 +364,6 @@ func (s *Submissions) GetAllFilings(email string) ([]Filing, error) {
 		// Convert to Filing structs and append
 		pageFilings := filings.GetFilings(s.CIK)
 		allFilings = append(allFilings, pageFilings...)
-
-		// Rate limiting: sleep 100ms between requests (10 req/sec max)
-		time.Sleep(100 * time.Millisecond)
 	}
 
 	return allFilings, nil
@@ -107,6 +107,29 @@ func TestFilterByForm(t *testing.T) {
 	t.Logf("Found %d Form 4 filings out of %d total", len(form4Filings), len(allFilings))
 }
 
+func TestFilterByForm_Schedule13(t *testing.T) {
+	filings := []Filing{
+		{Form: "SC 13D"}, {Form: "SC 13D/A"}, {Form: "SCHEDULE 13D"}, {Form: "SCHEDULE 13D/A"},
+		{Form: "SC 13G"}, {Form: "SCHEDULE 13G/A"}, {Form: "4"},
+	}
+
+	tests := []struct {
+		request string
+		want    int
+	}{
+		{"13D", 4},
+		{"SC 13D/A", 2},
+		{"13G", 2},
+		{"13", 6},
+		{"4", 1},
+	}
+	for _, tt := range tests {
+		if got := FilterByForm(filings, tt.request); len(got) != tt.want {
+			t.Errorf("FilterByForm(%q) matched %d filings, want %d: %v", tt.request, len(got), tt.want, got)
+		}
+	}
+}
+
 func TestFilterByDateRange(t *testing.T) {
 	f, err := os.Open("testdata/cik/CIK0000078003.json")
 	if err != nil {
@@ -0,0 +1,113 @@
+package edgar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompanyTickersURL is the SEC's ticker to CIK mapping for listed companies
+const CompanyTickersURL = "https://www.sec.gov/files/company_tickers.json"
+
+// CompanyTicker maps a trading symbol to the issuer's CIK
+type CompanyTicker struct {
+	CIK    string `json:"cik"` // Without leading zeros, e.g. "78003"
+	Ticker string `json:"ticker"`
+	Title  string `json:"title"` // Company name
+}
+
+// CompanyTickers is the company_tickers dataset, in SEC order
+type CompanyTickers []CompanyTicker
+
+// FetchCompanyTickers fetches and parses the SEC ticker dataset
+func FetchCompanyTickers(email string) (CompanyTickers, error) {
+	return defaultClient(email).FetchCompanyTickers()
+}
+
+// FetchCompanyTickers fetches and parses the SEC ticker dataset
+func (c *Client) FetchCompanyTickers() (CompanyTickers, error) {
+	body, err := c.get(CompanyTickersURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch company tickers: %w", err)
+	}
+	defer body.Close()
+	return ParseCompanyTickers(body)
+}
+
+// ParseCompanyTickers parses company_tickers.json, an object of
+// {"0": {"cik_str": 78003, "ticker": "PFE", "title": "PFIZER INC"}, ...}
+func ParseCompanyTickers(r io.Reader) (CompanyTickers, error) {
+	var raw map[string]struct {
+		CIK    int64  `json:"cik_str"`
+		Ticker string `json:"ticker"`
+		Title  string `json:"title"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse company tickers JSON: %w", err)
+	}
+
+	// Keys are row numbers; keep the SEC order
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA != nil || errB != nil {
+			return keys[i] < keys[j]
+		}
+		return a < b
+	})
+
+	tickers := make(CompanyTickers, 0, len(raw))
+	for _, k := range keys {
+		entry := raw[k]
+		tickers = append(tickers, CompanyTicker{
+			CIK:    strconv.FormatInt(entry.CIK, 10),
+			Ticker: entry.Ticker,
+			Title:  entry.Title,
+		})
+	}
+	return tickers, nil
+}
+
+// Lookup finds a ticker, ignoring case and share class punctuation
+// ("brk.b" matches "BRK-B")
+func (t CompanyTickers) Lookup(ticker string) (CompanyTicker, bool) {
+	want := NormalizeTicker(ticker)
+	for _, entry := range t {
+		if NormalizeTicker(entry.Ticker) == want {
+			return entry, true
+		}
+	}
+	return CompanyTicker{}, false
+}
+
+// NormalizeTicker uppercases a ticker and writes share classes the SEC way
+// ("BRK.B" and "BRK/B" become "BRK-B")
+func NormalizeTicker(ticker string) string {
+	ticker = strings.ToUpper(strings.TrimSpace(ticker))
+	return strings.NewReplacer(".", "-", "/", "-").Replace(ticker)
+}
+
+// LookupCIK resolves a ticker to a CIK using the SEC ticker dataset
+func LookupCIK(ticker, email string) (string, error) {
+	return defaultClient(email).LookupCIK(ticker)
+}
+
+// LookupCIK resolves a ticker to a CIK using the SEC ticker dataset
+func (c *Client) LookupCIK(ticker string) (string, error) {
+	tickers, err := c.FetchCompanyTickers()
+	if err != nil {
+		return "", err
+	}
+	entry, ok := tickers.Lookup(ticker)
+	if !ok {
+		return "", fmt.Errorf("unknown ticker %q", ticker)
+	}
+	return entry.CIK, nil
+}
@@ -0,0 +1,49 @@
+package edgar
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCompanyTickers(t *testing.T) {
+	data := `{
+  "1": {"cik_str": 78003, "ticker": "PFE", "title": "PFIZER INC"},
+  "0": {"cik_str": 320193, "ticker": "AAPL", "title": "Apple Inc."},
+  "10": {"cik_str": 1067983, "ticker": "BRK-B", "title": "BERKSHIRE HATHAWAY INC"},
+  "2": {"cik_str": 1682852, "ticker": "MRNA", "title": "Moderna, Inc."}
+}`
+	tickers, err := ParseCompanyTickers(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseCompanyTickers: %v", err)
+	}
+	var order []string
+	for _, entry := range tickers {
+		order = append(order, entry.Ticker)
+	}
+	if got := strings.Join(order, ","); got != "AAPL,PFE,MRNA,BRK-B" {
+		t.Errorf("order = %s, want the SEC row order", got)
+	}
+
+	tests := []struct {
+		ticker string
+		cik    string
+	}{
+		{"PFE", "78003"},
+		{" pfe ", "78003"},
+		{"BRK.B", "1067983"},
+		{"brk/b", "1067983"},
+	}
+	for _, tt := range tests {
+		entry, ok := tickers.Lookup(tt.ticker)
+		if !ok || entry.CIK != tt.cik {
+			t.Errorf("Lookup(%q) = %+v, %v, want CIK %s", tt.ticker, entry, ok, tt.cik)
+		}
+	}
+	if entry, ok := tickers.Lookup("ZZZZ"); ok {
+		t.Errorf("Lookup(ZZZZ) = %+v, want not found", entry)
+	}
+
+	if _, err := ParseCompanyTickers(strings.NewReader("[]")); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
@@ -0,0 +1,255 @@
+package edgar
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSeries is a tidy (one row per metric and period) quarterly series of a
+// company's key metrics, built from the XBRL company facts API
+type TimeSeries struct {
+	CIK        string            `json:"cik"`
+	EntityName string            `json:"entityName"`
+	Points     []TimeSeriesPoint `json:"points"` // By metric, then period end (oldest first)
+}
+
+// TimeSeriesPoint is one metric value for one period
+type TimeSeriesPoint struct {
+	Metric  string  `json:"metric"`          // "revenue", "cash", "burn", "shares"
+	Start   string  `json:"start,omitempty"` // Quarter start; empty for point-in-time metrics
+	End     string  `json:"end"`             // Quarter end, or the as-of date
+	Value   float64 `json:"value"`
+	Unit    string  `json:"unit"`              // "USD" or "shares"
+	Derived bool    `json:"derived,omitempty"` // Fourth quarter computed as fiscal year minus Q1-Q3
+}
+
+// TimeSeriesOptions selects the metrics and number of quarters of a TimeSeries
+type TimeSeriesOptions struct {
+	Metrics  []string // Metrics to include (see TimeSeriesMetrics); empty = all
+	Quarters int      // Most recent quarters per metric; 0 = all reported
+}
+
+// TimeSeriesCSVHeader is the column layout used by TimeSeries.WriteCSV
+var TimeSeriesCSVHeader = []string{"cik", "entity_name", "metric", "start", "end", "value", "unit", "derived"}
+
+type timeSeriesMetric struct {
+	name     string
+	labels   []string // Concept mapping labels, summed (burn = R&D + G&A)
+	concepts []string // Used instead of labels, in order of preference
+	unit     string
+	instant  bool
+}
+
+// timeSeriesMetrics use the concept mappings, so they match the snapshot fields
+var timeSeriesMetrics = []timeSeriesMetric{
+	{name: "revenue", labels: []string{"Revenue"}, unit: "USD"},
+	{name: "cash", labels: []string{"Cash and Cash Equivalents"}, unit: "USD", instant: true},
+	{name: "burn", labels: []string{"Research and Development Expense", "General and Administrative Expense"}, unit: "USD"},
+	{name: "shares", concepts: []string{"dei:EntityCommonStockSharesOutstanding", "us-gaap:CommonStockSharesOutstanding"}, unit: "shares", instant: true},
+}
+
+// components returns the concepts of each summed component of the metric
+func (m timeSeriesMetric) components() [][]string {
+	if len(m.labels) == 0 {
+		return [][]string{m.concepts}
+	}
+	components := make([][]string, len(m.labels))
+	for i, label := range m.labels {
+		components[i], _ = GetConceptsForLabel(label)
+	}
+	return components
+}
+
+// TimeSeriesMetrics returns the names of the metrics a TimeSeries can include
+func TimeSeriesMetrics() []string {
+	names := make([]string, len(timeSeriesMetrics))
+	for i, m := range timeSeriesMetrics {
+		names[i] = m.name
+	}
+	return names
+}
+
+// FetchTimeSeries fetches a company's facts from SEC and builds a quarterly time series
+func FetchTimeSeries(cik string, opts TimeSeriesOptions, email string) (*TimeSeries, error) {
+	return defaultClient(email).FetchTimeSeries(cik, opts)
+}
+
+// FetchTimeSeries fetches a company's facts from SEC and builds a quarterly time series
+func (c *Client) FetchTimeSeries(cik string, opts TimeSeriesOptions) (*TimeSeries, error) {
+	facts, err := c.FetchCompanyFacts(cik)
+	if err != nil {
+		return nil, err
+	}
+	return facts.TimeSeries(opts)
+}
+
+// TimeSeries builds a quarterly time series from company facts
+//
+// Each period uses the most recently filed value (so restatements win) of the
+// first mapped concept reported for it. Companies rarely report the fourth
+// quarter on its own; it is derived from the fiscal year and the three quarters
+// within it and marked Derived.
+func (f *CompanyFacts) TimeSeries(opts TimeSeriesOptions) (*TimeSeries, error) {
+	metrics := timeSeriesMetrics
+	if len(opts.Metrics) > 0 {
+		metrics = nil
+		for _, name := range opts.Metrics {
+			m, ok := findTimeSeriesMetric(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown metric %q (available: %s)", name, strings.Join(TimeSeriesMetrics(), ", "))
+			}
+			metrics = append(metrics, m)
+		}
+	}
+
+	ts := &TimeSeries{CIK: strconv.Itoa(f.CIK), EntityName: f.EntityName}
+	for _, m := range metrics {
+		points := f.metricPoints(m)
+		if opts.Quarters > 0 && len(points) > opts.Quarters {
+			points = points[len(points)-opts.Quarters:]
+		}
+		ts.Points = append(ts.Points, points...)
+	}
+	return ts, nil
+}
+
+func findTimeSeriesMetric(name string) (timeSeriesMetric, bool) {
+	for _, m := range timeSeriesMetrics {
+		if m.name == name {
+			return m, true
+		}
+	}
+	return timeSeriesMetric{}, false
+}
+
+// metricPoints returns a metric's quarterly values, oldest first
+func (f *CompanyFacts) metricPoints(m timeSeriesMetric) []TimeSeriesPoint {
+	byPeriod := make(map[[2]string]*TimeSeriesPoint) // [start, end] -> point
+	for _, concepts := range m.components() {
+		for period, point := range f.quarterlyValues(concepts, m.unit, m.instant) {
+			if sum, ok := byPeriod[period]; ok {
+				sum.Value += point.Value
+				sum.Derived = sum.Derived || point.Derived
+				continue
+			}
+			p := point
+			p.Metric = m.name
+			byPeriod[period] = &p
+		}
+	}
+
+	points := make([]TimeSeriesPoint, 0, len(byPeriod))
+	for _, p := range byPeriod {
+		points = append(points, *p)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].End < points[j].End })
+	return points
+}
+
+// quarterlyValues returns one value per quarter (or per date for instants) of
+// the first of concepts reported for each period
+func (f *CompanyFacts) quarterlyValues(concepts []string, unit string, instant bool) map[[2]string]TimeSeriesPoint {
+	type pick struct {
+		fact     CompanyFact
+		priority int
+	}
+	quarters := make(map[[2]string]pick)
+	years := make(map[[2]string]pick)
+
+	for priority, concept := range concepts {
+		taxonomy, name, _ := strings.Cut(concept, ":")
+		for _, fact := range f.Facts[taxonomy][name].Units[unit] {
+			target := quarters
+			if !instant {
+				days, ok := periodDays(Period{StartDate: fact.Start, EndDate: fact.End})
+				switch {
+				case !ok:
+					continue
+				case days >= 80 && days <= 100:
+				case days >= 350 && days <= 380:
+					target = years
+				default:
+					continue // Year-to-date (6 or 9 months)
+				}
+			} else if fact.Start != "" {
+				continue
+			}
+
+			key := [2]string{fact.Start, fact.End}
+			current, ok := target[key]
+			if !ok || priority < current.priority ||
+				(priority == current.priority && fact.Filed > current.fact.Filed) {
+				target[key] = pick{fact, priority}
+			}
+		}
+	}
+
+	values := make(map[[2]string]TimeSeriesPoint, len(quarters))
+	for key, p := range quarters {
+		values[key] = TimeSeriesPoint{Start: p.fact.Start, End: p.fact.End, Value: p.fact.Val, Unit: unit}
+	}
+
+	// Fourth quarter: fiscal year minus the three quarters within it
+	for _, year := range years {
+		var within []TimeSeriesPoint
+		lastEnd := ""
+		for _, q := range values {
+			if q.Derived || q.Start < year.fact.Start || q.End > year.fact.End {
+				continue
+			}
+			within = append(within, q)
+			if q.End > lastEnd {
+				lastEnd = q.End
+			}
+		}
+		if len(within) != 3 || lastEnd == year.fact.End {
+			continue
+		}
+		start, err := time.Parse("2006-01-02", lastEnd)
+		if err != nil {
+			continue
+		}
+		q4 := TimeSeriesPoint{
+			Start:   start.AddDate(0, 0, 1).Format("2006-01-02"),
+			End:     year.fact.End,
+			Value:   year.fact.Val,
+			Unit:    unit,
+			Derived: true,
+		}
+		for _, q := range within {
+			q4.Value -= q.Value
+		}
+		values[[2]string{q4.Start, q4.End}] = q4
+	}
+	return values
+}
+
+// WriteCSV writes the series as CSV rows with a header (see TimeSeriesCSVHeader)
+func (ts *TimeSeries) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(TimeSeriesCSVHeader); err != nil {
+		return err
+	}
+	for _, p := range ts.Points {
+		row := []string{
+			ts.CIK,
+			ts.EntityName,
+			p.Metric,
+			p.Start,
+			p.End,
+			strconv.FormatFloat(p.Value, 'f', -1, 64),
+			p.Unit,
+			strconv.FormatBool(p.Derived),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,105 @@
+package edgar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const testTimeSeriesFacts = `{
+  "cik": 1682852,
+  "entityName": "Moderna, Inc.",
+  "facts": {
+    "dei": {
+      "EntityCommonStockSharesOutstanding": {"units": {"shares": [
+        {"end": "2024-04-26", "val": 383000000, "accn": "a-q1", "form": "10-Q", "filed": "2024-05-02"},
+        {"end": "2025-02-14", "val": 385815877, "accn": "a-fy", "form": "10-K", "filed": "2025-02-21"}
+      ]}}
+    },
+    "us-gaap": {
+      "Revenues": {"units": {"USD": [
+        {"start": "2024-01-01", "end": "2024-03-31", "val": 167000000, "accn": "a-q1", "form": "10-Q", "filed": "2024-05-02"},
+        {"start": "2024-01-01", "end": "2024-03-31", "val": 167500000, "accn": "a-q2", "form": "10-Q", "filed": "2024-08-01"},
+        {"start": "2024-04-01", "end": "2024-06-30", "val": 241000000, "accn": "a-q2", "form": "10-Q", "filed": "2024-08-01"},
+        {"start": "2024-01-01", "end": "2024-06-30", "val": 408500000, "accn": "a-q2", "form": "10-Q", "filed": "2024-08-01"},
+        {"start": "2024-07-01", "end": "2024-09-30", "val": 1862000000, "accn": "a-q3", "form": "10-Q", "filed": "2024-11-07"},
+        {"start": "2024-01-01", "end": "2024-12-31", "val": 3236000000, "accn": "a-fy", "form": "10-K", "filed": "2025-02-21"}
+      ]}},
+      "ResearchAndDevelopmentExpense": {"units": {"USD": [
+        {"start": "2024-01-01", "end": "2024-03-31", "val": 1100000000, "accn": "a-q1", "form": "10-Q", "filed": "2024-05-02"}
+      ]}},
+      "SellingGeneralAndAdministrativeExpense": {"units": {"USD": [
+        {"start": "2024-01-01", "end": "2024-03-31", "val": 300000000, "accn": "a-q1", "form": "10-Q", "filed": "2024-05-02"}
+      ]}},
+      "CashAndCashEquivalentsAtCarryingValue": {"units": {"USD": [
+        {"end": "2024-03-31", "val": 2500000000, "accn": "a-q1", "form": "10-Q", "filed": "2024-05-02"},
+        {"end": "2024-12-31", "val": 1927000000, "accn": "a-fy", "form": "10-K", "filed": "2025-02-21"}
+      ]}}
+    }
+  }
+}`
+
+func TestCompanyFactsTimeSeries(t *testing.T) {
+	facts, err := ParseCompanyFacts(strings.NewReader(testTimeSeriesFacts))
+	if err != nil {
+		t.Fatalf("ParseCompanyFacts: %v", err)
+	}
+
+	ts, err := facts.TimeSeries(TimeSeriesOptions{})
+	if err != nil {
+		t.Fatalf("TimeSeries: %v", err)
+	}
+
+	var revenue []TimeSeriesPoint
+	values := make(map[string]float64) // metric/end -> value
+	for _, p := range ts.Points {
+		if p.Metric == "revenue" {
+			revenue = append(revenue, p)
+		}
+		values[p.Metric+"/"+p.End] = p.Value
+	}
+
+	// Q1 restated in the Q2 filing, the six-month total skipped, Q4 derived
+	if len(revenue) != 4 {
+		t.Fatalf("got %d revenue quarters, want 4: %+v", len(revenue), revenue)
+	}
+	if revenue[0].Value != 167_500_000 {
+		t.Errorf("Q1 revenue = %v, want the restated 167.5M", revenue[0].Value)
+	}
+	if q4 := revenue[3]; !q4.Derived || q4.Start != "2024-10-01" || q4.Value != 965_500_000 {
+		t.Errorf("Q4 revenue = %+v, want derived 965.5M from 2024-10-01", q4)
+	}
+
+	if got := values["burn/2024-03-31"]; got != 1_400_000_000 {
+		t.Errorf("Q1 burn = %v, want R&D + SG&A (1.4B)", got)
+	}
+	if got := values["cash/2024-12-31"]; got != 1_927_000_000 {
+		t.Errorf("year-end cash = %v", got)
+	}
+	if got := values["shares/2025-02-14"]; got != 385_815_877 {
+		t.Errorf("shares = %v", got)
+	}
+
+	// Selected metrics, most recent quarters only
+	ts, err = facts.TimeSeries(TimeSeriesOptions{Metrics: []string{"revenue"}, Quarters: 2})
+	if err != nil {
+		t.Fatalf("TimeSeries: %v", err)
+	}
+	if len(ts.Points) != 2 || ts.Points[0].End != "2024-09-30" || ts.Points[1].End != "2024-12-31" {
+		t.Errorf("last two revenue quarters = %+v", ts.Points)
+	}
+	if _, err := facts.TimeSeries(TimeSeriesOptions{Metrics: []string{"ebitda"}}); err == nil {
+		t.Error("expected an error for an unknown metric")
+	}
+
+	var buf bytes.Buffer
+	if err := ts.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	want := "cik,entity_name,metric,start,end,value,unit,derived\n" +
+		"1682852,\"Moderna, Inc.\",revenue,2024-07-01,2024-09-30,1862000000,USD,false\n" +
+		"1682852,\"Moderna, Inc.\",revenue,2024-10-01,2024-12-31,965500000,USD,true\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,96 @@
+package edgar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// lengthCheckedBody reads a response body and fails with ErrTruncatedDownload
+// when the connection ends before Content-Length bytes have arrived
+type lengthCheckedBody struct {
+	io.ReadCloser
+	url  string
+	want int64 // Content-Length, -1 if unknown
+	got  int64
+}
+
+func (b *lengthCheckedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.got += int64(n)
+	switch {
+	case err == io.EOF && b.want >= 0 && b.got < b.want, errors.Is(err, io.ErrUnexpectedEOF):
+		if b.want < 0 {
+			return n, fmt.Errorf("%w: connection closed after %d bytes of %s", ErrTruncatedDownload, b.got, b.url)
+		}
+		return n, fmt.Errorf("%w: received %d of %d bytes of %s", ErrTruncatedDownload, b.got, b.want, b.url)
+	}
+	return n, err
+}
+
+// truncationTail is how far from the end of a document its closing root tag
+// is looked for
+const truncationTail = 64 << 10
+
+// checkComplete catches HTML and XML documents that were cut off: the root
+// element (e.g. <ownershipDocument>, <html>, <DOCUMENT>) must be closed near the
+// end. Other content (JSON, archives, plain text) isn't checked.
+func checkComplete(data []byte, url string) error {
+	root := rootElement(data)
+	if root == "" {
+		return nil
+	}
+	tail := bytes.ToLower(data[max(0, len(data)-truncationTail):])
+	closing := [][]byte{[]byte("</" + root)}
+	if root == "html" {
+		closing = append(closing, []byte("</body")) // Pages that omit the optional </html>
+	}
+	for _, c := range closing {
+		if bytes.Contains(tail, c) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s ends without closing <%s> (%d bytes)", ErrTruncatedDownload, url, root, len(data))
+}
+
+// rootElement returns the lowercased name of a markup document's first
+// element, skipping the XML declaration, doctype and comments, or "" if the
+// data isn't markup or the root is an empty element (<root/>)
+func rootElement(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	for {
+		data = bytes.TrimLeft(data, " \t\r\n")
+		if len(data) < 2 || data[0] != '<' {
+			return ""
+		}
+		switch {
+		case bytes.HasPrefix(data, []byte("<!--")):
+			end := bytes.Index(data, []byte("-->"))
+			if end < 0 {
+				return ""
+			}
+			data = data[end+3:]
+		case data[1] == '?' || data[1] == '!':
+			end := bytes.IndexByte(data, '>')
+			if end < 0 {
+				return ""
+			}
+			data = data[end+1:]
+		default:
+			end := 1
+			for end < len(data) && isNameByte(data[end]) {
+				end++
+			}
+			if gt := bytes.IndexByte(data, '>'); gt > 0 && data[gt-1] == '/' {
+				return ""
+			}
+			return string(bytes.ToLower(data[1:end]))
+		}
+	}
+}
+
+// isNameByte reports whether c can be part of an element name
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == ':' || c == '.'
+}
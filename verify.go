@@ -0,0 +1,280 @@
+package edgar
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// VerifyIssue is one way a record differs from the output schema
+type VerifyIssue struct {
+	Record  int    `json:"record"` // 1-based position in the file
+	Path    string `json:"path"`   // JSON path, e.g. "transactions[2].shares"
+	Message string `json:"message"`
+}
+
+func (i VerifyIssue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("record %d: %s", i.Record, i.Message)
+	}
+	return fmt.Sprintf("record %d: %s: %s", i.Record, i.Path, i.Message)
+}
+
+// VerifyResult is the outcome of VerifyOutput
+type VerifyResult struct {
+	Records int            `json:"records"`
+	Kinds   map[string]int `json:"kinds"` // Records per kind: "Form 4", "Schedule 13D/G", "XBRL"
+	Issues  []VerifyIssue  `json:"issues"`
+}
+
+// OK reports whether every record matched the schema
+func (r *VerifyResult) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// ownershipSchemaVersion matches SEC ownership schema versions, e.g. "X0508"
+var ownershipSchemaVersion = regexp.MustCompile(`^X0[1-9]0[1-9]$`)
+
+// VerifyOutput checks saved output against the JSON structure of the output
+// types (Form4Output, Schedule13Output, FinancialSnapshot), catching stale or
+// hand-edited files before they reach a pipeline. The input may be a
+// single-file result ({"formType": ..., "data": ...}), a batch JSON array or
+// NDJSON.
+//
+// Every field without omitempty must be present, values must have the field's
+// JSON type (null only where the field is nullable), and fields the output
+// types don't have are reported. Form 4 records must carry a valid ownership
+// schemaVersion. An error is returned only when the input isn't JSON.
+func VerifyOutput(r io.Reader) (*VerifyResult, error) {
+	docs, err := readJSONRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{Kinds: make(map[string]int), Issues: []VerifyIssue{}}
+	for i, doc := range docs {
+		result.Records++
+		v := &verifier{record: i + 1}
+		kind := v.verifyRecord(doc)
+		if kind != "" {
+			result.Kinds[kind]++
+		}
+		result.Issues = append(result.Issues, v.issues...)
+	}
+	if result.Records == 0 {
+		result.Issues = append(result.Issues, VerifyIssue{Message: "no records"})
+	}
+	return result, nil
+}
+
+// verifier collects the issues of one record
+type verifier struct {
+	record int
+	issues []VerifyIssue
+}
+
+func (v *verifier) addf(path, format string, args ...any) {
+	v.issues = append(v.issues, VerifyIssue{Record: v.record, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// verifyRecord checks one record and returns its kind ("" if unrecognized)
+func (v *verifier) verifyRecord(doc json.RawMessage) string {
+	var value any
+	if err := json.Unmarshal(doc, &value); err != nil {
+		v.addf("", "invalid JSON: %v", err)
+		return ""
+	}
+	obj, ok := value.(map[string]any)
+	if !ok {
+		v.addf("", "expected an object, got %s", jsonKind(value))
+		return ""
+	}
+
+	// Single-file results wrap the data with its form type
+	path := ""
+	formType, wrapped := obj["formType"].(string)
+	if data, ok := obj["data"]; ok && wrapped {
+		for key := range obj {
+			if key != "formType" && key != "data" {
+				v.addf(key, "unknown field")
+			}
+		}
+		if obj, ok = data.(map[string]any); !ok {
+			v.addf("data", "expected an object, got %s", jsonKind(data))
+			return ""
+		}
+		path = "data"
+	} else {
+		formType = ""
+	}
+
+	var kind string
+	var typ reflect.Type
+	switch {
+	case formType == "4" || formType == "" && obj["reportingOwners"] != nil:
+		kind, typ = "Form 4", reflect.TypeOf(Form4Output{})
+	case strings.HasPrefix(formType, "SC 13") || formType == "" && obj["reportingPersons"] != nil:
+		kind, typ = "Schedule 13D/G", reflect.TypeOf(Schedule13Output{})
+	case formType == "XBRL" || formType == "" && obj["fiscalYearEnd"] != nil:
+		kind, typ = "XBRL", reflect.TypeOf(FinancialSnapshot{})
+	default:
+		if formType != "" {
+			v.addf("formType", "unsupported form type %q", formType)
+		} else {
+			v.addf(path, "not a Form 4, Schedule 13D/G or XBRL record")
+		}
+		return ""
+	}
+
+	v.verifyValue(obj, typ, path)
+	if kind == "Form 4" {
+		if version, ok := obj["schemaVersion"].(string); ok && !ownershipSchemaVersion.MatchString(version) {
+			v.addf(joinPath(path, "schemaVersion"), "unknown ownership schema version %q", version)
+		}
+	}
+	return kind
+}
+
+// verifyValue checks a decoded JSON value against the Go type it encodes
+func (v *verifier) verifyValue(value any, typ reflect.Type, path string) {
+	if value == nil {
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		default:
+			v.addf(path, "null is not allowed (%s)", typ.Kind())
+		}
+		return
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			v.addf(path, "expected a string, got %s", jsonKind(value))
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			v.addf(path, "expected a boolean, got %s", jsonKind(value))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := value.(float64); !ok {
+			v.addf(path, "expected an integer, got %s", jsonKind(value))
+		} else if n != math.Trunc(n) {
+			v.addf(path, "expected an integer, got %v", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(float64); !ok {
+			v.addf(path, "expected a number, got %s", jsonKind(value))
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			v.addf(path, "expected an array, got %s", jsonKind(value))
+			return
+		}
+		for i, item := range items {
+			v.verifyValue(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.addf(path, "expected an object, got %s", jsonKind(value))
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			v.verifyValue(obj[key], typ.Elem(), joinPath(path, key))
+		}
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.addf(path, "expected an object, got %s", jsonKind(value))
+			return
+		}
+		known := make(map[string]bool)
+		v.verifyFields(obj, typ, path, known)
+		for _, key := range sortedKeys(obj) {
+			if !known[key] {
+				v.addf(joinPath(path, key), "unknown field")
+			}
+		}
+	}
+}
+
+// verifyFields checks the fields of a struct type, including those of
+// embedded structs, recording the field names seen in known
+func (v *verifier) verifyFields(obj map[string]any, typ reflect.Type, path string, known map[string]bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			v.verifyFields(obj, field.Type, path, known)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = true
+
+		value, ok := obj[name]
+		if !ok {
+			if !strings.Contains(opts, "omitempty") {
+				v.addf(joinPath(path, name), "missing field")
+			}
+			continue
+		}
+		v.verifyValue(value, field.Type, joinPath(path, name))
+	}
+}
+
+// joinPath appends a field name to a JSON path
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonKind names the JSON type of a decoded value
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// sortedKeys returns an object's keys in order, so issues are reported
+// deterministically
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package edgar
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// parseTestForm parses a test case with ParseAny
+func parseTestForm(t *testing.T, path string) *ParsedForm {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	form, err := ParseAny(f)
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", path, err)
+	}
+	return form
+}
+
+func TestVerifyOutput_Valid(t *testing.T) {
+	forms := []*ParsedForm{
+		parseTestForm(t, "testdata/form4/wave_derivatives/input.xml"),
+		parseTestForm(t, "testdata/schedule13/13d_2024_1/input.htm"),
+		parseTestForm(t, "testdata/xbrl/moderna_10k/input.htm"),
+	}
+
+	single, err := FormatJSON(forms[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch, err := FormatJSONBatch(forms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ndjson, err := FormatNDJSONBatch(forms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string][]byte{"single": single, "batch": batch, "ndjson": ndjson} {
+		result, err := VerifyOutput(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: VerifyOutput failed: %v", name, err)
+		}
+		if !result.OK() {
+			t.Errorf("%s: expected no issues, got %v", name, result.Issues)
+		}
+		if name != "single" && (result.Records != 3 || result.Kinds["Form 4"] != 1 || result.Kinds["Schedule 13D/G"] != 1 || result.Kinds["XBRL"] != 1) {
+			t.Errorf("%s: unexpected counts %d %v", name, result.Records, result.Kinds)
+		}
+	}
+}
+
+func TestVerifyOutput_Issues(t *testing.T) {
+	data, err := FormatJSON(parseTestForm(t, "testdata/form4/snow/input.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := string(data)
+	for _, r := range []struct{ old, new string }{
+		{`"schemaVersion": "X0306"`, `"schemaVersion": "v2"`}, // Unknown version
+		{`"has10b51Plan": false`, `"has10b51Plan": "no"`},     // Wrong type
+		{`"equitySwapInvolved": false,`, ``},                  // Missing field (first transaction)
+		{`"issuer": {`, `"issuer": {"exchange": "NYSE", `},    // Unknown field
+		{`"transactionCode": "S"`, `"transactionCode": null`}, // Null where not nullable
+	} {
+		if !strings.Contains(edited, r.old) {
+			t.Fatalf("Test output has no %s", r.old)
+		}
+		edited = strings.Replace(edited, r.old, r.new, 1)
+	}
+
+	result, err := VerifyOutput(strings.NewReader(edited))
+	if err != nil {
+		t.Fatalf("VerifyOutput failed: %v", err)
+	}
+	want := map[string]string{
+		"data.schemaVersion":                      "unknown ownership schema version",
+		"data.has10b51Plan":                       "expected a boolean",
+		"data.transactions[0].equitySwapInvolved": "missing field",
+		"data.issuer.exchange":                    "unknown field",
+		"data.transactions[1].transactionCode":    "null is not allowed",
+	}
+	found := make(map[string]bool)
+	for _, issue := range result.Issues {
+		if msg, ok := want[issue.Path]; ok && strings.Contains(issue.Message, msg) {
+			found[issue.Path] = true
+		}
+	}
+	for path, msg := range want {
+		if !found[path] {
+			t.Errorf("Expected %q at %s, got %v", msg, path, result.Issues)
+		}
+	}
+	if len(result.Issues) != len(want) {
+		t.Errorf("Expected %d issues, got %v", len(want), result.Issues)
+	}
+
+	for _, bad := range []string{`{"formType": "10-K", "data": {}}`, `{"foo": 1}`, `[1]`} {
+		result, err := VerifyOutput(strings.NewReader(bad))
+		if err != nil {
+			t.Fatalf("VerifyOutput(%s) failed: %v", bad, err)
+		}
+		if result.OK() {
+			t.Errorf("VerifyOutput(%s) expected issues", bad)
+		}
+	}
+	if _, err := VerifyOutput(strings.NewReader("{not json")); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
@@ -1,8 +1,10 @@
 package edgar
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -14,19 +16,65 @@ type XBRL struct {
 	Contexts []Context `xml:"context"`
 	Units    []Unit    `xml:"unit"`
 	Facts    []Fact    `xml:"-"` // Populated during parsing
+
+	// Calculations are summation relationships from the filing's calculation
+	// linkbase (see ParseCalculationLinkbase), used by ValidateCalculations
+	Calculations []Calculation `xml:"-"`
+
+	// Presentations are the statement and note trees from the filing's
+	// presentation linkbase (see ParsePresentationLinkbase), used by GetStatements
+	Presentations []Presentation `xml:"-"`
+
+	// Profile is the industry concept mapping profile the facts are labeled
+	// with (see ApplyProfile); empty means the default mappings
+	Profile string         `xml:"-"`
+	mapper  *conceptMapper // Set by ApplyProfile
+
+	// Warnings are data quality issues found while parsing: skipped contexts
+	// and facts, facts whose context is missing, numeric facts that aren't
+	// numbers (see ParseWarning)
+	Warnings []ParseWarning `xml:"-"`
 }
 
 // Context defines the dimensional context for facts (period, entity, segments)
 type Context struct {
-	ID     string `xml:"id,attr"`
-	Entity Entity `xml:"entity"`
-	Period Period `xml:"period"`
+	ID       string   `xml:"id,attr"`
+	Entity   Entity   `xml:"entity"`
+	Period   Period   `xml:"period"`
+	Scenario *Segment `xml:"scenario,omitempty"` // Rarely used; same members as a segment
 }
 
 // Entity identifies the reporting company
 type Entity struct {
-	Identifier string `xml:"identifier"`
-	Segment    string `xml:"segment,omitempty"`
+	Identifier string   `xml:"identifier"`
+	Segment    *Segment `xml:"segment,omitempty"` // nil for the entity-wide (total) context
+}
+
+// Segment holds the dimension members that qualify a context
+type Segment struct {
+	ExplicitMembers []ExplicitMember `xml:"explicitMember"`
+	TypedMembers    []TypedMember    `xml:"typedMember"`
+}
+
+// ExplicitMember selects a domain member of a dimension (xbrldi:explicitMember)
+// e.g. srt:ProductOrServiceAxis = us-gaap:ProductMember
+type ExplicitMember struct {
+	Dimension string `xml:"dimension,attr"`
+	Member    string `xml:",chardata"`
+}
+
+// TypedMember qualifies a dimension by a value instead of a member (xbrldi:typedMember)
+// e.g. us-gaap:LeaseIdentifierAxis = "Building 3"
+type TypedMember struct {
+	Dimension string `xml:"dimension,attr"`
+	Value     string `xml:",any"` // Text of the typed domain element
+}
+
+// Dimension is one dimension/member pair of a context
+type Dimension struct {
+	Dimension string `json:"dimension"` // Axis, e.g. "us-gaap:StatementBusinessSegmentsAxis"
+	Member    string `json:"member"`    // Member QName, or the value of a typed dimension
+	Typed     bool   `json:"typed,omitempty"`
 }
 
 // Period defines the time period for a fact (instant or duration)
@@ -51,30 +99,38 @@ type Divide struct {
 
 // Fact represents a single XBRL fact (financial data point)
 type Fact struct {
+	ID         string // id attribute, which footnote links refer to (often empty)
 	Concept    string // XBRL concept name (e.g., "us-gaap:Cash")
-	Value      string // Raw value as string
+	Value      string // Value as string (inline values converted per their format, scale and sign)
 	ContextRef string // Reference to Context.ID
 	UnitRef    string // Reference to Unit.ID
-	Decimals   int    // Precision (-3 = thousands, -6 = millions)
+	Decimals   int    // Precision (-3 = rounded to thousands, -6 = millions)
 
 	// Derived fields (populated after parsing)
-	StandardLabel string   // Standardized concept label (from mappings)
-	Period        *Period  // Resolved period from context
-	NumericValue  *float64 // Parsed numeric value (nil if non-numeric)
+	StandardLabel string         // Standardized concept label (from mappings)
+	Label         string         // Taxonomy label (set by ApplyLabels), empty until loaded
+	Period        *Period        // Resolved period from context
+	Dimensions    []Dimension    // Resolved from context; nil for entity-wide totals
+	NumericValue  *float64       // Parsed numeric value (nil if non-numeric)
+	Currency      string         // ISO 4217 code of monetary values and per share amounts (e.g. "USD", "EUR")
+	Footnotes     []FactFootnote // Explanatory footnotes linked to the fact
 }
 
 // ParseXBRL parses an XBRL instance document from XML bytes
 func ParseXBRL(data []byte) (*XBRL, error) {
+	return ParseXBRLReader(bytes.NewReader(data))
+}
+
+// ParseXBRLReader parses an XBRL instance document from a reader in a single
+// streaming pass
+func ParseXBRLReader(r io.Reader) (*XBRL, error) {
 	var xbrl XBRL
-	if err := xml.Unmarshal(data, &xbrl); err != nil {
-		return nil, fmt.Errorf("failed to parse XBRL XML: %w", err)
-	}
 
-	// Extract facts from the XML tree
+	// Contexts, units and facts in one walk of the XML tree
 	// Note: XBRL facts are dynamic elements (us-gaap:Cash, us-gaap:Revenue, etc.)
 	// We need custom parsing to extract them
-	if err := extractFacts(&xbrl, data); err != nil {
-		return nil, fmt.Errorf("failed to extract facts: %w", err)
+	if err := extractFacts(&xbrl, r); err != nil {
+		return nil, fmt.Errorf("failed to parse XBRL XML: %w", err)
 	}
 
 	// Resolve contexts and standardize labels
@@ -85,22 +141,70 @@ func ParseXBRL(data []byte) (*XBRL, error) {
 	return &xbrl, nil
 }
 
-// extractFacts parses the XML tree to find all fact elements
+// extractFacts walks the XML tree once, decoding the contexts and units that
+// are children of the xbrl root and every fact element
 // XBRL facts are dynamic elements with namespaces (us-gaap:*, dei:*, etc.)
-func extractFacts(xbrl *XBRL, data []byte) error {
-	// Create a generic XML decoder to walk the tree
-	decoder := xml.NewDecoder(strings.NewReader(string(data)))
+func extractFacts(xbrl *XBRL, r io.Reader) error {
+	decoder := xml.NewDecoder(r)
 
 	var facts []Fact
+	notes := newFootnoteIndex()
+	depth := 0 // Of the open elements; the xbrl root is 1
 
 	for {
 		token, err := decoder.Token()
+		if err == io.EOF {
+			if xbrl.XMLName.Local == "" {
+				return err // Empty document
+			}
+			break
+		}
 		if err != nil {
-			break // End of document
+			return err
 		}
 
 		switch elem := token.(type) {
+		case xml.EndElement:
+			depth--
 		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				if elem.Name.Local != "xbrl" {
+					return fmt.Errorf("expected element type <xbrl> but have <%s>", elem.Name.Local)
+				}
+				xbrl.XMLName = elem.Name
+				continue
+			}
+
+			// Decoded elements are consumed up to their end element
+			if depth == 2 && elem.Name.Local == "context" {
+				depth--
+				var ctx Context
+				if err := decoder.DecodeElement(&ctx, &elem); err != nil {
+					return err
+				}
+				xbrl.Contexts = append(xbrl.Contexts, ctx)
+				continue
+			}
+			if depth == 2 && elem.Name.Local == "unit" {
+				depth--
+				var unit Unit
+				if err := decoder.DecodeElement(&unit, &elem); err != nil {
+					return err
+				}
+				xbrl.Units = append(xbrl.Units, unit)
+				continue
+			}
+			if elem.Name.Local == "footnoteLink" {
+				depth--
+				var link footnoteLink
+				if err := decoder.DecodeElement(&link, &elem); err != nil {
+					return err
+				}
+				notes.addLink(link)
+				continue
+			}
+
 			// Check if this is a fact element (has contextRef attribute)
 			contextRef := getAttr(elem.Attr, "contextRef")
 			if contextRef == "" {
@@ -108,9 +212,10 @@ func extractFacts(xbrl *XBRL, data []byte) error {
 			}
 
 			// Parse the fact value
+			depth--
 			var value string
 			if err := decoder.DecodeElement(&value, &elem); err != nil {
-				continue
+				return err
 			}
 
 			// Build the full concept name (namespace:localName)
@@ -129,6 +234,7 @@ func extractFacts(xbrl *XBRL, data []byte) error {
 			}
 
 			fact := Fact{
+				ID:         getAttr(elem.Attr, "id"),
 				Concept:    conceptName,
 				Value:      strings.TrimSpace(value),
 				ContextRef: contextRef,
@@ -140,6 +246,7 @@ func extractFacts(xbrl *XBRL, data []byte) error {
 		}
 	}
 
+	notes.apply(facts)
 	xbrl.Facts = facts
 	return nil
 }
@@ -151,6 +258,10 @@ func resolveFacts(xbrl *XBRL) error {
 	for i := range xbrl.Contexts {
 		contextMap[xbrl.Contexts[i].ID] = &xbrl.Contexts[i]
 	}
+	currencies := make(map[string]string, len(xbrl.Units))
+	for _, u := range xbrl.Units {
+		currencies[u.ID] = unitCurrency(u)
+	}
 
 	// Resolve each fact
 	for i := range xbrl.Facts {
@@ -159,50 +270,85 @@ func resolveFacts(xbrl *XBRL) error {
 		// Resolve context
 		if ctx, ok := contextMap[fact.ContextRef]; ok {
 			fact.Period = &ctx.Period
+			fact.Dimensions = ctx.Dimensions()
+		} else {
+			xbrl.warn(fact.Concept, fact.ContextRef, "context not found, fact has no period")
 		}
+		fact.Currency = currencies[fact.UnitRef]
 
 		// Get standardized label
 		fact.StandardLabel = GetStandardizedLabel(fact.Concept)
 
+		// Narrative disclosures as plain text
+		if IsTextBlock(fact.Concept) {
+			fact.Value = cleanTextBlock(fact.Value)
+		}
+
 		// Parse numeric value
-		if val, err := parseNumericValue(fact.Value, fact.Decimals); err == nil {
+		if val, err := parseNumericValue(fact.Value); err == nil {
 			fact.NumericValue = &val
+		} else if fact.UnitRef != "" && fact.Value != "" {
+			xbrl.warn(fact.Concept, fact.Value, "not a number, fact has no numeric value")
 		}
 	}
 
+	// IFRS filers need the ifrs-full mappings
+	if xbrl.Taxonomy() == TaxonomyIFRS {
+		xbrl.relabel(nil)
+	}
+
 	return nil
 }
 
-// parseNumericValue converts a string value to float64, applying decimal scaling
-func parseNumericValue(value string, decimals int) (float64, error) {
-	// Remove commas and whitespace
-	cleaned := strings.ReplaceAll(value, ",", "")
+// warn records a data quality issue
+func (x *XBRL) warn(field, value, message string) {
+	x.Warnings = append(x.Warnings, ParseWarning{Field: field, Value: value, Message: message})
+}
+
+// Dimensions returns the dimension/member pairs of the context's segment and scenario
+// An entity-wide context (no dimensions) returns nil.
+func (c *Context) Dimensions() []Dimension {
+	var dims []Dimension
+	for _, seg := range []*Segment{c.Entity.Segment, c.Scenario} {
+		if seg == nil {
+			continue
+		}
+		for _, m := range seg.ExplicitMembers {
+			dims = append(dims, Dimension{Dimension: strings.TrimSpace(m.Dimension), Member: strings.TrimSpace(m.Member)})
+		}
+		for _, m := range seg.TypedMembers {
+			dims = append(dims, Dimension{Dimension: strings.TrimSpace(m.Dimension), Member: strings.TrimSpace(m.Value), Typed: true})
+		}
+	}
+	return dims
+}
+
+// parseNumericValue converts a fact value to float64
+// Values are as reported: decimals gives their precision, not a scale (a value
+// of 1234000 with decimals -3 is accurate to the thousand). Inline XBRL scale
+// is applied when the fact is extracted.
+func parseNumericValue(value string) (float64, error) {
+	// Remove commas (and other thousands separators) and whitespace
+	cleaned := strings.ReplaceAll(cleanNumberText(value), ",", "")
 	cleaned = strings.TrimSpace(cleaned)
 
-	// Handle empty or non-numeric values
-	if cleaned == "" || cleaned == "-" || cleaned == "—" {
+	// Handle empty values and dash placeholders ("-", "—"); "-0-" is zero
+	if cleaned == "" || isPlaceholder(cleaned) {
 		return 0, fmt.Errorf("empty or invalid value")
 	}
-
-	// Parse to float
-	val, err := strconv.ParseFloat(cleaned, 64)
-	if err != nil {
-		return 0, err
+	if isZeroPlaceholder(cleaned) && strings.Trim(cleaned, dashes+" 0") == "" {
+		return 0, nil
 	}
 
-	// Apply decimal scaling
-	// Decimals of -3 means value is in thousands, -6 means millions, etc.
-	// We want to return the actual value (not scaled)
-	// Example: value="1234" decimals=-3 means 1,234,000
-	if decimals < 0 {
-		scale := 1.0
-		for i := 0; i < -decimals; i++ {
-			scale *= 10
+	// Negatives in parentheses: "(1234)"
+	if inner, ok := strings.CutPrefix(cleaned, "("); ok {
+		if inner, ok := strings.CutSuffix(inner, ")"); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(inner), 64)
+			return -f, err
 		}
-		val *= scale
 	}
 
-	return val, nil
+	return strconv.ParseFloat(cleaned, 64)
 }
 
 // getAttr gets an attribute value by name
@@ -248,6 +394,22 @@ func (f *Fact) Float64() (float64, error) {
 	return 0, fmt.Errorf("fact %s has no numeric value", f.Concept)
 }
 
+// IsDimensional returns true if the fact is broken out by segment, product,
+// geography or another dimension (i.e. it is not an entity-wide total)
+func (f *Fact) IsDimensional() bool {
+	return len(f.Dimensions) > 0
+}
+
+// Member returns the fact's member for a dimension (e.g. "srt:ProductOrServiceAxis")
+func (f *Fact) Member(dimension string) (string, bool) {
+	for _, d := range f.Dimensions {
+		if d.Dimension == dimension {
+			return d.Member, true
+		}
+	}
+	return "", false
+}
+
 // IsInstant returns true if this fact is for a point in time (balance sheet)
 func (f *Fact) IsInstant() bool {
 	return f.Period != nil && f.Period.Instant != ""
@@ -0,0 +1,215 @@
+package edgar
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Calculation is a summation relationship from a calculation linkbase:
+// Total = sum of Weight * Item, e.g. Assets = AssetsCurrent + AssetsNoncurrent
+type Calculation struct {
+	Role  string            // Extended link role (usually one statement)
+	Total string            // Concept name, e.g. "us-gaap:Assets"
+	Items []CalculationItem // Contributing concepts
+}
+
+// CalculationItem is one contributing concept of a Calculation
+type CalculationItem struct {
+	Concept string
+	Weight  float64 // 1 or -1 (subtracted)
+}
+
+// CalculationInconsistency is a total that does not match the sum of its items
+type CalculationInconsistency struct {
+	Role       string
+	Total      string // Concept name of the total
+	ContextRef string
+	Period     string // Period label, e.g. "2024-12-31"
+	Reported   float64
+	Computed   float64 // Weighted sum of the items reported in the context
+	Difference float64 // Reported - Computed
+	Tolerance  float64 // Allowed for rounding of the reported values
+	Items      []string
+}
+
+func (c CalculationInconsistency) String() string {
+	return fmt.Sprintf("%s (%s): reported %.0f, items sum to %.0f (off by %.0f)",
+		c.Total, c.Period, c.Reported, c.Computed, c.Difference)
+}
+
+// defaultCalculations are checked when no calculation linkbase is loaded
+var defaultCalculations = []Calculation{
+	{
+		Role:  "balance sheet identity",
+		Total: "us-gaap:Assets",
+		Items: []CalculationItem{{Concept: "us-gaap:LiabilitiesAndStockholdersEquity", Weight: 1}},
+	},
+}
+
+type calculationLinkbase struct {
+	Links []struct {
+		Role string `xml:"role,attr"`
+		Locs []struct {
+			Href  string `xml:"href,attr"`
+			Label string `xml:"label,attr"`
+		} `xml:"loc"`
+		Arcs []struct {
+			From   string `xml:"from,attr"`
+			To     string `xml:"to,attr"`
+			Weight string `xml:"weight,attr"`
+		} `xml:"calculationArc"`
+	} `xml:"calculationLink"`
+}
+
+// CalculationLinkbaseURL returns the calculation linkbase of a filing from the URL
+// of its XBRL instance or inline XBRL document, following SEC file naming
+// e.g. ".../mrna-20241231.htm" -> ".../mrna-20241231_cal.xml"
+func CalculationLinkbaseURL(instanceURL string) string {
+	return strings.TrimSuffix(LabelLinkbaseURL(instanceURL), "_lab.xml") + "_cal.xml"
+}
+
+// FetchCalculationLinkbase fetches and parses a calculation linkbase
+func FetchCalculationLinkbase(url string, email string) ([]Calculation, error) {
+	return defaultClient(email).FetchCalculationLinkbase(url)
+}
+
+// FetchCalculationLinkbase fetches and parses a calculation linkbase
+func (c *Client) FetchCalculationLinkbase(url string) ([]Calculation, error) {
+	body, err := c.get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calculation linkbase: %w", err)
+	}
+	defer body.Close()
+	return ParseCalculationLinkbase(body)
+}
+
+// ParseCalculationLinkbase parses the summation relationships of a calculation linkbase
+func ParseCalculationLinkbase(r io.Reader) ([]Calculation, error) {
+	var lb calculationLinkbase
+	if err := xml.NewDecoder(r).Decode(&lb); err != nil {
+		return nil, fmt.Errorf("failed to parse calculation linkbase: %w", err)
+	}
+
+	var calcs []Calculation
+	for _, link := range lb.Links {
+		concepts := make(map[string]string) // Locator label -> concept
+		for _, loc := range link.Locs {
+			concepts[loc.Label] = conceptFromHref(loc.Href)
+		}
+
+		byTotal := make(map[string]int) // Total concept -> index in calcs
+		for _, arc := range link.Arcs {
+			total, item := concepts[arc.From], concepts[arc.To]
+			if total == "" || item == "" {
+				continue
+			}
+			weight := 1.0
+			if arc.Weight != "" {
+				w, err := strconv.ParseFloat(arc.Weight, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid weight %q on %s: %w", arc.Weight, total, err)
+				}
+				weight = w
+			}
+
+			i, ok := byTotal[total]
+			if !ok {
+				i = len(calcs)
+				byTotal[total] = i
+				calcs = append(calcs, Calculation{Role: link.Role, Total: total})
+			}
+			calcs[i].Items = append(calcs[i].Items, CalculationItem{Concept: item, Weight: weight})
+		}
+	}
+	return calcs, nil
+}
+
+// ValidateCalculations checks that reported totals equal the weighted sum of
+// their reported items, context by context, within the rounding allowed by each
+// value's decimals. It uses x.Calculations when a calculation linkbase has been
+// loaded, otherwise a built-in balance sheet check (Assets = Liabilities and
+// Stockholders' Equity).
+//
+// As in XBRL calculation semantics, items not reported in a context are left
+// out of the sum, and a total with no reported items is not checked.
+func (x *XBRL) ValidateCalculations() []CalculationInconsistency {
+	calcs := x.Calculations
+	if len(calcs) == 0 {
+		calcs = defaultCalculations
+	}
+
+	// Most precise numeric value of each concept by context and unit
+	type key struct{ concept, context, unit string }
+	values := make(map[key]*Fact)
+	for i := range x.Facts {
+		fact := &x.Facts[i]
+		if fact.NumericValue == nil {
+			continue
+		}
+		k := key{fact.Concept, fact.ContextRef, fact.UnitRef}
+		if current, ok := values[k]; !ok || fact.Decimals > current.Decimals {
+			values[k] = fact
+		}
+	}
+
+	var issues []CalculationInconsistency
+	for _, calc := range calcs {
+		for k, total := range values {
+			if k.concept != calc.Total {
+				continue
+			}
+
+			computed, tolerance := 0.0, roundingTolerance(total)
+			var items []string
+			for _, item := range calc.Items {
+				fact, ok := values[key{item.Concept, k.context, k.unit}]
+				if !ok {
+					continue
+				}
+				computed += item.Weight * *fact.NumericValue
+				tolerance += math.Abs(item.Weight) * roundingTolerance(fact)
+				items = append(items, item.Concept)
+			}
+			if len(items) == 0 {
+				continue
+			}
+
+			diff := *total.NumericValue - computed
+			if math.Abs(diff) > tolerance {
+				issues = append(issues, CalculationInconsistency{
+					Role:       calc.Role,
+					Total:      calc.Total,
+					ContextRef: k.context,
+					Period:     total.GetPeriodLabel(),
+					Reported:   *total.NumericValue,
+					Computed:   computed,
+					Difference: diff,
+					Tolerance:  tolerance,
+					Items:      items,
+				})
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Total != issues[j].Total {
+			return issues[i].Total < issues[j].Total
+		}
+		if issues[i].ContextRef != issues[j].ContextRef {
+			return issues[i].ContextRef < issues[j].ContextRef
+		}
+		return issues[i].Role < issues[j].Role
+	})
+	return issues
+}
+
+// roundingTolerance is the most a value can be off by rounding to its decimals
+// e.g. decimals -6 (millions) allows 500,000
+func roundingTolerance(f *Fact) float64 {
+	return 0.5 * math.Pow(10, float64(-f.Decimals))
+}
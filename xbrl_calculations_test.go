@@ -0,0 +1,75 @@
+package edgar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const testCalculationLinkbase = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:calculationLink xlink:type="extended" xlink:role="http://example.com/role/IncomeStatement">
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_OperatingExpenses" xlink:label="loc_opex"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_ResearchAndDevelopmentExpense" xlink:label="loc_rd"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_SellingGeneralAndAdministrativeExpense" xlink:label="loc_sga"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_OperatingIncomeLoss" xlink:label="loc_oi"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_Revenues" xlink:label="loc_rev"/>
+    <link:calculationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/summation-item" xlink:from="loc_opex" xlink:to="loc_rd" weight="1.0" order="1"/>
+    <link:calculationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/summation-item" xlink:from="loc_opex" xlink:to="loc_sga" weight="1.0" order="2"/>
+    <link:calculationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/summation-item" xlink:from="loc_oi" xlink:to="loc_rev" weight="1.0" order="1"/>
+    <link:calculationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/summation-item" xlink:from="loc_oi" xlink:to="loc_opex" weight="-1.0" order="2"/>
+  </link:calculationLink>
+</link:linkbase>`
+
+func TestValidateCalculations(t *testing.T) {
+	calcs, err := ParseCalculationLinkbase(strings.NewReader(testCalculationLinkbase))
+	if err != nil {
+		t.Fatalf("ParseCalculationLinkbase: %v", err)
+	}
+	if len(calcs) != 2 || calcs[1].Total != "us-gaap:OperatingIncomeLoss" || calcs[1].Items[1].Weight != -1 {
+		t.Fatalf("calculations = %+v", calcs)
+	}
+
+	fact := func(concept, context string, value float64, decimals int) Fact {
+		return Fact{Concept: concept, ContextRef: context, UnitRef: "usd", Decimals: decimals, NumericValue: &value}
+	}
+	x := &XBRL{
+		Calculations: calcs,
+		Facts: []Fact{
+			// FY2024: rounded to millions, off by 1M in total (within rounding)
+			fact("us-gaap:Revenues", "FY2024", 3_236_000_000, -6),
+			fact("us-gaap:ResearchAndDevelopmentExpense", "FY2024", 4_543_000_000, -6),
+			fact("us-gaap:SellingGeneralAndAdministrativeExpense", "FY2024", 1_174_000_000, -6),
+			fact("us-gaap:OperatingExpenses", "FY2024", 5_718_000_000, -6),
+			fact("us-gaap:OperatingIncomeLoss", "FY2024", -2_482_000_000, -6),
+			// FY2023: operating expenses mis-extracted
+			fact("us-gaap:ResearchAndDevelopmentExpense", "FY2023", 4_845_000_000, -6),
+			fact("us-gaap:SellingGeneralAndAdministrativeExpense", "FY2023", 1_549_000_000, -6),
+			fact("us-gaap:OperatingExpenses", "FY2023", 6_494_000, -3),
+		},
+	}
+
+	issues := x.ValidateCalculations()
+	if len(issues) != 1 {
+		t.Fatalf("got %d inconsistencies, want 1: %v", len(issues), issues)
+	}
+	if got := issues[0]; got.Total != "us-gaap:OperatingExpenses" || got.ContextRef != "FY2023" || got.Computed != 6_394_000_000 {
+		t.Errorf("inconsistency = %+v", got)
+	}
+}
+
+func TestValidateCalculationsModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+
+	// Without a linkbase: Assets = Liabilities and Stockholders' Equity
+	if issues := x.ValidateCalculations(); len(issues) != 0 {
+		t.Errorf("balance sheet does not balance: %v", issues)
+	}
+}
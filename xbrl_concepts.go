@@ -4,7 +4,11 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"os"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 )
 
 //go:embed concept_mappings.json
@@ -22,10 +26,15 @@ type ConceptMapping struct {
 type ConceptDefinition struct {
 	Concepts []string `json:"concepts"`
 	Notes    string   `json:"notes"`
+
+	// Replace discards the label's existing concepts when merged with
+	// LoadConceptMappings instead of extending them
+	Replace bool `json:"replace,omitempty"`
 }
 
 // conceptMapper provides lookup capabilities for XBRL concepts
 type conceptMapper struct {
+	mu            sync.RWMutex
 	mappings      map[string]ConceptDefinition // standardized label -> definition
 	reverseLookup map[string]string            // XBRL concept -> standardized label
 }
@@ -65,6 +74,9 @@ func loadConceptMappings() (*conceptMapper, error) {
 // GetStandardizedLabel returns the standardized label for an XBRL concept
 // Returns empty string if no mapping exists
 func (m *conceptMapper) GetStandardizedLabel(xbrlConcept string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	// Try exact match first
 	if label, ok := m.reverseLookup[xbrlConcept]; ok {
 		return label
@@ -82,6 +94,9 @@ func (m *conceptMapper) GetStandardizedLabel(xbrlConcept string) string {
 
 // GetConcepts returns all XBRL concepts that map to a standardized label
 func (m *conceptMapper) GetConcepts(standardizedLabel string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	def, ok := m.mappings[standardizedLabel]
 	if !ok {
 		return nil, fmt.Errorf("unknown standardized label: %s", standardizedLabel)
@@ -91,6 +106,9 @@ func (m *conceptMapper) GetConcepts(standardizedLabel string) ([]string, error)
 
 // GetAllStandardizedLabels returns all available standardized labels
 func (m *conceptMapper) GetAllStandardizedLabels() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
 	labels := make([]string, 0, len(m.mappings))
 	for label := range m.mappings {
 		labels = append(labels, label)
@@ -98,6 +116,72 @@ func (m *conceptMapper) GetAllStandardizedLabels() []string {
 	return labels
 }
 
+// clone returns an independent copy of the mapper
+func (m *conceptMapper) clone() *conceptMapper {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	c := &conceptMapper{
+		mappings:      make(map[string]ConceptDefinition, len(m.mappings)),
+		reverseLookup: make(map[string]string, len(m.reverseLookup)),
+	}
+	for label, def := range m.mappings {
+		def.Concepts = slices.Clone(def.Concepts)
+		c.mappings[label] = def
+	}
+	for concept, label := range m.reverseLookup {
+		c.reverseLookup[concept] = label
+	}
+	return c
+}
+
+// priority returns the position of concept in the mappings for label
+// (lower is preferred); concepts not listed come last
+func (m *conceptMapper) priority(label, concept string) int {
+	concepts, _ := m.GetConcepts(label)
+	for i, c := range concepts {
+		if strings.EqualFold(c, concept) {
+			return i
+		}
+	}
+	return len(concepts)
+}
+
+// add merges a definition into the label: its concepts come first (in order
+// of preference) and move from any other label they were mapped to
+func (m *conceptMapper) add(label string, def ConceptDefinition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, concept := range def.Concepts {
+		if previous, ok := m.reverseLookup[concept]; ok && previous != label {
+			old := m.mappings[previous]
+			old.Concepts = slices.DeleteFunc(slices.Clone(old.Concepts), func(c string) bool { return c == concept })
+			m.mappings[previous] = old
+		}
+	}
+
+	existing := m.mappings[label]
+	merged := ConceptDefinition{Concepts: slices.Clone(def.Concepts), Notes: existing.Notes}
+	if def.Notes != "" {
+		merged.Notes = def.Notes
+	}
+	for _, concept := range existing.Concepts {
+		switch {
+		case slices.Contains(def.Concepts, concept):
+		case def.Replace:
+			delete(m.reverseLookup, concept)
+		default:
+			merged.Concepts = append(merged.Concepts, concept)
+		}
+	}
+
+	m.mappings[label] = merged
+	for _, concept := range merged.Concepts {
+		m.reverseLookup[concept] = label
+	}
+}
+
 // HasMapping returns true if the XBRL concept has a standardized mapping
 func (m *conceptMapper) HasMapping(xbrlConcept string) bool {
 	return m.GetStandardizedLabel(xbrlConcept) != ""
@@ -124,3 +208,48 @@ func GetAllStandardizedLabels() []string {
 func HasMapping(xbrlConcept string) bool {
 	return globalMapper.HasMapping(xbrlConcept)
 }
+
+// LoadConceptMappings merges a concept mappings file (same format as the
+// embedded concept_mappings.json) into the mappings used by the package
+//
+// A label already mapped is extended: the file's concepts are preferred over the
+// existing ones, unless the definition sets "replace": true. A concept mapped to
+// another label moves to the file's label. New labels are added. Mappings apply
+// to documents parsed afterwards.
+func LoadConceptMappings(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read concept mappings: %w", err)
+	}
+	var mapping ConceptMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return fmt.Errorf("failed to parse concept mappings %s: %w", path, err)
+	}
+	if len(mapping.Mappings) == 0 {
+		return fmt.Errorf("no mappings in %s", path)
+	}
+
+	globalMapper.merge(mapping.Mappings)
+	return nil
+}
+
+// merge adds each definition (see add) in label order, so results do not
+// depend on map iteration when definitions share concepts
+func (m *conceptMapper) merge(mappings map[string]ConceptDefinition) {
+	labels := make([]string, 0, len(mappings))
+	for label := range mappings {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		m.add(label, mappings[label])
+	}
+}
+
+// AddConceptMapping maps XBRL concepts to a standardized label, new or existing
+// e.g. AddConceptMapping("Revenue", "abc:CollaborationRevenue")
+// The concepts are preferred over the label's existing concepts and move from
+// any other label they were mapped to. Mappings apply to documents parsed afterwards.
+func AddConceptMapping(label string, concepts ...string) {
+	globalMapper.add(label, ConceptDefinition{Concepts: concepts})
+}
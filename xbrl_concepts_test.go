@@ -1,6 +1,10 @@
 package edgar
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -101,3 +105,79 @@ func TestConceptMappingCaseInsensitive(t *testing.T) {
 		})
 	}
 }
+
+// A concept listed under two labels gets either one, depending on map order
+func TestConceptMappingsUnique(t *testing.T) {
+	var mapping ConceptMapping
+	if err := json.Unmarshal(conceptMappingsJSON, &mapping); err != nil {
+		t.Fatalf("parsing concept_mappings.json: %v", err)
+	}
+	seen := make(map[string]string)
+	for label, def := range mapping.Mappings {
+		for _, concept := range def.Concepts {
+			if other, ok := seen[concept]; ok {
+				t.Errorf("%s is mapped to both %q and %q", concept, other, label)
+			}
+			seen[concept] = label
+		}
+	}
+}
+
+func TestLoadConceptMappings(t *testing.T) {
+	t.Cleanup(func() { globalMapper, _ = loadConceptMappings() })
+
+	path := filepath.Join(t.TempDir(), "mappings.json")
+	data := `{
+  "mappings": {
+    "Revenue": {"concepts": ["abc:CollaborationRevenue"]},
+    "Cash and Cash Equivalents": {"concepts": ["abc:CashOnHand"], "replace": true},
+    "Royalty Revenue": {"concepts": ["us-gaap:RevenueFromContractWithCustomerExcludingAssessedTax"]}
+  }
+}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConceptMappings(path); err != nil {
+		t.Fatalf("LoadConceptMappings: %v", err)
+	}
+
+	// Extended: the new concept is preferred, existing ones are kept
+	revenue, err := GetConceptsForLabel("Revenue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revenue[0] != "abc:CollaborationRevenue" || len(revenue) < 2 {
+		t.Errorf("Revenue concepts = %v, want abc:CollaborationRevenue first plus the built-in ones", revenue)
+	}
+	if got := GetStandardizedLabel("abc:CollaborationRevenue"); got != "Revenue" {
+		t.Errorf("GetStandardizedLabel(abc:CollaborationRevenue) = %q, want Revenue", got)
+	}
+
+	// Replaced: only the file's concept remains
+	cash, _ := GetConceptsForLabel("Cash and Cash Equivalents")
+	if len(cash) != 1 || cash[0] != "abc:CashOnHand" {
+		t.Errorf("Cash concepts = %v, want [abc:CashOnHand]", cash)
+	}
+	if HasMapping("us-gaap:CashAndCashEquivalentsAtCarryingValue") {
+		t.Error("replaced cash concept still mapped")
+	}
+
+	// Moved: a concept belongs to one label only
+	const moved = "us-gaap:RevenueFromContractWithCustomerExcludingAssessedTax"
+	if got := GetStandardizedLabel(moved); got != "Royalty Revenue" {
+		t.Errorf("GetStandardizedLabel(%s) = %q, want Royalty Revenue", moved, got)
+	}
+	revenue, _ = GetConceptsForLabel("Revenue")
+	if slices.Contains(revenue, moved) {
+		t.Errorf("Revenue concepts still include %s", moved)
+	}
+
+	AddConceptMapping("Milestone Revenue", "abc:MilestoneRevenue")
+	if got := GetStandardizedLabel("abc:MilestoneRevenue"); got != "Milestone Revenue" {
+		t.Errorf("GetStandardizedLabel(abc:MilestoneRevenue) = %q, want Milestone Revenue", got)
+	}
+
+	if err := LoadConceptMappings(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadConceptMappings(missing file) should return error")
+	}
+}
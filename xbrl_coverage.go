@@ -0,0 +1,100 @@
+package edgar
+
+import (
+	"sort"
+	"strings"
+)
+
+// CoverageReport shows how much of a filing the concept mappings cover: why a
+// snapshot field is missing, and which concepts to map (see LoadConceptMappings)
+type CoverageReport struct {
+	Profile        string `json:"profile,omitempty"`
+	TotalFacts     int    `json:"totalFacts"`
+	MappedFacts    int    `json:"mappedFacts"` // Facts with a standardized label
+	UnmappedFacts  int    `json:"unmappedFacts"`
+	Concepts       int    `json:"concepts"` // Distinct concepts
+	MappedConcepts int    `json:"mappedConcepts"`
+
+	// Standardized labels without an entity-wide numeric fact, so their
+	// snapshot fields are nil
+	MissingLabels []string `json:"missingLabels"`
+
+	// Unmapped numeric concepts reported entity-wide (not only by segment),
+	// most frequent first: the candidates for a mapping
+	TopUnmapped []UnmappedConcept `json:"topUnmapped"`
+}
+
+// UnmappedConcept is a concept without a standardized label
+type UnmappedConcept struct {
+	Concept string `json:"concept"`
+	Label   string `json:"label,omitempty"` // Taxonomy label when loaded (see ApplyLabels)
+	Facts   int    `json:"facts"`           // Entity-wide facts (periods) reported
+}
+
+// ConceptCoverage reports mapped and unmapped facts and concepts, with at most
+// limit of the most frequent unmapped concepts (all when limit <= 0)
+//
+// Cover page (dei) and text block facts are counted but never listed as
+// unmapped: they are not financial values a snapshot could use.
+func (x *XBRL) ConceptCoverage(limit int) *CoverageReport {
+	report := &CoverageReport{Profile: x.Profile, TotalFacts: len(x.Facts)}
+
+	concepts := make(map[string]bool) // Concept -> mapped
+	reported := make(map[string]bool) // Labels with an entity-wide numeric fact
+	unmapped := make(map[string]*UnmappedConcept)
+	var order []string // Unmapped concepts in document order, for stable ties
+	for _, f := range x.Facts {
+		mapped := f.StandardLabel != ""
+		concepts[f.Concept] = mapped
+		if mapped {
+			report.MappedFacts++
+		} else {
+			report.UnmappedFacts++
+		}
+
+		if f.NumericValue == nil || len(f.Dimensions) > 0 {
+			continue
+		}
+		if mapped {
+			reported[f.StandardLabel] = true
+			continue
+		}
+		if strings.HasPrefix(f.Concept, "dei:") || IsTextBlock(f.Concept) {
+			continue
+		}
+		u, ok := unmapped[f.Concept]
+		if !ok {
+			u = &UnmappedConcept{Concept: f.Concept, Label: f.Label}
+			unmapped[f.Concept] = u
+			order = append(order, f.Concept)
+		}
+		u.Facts++
+	}
+
+	report.Concepts = len(concepts)
+	for _, mapped := range concepts {
+		if mapped {
+			report.MappedConcepts++
+		}
+	}
+
+	report.MissingLabels = []string{}
+	for _, label := range x.mappings().GetAllStandardizedLabels() {
+		if !reported[label] {
+			report.MissingLabels = append(report.MissingLabels, label)
+		}
+	}
+	sort.Strings(report.MissingLabels)
+
+	report.TopUnmapped = make([]UnmappedConcept, 0, len(order))
+	for _, concept := range order {
+		report.TopUnmapped = append(report.TopUnmapped, *unmapped[concept])
+	}
+	sort.SliceStable(report.TopUnmapped, func(i, j int) bool {
+		return report.TopUnmapped[i].Facts > report.TopUnmapped[j].Facts
+	})
+	if limit > 0 && len(report.TopUnmapped) > limit {
+		report.TopUnmapped = report.TopUnmapped[:limit]
+	}
+	return report
+}
@@ -0,0 +1,58 @@
+package edgar
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConceptCoverageModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL: %v", err)
+	}
+
+	report := x.ConceptCoverage(10)
+	if report.TotalFacts != len(x.Facts) || report.MappedFacts+report.UnmappedFacts != report.TotalFacts {
+		t.Errorf("fact counts = %d mapped + %d unmapped, want %d total", report.MappedFacts, report.UnmappedFacts, len(x.Facts))
+	}
+	if report.MappedConcepts == 0 || report.MappedConcepts >= report.Concepts {
+		t.Errorf("MappedConcepts = %d of %d", report.MappedConcepts, report.Concepts)
+	}
+
+	// Moderna reports PP&E only including finance lease right-of-use assets
+	if !slices.Contains(report.MissingLabels, "Property Plant and Equipment") {
+		t.Errorf("MissingLabels = %v, want Property Plant and Equipment", report.MissingLabels)
+	}
+	if slices.Contains(report.MissingLabels, "Revenue") {
+		t.Error("Revenue is reported but listed as missing")
+	}
+	var ppe bool
+	for i, u := range report.TopUnmapped {
+		if u.Concept == "us-gaap:PropertyPlantAndEquipmentAndFinanceLeaseRightOfUseAssetAfterAccumulatedDepreciationAndAmortization" {
+			ppe = true
+		}
+		if i > 0 && u.Facts > report.TopUnmapped[i-1].Facts {
+			t.Errorf("TopUnmapped not sorted by frequency: %v", report.TopUnmapped)
+		}
+		if strings.HasPrefix(u.Concept, "dei:") || IsTextBlock(u.Concept) {
+			t.Errorf("%s listed as unmapped", u.Concept)
+		}
+	}
+	if len(report.TopUnmapped) != 10 || !ppe {
+		t.Errorf("TopUnmapped = %v, want 10 including the PP&E concept", report.TopUnmapped)
+	}
+
+	// Mapping the concept fills the label
+	t.Cleanup(func() { globalMapper, _ = loadConceptMappings() })
+	AddConceptMapping("Property Plant and Equipment", "us-gaap:PropertyPlantAndEquipmentAndFinanceLeaseRightOfUseAssetAfterAccumulatedDepreciationAndAmortization")
+	x, _ = ParseInlineXBRL(data)
+	if report := x.ConceptCoverage(0); slices.Contains(report.MissingLabels, "Property Plant and Equipment") {
+		t.Errorf("Property Plant and Equipment still missing after mapping its concept")
+	}
+}
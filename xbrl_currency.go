@@ -0,0 +1,68 @@
+package edgar
+
+import (
+	"sort"
+	"strings"
+)
+
+// unitCurrency returns the ISO 4217 currency code of a monetary unit ("EUR"
+// for iso4217:EUR, "USD" for iso4217:USD per share), or "" for shares, pure
+// numbers and other non-monetary units
+func unitCurrency(u Unit) string {
+	measure := u.Measure
+	if u.Divide != nil {
+		measure = u.Divide.Numerator
+	}
+	measure = strings.TrimSpace(measure)
+	if code, ok := strings.CutPrefix(measure, "iso4217:"); ok {
+		return strings.ToUpper(code)
+	}
+	return ""
+}
+
+// ReportingCurrency returns the currency most of the filing's standardized
+// entity-wide values are reported in, e.g. "USD" or "EUR", or "" when no
+// monetary value is mapped. Snapshots use only values in this currency: a
+// 20-F often tags a US dollar convenience translation next to each amount.
+func (x *XBRL) ReportingCurrency() string {
+	counts := x.mappedCurrencies()
+	best := ""
+	for _, code := range sortedCurrencies(counts) {
+		if best == "" || counts[code] > counts[best] {
+			best = code
+		}
+	}
+	return best
+}
+
+// mappedCurrencies counts the entity-wide standardized values per currency
+func (x *XBRL) mappedCurrencies() map[string]int {
+	counts := make(map[string]int)
+	for _, f := range x.Facts {
+		if f.Currency != "" && f.StandardLabel != "" && f.NumericValue != nil && !f.IsDimensional() {
+			counts[f.Currency]++
+		}
+	}
+	return counts
+}
+
+// otherCurrencies returns the currencies of standardized values other than
+// currency, which a snapshot in currency leaves out
+func (x *XBRL) otherCurrencies(currency string) []string {
+	counts := x.mappedCurrencies()
+	delete(counts, currency)
+	return sortedCurrencies(counts)
+}
+
+// sortedCurrencies returns the currency codes of counts in alphabetical order
+func sortedCurrencies(counts map[string]int) []string {
+	if len(counts) == 0 {
+		return nil
+	}
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
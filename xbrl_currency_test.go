@@ -0,0 +1,80 @@
+package edgar
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnapshotCurrency(t *testing.T) {
+	// The ifrsDoc 20-F with a US dollar convenience translation of some amounts
+	translated := `<xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="usdPerShare"><xbrli:divide><xbrli:unitNumerator><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unitNumerator>
+    <xbrli:unitDenominator><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unitDenominator></xbrli:divide></xbrli:unit>
+  <xbrli:unit id="shares"><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unit>
+</ix:resources>`
+	doc := strings.Replace(ifrsDoc, "</ix:resources>", translated, 1)
+	doc = strings.Replace(doc, "</body>", `<ix:nonFraction name="ifrs-full:Revenue" contextRef="FY" unitRef="usd" decimals="-6" scale="6" format="ixt:num-dot-decimal">1,300</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:CashAndCashEquivalents" contextRef="End" unitRef="usd" decimals="-6" scale="6">360</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:BasicEarningsLossPerShare" contextRef="FY" unitRef="usdPerShare" decimals="2">1.50</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:WeightedAverageShares" contextRef="FY" unitRef="shares" decimals="-6" scale="6">100</ix:nonFraction>
+</body>`, 1)
+
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	for _, f := range x.Query().ByConcept("ifrs-full:BasicEarningsLossPerShare", "ifrs-full:WeightedAverageShares").Get() {
+		want := map[string]string{"ifrs-full:BasicEarningsLossPerShare": "USD", "ifrs-full:WeightedAverageShares": ""}[f.Concept]
+		if f.Currency != want {
+			t.Errorf("%s Currency = %q, want %q", f.Concept, f.Currency, want)
+		}
+	}
+	if got := x.ReportingCurrency(); got != "EUR" {
+		t.Fatalf("ReportingCurrency = %q, want EUR", got)
+	}
+
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if snapshot.Currency != "EUR" || strings.Join(snapshot.OtherCurrencies, ",") != "USD" {
+		t.Errorf("Currency = %q, OtherCurrencies = %v, want EUR and [USD]", snapshot.Currency, snapshot.OtherCurrencies)
+	}
+	if value(snapshot.Revenue) != 1200e6 || value(snapshot.Cash) != 330e6 {
+		t.Errorf("Revenue = %s, Cash = %s, want the EUR amounts", formatCurrency(snapshot.Revenue), formatCurrency(snapshot.Cash))
+	}
+	// Only reported in USD, so left out rather than mixed into EUR values
+	if snapshot.EPSBasic != nil {
+		t.Errorf("EPSBasic = %v, want nil", *snapshot.EPSBasic)
+	}
+	// Share counts have no currency
+	if value(snapshot.BasicShares) != 100e6 {
+		t.Errorf("BasicShares = %s, want 100000000", formatCurrency(snapshot.BasicShares))
+	}
+
+	snapshots, err := x.GetSnapshots()
+	if err != nil {
+		t.Fatalf("GetSnapshots: %v", err)
+	}
+	if s := snapshots[0]; s.Currency != "EUR" || value(s.Revenue) != 1200e6 {
+		t.Errorf("period snapshot Currency = %q, Revenue = %s", s.Currency, formatCurrency(s.Revenue))
+	}
+
+	if got := len(x.Query().ByConcept("ifrs-full:Revenue").InCurrency("usd").Get()); got != 1 {
+		t.Errorf("InCurrency(usd) matched %d revenue facts, want 1", got)
+	}
+}
+
+func TestSnapshotCurrencyUSGAAP(t *testing.T) {
+	x, err := ParseInlineXBRL([]byte(strings.Replace(ifrsDoc, "iso4217:EUR", "iso4217:USD", 1)))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if snapshot.Currency != "USD" || snapshot.OtherCurrencies != nil {
+		t.Errorf("Currency = %q, OtherCurrencies = %v, want USD only", snapshot.Currency, snapshot.OtherCurrencies)
+	}
+}
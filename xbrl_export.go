@@ -0,0 +1,152 @@
+package edgar
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FactRecord is one fact as written by ExportFacts, with its context and unit
+// resolved
+type FactRecord struct {
+	Concept       string      `json:"concept"`
+	Label         string      `json:"label,omitempty"`         // Taxonomy label (see ApplyLabels)
+	StandardLabel string      `json:"standardLabel,omitempty"` // From the concept mappings
+	Value         string      `json:"value"`
+	NumericValue  *float64    `json:"numericValue"`
+	Unit          string      `json:"unit,omitempty"` // e.g. "iso4217:USD", "iso4217:USD/xbrli:shares"
+	Decimals      int         `json:"decimals"`
+	ContextRef    string      `json:"contextRef"`
+	PeriodType    string      `json:"periodType,omitempty"` // "instant" or "duration"
+	StartDate     string      `json:"startDate,omitempty"`
+	EndDate       string      `json:"endDate,omitempty"` // The instant for instant facts
+	Dimensions    []Dimension `json:"dimensions,omitempty"`
+	Footnotes     []string    `json:"footnotes,omitempty"` // Text of linked footnotes
+}
+
+// FactCSVHeader is the column layout of ExportFacts CSV output
+var FactCSVHeader = []string{
+	"concept",
+	"label",
+	"standard_label",
+	"value",
+	"numeric_value",
+	"unit",
+	"decimals",
+	"context_ref",
+	"period_type",
+	"start_date",
+	"end_date",
+	"dimensions",
+	"footnotes",
+}
+
+// FactRecords returns every fact in document order with its period, dimensions
+// and unit resolved, for mapping facts without relying on GetSnapshot
+func (x *XBRL) FactRecords() []FactRecord {
+	units := make(map[string]string, len(x.Units))
+	for _, u := range x.Units {
+		units[u.ID] = unitMeasure(u)
+	}
+
+	records := make([]FactRecord, 0, len(x.Facts))
+	for _, f := range x.Facts {
+		r := FactRecord{
+			Concept:       f.Concept,
+			Label:         f.Label,
+			StandardLabel: f.StandardLabel,
+			Value:         f.Value,
+			NumericValue:  f.NumericValue,
+			Unit:          f.UnitRef,
+			Decimals:      f.Decimals,
+			ContextRef:    f.ContextRef,
+			Dimensions:    f.Dimensions,
+		}
+		for _, fn := range f.Footnotes {
+			r.Footnotes = append(r.Footnotes, fn.Text)
+		}
+		if measure, ok := units[f.UnitRef]; ok {
+			r.Unit = measure
+		}
+		if f.Period != nil {
+			if f.Period.Instant != "" {
+				r.PeriodType = "instant"
+				r.EndDate = f.Period.Instant
+			} else {
+				r.PeriodType = "duration"
+				r.StartDate = f.Period.StartDate
+				r.EndDate = f.Period.EndDate
+			}
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// ExportFacts writes every fact (see FactRecords) as "csv" (with a header row,
+// see FactCSVHeader) or "ndjson" (one FactRecord per line)
+func (x *XBRL) ExportFacts(w io.Writer, format string) error {
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(FactCSVHeader); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+		for _, r := range x.FactRecords() {
+			if err := cw.Write(factCSVRecord(r)); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return nil
+
+	case "ndjson":
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		for _, r := range x.FactRecords() {
+			if err := enc.Encode(r); err != nil {
+				return fmt.Errorf("failed to write NDJSON: %w", err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported fact export format %q (use csv or ndjson)", format)
+}
+
+// factCSVRecord flattens a fact; dimensions are "axis=member" pairs joined by
+// ";" and footnotes are separated by a blank line
+func factCSVRecord(r FactRecord) []string {
+	dims := make([]string, len(r.Dimensions))
+	for i, d := range r.Dimensions {
+		dims[i] = d.Dimension + "=" + d.Member
+	}
+	return []string{
+		r.Concept,
+		r.Label,
+		r.StandardLabel,
+		r.Value,
+		formatCSVFloat(r.NumericValue),
+		r.Unit,
+		strconv.Itoa(r.Decimals),
+		r.ContextRef,
+		r.PeriodType,
+		r.StartDate,
+		r.EndDate,
+		strings.Join(dims, ";"),
+		strings.Join(r.Footnotes, "\n\n"),
+	}
+}
+
+// unitMeasure returns a unit's measure, or "numerator/denominator" for a ratio
+func unitMeasure(u Unit) string {
+	if u.Divide != nil {
+		return strings.TrimSpace(u.Divide.Numerator) + "/" + strings.TrimSpace(u.Divide.Denominator)
+	}
+	return strings.TrimSpace(u.Measure)
+}
@@ -0,0 +1,93 @@
+package edgar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportFacts(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+  xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:us-gaap="http://fasb.org/us-gaap/2024"
+  xmlns:srt="http://fasb.org/srt/2024" xmlns:dei="http://xbrl.sec.gov/dei/2024"><body>
+<ix:header><ix:resources>
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="FY_Product"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier>
+    <xbrli:segment><xbrldi:explicitMember dimension="srt:ProductOrServiceAxis">us-gaap:ProductMember</xbrldi:explicitMember></xbrli:segment></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="End"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <xbrli:unit id="usdPerShare"><xbrli:divide><xbrli:unitNumerator><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unitNumerator>
+    <xbrli:unitDenominator><xbrli:measure>xbrli:shares</xbrli:measure></xbrli:unitDenominator></xbrli:divide></xbrli:unit>
+</ix:resources></ix:header>
+<ix:nonNumeric name="dei:DocumentType" contextRef="FY">10-K</ix:nonNumeric>
+<ix:nonFraction name="us-gaap:Revenues" contextRef="FY" unitRef="usd" decimals="-6" scale="6">120</ix:nonFraction>
+<ix:nonFraction name="us-gaap:Revenues" contextRef="FY_Product" unitRef="usd" decimals="-6" scale="6">80</ix:nonFraction>
+<ix:nonFraction name="us-gaap:CashAndCashEquivalentsAtCarryingValue" contextRef="End" unitRef="usd" decimals="-6" scale="6">55</ix:nonFraction>
+<ix:nonFraction name="us-gaap:EarningsPerShareDiluted" contextRef="FY" unitRef="usdPerShare" decimals="2">1.25</ix:nonFraction>
+</body></html>`
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := x.ExportFacts(&buf, "csv"); err != nil {
+		t.Fatalf("ExportFacts csv: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d CSV rows, want a header and 5 facts", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(FactCSVHeader, ",") {
+		t.Errorf("header = %v", rows[0])
+	}
+	want := [][]string{
+		{"dei:DocumentType", "", "", "10-K", "", "", "0", "FY", "duration", "2024-01-01", "2024-12-31", "", ""},
+		{"us-gaap:Revenues", "", "Revenue", "120000000", "120000000", "iso4217:USD", "-6", "FY", "duration", "2024-01-01", "2024-12-31", "", ""},
+		{"us-gaap:Revenues", "", "Revenue", "80000000", "80000000", "iso4217:USD", "-6", "FY_Product", "duration", "2024-01-01", "2024-12-31", "srt:ProductOrServiceAxis=us-gaap:ProductMember", ""},
+		{"us-gaap:CashAndCashEquivalentsAtCarryingValue", "", "Cash and Cash Equivalents", "55000000", "55000000", "iso4217:USD", "-6", "End", "instant", "", "2024-12-31", "", ""},
+		{"us-gaap:EarningsPerShareDiluted", "", "EPS Diluted", "1.25", "1.25", "iso4217:USD/xbrli:shares", "2", "FY", "duration", "2024-01-01", "2024-12-31", "", ""},
+	}
+	for i, w := range want {
+		if got := strings.Join(rows[i+1], ","); got != strings.Join(w, ",") {
+			t.Errorf("row %d = %s\n want %s", i+1, got, strings.Join(w, ","))
+		}
+	}
+
+	buf.Reset()
+	if err := x.ExportFacts(&buf, "ndjson"); err != nil {
+		t.Fatalf("ExportFacts ndjson: %v", err)
+	}
+	var records []FactRecord
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var r FactRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("line %d: %v", len(records)+1, err)
+		}
+		records = append(records, r)
+	}
+	if len(records) != 5 {
+		t.Fatalf("got %d NDJSON records, want 5", len(records))
+	}
+	if r := records[2]; len(r.Dimensions) != 1 || r.Dimensions[0].Member != "us-gaap:ProductMember" || value(r.NumericValue) != 80e6 {
+		t.Errorf("dimensional record = %+v", r)
+	}
+	if records[0].NumericValue != nil {
+		t.Errorf("text fact numericValue = %v, want null", *records[0].NumericValue)
+	}
+
+	if err := x.ExportFacts(&buf, "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -2,7 +2,9 @@ package edgar
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,9 +18,18 @@ type FactQuery struct {
 	periodFilter  string
 	instantOnly   bool
 	durationOnly  bool
+
+	textBlocksOnly bool
+
+	includeDimensional bool
+	dimensionFilter    []Dimension
+	currencyFilter     string
+	deduplicate        bool
 }
 
 // Query returns a new FactQuery for the XBRL document
+// Only entity-wide facts are returned unless IncludeDimensional or WithDimension
+// is used, so segment and product breakdowns are not mistaken for totals.
 func (x *XBRL) Query() *FactQuery {
 	return &FactQuery{
 		xbrl:  x,
@@ -56,6 +67,27 @@ func (q *FactQuery) DurationOnly() *FactQuery {
 	return q
 }
 
+// IncludeDimensional also returns facts broken out by dimension (segments, products, ...)
+func (q *FactQuery) IncludeDimensional() *FactQuery {
+	q.includeDimensional = true
+	return q
+}
+
+// WithDimension returns only facts with the given dimension member
+// (e.g. "us-gaap:StatementBusinessSegmentsAxis", "abc:RetailMember"). An empty
+// member matches any member of the dimension. Repeated calls must all match.
+func (q *FactQuery) WithDimension(dimension, member string) *FactQuery {
+	q.dimensionFilter = append(q.dimensionFilter, Dimension{Dimension: dimension, Member: member})
+	return q
+}
+
+// InCurrency filters monetary facts by ISO 4217 currency code (e.g. "EUR");
+// facts without a currency, such as share counts, still match
+func (q *FactQuery) InCurrency(code string) *FactQuery {
+	q.currencyFilter = strings.ToUpper(code)
+	return q
+}
+
 // Get returns all matching facts
 func (q *FactQuery) Get() []Fact {
 	var results []Fact
@@ -91,6 +123,15 @@ func (q *FactQuery) Get() []Fact {
 			}
 		}
 
+		// Apply dimension filters (entity-wide totals only by default)
+		if len(q.dimensionFilter) > 0 {
+			if !matchesDimensions(fact, q.dimensionFilter) {
+				continue
+			}
+		} else if !q.includeDimensional && fact.IsDimensional() {
+			continue
+		}
+
 		// Apply instant/duration filters
 		if q.instantOnly && !fact.IsInstant() {
 			continue
@@ -98,13 +139,33 @@ func (q *FactQuery) Get() []Fact {
 		if q.durationOnly && !fact.IsDuration() {
 			continue
 		}
+		if q.textBlocksOnly && !IsTextBlock(fact.Concept) {
+			continue
+		}
+		if q.currencyFilter != "" && fact.Currency != "" && fact.Currency != q.currencyFilter {
+			continue
+		}
 
 		results = append(results, fact)
 	}
 
+	if q.deduplicate {
+		results = deduplicateFacts(results)
+	}
 	return results
 }
 
+// matchesDimensions reports whether a fact has every dimension member in filter
+func matchesDimensions(fact Fact, filter []Dimension) bool {
+	for _, want := range filter {
+		member, ok := fact.Member(want.Dimension)
+		if !ok || (want.Member != "" && member != want.Member) {
+			return false
+		}
+	}
+	return true
+}
+
 // First returns the first matching fact, or error if none found
 func (q *FactQuery) First() (*Fact, error) {
 	results := q.Get()
@@ -114,24 +175,29 @@ func (q *FactQuery) First() (*Fact, error) {
 	return &results[0], nil
 }
 
-// MostRecent returns the fact with the most recent period end date
+// MostRecent returns the fact with the most recent period end date, ranked by
+// CompareFactPreference among facts ending on that date
 func (q *FactQuery) MostRecent() (*Fact, error) {
 	results := q.Get()
 	if len(results) == 0 {
 		return nil, fmt.Errorf("no facts found")
 	}
 
-	// Sort by end date descending
-	sort.Slice(results, func(i, j int) bool {
-		dateI, errI := results[i].GetEndDate()
-		dateJ, errJ := results[j].GetEndDate()
-		if errI != nil || errJ != nil {
-			return false
+	var best *Fact
+	var bestEnd time.Time
+	for i := range results {
+		end, err := results[i].GetEndDate()
+		if err != nil {
+			continue
 		}
-		return dateI.After(dateJ)
-	})
-
-	return &results[0], nil
+		if best == nil || end.After(bestEnd) || (end.Equal(bestEnd) && CompareFactPreference(&results[i], best) < 0) {
+			best, bestEnd = &results[i], end
+		}
+	}
+	if best == nil {
+		return &results[0], nil
+	}
+	return best, nil
 }
 
 // Sum returns the sum of all matching numeric facts
@@ -288,80 +354,135 @@ func (x *XBRL) GetRevenue(period string) (float64, error) {
 	return fact.Float64()
 }
 
-// GetFinancialSnapshot returns a snapshot of key financial metrics
+// FinancialSnapshot is a snapshot of key financial metrics
+// Metrics are nil when the filing does not report them, so a reported zero
+// (e.g. no revenue) can be told apart from a missing value.
 type FinancialSnapshot struct {
 	// Period information
-	FiscalYearEnd string `json:"fiscalYearEnd"`        // Fiscal year end date (YYYY-MM-DD)
-	FilingDate    string `json:"filingDate,omitempty"` // When filed with SEC
-	FiscalPeriod  string `json:"fiscalPeriod"`         // "FY" for 10-K, "Q1/Q2/Q3/Q4" for 10-Q
-	FormType      string `json:"formType,omitempty"`   // "10-K", "10-Q", etc.
+	FiscalYearEnd         string `json:"fiscalYearEnd"`                   // Period end date (YYYY-MM-DD)
+	FiscalYearEndMonthDay string `json:"fiscalYearEndMonthDay,omitempty"` // Company's fiscal year end, e.g. "12-31"
+	FiscalYear            int    `json:"fiscalYear,omitempty"`            // Fiscal year of the document period
+	FilingDate            string `json:"filingDate,omitempty"`            // When filed with SEC (set in batch mode; not in DEI)
+	AccessionNumber       string `json:"accessionNumber,omitempty"`       // Set in batch mode
+	FiscalPeriod          string `json:"fiscalPeriod"`                    // "FY" for 10-K, "Q1/Q2/Q3/Q4" for 10-Q
+	FormType              string `json:"formType,omitempty"`              // "10-K", "10-Q", etc.
+	IsAmendment           bool   `json:"isAmendment,omitempty"`           // 10-K/A, 10-Q/A
 
 	// Company information
 	CompanyName string `json:"companyName,omitempty"`
 	CIK         string `json:"cik,omitempty"`
+	Ticker      string `json:"ticker,omitempty"`   // Trading symbol of the primary security
+	Exchange    string `json:"exchange,omitempty"` // Exchange code, e.g. "NASDAQ", "NYSE"
+	Taxonomy    string `json:"taxonomy,omitempty"` // "us-gaap" or "ifrs-full" (see XBRL.Taxonomy)
+	Profile     string `json:"profile,omitempty"`  // Industry concept mapping profile (see XBRL.ApplyProfile)
+
+	// Currency of the monetary values (see XBRL.ReportingCurrency). Values
+	// tagged in OtherCurrencies, such as convenience translations, are left out.
+	Currency        string   `json:"currency,omitempty"`
+	OtherCurrencies []string `json:"otherCurrencies,omitempty"`
 
 	// Validation
-	MissingRequiredFields []string `json:"missingRequiredFields,omitempty"` // Required GAAP fields that are missing
+	MissingRequiredFields []string       `json:"missingRequiredFields,omitempty"` // Required GAAP fields that are missing
+	Warnings              []ParseWarning `json:"warnings,omitempty"`              // Data quality issues found while parsing (XBRL.Warnings)
+	RedFlags              []RedFlag      `json:"redFlags,omitempty"`              // Going concern, covenant breach and restatement language (XBRL.RedFlags)
+
+	// Cover Page (DEI, often in ix:hidden)
+	EntitySharesOutstanding *float64 `json:"entitySharesOutstanding"` // All classes, as of the cover page date
+	PublicFloat             *float64 `json:"publicFloat"`             // As of the end of the second fiscal quarter
 
 	// Balance Sheet - Assets (instant, as of fiscal year end)
-	Cash                   float64 `json:"cash"`
-	AccountsReceivable     float64 `json:"accountsReceivable"`
-	Inventory              float64 `json:"inventory"`
-	PrepaidExpenses        float64 `json:"prepaidExpenses"`
-	PropertyPlantEquipment float64 `json:"propertyPlantEquipment"`
-	IntangibleAssets       float64 `json:"intangibleAssets"`
-	Goodwill               float64 `json:"goodwill"`
-	TotalAssets            float64 `json:"totalAssets"`
+	Cash                   *float64 `json:"cash"`
+	ShortTermInvestments   *float64 `json:"shortTermInvestments"` // Current marketable securities
+	LongTermInvestments    *float64 `json:"longTermInvestments"`  // Non-current marketable securities
+	TotalLiquidity         *float64 `json:"totalLiquidity"`       // Cash + short-term + long-term investments
+	AccountsReceivable     *float64 `json:"accountsReceivable"`
+	Inventory              *float64 `json:"inventory"`
+	PrepaidExpenses        *float64 `json:"prepaidExpenses"`
+	PropertyPlantEquipment *float64 `json:"propertyPlantEquipment"`
+	IntangibleAssets       *float64 `json:"intangibleAssets"`
+	Goodwill               *float64 `json:"goodwill"`
+	CurrentAssets          *float64 `json:"currentAssets"`
+	TotalAssets            *float64 `json:"totalAssets"`
 
 	// Balance Sheet - Liabilities (instant, as of fiscal year end)
-	ShortTermDebt      float64 `json:"shortTermDebt"`
-	LongTermDebt       float64 `json:"longTermDebt"`
-	TotalDebt          float64 `json:"totalDebt"` // Short-term + Long-term
-	AccountsPayable    float64 `json:"accountsPayable"`
-	AccruedLiabilities float64 `json:"accruedLiabilities"`
-	DeferredRevenue    float64 `json:"deferredRevenue"`
-	TotalLiabilities   float64 `json:"totalLiabilities"`
+	ShortTermDebt      *float64 `json:"shortTermDebt"`
+	LongTermDebt       *float64 `json:"longTermDebt"`
+	TotalDebt          *float64 `json:"totalDebt"` // Short-term + Long-term
+	AccountsPayable    *float64 `json:"accountsPayable"`
+	AccruedLiabilities *float64 `json:"accruedLiabilities"`
+	DeferredRevenue    *float64 `json:"deferredRevenue"`
+	CurrentLiabilities *float64 `json:"currentLiabilities"`
+	TotalLiabilities   *float64 `json:"totalLiabilities"`
 
 	// Balance Sheet - Equity (instant, as of fiscal year end)
-	StockholdersEquity           float64 `json:"stockholdersEquity"`
-	AccumulatedDeficit           float64 `json:"accumulatedDeficit"`
-	CommonStockSharesOutstanding float64 `json:"commonStockSharesOutstanding"`
+	StockholdersEquity           *float64 `json:"stockholdersEquity"`
+	AccumulatedDeficit           *float64 `json:"accumulatedDeficit"`
+	CommonStockSharesOutstanding *float64 `json:"commonStockSharesOutstanding"`
 
 	// Income Statement (duration, for the period)
-	Revenue                 float64 `json:"revenue"`
-	CostOfRevenue           float64 `json:"costOfRevenue"`
-	GrossProfit             float64 `json:"grossProfit"`
-	RDExpense               float64 `json:"rdExpense"`
-	GAExpense               float64 `json:"gaExpense"`
-	SellingMarketingExpense float64 `json:"sellingMarketingExpense"`
-	TotalOperatingExpenses  float64 `json:"totalOperatingExpenses"`
-	OperatingIncome         float64 `json:"operatingIncome"`
-	InterestExpense         float64 `json:"interestExpense"`
-	IncomeTaxExpense        float64 `json:"incomeTaxExpense"`
-	NetIncome               float64 `json:"netIncome"`
+	Revenue                 *float64 `json:"revenue"`
+	CostOfRevenue           *float64 `json:"costOfRevenue"`
+	GrossProfit             *float64 `json:"grossProfit"`
+	RDExpense               *float64 `json:"rdExpense"`
+	GAExpense               *float64 `json:"gaExpense"`
+	SellingMarketingExpense *float64 `json:"sellingMarketingExpense"`
+	TotalOperatingExpenses  *float64 `json:"totalOperatingExpenses"`
+	OperatingIncome         *float64 `json:"operatingIncome"`
+	InterestExpense         *float64 `json:"interestExpense"`
+	IncomeTaxExpense        *float64 `json:"incomeTaxExpense"`
+	NetIncome               *float64 `json:"netIncome"`
 
 	// Per Share Metrics (duration, for the period)
-	BasicShares   float64 `json:"basicShares"`
-	DilutedShares float64 `json:"dilutedShares"`
-	EPSBasic      float64 `json:"epsBasic"`
-	EPSDiluted    float64 `json:"epsDiluted"`
+	BasicShares   *float64 `json:"basicShares"`
+	DilutedShares *float64 `json:"dilutedShares"`
+	EPSBasic      *float64 `json:"epsBasic"`
+	EPSDiluted    *float64 `json:"epsDiluted"`
 
 	// Cash Flow Statement (duration, for the period)
-	CashFlowOperations  float64 `json:"cashFlowOperations"`
-	CashFlowInvesting   float64 `json:"cashFlowInvesting"`
-	CashFlowFinancing   float64 `json:"cashFlowFinancing"`
-	CapitalExpenditures float64 `json:"capitalExpenditures"`
+	CashFlowOperations  *float64 `json:"cashFlowOperations"`
+	CashFlowInvesting   *float64 `json:"cashFlowInvesting"`
+	CashFlowFinancing   *float64 `json:"cashFlowFinancing"`
+	CapitalExpenditures *float64 `json:"capitalExpenditures"`
 
 	// Non-Cash Items (duration, for the period)
-	DepreciationAmortization float64 `json:"depreciationAmortization"`
-	StockBasedCompensation   float64 `json:"stockBasedCompensation"`
+	DepreciationAmortization *float64 `json:"depreciationAmortization"`
+	StockBasedCompensation   *float64 `json:"stockBasedCompensation"`
+
+	// Banks (profile "bank")
+	NetInterestIncome        *float64 `json:"netInterestIncome,omitempty"`
+	NoninterestIncome        *float64 `json:"noninterestIncome,omitempty"`
+	ProvisionForCreditLosses *float64 `json:"provisionForCreditLosses,omitempty"`
+	Loans                    *float64 `json:"loans,omitempty"` // Net of the allowance for credit losses
+	Deposits                 *float64 `json:"deposits,omitempty"`
+
+	// Insurers (profile "insurance")
+	PremiumsEarned       *float64 `json:"premiumsEarned,omitempty"`
+	PolicyholderBenefits *float64 `json:"policyholderBenefits,omitempty"`
+	NetInvestmentIncome  *float64 `json:"netInvestmentIncome,omitempty"`
+
+	// REITs (profile "reit")
+	RealEstateInvestments        *float64 `json:"realEstateInvestments,omitempty"`
+	GainOnSaleOfRealEstate       *float64 `json:"gainOnSaleOfRealEstate,omitempty"`
+	FundsFromOperations          *float64 `json:"fundsFromOperations,omitempty"`
+	FundsFromOperationsEstimated bool     `json:"fundsFromOperationsEstimated,omitempty"` // FFO estimated, not reported
+
+	// Derived from the values above (see FinancialRatios)
+	Ratios FinancialRatios `json:"ratios"`
 }
 
 // GetSnapshot returns a financial snapshot for the most recent period
 func (x *XBRL) GetSnapshot() (*FinancialSnapshot, error) {
-	snapshot := &FinancialSnapshot{}
+	currency := x.ReportingCurrency()
+	snapshot := &FinancialSnapshot{
+		Taxonomy:        x.Taxonomy(),
+		Profile:         x.Profile,
+		Currency:        currency,
+		OtherCurrencies: x.otherCurrencies(currency),
+		Warnings:        x.Warnings,
+		RedFlags:        x.RedFlags(),
+	}
 
-	// Extract metadata from DEI (Document and Entity Information) facts
+	// Extract metadata and cover page values from DEI (Document and Entity Information) facts
 	extractMetadata(x, snapshot)
 
 	// Find the fiscal year end date (latest annual/quarterly period)
@@ -371,42 +492,212 @@ func (x *XBRL) GetSnapshot() (*FinancialSnapshot, error) {
 	}
 
 	// Helper function to get instant (balance sheet) metrics
-	getInstant := func(label string) float64 {
-		if fact, err := x.Query().ByLabel(label).InstantOnly().MostRecent(); err == nil {
-			if val, err := fact.Float64(); err == nil {
-				return val
-			}
-		}
-		return 0
+	getInstant := func(label string) *float64 {
+		return x.mostRecentValue(label, x.Query().ByLabel(label).InCurrency(currency).InstantOnly())
 	}
 
 	// Helper function to get duration (income/cash flow statement) metrics
-	getDuration := func(label string) float64 {
-		if fact, err := x.Query().ByLabel(label).DurationOnly().MostRecent(); err == nil {
-			if val, err := fact.Float64(); err == nil {
-				return val
+	getDuration := func(label string) *float64 {
+		return x.mostRecentValue(label, x.Query().ByLabel(label).InCurrency(currency).DurationOnly())
+	}
+
+	fillSnapshot(snapshot, getInstant, getDuration)
+
+	// Validate required fields
+	snapshot.MissingRequiredFields = validateRequiredFields(snapshot)
+
+	return snapshot, nil
+}
+
+// GetSnapshots returns one snapshot per fiscal period reported in the filing,
+// most recent first: a 10-K's fiscal year and its prior-year comparatives, or a
+// 10-Q's period and the same period a year earlier
+//
+// Each snapshot uses only facts for its own period: balance sheet values as of
+// the period end, and income and cash flow values for periods as long as the
+// document period. A 10-K presents fewer years of balance sheet than of income
+// statement, so the oldest snapshots have no balance sheet values. Cover page
+// values (shares outstanding, public float) are set on the most recent only.
+func (x *XBRL) GetSnapshots() ([]*FinancialSnapshot, error) {
+	current, err := x.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	periods := reportingPeriods(x)
+	if len(periods) == 0 {
+		return []*FinancialSnapshot{current}, nil
+	}
+
+	snapshots := make([]*FinancialSnapshot, 0, len(periods))
+	for i, period := range periods {
+		snapshots = append(snapshots, x.periodSnapshot(period, current, i == 0))
+	}
+	return snapshots, nil
+}
+
+// GetSnapshotForPeriod returns the snapshot of the period ending on end
+// (YYYY-MM-DD), e.g. a prior-year comparative or one quarter of a filing with
+// many contexts
+//
+// Income and cash flow values are for the period of the document's length
+// ending on end when reported (see GetSnapshots), otherwise the longest period
+// ending on end. When only balance sheet values are reported as of end (such
+// as the prior year end in a 10-Q), the snapshot has only those.
+func (x *XBRL) GetSnapshotForPeriod(end string) (*FinancialSnapshot, error) {
+	if _, err := time.Parse("2006-01-02", end); err != nil {
+		return nil, fmt.Errorf("invalid period end %q (want YYYY-MM-DD): %w", end, err)
+	}
+	current, err := x.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	periods := reportingPeriods(x)
+	for i, p := range periods {
+		if p.EndDate == end {
+			return x.periodSnapshot(p, current, i == 0), nil
+		}
+	}
+
+	// Longest duration, else only the balance sheet, as of end
+	var period *Period
+	for _, f := range x.Query().ForPeriodEndingOn(end).Get() {
+		if f.StandardLabel == "" || f.NumericValue == nil {
+			continue
+		}
+		if period == nil {
+			period = &Period{EndDate: end}
+		}
+		if f.IsDuration() && (period.StartDate == "" || f.Period.StartDate < period.StartDate) {
+			period = f.Period
+		}
+	}
+	if period == nil {
+		ends := make([]string, len(periods))
+		for i, p := range periods {
+			ends[i] = p.EndDate
+		}
+		return nil, fmt.Errorf("no values reported for the period ending %s (reporting periods: %s)", end, strings.Join(ends, ", "))
+	}
+	return x.periodSnapshot(*period, current, len(periods) > 0 && end == periods[0].EndDate), nil
+}
+
+// GetSnapshotFiscalYear returns the snapshot of the given fiscal year among the
+// reporting periods of the filing (see GetSnapshots): for a 10-K the fiscal
+// year itself, for a 10-Q the same quarter of that fiscal year
+//
+// The current period's fiscal year is dei:DocumentFiscalYearFocus, so fiscal
+// years not ending in December are numbered as the filer numbers them.
+func (x *XBRL) GetSnapshotFiscalYear(year int) (*FinancialSnapshot, error) {
+	current, err := x.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	periods := reportingPeriods(x)
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("no reporting periods found")
+	}
+
+	focus := documentFiscalYear(x, periods[0])
+	latest, _ := time.Parse("2006-01-02", periods[0].EndDate)
+	var years []string
+	for i, p := range periods {
+		end, err := time.Parse("2006-01-02", p.EndDate)
+		if err != nil {
+			continue
+		}
+		// Whole years before the current period (52/53-week years drift a few days)
+		fy := focus - int(math.Round(latest.Sub(end).Hours()/24/365.25))
+		if fy == year {
+			return x.periodSnapshot(p, current, i == 0), nil
+		}
+		years = append(years, strconv.Itoa(fy))
+	}
+	return nil, fmt.Errorf("fiscal year %d not reported (reporting fiscal years: %s)", year, strings.Join(years, ", "))
+}
+
+// documentFiscalYear returns the fiscal year of the document period from
+// dei:DocumentFiscalYearFocus, or the calendar year its period ends in
+func documentFiscalYear(x *XBRL, document Period) int {
+	if fact, err := x.Query().ByConcept("dei:DocumentFiscalYearFocus").First(); err == nil {
+		if year, err := strconv.Atoi(strings.TrimSpace(fact.Value)); err == nil {
+			return year
+		}
+	}
+	end, _ := time.Parse("2006-01-02", document.EndDate)
+	return end.Year()
+}
+
+// periodSnapshot returns the snapshot of one period: balance sheet values as of
+// its end and income and cash flow values for exactly that period. Metadata is
+// copied from current, and cover page values too when cover is set.
+func (x *XBRL) periodSnapshot(period Period, current *FinancialSnapshot, cover bool) *FinancialSnapshot {
+	snapshot := &FinancialSnapshot{
+		FiscalYearEnd:         period.EndDate,
+		FiscalYearEndMonthDay: current.FiscalYearEndMonthDay,
+		FilingDate:            current.FilingDate,
+		FiscalPeriod:          current.FiscalPeriod,
+		FormType:              current.FormType,
+		IsAmendment:           current.IsAmendment,
+		CompanyName:           current.CompanyName,
+		CIK:                   current.CIK,
+		Ticker:                current.Ticker,
+		Exchange:              current.Exchange,
+		Taxonomy:              current.Taxonomy,
+		Profile:               current.Profile,
+		Currency:              current.Currency,
+		OtherCurrencies:       current.OtherCurrencies,
+	}
+	if cover {
+		snapshot.EntitySharesOutstanding = current.EntitySharesOutstanding
+		snapshot.PublicFloat = current.PublicFloat
+	}
+
+	getInstant := func(label string) *float64 {
+		facts := x.Query().ByLabel(label).InCurrency(current.Currency).InstantOnly().ForPeriodEndingOn(period.EndDate).Get()
+		return x.preferredValue(label, facts)
+	}
+	getDuration := func(label string) *float64 {
+		var facts []Fact
+		for _, f := range x.Query().ByLabel(label).InCurrency(current.Currency).DurationOnly().ForPeriodEndingOn(period.EndDate).Get() {
+			if *f.Period == period {
+				facts = append(facts, f)
 			}
 		}
-		return 0
+		return x.preferredValue(label, facts)
 	}
+	fillSnapshot(snapshot, getInstant, getDuration)
+
+	snapshot.MissingRequiredFields = validateRequiredFields(snapshot)
+	return snapshot
+}
 
+// fillSnapshot sets the financial statement values of a snapshot
+func fillSnapshot(snapshot *FinancialSnapshot, getInstant, getDuration func(label string) *float64) {
 	// Balance Sheet - Assets (instant)
 	snapshot.Cash = getInstant("Cash and Cash Equivalents")
+	snapshot.ShortTermInvestments = getInstant("Short-Term Investments")
+	snapshot.LongTermInvestments = getInstant("Long-Term Investments")
+	if snapshot.Cash != nil {
+		snapshot.TotalLiquidity = sumValues(snapshot.Cash, snapshot.ShortTermInvestments, snapshot.LongTermInvestments)
+	}
 	snapshot.AccountsReceivable = getInstant("Accounts Receivable")
 	snapshot.Inventory = getInstant("Inventory")
 	snapshot.PrepaidExpenses = getInstant("Prepaid Expenses")
 	snapshot.PropertyPlantEquipment = getInstant("Property Plant and Equipment")
 	snapshot.IntangibleAssets = getInstant("Intangible Assets")
 	snapshot.Goodwill = getInstant("Goodwill")
+	snapshot.CurrentAssets = getInstant("Total Current Assets")
 	snapshot.TotalAssets = getInstant("Total Assets")
 
 	// Balance Sheet - Liabilities (instant)
 	snapshot.ShortTermDebt = getInstant("Short-Term Debt")
 	snapshot.LongTermDebt = getInstant("Long-Term Debt")
-	snapshot.TotalDebt = snapshot.ShortTermDebt + snapshot.LongTermDebt
+	snapshot.TotalDebt = sumValues(snapshot.ShortTermDebt, snapshot.LongTermDebt)
 	snapshot.AccountsPayable = getInstant("Accounts Payable")
 	snapshot.AccruedLiabilities = getInstant("Accrued Liabilities")
 	snapshot.DeferredRevenue = getInstant("Deferred Revenue")
+	snapshot.CurrentLiabilities = getInstant("Total Current Liabilities")
 	snapshot.TotalLiabilities = getInstant("Total Liabilities")
 
 	// Balance Sheet - Equity (instant)
@@ -443,10 +734,138 @@ func (x *XBRL) GetSnapshot() (*FinancialSnapshot, error) {
 	snapshot.DepreciationAmortization = getDuration("Depreciation and Amortization")
 	snapshot.StockBasedCompensation = getDuration("Stock-Based Compensation")
 
-	// Validate required fields
-	snapshot.MissingRequiredFields = validateRequiredFields(snapshot)
+	// Industry metrics (labels only mapped by their profile, so zero otherwise)
+	snapshot.NetInterestIncome = getDuration("Net Interest Income")
+	snapshot.NoninterestIncome = getDuration("Noninterest Income")
+	snapshot.ProvisionForCreditLosses = getDuration("Provision for Credit Losses")
+	snapshot.Loans = getInstant("Loans")
+	snapshot.Deposits = getInstant("Deposits")
+	snapshot.PremiumsEarned = getDuration("Premiums Earned")
+	snapshot.PolicyholderBenefits = getDuration("Policyholder Benefits")
+	snapshot.NetInvestmentIncome = getDuration("Net Investment Income")
+	snapshot.RealEstateInvestments = getInstant("Real Estate Investments")
+	snapshot.GainOnSaleOfRealEstate = getDuration("Gain on Sale of Real Estate")
+	snapshot.FundsFromOperations = getDuration("Funds From Operations")
+
+	// Without a reported FFO, estimate it as NAREIT defines it: net income plus
+	// real estate depreciation less gains on sales of real estate. All of a
+	// REIT's depreciation is taken to be real estate depreciation.
+	if snapshot.Profile == "reit" && snapshot.FundsFromOperations == nil &&
+		snapshot.NetIncome != nil && snapshot.DepreciationAmortization != nil {
+		ffo := *snapshot.NetIncome + *snapshot.DepreciationAmortization
+		if snapshot.GainOnSaleOfRealEstate != nil {
+			ffo -= *snapshot.GainOnSaleOfRealEstate
+		}
+		snapshot.FundsFromOperations = &ffo
+		snapshot.FundsFromOperationsEstimated = true
+	}
 
-	return snapshot, nil
+	snapshot.Ratios = snapshot.ComputeRatios()
+}
+
+// sumValues returns the sum of the reported values, or nil if none is reported
+func sumValues(values ...*float64) *float64 {
+	var sum *float64
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if sum == nil {
+			sum = new(float64)
+		}
+		*sum += *v
+	}
+	return sum
+}
+
+// mostRecentValue returns the value of the most recent period among the query's
+// facts, from the preferred concept when several report that period
+func (x *XBRL) mostRecentValue(label string, q *FactQuery) *float64 {
+	latest, err := q.MostRecent()
+	if err != nil || latest.Period == nil {
+		return nil
+	}
+	var facts []Fact
+	for _, f := range q.Get() {
+		if f.Period != nil && *f.Period == *latest.Period {
+			facts = append(facts, f)
+		}
+	}
+	return x.preferredValue(label, facts)
+}
+
+// preferredValue returns the value of the fact whose concept is listed first in
+// the mappings for label (several concepts map to one label), ranked by
+// CompareFactPreference among facts of that concept, or nil
+func (x *XBRL) preferredValue(label string, facts []Fact) *float64 {
+	m := x.mappings()
+	var best *Fact
+	for i := range facts {
+		if facts[i].NumericValue == nil {
+			continue
+		}
+		if best == nil {
+			best = &facts[i]
+			continue
+		}
+		p, bp := m.priority(label, facts[i].Concept), m.priority(label, best.Concept)
+		if p < bp || (p == bp && CompareFactPreference(&facts[i], best) < 0) {
+			best = &facts[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	value := *best.NumericValue
+	return &value
+}
+
+// reportingPeriods returns the document period (the period of the DEI cover
+// facts) and the earlier periods of about the same length with mapped values,
+// most recent first
+func reportingPeriods(x *XBRL) []Period {
+	var document *Period
+	for i := range x.Facts {
+		f := &x.Facts[i]
+		if f.Concept == "dei:DocumentType" && f.IsDuration() {
+			document = f.Period
+			break
+		}
+	}
+	if document == nil {
+		return nil
+	}
+	length, ok := periodDays(*document)
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[Period]bool)
+	var periods []Period
+	for i := range x.Facts {
+		f := &x.Facts[i]
+		if f.StandardLabel == "" || f.NumericValue == nil || !f.IsDuration() || f.IsDimensional() ||
+			f.Period.EndDate > document.EndDate || seen[*f.Period] {
+			continue
+		}
+		// Within about two weeks of the document period (52/53-week fiscal years)
+		if days, ok := periodDays(*f.Period); ok && days >= length-15 && days <= length+15 {
+			seen[*f.Period] = true
+			periods = append(periods, *f.Period)
+		}
+	}
+	sortPeriods(periods)
+	return periods
+}
+
+// periodDays returns the length of a duration period in days
+func periodDays(p Period) (int, bool) {
+	start, err1 := time.Parse("2006-01-02", p.StartDate)
+	end, err2 := time.Parse("2006-01-02", p.EndDate)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return int(end.Sub(start).Hours() / 24), true
 }
 
 // validateRequiredFields checks if required GAAP fields are present
@@ -455,7 +874,7 @@ func validateRequiredFields(snapshot *FinancialSnapshot) []string {
 	var missing []string
 
 	// Map of required field labels to their snapshot values
-	requiredFields := map[string]float64{
+	requiredFields := map[string]*float64{
 		"Total Assets":                 snapshot.TotalAssets,
 		"Total Liabilities":            snapshot.TotalLiabilities,
 		"Stockholders Equity":          snapshot.StockholdersEquity,
@@ -467,8 +886,8 @@ func validateRequiredFields(snapshot *FinancialSnapshot) []string {
 
 	// Check each required field
 	for label, value := range requiredFields {
-		// Zero value indicates the field is missing (or legitimately zero, but that's rare for required fields)
-		if value == 0 {
+		// A reported zero (e.g. pre-revenue biotech) is present, not missing
+		if value == nil {
 			missing = append(missing, label)
 		}
 	}
@@ -499,27 +918,77 @@ func (x *XBRL) GetNetIncome(period string) (float64, error) {
 
 // extractMetadata extracts company and document metadata from DEI facts
 func extractMetadata(x *XBRL, snapshot *FinancialSnapshot) {
+	tickerContext := ""
 	for _, fact := range x.Facts {
-		// Extract company name
-		if fact.Concept == "dei:EntityRegistrantName" {
+		switch fact.Concept {
+		case "dei:EntityRegistrantName":
 			snapshot.CompanyName = fact.Value
+		case "dei:EntityCentralIndexKey":
+			snapshot.CIK = fact.Value
+		case "dei:DocumentFiscalPeriodFocus":
+			// FY for 10-K, Q1/Q2/Q3/Q4 for 10-Q
+			snapshot.FiscalPeriod = fact.Value
+		case "dei:DocumentFiscalYearFocus":
+			snapshot.FiscalYear, _ = strconv.Atoi(strings.TrimSpace(fact.Value))
+		case "dei:DocumentType":
+			// 10-K, 10-Q, etc.
+			snapshot.FormType = fact.Value
+		case "dei:AmendmentFlag":
+			snapshot.IsAmendment = strings.EqualFold(strings.TrimSpace(fact.Value), "true")
+		case "dei:CurrentFiscalYearEndDate":
+			// xs:gMonthDay, e.g. "--12-31"
+			snapshot.FiscalYearEndMonthDay = strings.TrimPrefix(strings.TrimSpace(fact.Value), "--")
+		case "dei:TradingSymbol":
+			// One per class of security; the first listed is the primary one
+			if snapshot.Ticker == "" {
+				snapshot.Ticker = strings.ToUpper(strings.TrimSpace(fact.Value))
+				tickerContext = fact.ContextRef
+			}
 		}
+	}
 
-		// Extract CIK
-		if fact.Concept == "dei:EntityCentralIndexKey" {
-			snapshot.CIK = fact.Value
+	// The exchange the primary security trades on (same context as its symbol)
+	for _, fact := range x.Facts {
+		if fact.Concept != "dei:SecurityExchangeName" {
+			continue
+		}
+		if snapshot.Exchange == "" || fact.ContextRef == tickerContext {
+			snapshot.Exchange = strings.TrimSpace(fact.Value)
+		}
+		if fact.ContextRef == tickerContext {
+			break
 		}
+	}
 
-		// Extract fiscal period (FY for 10-K, Q1/Q2/Q3/Q4 for 10-Q)
-		if fact.Concept == "dei:DocumentFiscalPeriodFocus" {
-			snapshot.FiscalPeriod = fact.Value
+	// Cover page values
+	snapshot.EntitySharesOutstanding = getCoverValue(x, "dei:EntityCommonStockSharesOutstanding")
+	snapshot.PublicFloat = getCoverValue(x, "dei:EntityPublicFloat")
+}
+
+// getCoverValue returns the most recent value of a cover page (DEI) fact
+// Companies with several classes of stock report one value per class, which are summed
+func getCoverValue(x *XBRL, concept string) *float64 {
+	if fact, err := x.Query().ByConcept(concept).MostRecent(); err == nil {
+		if val, err := fact.Float64(); err == nil {
+			return &val
 		}
+	}
 
-		// Extract document type (10-K, 10-Q, etc.)
-		if fact.Concept == "dei:DocumentType" {
-			snapshot.FormType = fact.Value
+	byClass := x.Query().ByConcept(concept).WithDimension("us-gaap:StatementClassOfStockAxis", "")
+	latest, err := byClass.MostRecent()
+	if err != nil || latest.Period == nil {
+		return nil
+	}
+	var total *float64
+	for _, fact := range byClass.Get() {
+		if fact.Period == nil || *fact.Period != *latest.Period || len(fact.Dimensions) != 1 {
+			continue
+		}
+		if val, err := fact.Float64(); err == nil {
+			total = sumValues(total, &val)
 		}
 	}
+	return total
 }
 
 // findFiscalYearEnd finds the fiscal year end date from the XBRL contexts
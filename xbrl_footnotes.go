@@ -0,0 +1,125 @@
+package edgar
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FactFootnote is an explanatory note linked to facts, such as a qualification
+// of a line item ("Includes $12 million of restructuring costs")
+type FactFootnote struct {
+	ID   string `json:"id,omitempty"`
+	Lang string `json:"lang,omitempty"` // e.g. "en-US"
+	Text string `json:"text"`
+}
+
+// footnoteLink is a link:footnoteLink of a standalone instance: locators point
+// at facts by id, and arcs tie locator labels to footnote labels
+type footnoteLink struct {
+	Locs []struct {
+		Href  string `xml:"href,attr"` // "#fact-id"
+		Label string `xml:"label,attr"`
+	} `xml:"loc"`
+	Footnotes []struct {
+		ID    string `xml:"id,attr"`
+		Label string `xml:"label,attr"`
+		Lang  string `xml:"lang,attr"`
+		Text  string `xml:",innerxml"` // May be XHTML
+	} `xml:"footnote"`
+	Arcs []struct {
+		Arcrole string `xml:"arcrole,attr"`
+		From    string `xml:"from,attr"`
+		To      string `xml:"to,attr"`
+	} `xml:"footnoteArc"`
+}
+
+// footnoteIndex collects footnotes and the facts linking to them while a
+// document is read; facts are linked once the whole document has been seen
+type footnoteIndex struct {
+	footnotes map[string]FactFootnote // By key (see addLink)
+	links     map[string][]string     // Fact ID -> footnote keys, in document order
+	count     int                     // footnoteLinks read, to scope their labels
+}
+
+func newFootnoteIndex() *footnoteIndex {
+	return &footnoteIndex{
+		footnotes: make(map[string]FactFootnote),
+		links:     make(map[string][]string),
+	}
+}
+
+// addLink adds the footnotes and fact-footnote arcs of a standalone
+// footnoteLink. Labels are local to their link, so they are keyed by link.
+func (fi *footnoteIndex) addLink(link footnoteLink) {
+	fi.count++
+	scope := strconv.Itoa(fi.count) + "#"
+
+	for _, fn := range link.Footnotes {
+		fi.footnotes[scope+fn.Label] = FactFootnote{
+			ID:   fn.ID,
+			Lang: fn.Lang,
+			Text: cleanTextBlock(fn.Text),
+		}
+	}
+
+	facts := make(map[string][]string) // Locator label -> fact IDs
+	for _, loc := range link.Locs {
+		if _, id, ok := strings.Cut(loc.Href, "#"); ok {
+			facts[loc.Label] = append(facts[loc.Label], id)
+		}
+	}
+	for _, arc := range link.Arcs {
+		if !isFactFootnoteArc(arc.Arcrole) {
+			continue
+		}
+		for _, id := range facts[arc.From] {
+			fi.links[id] = append(fi.links[id], scope+arc.To)
+		}
+	}
+}
+
+// addInlineFootnote adds an ix:footnote, which inline relationships refer to
+// by id
+func (fi *footnoteIndex) addInlineFootnote(id, lang, text string) {
+	fi.footnotes["ix#"+id] = FactFootnote{ID: id, Lang: lang, Text: cleanInlineText(text)}
+}
+
+// addInlineRelationship adds an ix:relationship linking the facts in fromRefs
+// to the footnotes in toRefs (space-separated ids)
+func (fi *footnoteIndex) addInlineRelationship(arcrole, fromRefs, toRefs string) {
+	if !isFactFootnoteArc(arcrole) {
+		return
+	}
+	for _, from := range strings.Fields(fromRefs) {
+		for _, to := range strings.Fields(toRefs) {
+			fi.links[from] = append(fi.links[from], "ix#"+to)
+		}
+	}
+}
+
+// apply sets the Footnotes of each fact with an ID that footnotes link to
+func (fi *footnoteIndex) apply(facts []Fact) {
+	if len(fi.links) == 0 {
+		return
+	}
+	for i := range facts {
+		if facts[i].ID == "" {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, key := range fi.links[facts[i].ID] {
+			fn, ok := fi.footnotes[key]
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+			facts[i].Footnotes = append(facts[i].Footnotes, fn)
+		}
+	}
+}
+
+// isFactFootnoteArc reports whether an arcrole links facts to footnotes; it is
+// the default when absent
+func isFactFootnoteArc(arcrole string) bool {
+	return arcrole == "" || strings.HasSuffix(arcrole, "/fact-footnote")
+}
@@ -0,0 +1,103 @@
+package edgar
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestStandaloneXBRLFootnotes(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:link="http://www.xbrl.org/2003/linkbase"
+  xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+  xmlns:us-gaap="http://fasb.org/us-gaap/2024" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <us-gaap:Revenues id="f1" contextRef="FY" unitRef="usd" decimals="-6">120000000</us-gaap:Revenues>
+  <us-gaap:OperatingIncomeLoss id="f2" contextRef="FY" unitRef="usd" decimals="-6">30000000</us-gaap:OperatingIncomeLoss>
+  <us-gaap:NetIncomeLoss id="f3" contextRef="FY" unitRef="usd" decimals="-6">20000000</us-gaap:NetIncomeLoss>
+  <link:footnoteLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="#f1" xlink:label="fact_revenue"/>
+    <link:loc xlink:type="locator" xlink:href="#f2" xlink:label="fact_operating"/>
+    <link:footnote xlink:type="resource" xlink:label="fn1" xlink:role="http://www.xbrl.org/2003/role/footnote" xml:lang="en-US" id="note1"><xhtml:span>Includes $12 million from an acquired business.</xhtml:span></link:footnote>
+    <link:footnote xlink:type="resource" xlink:label="fn2" xlink:role="http://www.xbrl.org/2003/role/footnote" xml:lang="en-US">Restated for a change in accounting principle.</link:footnote>
+    <link:footnoteArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" xlink:from="fact_revenue" xlink:to="fn1"/>
+    <link:footnoteArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" xlink:from="fact_revenue" xlink:to="fn2"/>
+    <link:footnoteArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" xlink:from="fact_operating" xlink:to="fn2"/>
+  </link:footnoteLink>
+</xbrli:xbrl>`
+	x, err := ParseXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseXBRL: %v", err)
+	}
+	if len(x.Facts) != 3 {
+		t.Fatalf("got %d facts, want 3 (footnotes are not facts)", len(x.Facts))
+	}
+
+	revenue, err := x.Query().ByConcept("us-gaap:Revenues").First()
+	if err != nil {
+		t.Fatalf("revenue: %v", err)
+	}
+	if revenue.ID != "f1" || len(revenue.Footnotes) != 2 {
+		t.Fatalf("revenue ID = %q, footnotes = %+v, want 2", revenue.ID, revenue.Footnotes)
+	}
+	want := FactFootnote{ID: "note1", Lang: "en-US", Text: "Includes $12 million from an acquired business."}
+	if revenue.Footnotes[0] != want {
+		t.Errorf("footnote = %+v, want %+v", revenue.Footnotes[0], want)
+	}
+	if got := revenue.Footnotes[1].Text; got != "Restated for a change in accounting principle." {
+		t.Errorf("second footnote = %q", got)
+	}
+
+	operating, _ := x.Query().ByConcept("us-gaap:OperatingIncomeLoss").First()
+	if len(operating.Footnotes) != 1 || operating.Footnotes[0].Text != revenue.Footnotes[1].Text {
+		t.Errorf("operating income footnotes = %+v, want the shared footnote", operating.Footnotes)
+	}
+	if netIncome, _ := x.Query().ByConcept("us-gaap:NetIncomeLoss").First(); netIncome.Footnotes != nil {
+		t.Errorf("net income footnotes = %+v, want none", netIncome.Footnotes)
+	}
+
+	// Footnotes survive the fact export
+	var buf bytes.Buffer
+	if err := x.ExportFacts(&buf, "csv"); err != nil {
+		t.Fatalf("ExportFacts: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if got := rows[2][len(rows[2])-1]; got != "Restated for a change in accounting principle." {
+		t.Errorf("exported operating income footnotes = %q", got)
+	}
+}
+
+func TestInlineXBRLFootnotes(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:us-gaap="http://fasb.org/us-gaap/2024"><body>
+<ix:header><ix:resources>
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <ix:relationship arcrole="http://www.xbrl.org/2003/arcrole/fact-footnote" fromRefs="rev opinc" toRefs="fn1"/>
+  <ix:relationship arcrole="http://www.xbrl.org/2009/arcrole/fact-explanatoryFact" fromRefs="rev" toRefs="opinc"/>
+</ix:resources></ix:header>
+<table><tr><td>Revenue (1)</td><td><ix:nonFraction id="rev" name="us-gaap:Revenues" contextRef="FY" unitRef="usd" decimals="-6" scale="6">120</ix:nonFraction></td></tr>
+<tr><td>Operating income (1)</td><td><ix:nonFraction id="opinc" name="us-gaap:OperatingIncomeLoss" contextRef="FY" unitRef="usd" decimals="-6" scale="6">30</ix:nonFraction></td></tr></table>
+<p><ix:footnote id="fn1" xml:lang="en-US">(1) Excludes   discontinued operations.</ix:footnote></p>
+</body></html>`
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	for _, concept := range []string{"us-gaap:Revenues", "us-gaap:OperatingIncomeLoss"} {
+		fact, err := x.Query().ByConcept(concept).First()
+		if err != nil {
+			t.Fatalf("%s: %v", concept, err)
+		}
+		want := FactFootnote{ID: "fn1", Lang: "en-US", Text: "(1) Excludes discontinued operations."}
+		if len(fact.Footnotes) != 1 || fact.Footnotes[0] != want {
+			t.Errorf("%s footnotes = %+v, want [%+v]", concept, fact.Footnotes, want)
+		}
+	}
+}
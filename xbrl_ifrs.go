@@ -0,0 +1,54 @@
+package edgar
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Foreign private issuers (20-F, 40-F, 6-K) may report under IFRS, tagging
+// facts with the ifrs-full taxonomy instead of us-gaap. Their concepts are
+// mapped to the same standardized labels in concept_mappings_ifrs.json, which
+// is merged over the built-in mappings for documents using ifrs-full.
+
+//go:embed concept_mappings_ifrs.json
+var ifrsMappingsJSON []byte
+
+// Taxonomies of the financial statement facts (see XBRL.Taxonomy)
+const (
+	TaxonomyUSGAAP = "us-gaap"
+	TaxonomyIFRS   = "ifrs-full"
+)
+
+var ifrsMappings map[string]ConceptDefinition
+
+func init() {
+	var mapping ConceptMapping
+	if err := json.Unmarshal(ifrsMappingsJSON, &mapping); err != nil {
+		panic(fmt.Sprintf("Failed to load IFRS concept mappings: %v", err))
+	}
+	ifrsMappings = mapping.Mappings
+}
+
+// Taxonomy returns the taxonomy of the document's financial statement facts,
+// TaxonomyUSGAAP or TaxonomyIFRS (whichever tags more facts), or "" when
+// neither is used
+func (x *XBRL) Taxonomy() string {
+	var usgaap, ifrs int
+	for _, f := range x.Facts {
+		switch {
+		case strings.HasPrefix(f.Concept, TaxonomyUSGAAP+":"):
+			usgaap++
+		case strings.HasPrefix(f.Concept, TaxonomyIFRS+":"):
+			ifrs++
+		}
+	}
+	switch {
+	case ifrs > usgaap:
+		return TaxonomyIFRS
+	case usgaap > 0:
+		return TaxonomyUSGAAP
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package edgar
+
+import "testing"
+
+// A 20-F of a foreign private issuer reporting under IFRS
+const ifrsDoc = `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:ifrs-full="https://xbrl.ifrs.org/taxonomy/2024-03-27/ifrs-full"
+  xmlns:dei="http://xbrl.sec.gov/dei/2024"><body>
+<ix:header><ix:resources>
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="End"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period></xbrli:context>
+  <xbrli:unit id="eur"><xbrli:measure>iso4217:EUR</xbrli:measure></xbrli:unit>
+</ix:resources></ix:header>
+<ix:nonNumeric name="dei:DocumentType" contextRef="FY">20-F</ix:nonNumeric>
+<ix:nonNumeric name="dei:DocumentFiscalPeriodFocus" contextRef="FY">FY</ix:nonNumeric>
+<ix:nonFraction name="ifrs-full:Revenue" contextRef="FY" unitRef="eur" decimals="-6" scale="6" format="ixt:num-dot-decimal">1,200</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:CostOfSales" contextRef="FY" unitRef="eur" decimals="-6" scale="6">700</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:ProfitLoss" contextRef="FY" unitRef="eur" decimals="-6" scale="6">150</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:ProfitLossAttributableToOwnersOfParent" contextRef="FY" unitRef="eur" decimals="-6" scale="6">140</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:CashFlowsFromUsedInOperatingActivities" contextRef="FY" unitRef="eur" decimals="-6" scale="6">210</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:CashAndCashEquivalents" contextRef="End" unitRef="eur" decimals="-6" scale="6">330</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:ShorttermDepositsNotClassifiedAsCashEquivalents" contextRef="End" unitRef="eur" decimals="-6" scale="6">120</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:Assets" contextRef="End" unitRef="eur" decimals="-6" scale="6" format="ixt:num-dot-decimal">4,000</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:Liabilities" contextRef="End" unitRef="eur" decimals="-6" scale="6" format="ixt:num-dot-decimal">2,500</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:EquityAttributableToOwnersOfParent" contextRef="End" unitRef="eur" decimals="-6" scale="6" format="ixt:num-dot-decimal">1,400</ix:nonFraction>
+<ix:nonFraction name="ifrs-full:Equity" contextRef="End" unitRef="eur" decimals="-6" scale="6" format="ixt:num-dot-decimal">1,500</ix:nonFraction>
+</body></html>`
+
+func TestIFRSSnapshot(t *testing.T) {
+	x, err := ParseInlineXBRL([]byte(ifrsDoc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	if got := x.Taxonomy(); got != TaxonomyIFRS {
+		t.Fatalf("Taxonomy = %q, want %q", got, TaxonomyIFRS)
+	}
+
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if snapshot.Taxonomy != TaxonomyIFRS || snapshot.FormType != "20-F" {
+		t.Errorf("Taxonomy = %q, FormType = %q", snapshot.Taxonomy, snapshot.FormType)
+	}
+	tests := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"Revenue", snapshot.Revenue, 1200e6},
+		{"CostOfRevenue", snapshot.CostOfRevenue, 700e6},
+		{"NetIncome", snapshot.NetIncome, 140e6}, // Attributable to owners of the parent
+		{"CashFlowOperations", snapshot.CashFlowOperations, 210e6},
+		{"Cash", snapshot.Cash, 330e6},
+		{"ShortTermInvestments", snapshot.ShortTermInvestments, 120e6},
+		{"TotalLiquidity", snapshot.TotalLiquidity, 450e6},
+		{"TotalAssets", snapshot.TotalAssets, 4000e6},
+		{"TotalLiabilities", snapshot.TotalLiabilities, 2500e6},
+		{"StockholdersEquity", snapshot.StockholdersEquity, 1400e6},
+	}
+	for _, tt := range tests {
+		if tt.got == nil || *tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, formatCurrency(tt.got), formatCurrency(&tt.want))
+		}
+	}
+
+	// IFRS mappings stay under an industry profile, and never leak into the
+	// package mappings used for US GAAP documents
+	if err := x.ApplyProfile("bank"); err != nil {
+		t.Fatalf("ApplyProfile: %v", err)
+	}
+	if snapshot, _ := x.GetSnapshot(); value(snapshot.Cash) != 330e6 {
+		t.Errorf("bank profile Cash = %s, want the IFRS cash", formatCurrency(snapshot.Cash))
+	}
+	if label := GetStandardizedLabel("ifrs-full:Revenue"); label != "" {
+		t.Errorf("package mappings label ifrs-full:Revenue as %q", label)
+	}
+}
+
+func TestTaxonomyUSGAAP(t *testing.T) {
+	x := &XBRL{Facts: []Fact{{Concept: "dei:DocumentType"}, {Concept: "us-gaap:Revenues"}, {Concept: "ifrs-full:Revenue"}, {Concept: "us-gaap:Assets"}}}
+	if got := x.Taxonomy(); got != TaxonomyUSGAAP {
+		t.Errorf("Taxonomy = %q, want %q", got, TaxonomyUSGAAP)
+	}
+	if got := (&XBRL{Facts: []Fact{{Concept: "dei:DocumentType"}}}).Taxonomy(); got != "" {
+		t.Errorf("Taxonomy of a cover page only = %q, want empty", got)
+	}
+}
@@ -1,26 +1,29 @@
 package edgar
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 )
 
 // ParseInlineXBRL parses an inline XBRL (iXBRL) document from HTML
 // Inline XBRL embeds XBRL facts within HTML using the ix: namespace
 func ParseInlineXBRL(data []byte) (*XBRL, error) {
-	xbrl := &XBRL{}
+	return ParseInlineXBRLReader(bytes.NewReader(data))
+}
 
-	// Parse contexts and units from ix:resources section
-	if err := extractResources(xbrl, data); err != nil {
-		return nil, fmt.Errorf("failed to extract resources: %w", err)
-	}
+// ParseInlineXBRLReader parses an inline XBRL document from a reader in a single
+// streaming pass, so large 10-K documents never need to be held in memory
+func ParseInlineXBRLReader(r io.Reader) (*XBRL, error) {
+	xbrl := &XBRL{}
 
-	// Extract facts from ix:nonFraction and ix:nonNumeric tags
-	if err := extractInlineFacts(xbrl, data); err != nil {
-		return nil, fmt.Errorf("failed to extract facts: %w", err)
+	// Extract contexts/units from ix:resources and facts from ix:nonFraction/ix:nonNumeric
+	if err := extractInline(xbrl, r); err != nil {
+		return nil, fmt.Errorf("failed to extract inline XBRL: %w", err)
 	}
 
 	// Resolve contexts and standardize labels
@@ -31,15 +34,24 @@ func ParseInlineXBRL(data []byte) (*XBRL, error) {
 	return xbrl, nil
 }
 
-// extractResources extracts contexts and units from the ix:resources section
-func extractResources(xbrl *XBRL, data []byte) error {
-	decoder := xml.NewDecoder(bytes.NewReader(data))
+// extractInline walks the document once, collecting contexts and units from the
+// ix:resources section and facts from ix:nonFraction and ix:nonNumeric tags,
+// wherever they appear (including the ix:hidden section of the header)
+// Facts are resolved afterwards, so resources may appear anywhere in the document
+func extractInline(xbrl *XBRL, r io.Reader) error {
+	decoder := xml.NewDecoder(r)
 	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
 		// Treat ASCII and other charsets as UTF-8
 		return input, nil
 	}
 
 	inResources := false
+	ix := &inlineText{
+		continuations: make(map[string]inlineContinuation),
+		continuedAt:   make(map[int]string),
+		invalid:       make(map[int]bool),
+	}
+	notes := newFootnoteIndex()
 
 	for {
 		token, err := decoder.Token()
@@ -52,32 +64,57 @@ func extractResources(xbrl *XBRL, data []byte) error {
 
 		switch elem := token.(type) {
 		case xml.StartElement:
-			// Track when we enter/exit ix:resources
-			if elem.Name.Local == "resources" {
+			switch elem.Name.Local {
+			case "resources":
+				// Track when we enter/exit ix:resources
 				inResources = true
-				continue
-			}
-
-			if !inResources {
-				continue
-			}
 
-			// Parse context elements
-			if elem.Name.Local == "context" {
+			case "context":
+				if !inResources {
+					continue
+				}
 				var ctx Context
 				if err := decoder.DecodeElement(&ctx, &elem); err != nil {
-					continue // Skip malformed contexts
+					xbrl.warn("context", getAttr(elem.Attr, "id"), "malformed context skipped: "+err.Error())
+					continue
 				}
 				xbrl.Contexts = append(xbrl.Contexts, ctx)
-			}
 
-			// Parse unit elements
-			if elem.Name.Local == "unit" {
+			case "unit":
+				if !inResources {
+					continue
+				}
 				var unit Unit
 				if err := decoder.DecodeElement(&unit, &elem); err != nil {
-					continue // Skip malformed units
+					xbrl.warn("unit", getAttr(elem.Attr, "id"), "malformed unit skipped: "+err.Error())
+					continue
 				}
 				xbrl.Units = append(xbrl.Units, unit)
+
+			case "nonFraction", "nonNumeric":
+				if _, err := ix.readFact(decoder, elem, xbrl); err != nil {
+					return err
+				}
+
+			case "continuation":
+				text, err := ix.readText(decoder, xbrl)
+				if err != nil {
+					return err
+				}
+				ix.continuations[getAttr(elem.Attr, "id")] = inlineContinuation{
+					text:        text,
+					continuedAt: getAttr(elem.Attr, "continuedAt"),
+				}
+
+			case "footnote":
+				text, err := ix.readText(decoder, xbrl)
+				if err != nil {
+					return err
+				}
+				notes.addInlineFootnote(getAttr(elem.Attr, "id"), getAttr(elem.Attr, "lang"), text)
+
+			case "relationship":
+				notes.addInlineRelationship(getAttr(elem.Attr, "arcrole"), getAttr(elem.Attr, "fromRefs"), getAttr(elem.Attr, "toRefs"))
 			}
 
 		case xml.EndElement:
@@ -87,99 +124,249 @@ func extractResources(xbrl *XBRL, data []byte) error {
 		}
 	}
 
+	ix.finish(xbrl)
+	notes.apply(xbrl.Facts)
 	return nil
 }
 
-// extractInlineFacts extracts facts from ix:nonFraction and ix:nonNumeric tags
-func extractInlineFacts(xbrl *XBRL, data []byte) error {
-	decoder := xml.NewDecoder(bytes.NewReader(data))
-	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
-		// Treat ASCII and other charsets as UTF-8
-		return input, nil
+// inlineText collects the text of inline facts, which may be split across
+// ix:continuation elements anywhere in the document
+type inlineText struct {
+	continuations map[string]inlineContinuation // By id
+	continuedAt   map[int]string                // Fact index -> first continuation id
+	invalid       map[int]bool                  // Fact slots reserved for elements that were not facts
+}
+
+type inlineContinuation struct {
+	text        string
+	continuedAt string
+}
+
+// readFact reads an ix:nonFraction or ix:nonNumeric element into a Fact and appends
+// it (and any facts nested in it) to xbrl.Facts. It returns the fact's own text,
+// which is also part of an enclosing fact's text.
+func (ix *inlineText) readFact(decoder *xml.Decoder, elem xml.StartElement, xbrl *XBRL) (string, error) {
+	// Reserve the slot first so nested facts follow their parent
+	index := len(xbrl.Facts)
+	xbrl.Facts = append(xbrl.Facts, Fact{})
+
+	text, err := ix.readText(decoder, xbrl)
+	if err != nil {
+		return "", err
 	}
 
-	var facts []Fact
+	fact, ok := decodeInlineFact(elem, text)
+	if !ok {
+		ix.invalid[index] = true // Removed by finish
+		xbrl.warn("ix:"+elem.Name.Local, getAttr(elem.Attr, "name"), "fact without a name or contextRef skipped")
+		return text, nil
+	}
+	xbrl.Facts[index] = fact
+	if next := getAttr(elem.Attr, "continuedAt"); next != "" {
+		ix.continuedAt[index] = next
+	}
+	return text, nil
+}
 
+// readText returns the text content of the current element up to its end tag
+// Content of ix:exclude is dropped, block elements end a line (see elementBreak),
+// and nested facts are read as facts of their own.
+func (ix *inlineText) readText(decoder *xml.Decoder, xbrl *XBRL) (string, error) {
+	var buf strings.Builder
+	depth, exclude, cells := 0, 0, 0
 	for {
 		token, err := decoder.Token()
-		if err == io.EOF {
-			break
-		}
 		if err != nil {
-			return err
+			return "", err
 		}
 
-		switch elem := token.(type) {
+		switch t := token.(type) {
 		case xml.StartElement:
-			// Check for inline XBRL fact elements (ix:nonFraction, ix:nonNumeric)
-			if elem.Name.Local != "nonFraction" && elem.Name.Local != "nonNumeric" {
-				continue
+			switch {
+			case t.Name.Local == "exclude":
+				exclude++
+			case exclude == 0 && (t.Name.Local == "nonFraction" || t.Name.Local == "nonNumeric"):
+				text, err := ix.readFact(decoder, t, xbrl)
+				if err != nil {
+					return "", err
+				}
+				buf.WriteString(text)
+				continue // readFact consumed the end tag
+			case isTableCell(t.Name.Local):
+				cells++
 			}
+			depth++
 
-			// Extract attributes
-			contextRef := getAttr(elem.Attr, "contextRef")
-			if contextRef == "" {
-				continue // Not a valid fact
+		case xml.EndElement:
+			if depth == 0 {
+				return buf.String(), nil
 			}
-
-			conceptName := getAttr(elem.Attr, "name")
-			if conceptName == "" {
-				continue // No concept name
+			depth--
+			if t.Name.Local == "exclude" {
+				exclude--
 			}
+			if isTableCell(t.Name.Local) && cells > 0 {
+				cells--
+			}
+			buf.WriteString(elementBreak(t.Name.Local, cells > 0))
 
-			unitRef := getAttr(elem.Attr, "unitRef")
-			decimalsStr := getAttr(elem.Attr, "decimals")
-
-			// Parse decimals
-			decimals := 0
-			if decimalsStr != "" && decimalsStr != "INF" {
-				fmt.Sscanf(decimalsStr, "%d", &decimals)
+		case xml.CharData:
+			if exclude == 0 {
+				buf.Write(t)
 			}
+		}
+	}
+}
 
-			// Extract the fact value (text content)
-			var value string
-			if err := decoder.DecodeElement(&value, &elem); err != nil {
-				continue
+// finish appends each fact's continuations (following the continuedAt chain) to its
+// value and drops slots reserved for invalid facts
+func (ix *inlineText) finish(xbrl *XBRL) {
+	for index, next := range ix.continuedAt {
+		fact := &xbrl.Facts[index]
+		parts := []string{fact.Value}
+		seen := make(map[string]bool)
+		for next != "" && !seen[next] {
+			seen[next] = true
+			cont, ok := ix.continuations[next]
+			if !ok {
+				break
 			}
+			parts = append(parts, cont.text)
+			next = cont.continuedAt
+		}
+		fact.Value = cleanInlineText(strings.Join(parts, "\n"))
+	}
 
-			fact := Fact{
-				Concept:    conceptName,
-				Value:      strings.TrimSpace(value),
-				ContextRef: contextRef,
-				UnitRef:    unitRef,
-				Decimals:   decimals,
+	if len(ix.invalid) > 0 {
+		facts := xbrl.Facts[:0]
+		for i, fact := range xbrl.Facts {
+			if !ix.invalid[i] {
+				facts = append(facts, fact)
 			}
+		}
+		xbrl.Facts = facts
+	}
+}
 
-			facts = append(facts, fact)
+// cleanInlineText collapses whitespace within lines and drops empty lines
+func cleanInlineText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
 		}
 	}
+	return strings.Join(lines, "\n")
+}
 
-	xbrl.Facts = facts
-	return nil
+// decodeInlineFact builds a Fact from an ix:nonFraction or ix:nonNumeric element and its text
+// Returns false for elements that are not valid facts
+func decodeInlineFact(elem xml.StartElement, text string) (Fact, bool) {
+	// Extract attributes
+	contextRef := getAttr(elem.Attr, "contextRef")
+	if contextRef == "" {
+		return Fact{}, false // Not a valid fact
+	}
+
+	conceptName := getAttr(elem.Attr, "name")
+	if conceptName == "" {
+		return Fact{}, false // No concept name
+	}
+
+	unitRef := getAttr(elem.Attr, "unitRef")
+	decimalsStr := getAttr(elem.Attr, "decimals")
+
+	// Parse decimals
+	decimals := 0
+	if decimalsStr != "" && decimalsStr != "INF" {
+		fmt.Sscanf(decimalsStr, "%d", &decimals)
+	}
+
+	return Fact{
+		ID:         getAttr(elem.Attr, "id"),
+		Concept:    conceptName,
+		Value:      inlineValue(elem, cleanInlineText(text)),
+		ContextRef: contextRef,
+		UnitRef:    unitRef,
+		Decimals:   decimals,
+	}, true
+}
+
+// inlineValue converts the displayed text of an inline fact to its XBRL value:
+// the format transformation first, then (for ix:nonFraction) the scale and sign
+// attributes. "(1,234)" with scale="6" and sign="-" becomes "-1234000000".
+// Text in an unsupported format is kept as displayed.
+func inlineValue(elem xml.StartElement, text string) string {
+	value := text
+	if format := getAttr(elem.Attr, "format"); format != "" {
+		if v, ok, err := transformInlineValue(format, text); ok && err == nil {
+			value = v
+		}
+	}
+	if elem.Name.Local != "nonFraction" || !isDecimal(value) {
+		return value
+	}
+
+	if scale := getAttr(elem.Attr, "scale"); scale != "" {
+		if n, err := strconv.Atoi(scale); err == nil {
+			value = shiftDecimal(value, n)
+		}
+	}
+	if getAttr(elem.Attr, "sign") == "-" && strings.Trim(value, "0.") != "" {
+		value = "-" + value
+	}
+	return value
+}
+
+// shiftDecimal multiplies a decimal string by 10^scale without going through
+// float64, e.g. ("1,927" after transformation) "1927" scale 6 -> "1927000000"
+func shiftDecimal(value string, scale int) string {
+	whole, frac, _ := strings.Cut(value, ".")
+	digits := whole + frac
+	point := len(whole) + scale
+	if point < 1 {
+		digits = strings.Repeat("0", 1-point) + digits
+		point = 1
+	}
+	if len(digits) < point {
+		digits += strings.Repeat("0", point-len(digits))
+	}
+
+	whole = strings.TrimLeft(digits[:point], "0")
+	if whole == "" {
+		whole = "0"
+	}
+	if frac = strings.TrimRight(digits[point:], "0"); frac != "" {
+		return whole + "." + frac
+	}
+	return whole
 }
 
 // DetectXBRLType determines if the data is inline XBRL or standalone XBRL
+// Searches the bytes in place: a 10-K can be over 100MB
 func DetectXBRLType(data []byte) string {
-	content := string(data)
-
 	// Check for inline XBRL markers
-	if strings.Contains(content, "xmlns:ix=") ||
-		strings.Contains(content, "<ix:") ||
-		strings.Contains(content, "inlineXBRL") {
+	if bytes.Contains(data, []byte("xmlns:ix=")) ||
+		bytes.Contains(data, []byte("<ix:")) ||
+		bytes.Contains(data, []byte("inlineXBRL")) {
 		return "inline"
 	}
 
 	// Check for standalone XBRL markers
-	if strings.Contains(content, "<xbrl") ||
-		strings.Contains(content, "xmlns:xbrli=") {
+	if bytes.Contains(data, []byte("<xbrl")) ||
+		bytes.Contains(data, []byte("xmlns:xbrli=")) {
 		return "standalone"
 	}
 
 	return "unknown"
 }
 
-// ParseXBRLAuto automatically detects and parses inline or standalone XBRL
+// ParseXBRLAuto automatically detects and parses inline or standalone XBRL,
+// or a filing's XBRL archive (see ParseXBRLZip)
 func ParseXBRLAuto(data []byte) (*XBRL, error) {
+	if isZip(data) {
+		return ParseXBRLZip(data)
+	}
 	xbrlType := DetectXBRLType(data)
 
 	switch xbrlType {
@@ -188,6 +375,32 @@ func ParseXBRLAuto(data []byte) (*XBRL, error) {
 	case "standalone":
 		return ParseXBRL(data)
 	default:
-		return nil, fmt.Errorf("unable to detect XBRL type")
+		return nil, fmt.Errorf("unable to detect XBRL type: %w", ErrNotXBRL)
+	}
+}
+
+// ParseXBRLAutoReader is ParseXBRLAuto for a reader: inline and standalone
+// documents recognized from their start are parsed as they are read; XBRL
+// archives, which need random access, and documents whose type only shows
+// later are read in full first
+func ParseXBRLAutoReader(r io.Reader) (*XBRL, error) {
+	br := bufio.NewReaderSize(r, detectWindow)
+	head, err := br.Peek(detectWindow)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if !isZip(head) {
+		switch DetectXBRLType(head) {
+		case "inline":
+			return ParseInlineXBRLReader(br)
+		case "standalone":
+			return ParseXBRLReader(br)
+		}
+	}
+
+	data, err := io.ReadAll(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
 	}
+	return ParseXBRLAuto(data)
 }
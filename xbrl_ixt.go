@@ -0,0 +1,362 @@
+package edgar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Inline XBRL transformation rules (the ix format attribute)
+//
+// A displayed value such as "1,234.5" or "March 31, 2024" is converted to its
+// XBRL value ("1234.5", "2024-03-31") by the rule its format names. Rules are
+// looked up by local name, so the same rule matches under any prefix
+// (ixt, ixt4, ixt-sec). Both the TR1/TR2 names (numdotdecimal, datemonthdayyearen)
+// and the TR3/TR4 names (num-dot-decimal, date-monthname-day-year-en) are
+// registered. See https://www.xbrl.org/Specification/inlineXBRL-transformationRegistry/
+
+// ixtTransform converts displayed text to an XBRL value
+type ixtTransform func(text string) (string, error)
+
+var ixtRegistry = map[string]ixtTransform{
+	// Numbers: "1,234.56" / "1.234,56"
+	"num-dot-decimal":   ixtNumDotDecimal,
+	"numdotdecimal":     ixtNumDotDecimal,
+	"numcommadot":       ixtNumDotDecimal,
+	"numspacedot":       ixtNumDotDecimal,
+	"num-comma-decimal": ixtNumCommaDecimal,
+	"numcommadecimal":   ixtNumCommaDecimal,
+	"numdotcomma":       ixtNumCommaDecimal,
+	"numspacecomma":     ixtNumCommaDecimal,
+	"num-unit-decimal":  ixtNumUnitDecimal,
+	"numunitdecimal":    ixtNumUnitDecimal,
+	"numwordsen":        ixtNumWordsEn, // ixt-sec: "three", "no", "none"
+
+	// Exchange names (ixt-sec): "The Nasdaq Stock Market LLC" -> "NASDAQ"
+	"exchnameen": ixtExchangeName,
+
+	// Fixed values, displayed as "—", "None", a checkbox, ...
+	"fixed-zero":   ixtFixed("0"),
+	"zerodash":     ixtFixed("0"),
+	"fixed-empty":  ixtFixed(""),
+	"nocontent":    ixtFixed(""),
+	"fixed-false":  ixtFixed("false"),
+	"booleanfalse": ixtFixed("false"),
+	"fixed-true":   ixtFixed("true"),
+	"booleantrue":  ixtFixed("true"),
+
+	// Numeric dates: "03/31/2024", "31.03.2024", "2024-03-31"
+	"date-month-day-year": ixtDate(monthFirst),
+	"dateslashus":         ixtDate(monthFirst),
+	"datedotus":           ixtDate(monthFirst),
+	"date-day-month-year": ixtDate(dayFirst),
+	"dateslasheu":         ixtDate(dayFirst),
+	"datedoteu":           ixtDate(dayFirst),
+	"date-year-month-day": ixtDate(yearFirst),
+
+	// English month names: "March 31, 2024", "31 March 2024", "March 2024"
+	"date-monthname-day-year-en": ixtDate(monthFirst),
+	"datemonthdayyearen":         ixtDate(monthFirst),
+	"datelongus":                 ixtDate(monthFirst),
+	"dateshortus":                ixtDate(monthFirst),
+	"date-day-monthname-year-en": ixtDate(dayFirst),
+	"datedaymonthyearen":         ixtDate(dayFirst),
+	"datelonguk":                 ixtDate(dayFirst),
+	"dateshortuk":                ixtDate(dayFirst),
+	"date-monthname-day-en":      ixtMonthDay(monthFirst),
+	"datemonthdayen":             ixtMonthDay(monthFirst),
+	"date-day-monthname-en":      ixtMonthDay(dayFirst),
+	"datedaymonthen":             ixtMonthDay(dayFirst),
+	"date-monthname-year-en":     ixtYearMonth,
+	"datemonthyearen":            ixtYearMonth,
+	"date-month-year":            ixtYearMonth,
+}
+
+// transformInlineValue applies the transformation rule named by format (e.g.
+// "ixt:num-dot-decimal") to text. ok is false when the rule is not supported,
+// in which case text is returned unchanged.
+func transformInlineValue(format, text string) (value string, ok bool, err error) {
+	name := format[strings.LastIndex(format, ":")+1:]
+	transform, ok := ixtRegistry[strings.ToLower(name)]
+	if !ok {
+		return text, false, nil
+	}
+	value, err = transform(strings.TrimSpace(text))
+	if err != nil {
+		return text, true, fmt.Errorf("%s: %w", format, err)
+	}
+	return value, true, nil
+}
+
+func ixtFixed(value string) ixtTransform {
+	return func(string) (string, error) { return value, nil }
+}
+
+func ixtNumDotDecimal(text string) (string, error) {
+	return normalizeNumber(text, '.', ", '\u2019")
+}
+
+func ixtNumCommaDecimal(text string) (string, error) {
+	return normalizeNumber(text, ',', ". '\u2019")
+}
+
+// normalizeNumber drops grouping separators and turns the decimal separator into "."
+// Unicode spaces (thin, narrow no-break, ...) count as spaces.
+func normalizeNumber(text string, decimal rune, grouping string) (string, error) {
+	var b strings.Builder
+	for _, r := range removeInvisibleChars(normalizeWhitespace(text)) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == decimal:
+			b.WriteRune('.')
+		case strings.ContainsRune(grouping, r):
+		default:
+			return "", fmt.Errorf("invalid number %q", text)
+		}
+	}
+	value := b.String()
+	if !isDecimal(value) {
+		return "", fmt.Errorf("invalid number %q", text)
+	}
+	return value, nil
+}
+
+// ixtNumUnitDecimal handles a whole and fractional part separated by unit words,
+// e.g. "5 dollars and 50 cents" -> "5.50"
+func ixtNumUnitDecimal(text string) (string, error) {
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return (r < '0' || r > '9') && r != ',' && r != '.'
+	})
+	if len(parts) == 0 || len(parts) > 2 {
+		return "", fmt.Errorf("invalid number %q", text)
+	}
+	whole := strings.NewReplacer(",", "", ".", "").Replace(parts[0])
+	if len(parts) == 1 {
+		return normalizeNumber(whole, '.', "")
+	}
+	return normalizeNumber(whole+"."+parts[1], '.', "")
+}
+
+// numberWords maps English number words to their values
+var numberWords = map[string]int64{
+	"zero": 0, "no": 0, "none": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+	"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20,
+	"thirty": 30, "forty": 40, "fifty": 50, "sixty": 60, "seventy": 70,
+	"eighty": 80, "ninety": 90,
+}
+
+var numberScales = map[string]int64{
+	"thousand": 1_000, "million": 1_000_000, "billion": 1_000_000_000,
+}
+
+// ixtNumWordsEn converts English number words, e.g. "twenty-one" -> "21"
+func ixtNumWordsEn(text string) (string, error) {
+	if value, err := ixtNumDotDecimal(text); err == nil {
+		return value, nil
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == ','
+	})
+	if len(words) == 0 {
+		return "", fmt.Errorf("invalid number %q", text)
+	}
+
+	var total, group int64
+	for _, w := range words {
+		switch {
+		case w == "and":
+		case w == "hundred":
+			group *= 100
+		case numberScales[w] > 0:
+			total += group * numberScales[w]
+			group = 0
+		default:
+			n, ok := numberWords[w]
+			if !ok {
+				return "", fmt.Errorf("invalid number %q", text)
+			}
+			group += n
+		}
+	}
+	return strconv.FormatInt(total+group, 10), nil
+}
+
+// SEC exchange codes (dei:SecurityExchangeName) by a word in the displayed
+// name, most specific first
+var exchangeCodes = []struct{ name, code string }{
+	{"nyse american", "NYSEAMER"},
+	{"nyse mkt", "NYSEAMER"},
+	{"nyse arca", "NYSEArca"},
+	{"nyse national", "NYSENAT"},
+	{"nyse chicago", "CHX"},
+	{"chicago stock exchange", "CHX"},
+	{"phlx", "Phlx"},
+	{"nasdaq", "NASDAQ"},
+	{"new york stock exchange", "NYSE"},
+	{"nyse", "NYSE"},
+	{"cboe byx", "CboeBYX"},
+	{"cboe bzx", "CboeBZX"},
+	{"cboe edga", "CboeEDGA"},
+	{"cboe edgx", "CboeEDGX"},
+	{"cboe", "CBOE"},
+	{"investors exchange", "IEX"},
+	{"iex", "IEX"},
+	{"long-term stock exchange", "LTSE"},
+	{"long term stock exchange", "LTSE"},
+}
+
+// ixtExchangeName converts an exchange name to its SEC code,
+// e.g. "The Nasdaq Stock Market LLC" -> "NASDAQ"
+func ixtExchangeName(text string) (string, error) {
+	name := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, e := range exchangeCodes {
+		if strings.Contains(name, e.name) {
+			return e.code, nil
+		}
+	}
+	return "", fmt.Errorf("unknown exchange %q", text)
+}
+
+// Field order of a displayed date
+type dateOrder int
+
+const (
+	monthFirst dateOrder = iota
+	dayFirst
+	yearFirst
+)
+
+// ixtDate converts a date with numeric or English month name fields to YYYY-MM-DD
+func ixtDate(order dateOrder) ixtTransform {
+	return func(text string) (string, error) {
+		fields := dateFields(text)
+		if len(fields) != 3 {
+			return "", fmt.Errorf("invalid date %q", text)
+		}
+		var y, m, d string
+		switch order {
+		case monthFirst:
+			m, d, y = fields[0], fields[1], fields[2]
+		case dayFirst:
+			d, m, y = fields[0], fields[1], fields[2]
+		case yearFirst:
+			y, m, d = fields[0], fields[1], fields[2]
+		}
+		return formatDate(text, y, m, d)
+	}
+}
+
+// ixtMonthDay converts a date without a year to an xs:gMonthDay, e.g. "--03-31"
+func ixtMonthDay(order dateOrder) ixtTransform {
+	return func(text string) (string, error) {
+		fields := dateFields(text)
+		if len(fields) != 2 {
+			return "", fmt.Errorf("invalid date %q", text)
+		}
+		m, d := fields[0], fields[1]
+		if order == dayFirst {
+			d, m = fields[0], fields[1]
+		}
+		date, err := formatDate(text, "2000", m, d) // Leap year, so Feb 29 is valid
+		if err != nil {
+			return "", err
+		}
+		return "--" + date[5:], nil
+	}
+}
+
+// ixtYearMonth converts a month and year to an xs:gYearMonth, e.g. "2024-03"
+func ixtYearMonth(text string) (string, error) {
+	fields := dateFields(text)
+	if len(fields) != 2 {
+		return "", fmt.Errorf("invalid date %q", text)
+	}
+	date, err := formatDate(text, fields[1], fields[0], "1")
+	if err != nil {
+		return "", err
+	}
+	return date[:7], nil
+}
+
+// dateFields splits a displayed date into its numbers and words, dropping
+// separators and ordinal suffixes ("31st")
+func dateFields(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, f := range fields {
+		if len(f) > 2 && unicode.IsDigit(rune(f[0])) {
+			for _, suffix := range []string{"st", "nd", "rd", "th"} {
+				if strings.HasSuffix(f, suffix) {
+					fields[i] = strings.TrimSuffix(f, suffix)
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// formatDate validates year, month (number or English name) and day and returns YYYY-MM-DD
+func formatDate(text, year, month, day string) (string, error) {
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q", text)
+	}
+	if len(year) <= 2 {
+		y += 2000
+	}
+	m, ok := parseMonth(month)
+	if !ok {
+		return "", fmt.Errorf("invalid date %q", text)
+	}
+	d, err := strconv.Atoi(day)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q", text)
+	}
+
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if t.Year() != y || int(t.Month()) != m || t.Day() != d {
+		return "", fmt.Errorf("invalid date %q", text)
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+// parseMonth parses a month number or English month name ("March", "Mar", "Sept")
+func parseMonth(s string) (int, bool) {
+	if m, err := strconv.Atoi(s); err == nil {
+		return m, m >= 1 && m <= 12
+	}
+	if len(s) < 3 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if strings.HasPrefix(name, s) || (s == "sept" && m == time.September) {
+			return int(m), true
+		}
+	}
+	return 0, false
+}
+
+// isDecimal reports whether s is an unsigned decimal number such as "1234" or "0.5"
+func isDecimal(s string) bool {
+	digits := 0
+	point := false
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '.' && !point:
+			point = true
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
@@ -0,0 +1,177 @@
+package edgar
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Label linkbases give every concept in a taxonomy its human-readable labels.
+// A filing's own linkbase (e.g. mrna-20241231_lab.xml) covers the standard
+// concepts it uses and its company extensions; the us-gaap linkbase published
+// by FASB covers the whole standard taxonomy.
+
+// Label roles, most preferred first
+var labelRoles = []string{
+	"http://www.xbrl.org/2003/role/label",
+	"http://www.xbrl.org/2003/role/terseLabel",
+	"http://www.xbrl.org/2003/role/verboseLabel",
+}
+
+type labelLinkbase struct {
+	Links []struct {
+		Locs []struct {
+			Href  string `xml:"href,attr"`
+			Label string `xml:"label,attr"`
+		} `xml:"loc"`
+		Labels []struct {
+			Label string `xml:"label,attr"`
+			Role  string `xml:"role,attr"`
+			Lang  string `xml:"lang,attr"`
+			Text  string `xml:",chardata"`
+		} `xml:"label"`
+		Arcs []struct {
+			From string `xml:"from,attr"`
+			To   string `xml:"to,attr"`
+		} `xml:"labelArc"`
+	} `xml:"labelLink"`
+}
+
+// LabelLinkbaseURL returns the label linkbase of a filing from the URL of its
+// XBRL instance or inline XBRL document, following SEC file naming
+// e.g. ".../mrna-20241231.htm" -> ".../mrna-20241231_lab.xml"
+func LabelLinkbaseURL(instanceURL string) string {
+	base := instanceURL
+	if i := strings.LastIndex(base, "."); i > strings.LastIndex(base, "/") {
+		base = base[:i]
+	}
+	return base + "_lab.xml"
+}
+
+// USGAAPLabelLinkbaseURL returns the FASB label linkbase for a us-gaap taxonomy year
+func USGAAPLabelLinkbaseURL(year int) string {
+	return fmt.Sprintf("https://xbrl.fasb.org/us-gaap/%d/elts/us-gaap-lab-%d.xml", year, year)
+}
+
+// FetchLabelLinkbase fetches and parses a label linkbase
+func FetchLabelLinkbase(url string, email string) (map[string]string, error) {
+	return defaultClient(email).FetchLabelLinkbase(url)
+}
+
+// FetchLabelLinkbase fetches and parses a label linkbase
+func (c *Client) FetchLabelLinkbase(url string) (map[string]string, error) {
+	body, err := c.get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch label linkbase: %w", err)
+	}
+	defer body.Close()
+	return ParseLabelLinkbase(body)
+}
+
+// ParseLabelLinkbase parses a label linkbase into a map of concept name
+// (e.g. "us-gaap:Revenues") to label. The standard label is used when present,
+// then the terse and verbose labels; English labels are preferred.
+func ParseLabelLinkbase(r io.Reader) (map[string]string, error) {
+	var lb labelLinkbase
+	if err := xml.NewDecoder(r).Decode(&lb); err != nil {
+		return nil, fmt.Errorf("failed to parse label linkbase: %w", err)
+	}
+
+	type candidate struct {
+		text string
+		rank int
+	}
+	best := make(map[string]candidate)
+
+	for _, link := range lb.Links {
+		concepts := make(map[string][]string) // Locator label -> concepts
+		for _, loc := range link.Locs {
+			if concept := conceptFromHref(loc.Href); concept != "" {
+				concepts[loc.Label] = append(concepts[loc.Label], concept)
+			}
+		}
+		resources := make(map[string][]candidate) // Resource label -> labels
+		for _, l := range link.Labels {
+			text := strings.TrimSpace(l.Text)
+			if text == "" {
+				continue
+			}
+			resources[l.Label] = append(resources[l.Label], candidate{text, labelRank(l.Role, l.Lang)})
+		}
+
+		for _, arc := range link.Arcs {
+			for _, concept := range concepts[arc.From] {
+				for _, c := range resources[arc.To] {
+					if current, ok := best[concept]; !ok || c.rank < current.rank {
+						best[concept] = c
+					}
+				}
+			}
+		}
+	}
+
+	labels := make(map[string]string, len(best))
+	for concept, c := range best {
+		labels[concept] = c.text
+	}
+	return labels, nil
+}
+
+// conceptFromHref converts a locator href to a concept name
+// e.g. "us-gaap-2024.xsd#us-gaap_Revenues" -> "us-gaap:Revenues"
+func conceptFromHref(href string) string {
+	_, id, ok := strings.Cut(href, "#")
+	if !ok {
+		return ""
+	}
+	prefix, name, ok := strings.Cut(id, "_")
+	if !ok {
+		return ""
+	}
+	return prefix + ":" + name
+}
+
+// labelRank orders labels by role, then language (lower is better)
+func labelRank(role, lang string) int {
+	rank := len(labelRoles)
+	for i, r := range labelRoles {
+		if role == r || (role == "" && i == 0) {
+			rank = i
+			break
+		}
+	}
+	rank *= 2
+	if lang != "" && !strings.HasPrefix(strings.ToLower(lang), "en") {
+		rank++
+	}
+	return rank
+}
+
+// ApplyLabels sets Fact.Label from a parsed label linkbase. It may be called
+// several times (a filing's linkbase, then the us-gaap linkbase); labels already
+// set are kept.
+func (x *XBRL) ApplyLabels(labels map[string]string) {
+	for i := range x.Facts {
+		fact := &x.Facts[i]
+		if fact.Label != "" {
+			continue
+		}
+		if label, ok := labels[fact.Concept]; ok {
+			fact.Label = label
+		}
+	}
+}
+
+// DisplayLabel returns the best available name for a fact: the standardized
+// label, then the taxonomy label, then the raw concept name
+func (f *Fact) DisplayLabel() string {
+	switch {
+	case f.StandardLabel != "":
+		return f.StandardLabel
+	case f.Label != "":
+		return f.Label
+	default:
+		return f.Concept
+	}
+}
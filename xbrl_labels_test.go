@@ -0,0 +1,52 @@
+package edgar
+
+import (
+	"strings"
+	"testing"
+)
+
+const testLabelLinkbase = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:xml="http://www.w3.org/XML/1998/namespace">
+  <link:labelLink xlink:type="extended" xlink:role="http://www.xbrl.org/2003/role/link">
+    <link:loc xlink:type="locator" xlink:href="mrna-20241231.xsd#mrna_InventoryShelfLife" xlink:label="loc_shelf"/>
+    <link:label xlink:type="resource" xlink:label="lab_shelf" xlink:role="http://www.xbrl.org/2003/role/terseLabel" xml:lang="en-US">Shelf life</link:label>
+    <link:label xlink:type="resource" xlink:label="lab_shelf" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="en-US">Inventory, Shelf Life</link:label>
+    <link:labelArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-label" xlink:from="loc_shelf" xlink:to="lab_shelf"/>
+    <link:loc xlink:type="locator" xlink:href="https://xbrl.fasb.org/us-gaap/2024/elts/us-gaap-2024.xsd#us-gaap_Revenues" xlink:label="loc_rev"/>
+    <link:label xlink:type="resource" xlink:label="lab_rev" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="de">Umsatz</link:label>
+    <link:label xlink:type="resource" xlink:label="lab_rev" xlink:role="http://www.xbrl.org/2003/role/label" xml:lang="en-US">Revenues</link:label>
+    <link:labelArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/concept-label" xlink:from="loc_rev" xlink:to="lab_rev"/>
+  </link:labelLink>
+</link:linkbase>`
+
+func TestParseLabelLinkbase(t *testing.T) {
+	labels, err := ParseLabelLinkbase(strings.NewReader(testLabelLinkbase))
+	if err != nil {
+		t.Fatalf("ParseLabelLinkbase: %v", err)
+	}
+	if got := labels["mrna:InventoryShelfLife"]; got != "Inventory, Shelf Life" {
+		t.Errorf("extension label = %q, want the standard label", got)
+	}
+	if got := labels["us-gaap:Revenues"]; got != "Revenues" {
+		t.Errorf("us-gaap label = %q, want the English label", got)
+	}
+
+	x := &XBRL{Facts: []Fact{
+		{Concept: "mrna:InventoryShelfLife"},
+		{Concept: "us-gaap:Revenues", StandardLabel: "Revenue"},
+		{Concept: "mrna:Unlabeled"},
+	}}
+	x.ApplyLabels(labels)
+	x.ApplyLabels(map[string]string{"mrna:InventoryShelfLife": "Ignored"})
+
+	want := []string{"Inventory, Shelf Life", "Revenue", "mrna:Unlabeled"}
+	for i, fact := range x.Facts {
+		if got := fact.DisplayLabel(); got != want[i] {
+			t.Errorf("DisplayLabel(%s) = %q, want %q", fact.Concept, got, want[i])
+		}
+	}
+
+	if got := LabelLinkbaseURL("https://www.sec.gov/Archives/edgar/data/1682852/000168285225000011/mrna-20241231.htm"); got != "https://www.sec.gov/Archives/edgar/data/1682852/000168285225000011/mrna-20241231_lab.xml" {
+		t.Errorf("LabelLinkbaseURL = %s", got)
+	}
+}
@@ -0,0 +1,271 @@
+package edgar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Peer comparison bases
+const (
+	BasisAnnual    = "annual"    // Latest 10-K, 20-F or 40-F
+	BasisQuarterly = "quarterly" // Latest 10-Q (year to date income and cash flow values)
+)
+
+// peerForms are the forms of each comparison basis; amendments are skipped, as
+// many only add Part III or exhibits
+var peerForms = map[string][]string{
+	BasisAnnual:    {"10-K", "20-F", "40-F"},
+	BasisQuarterly: {"10-Q"},
+}
+
+// PeerMetrics are the snapshot fields compared by default, by JSON name
+var PeerMetrics = []string{
+	"revenue", "grossMargin", "operatingMargin", "netIncome", "rdExpense", "gaExpense",
+	"cashFlowOperations", "freeCashFlow", "cash", "totalLiquidity", "totalDebt",
+	"stockholdersEquity", "currentRatio", "debtToEquity", "cashRunwayMonths", "dilutedShares",
+}
+
+// PeerOptions configure ComparePeers
+type PeerOptions struct {
+	Basis   string   // BasisAnnual (default) or BasisQuarterly
+	Metrics []string // Numeric snapshot fields by JSON name, as in the CSV and Parquet exports (default PeerMetrics)
+}
+
+// PeerComparison is an aligned table of companies' snapshots: one row per
+// peer, one value per metric
+type PeerComparison struct {
+	Basis    string   `json:"basis,omitempty"`
+	Metrics  []string `json:"metrics"`
+	Peers    []Peer   `json:"peers"`
+	Warnings []string `json:"warnings,omitempty"` // Where the peers are not on the same basis (currency, period)
+}
+
+// Peer is one company's row of a PeerComparison
+type Peer struct {
+	Company         string             `json:"company"` // As requested: ticker or CIK
+	CIK             string             `json:"cik"`
+	Name            string             `json:"name"`
+	Ticker          string             `json:"ticker,omitempty"`
+	FormType        string             `json:"formType,omitempty"`
+	FilingDate      string             `json:"filingDate,omitempty"`
+	AccessionNumber string             `json:"accessionNumber,omitempty"`
+	FiscalYearEnd   string             `json:"fiscalYearEnd,omitempty"` // Period end
+	FiscalPeriod    string             `json:"fiscalPeriod,omitempty"`
+	Currency        string             `json:"currency,omitempty"`
+	Values          []*float64         `json:"values"`          // One per metric; nil when not reported
+	Error           string             `json:"error,omitempty"` // Why the peer has no values
+	Snapshot        *FinancialSnapshot `json:"-"`
+}
+
+// CompareSnapshots lines up the given snapshots' metrics (numeric JSON field
+// names, the same columns as the CSV and Parquet exports; nil for PeerMetrics) in a PeerComparison, with warnings
+// where the snapshots differ in currency, fiscal period or period end
+func CompareSnapshots(snapshots []*FinancialSnapshot, metrics []string) (*PeerComparison, error) {
+	if metrics == nil {
+		metrics = PeerMetrics
+	}
+	all := snapshotColumns()
+	columns := make([]snapshotColumn, len(metrics))
+	for i, metric := range metrics {
+		j := slices.IndexFunc(all, func(c snapshotColumn) bool { return c.name == metric })
+		if j < 0 || !all[j].numeric {
+			return nil, fmt.Errorf("unknown snapshot metric %q", metric)
+		}
+		columns[i] = all[j]
+	}
+
+	c := &PeerComparison{Metrics: metrics, Peers: make([]Peer, 0, len(snapshots))}
+	for _, s := range snapshots {
+		peer := Peer{
+			Company:         s.Ticker,
+			CIK:             s.CIK,
+			Name:            s.CompanyName,
+			Ticker:          s.Ticker,
+			FormType:        s.FormType,
+			FilingDate:      s.FilingDate,
+			AccessionNumber: s.AccessionNumber,
+			FiscalYearEnd:   s.FiscalYearEnd,
+			FiscalPeriod:    s.FiscalPeriod,
+			Currency:        s.Currency,
+			Values:          make([]*float64, len(metrics)),
+			Snapshot:        s,
+		}
+		if peer.Company == "" {
+			peer.Company = s.CIK
+		}
+		for j, col := range columns {
+			if v, ok := col.value(s).(float64); ok {
+				peer.Values[j] = &v
+			}
+		}
+		c.Peers = append(c.Peers, peer)
+	}
+	c.Warnings = peerWarnings(c.Peers)
+	return c, nil
+}
+
+// peerWarnings reports where peers' values are not comparable as they stand
+func peerWarnings(peers []Peer) []string {
+	var warnings []string
+	differ := func(what string, value func(Peer) string) {
+		seen := make(map[string][]string)
+		var order []string
+		for _, p := range peers {
+			if p.Snapshot == nil {
+				continue
+			}
+			v := value(p)
+			if _, ok := seen[v]; !ok {
+				order = append(order, v)
+			}
+			seen[v] = append(seen[v], p.Company)
+		}
+		if len(order) < 2 {
+			return
+		}
+		var parts []string
+		for _, v := range order {
+			parts = append(parts, fmt.Sprintf("%s (%s)", v, strings.Join(seen[v], ", ")))
+		}
+		warnings = append(warnings, fmt.Sprintf("%s differ: %s", what, strings.Join(parts, "; ")))
+	}
+	differ("currencies", func(p Peer) string { return p.Currency })
+	// Year to date 10-Q values cover 3 to 9 months
+	differ("fiscal periods", func(p Peer) string { return p.FiscalPeriod })
+
+	var first, last time.Time
+	for _, p := range peers {
+		end, err := time.Parse("2006-01-02", p.FiscalYearEnd)
+		if p.Snapshot == nil || err != nil {
+			continue
+		}
+		if first.IsZero() || end.Before(first) {
+			first = end
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+	if last.Sub(first) > 183*24*time.Hour {
+		warnings = append(warnings, fmt.Sprintf("periods end more than six months apart (%s to %s)",
+			first.Format("2006-01-02"), last.Format("2006-01-02")))
+	}
+	return warnings
+}
+
+// ComparePeers fetches the latest report of each company (a CIK, or a ticker
+// looked up in the SEC ticker dataset) on the same basis, annual (10-K, 20-F,
+// 40-F) by default, and lines up their snapshots as in CompareSnapshots.
+// Each company's concept mapping profile is selected from its SIC code.
+//
+// A company that can't be resolved, fetched or parsed gets a row with Error
+// set and no values, so one bad ticker doesn't lose the rest of the screen.
+func (c *Client) ComparePeers(companies []string, opts PeerOptions) (*PeerComparison, error) {
+	if opts.Basis == "" {
+		opts.Basis = BasisAnnual
+	}
+	forms, ok := peerForms[opts.Basis]
+	if !ok {
+		return nil, fmt.Errorf("unknown comparison basis %q (want %s or %s)", opts.Basis, BasisAnnual, BasisQuarterly)
+	}
+	if len(companies) == 0 {
+		return nil, fmt.Errorf("no companies given")
+	}
+
+	// Check the metrics before fetching anything
+	if _, err := CompareSnapshots(nil, opts.Metrics); err != nil {
+		return nil, err
+	}
+
+	var tickers CompanyTickers
+	var snapshots []*FinancialSnapshot
+	errs := make([]error, len(companies))
+	for i, company := range companies {
+		snapshot, err := c.latestSnapshot(strings.TrimSpace(company), forms, &tickers)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	comparison, err := CompareSnapshots(snapshots, opts.Metrics)
+	if err != nil {
+		return nil, err
+	}
+	comparison.Basis = opts.Basis
+	peers := make([]Peer, 0, len(companies))
+	next := 0
+	for i, company := range companies {
+		company = strings.TrimSpace(company)
+		if errs[i] != nil {
+			peers = append(peers, Peer{Company: company, Values: make([]*float64, len(comparison.Metrics)), Error: errs[i].Error()})
+			continue
+		}
+		peer := comparison.Peers[next]
+		next++
+		peer.Company = company
+		peers = append(peers, peer)
+	}
+	comparison.Peers = peers
+	comparison.Warnings = peerWarnings(peers)
+	return comparison, nil
+}
+
+// latestSnapshot fetches the snapshot of a company's most recent filing of
+// one of forms, loading the ticker dataset into tickers on first use
+func (c *Client) latestSnapshot(company string, forms []string, tickers *CompanyTickers) (*FinancialSnapshot, error) {
+	cik := company
+	if !allDigits(company) {
+		if *tickers == nil {
+			t, err := c.FetchCompanyTickers()
+			if err != nil {
+				return nil, err
+			}
+			*tickers = t
+		}
+		entry, ok := tickers.Lookup(company)
+		if !ok {
+			return nil, fmt.Errorf("unknown ticker %q", company)
+		}
+		cik = entry.CIK
+	}
+
+	subs, err := c.FetchSubmissions(cik)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch submissions: %w", err)
+	}
+	var latest *Filing
+	for _, f := range subs.GetRecentFilings() {
+		if slices.Contains(forms, f.Form) && (latest == nil || f.FilingDate > latest.FilingDate) {
+			latest = &f
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no %s among recent filings: %w", strings.Join(forms, ", "), ErrNotFound)
+	}
+
+	parsed, err := c.fetchFiling(*latest, BatchOptions{Profile: ProfileForSIC(subs.SIC)})
+	if err != nil {
+		return nil, err
+	}
+	snapshot, ok := parsed.Data.(*FinancialSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("%s %s is not XBRL: %w", latest.Form, latest.AccessionNumber, ErrNotXBRL)
+	}
+	if snapshot.CIK == "" {
+		snapshot.CIK = subs.CIK
+	}
+	if snapshot.CompanyName == "" {
+		snapshot.CompanyName = subs.Name
+	}
+	if snapshot.Ticker == "" && len(subs.Ticker) > 0 {
+		snapshot.Ticker = subs.Ticker[0]
+	}
+	if snapshot.FormType == "" {
+		snapshot.FormType = latest.Form
+	}
+	return snapshot, nil
+}
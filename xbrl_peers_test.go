@@ -0,0 +1,124 @@
+package edgar
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestComparePeers(t *testing.T) {
+	moderna, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	// One recent filing list per CIK: "form|filingDate|accession|document"
+	submissions := map[string][]string{
+		"0001682852": {"10-Q|2025-05-01|0001682852-25-000020|q1.htm", "10-K|2025-02-21|0001682852-25-000011|mrna-20241231.htm", "10-K/A|2025-03-01|0001682852-25-000015|amend.htm"},
+		"0000000001": {"6-K|2025-04-01|0000000001-25-000009|pr.htm", "20-F|2025-03-20|0000000001-25-000007|annual.htm"},
+		"0000000002": {"8-K|2025-01-10|0000000002-25-000001|pr.htm"},
+	}
+	documents := map[string][]byte{"mrna-20241231.htm": moderna, "annual.htm": []byte(ifrsDoc)}
+
+	client := NewClient("jane@acme.test")
+	client.HTTPClient = &http.Client{Transport: handlerTransport{http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case path == "/files/company_tickers.json":
+			fmt.Fprint(w, `{"0": {"cik_str": 1682852, "ticker": "MRNA", "title": "Moderna, Inc."}, "1": {"cik_str": 1, "ticker": "EURO", "title": "Euro Pharma SE"}}`)
+		case strings.HasPrefix(path, "/submissions/CIK"):
+			cik := strings.TrimSuffix(strings.TrimPrefix(path, "/submissions/CIK"), ".json")
+			var forms, dates, accessions, docs []string
+			for _, f := range submissions[cik] {
+				parts := strings.Split(f, "|")
+				forms, dates, accessions, docs = append(forms, `"`+parts[0]+`"`), append(dates, `"`+parts[1]+`"`), append(accessions, `"`+parts[2]+`"`), append(docs, `"`+parts[3]+`"`)
+			}
+			inline := strings.TrimSuffix(strings.Repeat("1,", len(forms)), ",")
+			fmt.Fprintf(w, `{"cik": "%s", "name": "Company %s", "sic": "2834", "tickers": [], "filings": {"recent": {"form": [%s], "filingDate": [%s], "accessionNumber": [%s], "primaryDocument": [%s], "isXBRL": [%s], "isInlineXBRL": [%s]}}}`,
+				strings.TrimLeft(cik, "0"), cik, strings.Join(forms, ","), strings.Join(dates, ","), strings.Join(accessions, ","), strings.Join(docs, ","), inline, inline)
+		default:
+			doc, ok := documents[path[strings.LastIndex(path, "/")+1:]]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(doc)
+		}
+	})}}
+
+	c, err := client.ComparePeers([]string{"mrna", "EURO", "2", "NOPE"}, PeerOptions{Metrics: []string{"revenue", "cash", "totalLiquidity", "currentRatio"}})
+	if err != nil {
+		t.Fatalf("ComparePeers: %v", err)
+	}
+	if c.Basis != BasisAnnual || len(c.Peers) != 4 {
+		t.Fatalf("comparison = %s basis with %d peers, want annual with 4", c.Basis, len(c.Peers))
+	}
+
+	// The latest 10-K, not the newer 10-Q or the 10-K/A
+	mrna := c.Peers[0]
+	if mrna.Company != "mrna" || mrna.CIK != "0001682852" || mrna.FormType != "10-K" || mrna.AccessionNumber != "0001682852-25-000011" || mrna.FiscalYearEnd != "2024-12-31" {
+		t.Errorf("MRNA = %+v", mrna)
+	}
+	want := []float64{3.236e9, 1.927e9, 9.519e9, 8.099e9 / 2.206e9}
+	for i, w := range want {
+		if v := mrna.Values[i]; v == nil || *v != w {
+			t.Errorf("MRNA %s = %v, want %v", c.Metrics[i], v, w)
+		}
+	}
+
+	euro := c.Peers[1]
+	if euro.FormType != "20-F" || euro.Currency != "EUR" || euro.Values[0] == nil || *euro.Values[0] != 1200e6 || euro.Values[3] != nil {
+		t.Errorf("EURO = %+v", euro)
+	}
+	for _, p := range c.Peers[2:] {
+		if p.Error == "" || p.Snapshot != nil || len(p.Values) != 4 || p.Values[0] != nil {
+			t.Errorf("peer %s = %+v, want an error and no values", p.Company, p)
+		}
+	}
+	if !strings.Contains(c.Peers[3].Error, `unknown ticker "NOPE"`) {
+		t.Errorf("NOPE error = %q", c.Peers[3].Error)
+	}
+	if len(c.Warnings) != 1 || !strings.HasPrefix(c.Warnings[0], "currencies differ: USD (mrna); EUR (EURO)") {
+		t.Errorf("warnings = %q", c.Warnings)
+	}
+
+	if _, err := client.ComparePeers([]string{"MRNA"}, PeerOptions{Metrics: []string{"companyName"}}); err == nil {
+		t.Error("ComparePeers with a non-numeric metric should return error")
+	}
+	if _, err := client.ComparePeers([]string{"MRNA"}, PeerOptions{Basis: "monthly"}); err == nil {
+		t.Error("ComparePeers with an unknown basis should return error")
+	}
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	snapshots := []*FinancialSnapshot{
+		{CIK: "1", Ticker: "AAA", FiscalYearEnd: "2024-12-31", FiscalPeriod: "Q3", Currency: "USD", Revenue: ptrFloat(10)},
+		{CIK: "2", FiscalYearEnd: "2024-03-31", FiscalPeriod: "Q1", Currency: "USD", Ratios: FinancialRatios{CurrentRatio: ptrFloat(2)}},
+	}
+	c, err := CompareSnapshots(snapshots, []string{"revenue", "currentRatio"})
+	if err != nil {
+		t.Fatalf("CompareSnapshots: %v", err)
+	}
+	if c.Peers[0].Company != "AAA" || c.Peers[1].Company != "2" {
+		t.Errorf("companies = %s, %s", c.Peers[0].Company, c.Peers[1].Company)
+	}
+	if *c.Peers[0].Values[0] != 10 || c.Peers[0].Values[1] != nil || c.Peers[1].Values[0] != nil || *c.Peers[1].Values[1] != 2 {
+		t.Errorf("values = %v, %v", c.Peers[0].Values, c.Peers[1].Values)
+	}
+	want := []string{
+		"fiscal periods differ: Q3 (AAA); Q1 (2)",
+		"periods end more than six months apart (2024-03-31 to 2024-12-31)",
+	}
+	if strings.Join(c.Warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("warnings = %q, want %q", c.Warnings, want)
+	}
+
+	c, err = CompareSnapshots(snapshots, nil)
+	if err != nil || len(c.Metrics) != len(PeerMetrics) {
+		t.Errorf("default metrics = %v, %v", c.Metrics, err)
+	}
+	if _, err := CompareSnapshots(snapshots, []string{"revenu"}); err == nil {
+		t.Error("CompareSnapshots with an unknown metric should return error")
+	}
+}
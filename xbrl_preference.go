@@ -0,0 +1,121 @@
+package edgar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A concept is often reported more than once for the same period: rounded in
+// the highlights and exact in the statements, entity-wide and by segment, for
+// the consolidated group and the parent company alone. When one value is
+// needed, facts are ranked by CompareFactPreference:
+//
+//  1. Entity-wide facts (no dimensions) first
+//  2. Then facts whose members all name the consolidated entity (e.g.
+//     abc:ConsolidatedMember), before other dimensional facts
+//  3. Then fewer dimensions
+//  4. Then higher precision (larger decimals; INF and absent are 0)
+//  5. Then document order
+//
+// Period and concept come before these rules: MostRecent picks the latest
+// period end first, and snapshot values the concept listed first in the
+// mappings.
+
+// CompareFactPreference returns a negative number when a is preferred over b,
+// a positive number when b is preferred, and 0 when neither is (rules 1-4
+// above; callers keep document order for ties)
+func CompareFactPreference(a, b *Fact) int {
+	if ra, rb := dimensionRank(a), dimensionRank(b); ra != rb {
+		return ra - rb
+	}
+	if len(a.Dimensions) != len(b.Dimensions) {
+		return len(a.Dimensions) - len(b.Dimensions)
+	}
+	return b.Decimals - a.Decimals
+}
+
+// dimensionRank is 0 for entity-wide facts, 1 for consolidated-entity members
+// only, and 2 for other dimensional facts
+func dimensionRank(f *Fact) int {
+	if len(f.Dimensions) == 0 {
+		return 0
+	}
+	for _, d := range f.Dimensions {
+		if d.Typed || !isConsolidatedMember(d.Member) {
+			return 2
+		}
+	}
+	return 1
+}
+
+// isConsolidatedMember reports whether a member names the consolidated entity,
+// such as srt:ConsolidatedEntitiesMember (not "Unconsolidated" or
+// "Deconsolidated" members)
+func isConsolidatedMember(member string) bool {
+	local := member
+	if i := strings.LastIndex(member, ":"); i >= 0 {
+		local = member[i+1:]
+	}
+	return strings.HasPrefix(local, "Consolidated")
+}
+
+// Preferred returns the matching fact ranked first by CompareFactPreference,
+// regardless of period; combine it with ForPeriodEndingOn, or use MostRecent
+func (q *FactQuery) Preferred() (*Fact, error) {
+	results := q.Get()
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no facts found")
+	}
+	best := &results[0]
+	for i := 1; i < len(results); i++ {
+		if CompareFactPreference(&results[i], best) < 0 {
+			best = &results[i]
+		}
+	}
+	return best, nil
+}
+
+// Deduplicate makes Get return one fact per concept, period, dimensions and
+// unit: the most precise, at the position of the first one reported
+func (q *FactQuery) Deduplicate() *FactQuery {
+	q.deduplicate = true
+	return q
+}
+
+// deduplicateFacts keeps the most precise of facts reported more than once
+// (same concept, period, dimensions and unit)
+func deduplicateFacts(facts []Fact) []Fact {
+	index := make(map[string]int, len(facts))
+	results := make([]Fact, 0, len(facts))
+	for _, f := range facts {
+		k := factKey(f)
+		if i, ok := index[k]; ok {
+			if CompareFactPreference(&f, &results[i]) < 0 {
+				results[i] = f
+			}
+			continue
+		}
+		index[k] = len(results)
+		results = append(results, f)
+	}
+	return results
+}
+
+// factKey identifies a reported value: the same concept, period, dimensions
+// and unit, whichever context IDs the filer used
+func factKey(f Fact) string {
+	var b strings.Builder
+	b.WriteString(f.Concept)
+	if f.Period != nil {
+		b.WriteString("|" + f.Period.Instant + "|" + f.Period.StartDate + "|" + f.Period.EndDate)
+	}
+	dims := make([]string, len(f.Dimensions))
+	for i, d := range f.Dimensions {
+		dims[i] = d.Dimension + "=" + d.Member
+	}
+	sort.Strings(dims)
+	b.WriteString("|" + strings.Join(dims, ";"))
+	b.WriteString("|" + f.UnitRef)
+	return b.String()
+}
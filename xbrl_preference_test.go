@@ -0,0 +1,77 @@
+package edgar
+
+import "testing"
+
+func TestFactPreference(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+  xmlns:us-gaap="http://fasb.org/us-gaap/2024" xmlns:srt="http://fasb.org/srt/2024" xmlns:dei="http://xbrl.sec.gov/dei/2024"><body>
+<ix:header><ix:resources>
+  <xbrli:context id="FY_Product"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier>
+    <xbrli:segment><xbrldi:explicitMember dimension="srt:ProductOrServiceAxis">us-gaap:ProductMember</xbrldi:explicitMember></xbrli:segment></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="FY_copy"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+</ix:resources></ix:header>
+<ix:nonNumeric name="dei:DocumentType" contextRef="FY">10-K</ix:nonNumeric>
+<ix:nonFraction name="us-gaap:Revenues" contextRef="FY_Product" unitRef="usd" decimals="-6" scale="6">800</ix:nonFraction>
+<ix:nonFraction name="us-gaap:Revenues" contextRef="FY" unitRef="usd" decimals="-8" scale="9">1.2</ix:nonFraction>
+<ix:nonFraction name="us-gaap:Revenues" contextRef="FY_copy" unitRef="usd" decimals="-6" scale="6" format="ixt:num-dot-decimal">1,234</ix:nonFraction>
+</body></html>`
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+
+	// The exact statement amount, not the rounded highlight or the segment
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if value(snapshot.Revenue) != 1234e6 {
+		t.Errorf("Revenue = %s, want 1234000000", formatCurrency(snapshot.Revenue))
+	}
+	q := x.Query().ByConcept("us-gaap:Revenues").IncludeDimensional()
+	if fact, err := q.MostRecent(); err != nil || fact.ContextRef != "FY_copy" {
+		t.Errorf("MostRecent = %+v, %v, want the FY_copy fact", fact, err)
+	}
+	if fact, err := q.Preferred(); err != nil || fact.ContextRef != "FY_copy" {
+		t.Errorf("Preferred = %+v, %v, want the FY_copy fact", fact, err)
+	}
+
+	facts := x.Query().ByConcept("us-gaap:Revenues").IncludeDimensional().Deduplicate().Get()
+	if len(facts) != 2 || facts[0].ContextRef != "FY_Product" || facts[1].ContextRef != "FY_copy" {
+		t.Errorf("Deduplicate = %d facts %+v, want the segment and the precise total", len(facts), facts)
+	}
+}
+
+func TestCompareFactPreference(t *testing.T) {
+	entityWide := &Fact{Decimals: -9}
+	consolidated := &Fact{Decimals: -3, Dimensions: []Dimension{{Dimension: "srt:ConsolidatedEntitiesAxis", Member: "abc:ConsolidatedMember"}}}
+	parent := &Fact{Decimals: -3, Dimensions: []Dimension{{Dimension: "srt:ConsolidatedEntitiesAxis", Member: "srt:ParentCompanyMember"}}}
+	unconsolidated := &Fact{Dimensions: []Dimension{{Dimension: "abc:EntityAxis", Member: "abc:UnconsolidatedAffiliatesMember"}}}
+	twoDims := &Fact{Dimensions: []Dimension{{Dimension: "a:X", Member: "a:M"}, {Dimension: "a:Y", Member: "a:N"}}}
+
+	tests := []struct {
+		name      string
+		preferred *Fact
+		other     *Fact
+	}{
+		{"entity-wide before consolidated", entityWide, consolidated},
+		{"consolidated before parent company", consolidated, parent},
+		{"consolidated before unconsolidated", consolidated, unconsolidated},
+		{"fewer dimensions", unconsolidated, twoDims},
+		{"higher precision", &Fact{Decimals: 2}, &Fact{Decimals: -3}},
+	}
+	for _, tt := range tests {
+		if CompareFactPreference(tt.preferred, tt.other) >= 0 || CompareFactPreference(tt.other, tt.preferred) <= 0 {
+			t.Errorf("%s: not preferred", tt.name)
+		}
+	}
+	if got := CompareFactPreference(&Fact{Decimals: -6}, &Fact{Decimals: -6}); got != 0 {
+		t.Errorf("equal facts compare %d, want 0", got)
+	}
+}
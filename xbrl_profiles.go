@@ -0,0 +1,121 @@
+package edgar
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Concept mapping profiles adapt the standardized labels to industries whose
+// primary line items differ from an operating company's. A bank reports net
+// interest income and deposits rather than product revenue and inventory, and
+// tags RevenueFromContractWithCustomer for fee income only, so the default
+// mappings give it a misleading revenue and mostly zero fields.
+
+//go:embed concept_profiles.json
+var conceptProfilesJSON []byte
+
+// DefaultProfile selects the built-in mappings alone
+const DefaultProfile = "default"
+
+// ConceptProfile is a set of mappings merged over the built-in ones for one industry
+type ConceptProfile struct {
+	Description string                       `json:"description"`
+	SIC         []string                     `json:"sic"` // Standard Industrial Classification codes the profile applies to
+	Mappings    map[string]ConceptDefinition `json:"mappings"`
+}
+
+var conceptProfiles map[string]ConceptProfile
+
+func init() {
+	var file struct {
+		Profiles map[string]ConceptProfile `json:"profiles"`
+	}
+	if err := json.Unmarshal(conceptProfilesJSON, &file); err != nil {
+		panic(fmt.Sprintf("Failed to load concept profiles: %v", err))
+	}
+	conceptProfiles = file.Profiles
+}
+
+// ConceptProfiles returns the names of the available profiles, sorted
+func ConceptProfiles() []string {
+	names := make([]string, 0, len(conceptProfiles))
+	for name := range conceptProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetConceptProfile returns a profile by name
+func GetConceptProfile(name string) (ConceptProfile, bool) {
+	p, ok := conceptProfiles[name]
+	return p, ok
+}
+
+// ProfileForSIC returns the profile for a company's SIC code (Submissions.SIC),
+// or DefaultProfile when no industry profile applies
+func ProfileForSIC(sic string) string {
+	sic = strings.TrimSpace(sic)
+	for _, name := range ConceptProfiles() {
+		if slices.Contains(conceptProfiles[name].SIC, sic) {
+			return name
+		}
+	}
+	return DefaultProfile
+}
+
+// ApplyProfile re-labels the facts with a profile's mappings merged over the
+// package mappings (including any loaded with LoadConceptMappings), so that
+// GetSnapshot and queries by label use them. DefaultProfile or "" restores the
+// package mappings.
+func (x *XBRL) ApplyProfile(name string) error {
+	if name == "" {
+		name = DefaultProfile
+	}
+	var profile *ConceptProfile
+	if name != DefaultProfile {
+		p, ok := conceptProfiles[name]
+		if !ok {
+			return fmt.Errorf("unknown concept profile %q (available: %s, %s)",
+				name, DefaultProfile, strings.Join(ConceptProfiles(), ", "))
+		}
+		profile = &p
+	}
+
+	x.Profile = name
+	x.relabel(profile)
+	return nil
+}
+
+// relabel labels the facts with the package mappings, then the IFRS mappings for
+// an IFRS document, then the profile's mappings (nil for none)
+func (x *XBRL) relabel(profile *ConceptProfile) {
+	ifrs := x.Taxonomy() == TaxonomyIFRS
+	mapper := globalMapper
+	if ifrs || profile != nil {
+		mapper = globalMapper.clone()
+	}
+	if ifrs {
+		mapper.merge(ifrsMappings)
+	}
+	if profile != nil {
+		mapper.merge(profile.Mappings)
+	}
+
+	x.mapper = mapper
+	for i := range x.Facts {
+		x.Facts[i].StandardLabel = mapper.GetStandardizedLabel(x.Facts[i].Concept)
+	}
+}
+
+// mappings returns the concept mappings the facts were labeled with
+func (x *XBRL) mappings() *conceptMapper {
+	if x.mapper != nil {
+		return x.mapper
+	}
+	return globalMapper
+}
@@ -0,0 +1,117 @@
+package edgar
+
+import "testing"
+
+func TestProfileForSIC(t *testing.T) {
+	tests := map[string]string{
+		"6022": "bank",
+		"6331": "insurance",
+		"6798": "reit",
+		"2836": DefaultProfile, // Biological products
+		"":     DefaultProfile,
+	}
+	for sic, want := range tests {
+		if got := ProfileForSIC(sic); got != want {
+			t.Errorf("ProfileForSIC(%q) = %q, want %q", sic, got, want)
+		}
+	}
+}
+
+func TestApplyProfileBank(t *testing.T) {
+	year := &Period{StartDate: "2024-01-01", EndDate: "2024-12-31"}
+	end := &Period{Instant: "2024-12-31"}
+	fact := func(concept string, period *Period, value float64) Fact {
+		return Fact{Concept: concept, Period: period, NumericValue: &value, StandardLabel: GetStandardizedLabel(concept)}
+	}
+	x := &XBRL{Facts: []Fact{
+		fact("us-gaap:RevenueFromContractWithCustomerExcludingAssessedTax", year, 150e6), // Fee income only
+		fact("us-gaap:RevenuesNetOfInterestExpense", year, 900e6),
+		fact("us-gaap:InterestIncomeExpenseNet", year, 700e6),
+		fact("us-gaap:NoninterestIncome", year, 200e6),
+		fact("us-gaap:NoninterestExpense", year, 500e6),
+		fact("us-gaap:ProvisionForLoanLeaseAndOtherLosses", year, 40e6),
+		fact("us-gaap:NetIncomeLoss", year, 280e6),
+		fact("us-gaap:Deposits", end, 25e9),
+		fact("us-gaap:LoansAndLeasesReceivableNetReportedAmount", end, 20e9),
+	}}
+
+	// Default mappings: fee income is mistaken for revenue, bank items are unmapped
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value(snapshot.Revenue) != 150e6 || snapshot.Deposits != nil {
+		t.Fatalf("default snapshot: revenue %s, deposits %s", formatCurrency(snapshot.Revenue), formatCurrency(snapshot.Deposits))
+	}
+
+	if err := x.ApplyProfile("bank"); err != nil {
+		t.Fatalf("ApplyProfile: %v", err)
+	}
+	snapshot, err = x.GetSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Profile != "bank" {
+		t.Errorf("Profile = %q, want bank", snapshot.Profile)
+	}
+	checks := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"Revenue", snapshot.Revenue, 900e6},
+		{"NetInterestIncome", snapshot.NetInterestIncome, 700e6},
+		{"NoninterestIncome", snapshot.NoninterestIncome, 200e6},
+		{"TotalOperatingExpenses", snapshot.TotalOperatingExpenses, 500e6},
+		{"ProvisionForCreditLosses", snapshot.ProvisionForCreditLosses, 40e6},
+		{"Loans", snapshot.Loans, 20e9},
+		{"Deposits", snapshot.Deposits, 25e9},
+		{"NetIncome", snapshot.NetIncome, 280e6},
+	}
+	for _, c := range checks {
+		if c.got == nil || *c.got != c.want {
+			t.Errorf("%s = %s, want %.0f", c.name, formatCurrency(c.got), c.want)
+		}
+	}
+
+	// The package mappings are unchanged
+	if got := GetStandardizedLabel("us-gaap:Deposits"); got != "" {
+		t.Errorf("GetStandardizedLabel(us-gaap:Deposits) = %q after ApplyProfile, want none", got)
+	}
+
+	if err := x.ApplyProfile(DefaultProfile); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot, _ := x.GetSnapshot(); snapshot.Deposits != nil {
+		t.Errorf("Deposits = %.0f after restoring the default profile, want none", *snapshot.Deposits)
+	}
+	if err := x.ApplyProfile("shipping"); err == nil {
+		t.Error("ApplyProfile(unknown) should return error")
+	}
+}
+
+func TestApplyProfileREITFundsFromOperations(t *testing.T) {
+	year := &Period{StartDate: "2024-01-01", EndDate: "2024-12-31"}
+	fact := func(concept string, value float64) Fact {
+		return Fact{Concept: concept, Period: year, NumericValue: &value}
+	}
+	x := &XBRL{Facts: []Fact{
+		fact("us-gaap:OperatingLeaseLeaseIncome", 400e6),
+		fact("us-gaap:NetIncomeLoss", 100e6),
+		fact("us-gaap:DepreciationAndAmortization", 150e6),
+		fact("us-gaap:GainsLossesOnSalesOfInvestmentRealEstate", 30e6),
+	}}
+	if err := x.ApplyProfile("reit"); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value(snapshot.Revenue) != 400e6 {
+		t.Errorf("Revenue = %s, want lease income 400000000", formatCurrency(snapshot.Revenue))
+	}
+	if value(snapshot.FundsFromOperations) != 220e6 || !snapshot.FundsFromOperationsEstimated {
+		t.Errorf("FFO = %s (estimated %v), want 220000000 estimated", formatCurrency(snapshot.FundsFromOperations), snapshot.FundsFromOperationsEstimated)
+	}
+}
@@ -0,0 +1,114 @@
+package edgar
+
+import "math"
+
+// FinancialRatios are metrics derived from the values of a snapshot. A ratio is
+// nil when one of its inputs is not reported or its denominator is zero, never
+// computed from a missing value taken as zero. Margins and ratios are fractions
+// (0.25 is 25%).
+type FinancialRatios struct {
+	GrossMargin      *float64 `json:"grossMargin"`      // Gross profit / revenue
+	OperatingMargin  *float64 `json:"operatingMargin"`  // Operating income / revenue
+	CurrentRatio     *float64 `json:"currentRatio"`     // Current assets / current liabilities
+	DebtToEquity     *float64 `json:"debtToEquity"`     // Total debt / stockholders equity
+	FreeCashFlow     *float64 `json:"freeCashFlow"`     // Operating cash flow - capital expenditures
+	CashRunwayMonths *float64 `json:"cashRunwayMonths"` // Months of cash at the period's free cash flow burn
+}
+
+// ComputeRatios returns the derived ratios of the snapshot's current values
+// (the snapshot's Ratios field holds them as of when it was built)
+func (s *FinancialSnapshot) ComputeRatios() FinancialRatios {
+	return FinancialRatios{
+		GrossMargin:      s.GrossMargin(),
+		OperatingMargin:  s.OperatingMargin(),
+		CurrentRatio:     s.CurrentRatio(),
+		DebtToEquity:     s.DebtToEquity(),
+		FreeCashFlow:     s.FreeCashFlow(),
+		CashRunwayMonths: s.CashRunwayMonths(),
+	}
+}
+
+// GrossMargin returns gross profit / revenue. Without a reported gross profit
+// it is computed from revenue less cost of revenue.
+func (s *FinancialSnapshot) GrossMargin() *float64 {
+	gross := s.GrossProfit
+	if gross == nil && s.Revenue != nil && s.CostOfRevenue != nil {
+		g := *s.Revenue - *s.CostOfRevenue
+		gross = &g
+	}
+	return ratio(gross, s.Revenue)
+}
+
+// OperatingMargin returns operating income (loss) / revenue
+func (s *FinancialSnapshot) OperatingMargin() *float64 {
+	return ratio(s.OperatingIncome, s.Revenue)
+}
+
+// CurrentRatio returns current assets / current liabilities
+func (s *FinancialSnapshot) CurrentRatio() *float64 {
+	return ratio(s.CurrentAssets, s.CurrentLiabilities)
+}
+
+// DebtToEquity returns total debt / stockholders equity. It is nil when equity
+// is negative (a deficit), where the ratio has no meaning, and when no debt is
+// reported: filers without debt usually omit the line rather than report zero.
+func (s *FinancialSnapshot) DebtToEquity() *float64 {
+	if s.StockholdersEquity != nil && *s.StockholdersEquity < 0 {
+		return nil
+	}
+	return ratio(s.TotalDebt, s.StockholdersEquity)
+}
+
+// FreeCashFlow returns operating cash flow less capital expenditures, for the
+// period of the cash flow statement (year to date in a 10-Q)
+func (s *FinancialSnapshot) FreeCashFlow() *float64 {
+	if s.CashFlowOperations == nil || s.CapitalExpenditures == nil {
+		return nil
+	}
+	// Capital expenditures are reported as a positive payment
+	fcf := *s.CashFlowOperations - math.Abs(*s.CapitalExpenditures)
+	return &fcf
+}
+
+// CashRunwayMonths returns how many months cash and cash equivalents last at
+// the period's average monthly burn (negative free cash flow, or operating cash
+// flow when capital expenditures are not reported). It is nil when the company
+// is not burning cash. Marketable securities are not included in cash.
+func (s *FinancialSnapshot) CashRunwayMonths() *float64 {
+	flow := s.FreeCashFlow()
+	if flow == nil {
+		flow = s.CashFlowOperations
+	}
+	months := cashFlowMonths(s.FiscalPeriod)
+	if s.Cash == nil || flow == nil || *flow >= 0 || months == 0 {
+		return nil
+	}
+	runway := *s.Cash / (-*flow / months)
+	return &runway
+}
+
+// cashFlowMonths returns the length in months of the cash flow statement
+// period, which is year to date: 6 months for a second quarter 10-Q
+func cashFlowMonths(fiscalPeriod string) float64 {
+	switch fiscalPeriod {
+	case "Q1":
+		return 3
+	case "Q2", "H1":
+		return 6
+	case "Q3":
+		return 9
+	case "FY", "Q4", "H2":
+		return 12
+	}
+	return 0
+}
+
+// ratio returns numerator / denominator, or nil if either is not reported or
+// the denominator is zero
+func ratio(numerator, denominator *float64) *float64 {
+	if numerator == nil || denominator == nil || *denominator == 0 {
+		return nil
+	}
+	r := *numerator / *denominator
+	return &r
+}
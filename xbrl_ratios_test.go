@@ -0,0 +1,82 @@
+package edgar
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestSnapshotRatiosModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL: %v", err)
+	}
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+
+	r := snapshot.Ratios
+	tests := []struct {
+		name string
+		got  *float64
+		want float64
+	}{
+		{"GrossMargin", r.GrossMargin, (3.236e9 - 1.464e9) / 3.236e9}, // Revenue less cost of revenue
+		{"OperatingMargin", r.OperatingMargin, -3.945e9 / 3.236e9},
+		{"CurrentRatio", r.CurrentRatio, 8.099e9 / 2.206e9},
+		{"FreeCashFlow", r.FreeCashFlow, -3.004e9 - 1.051e9},
+		{"CashRunwayMonths", r.CashRunwayMonths, 1.927e9 / (4.055e9 / 12)},
+	}
+	for _, tt := range tests {
+		if tt.got == nil {
+			t.Errorf("%s = nil, want %.4f", tt.name, tt.want)
+			continue
+		}
+		if math.Abs(*tt.got-tt.want) > 1e-6*math.Abs(tt.want) {
+			t.Errorf("%s = %.4f, want %.4f", tt.name, *tt.got, tt.want)
+		}
+	}
+
+	// No debt reported
+	if r.DebtToEquity != nil {
+		t.Errorf("DebtToEquity = %.4f, want nil", *r.DebtToEquity)
+	}
+}
+
+func TestSnapshotRatiosMissingInputs(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		snapshot FinancialSnapshot
+		got      func(*FinancialSnapshot) *float64
+		want     *float64
+	}{
+		{"gross margin without revenue", FinancialSnapshot{GrossProfit: ptrFloat(10)}, (*FinancialSnapshot).GrossMargin, nil},
+		{"gross margin with zero revenue", FinancialSnapshot{GrossProfit: ptrFloat(0), Revenue: ptrFloat(0)}, (*FinancialSnapshot).GrossMargin, nil},
+		{"gross margin reported", FinancialSnapshot{GrossProfit: ptrFloat(40), CostOfRevenue: ptrFloat(90), Revenue: ptrFloat(100)}, (*FinancialSnapshot).GrossMargin, ptrFloat(0.4)},
+		{"current ratio without liabilities", FinancialSnapshot{CurrentAssets: ptrFloat(10)}, (*FinancialSnapshot).CurrentRatio, nil},
+		{"debt to equity with a deficit", FinancialSnapshot{TotalDebt: ptrFloat(10), StockholdersEquity: ptrFloat(-5)}, (*FinancialSnapshot).DebtToEquity, nil},
+		{"debt to equity", FinancialSnapshot{TotalDebt: ptrFloat(10), StockholdersEquity: ptrFloat(40)}, (*FinancialSnapshot).DebtToEquity, ptrFloat(0.25)},
+		{"free cash flow without capex", FinancialSnapshot{CashFlowOperations: ptrFloat(10)}, (*FinancialSnapshot).FreeCashFlow, nil},
+		{"free cash flow with negative capex", FinancialSnapshot{CashFlowOperations: ptrFloat(10), CapitalExpenditures: ptrFloat(-4)}, (*FinancialSnapshot).FreeCashFlow, ptrFloat(6)},
+		{"runway when generating cash", FinancialSnapshot{FiscalPeriod: "FY", Cash: ptrFloat(100), CashFlowOperations: ptrFloat(10)}, (*FinancialSnapshot).CashRunwayMonths, nil},
+		{"runway without cash", FinancialSnapshot{FiscalPeriod: "FY", CashFlowOperations: ptrFloat(-10)}, (*FinancialSnapshot).CashRunwayMonths, nil},
+		{"runway from operating cash flow", FinancialSnapshot{FiscalPeriod: "FY", Cash: ptrFloat(100), CashFlowOperations: ptrFloat(-50)}, (*FinancialSnapshot).CashRunwayMonths, ptrFloat(24)},
+		{"runway from a year to date 10-Q", FinancialSnapshot{FiscalPeriod: "Q2", Cash: ptrFloat(100), CashFlowOperations: ptrFloat(-45), CapitalExpenditures: ptrFloat(5)}, (*FinancialSnapshot).CashRunwayMonths, ptrFloat(12)},
+		{"runway with an unknown period length", FinancialSnapshot{Cash: ptrFloat(100), CashFlowOperations: ptrFloat(-50)}, (*FinancialSnapshot).CashRunwayMonths, nil},
+	}
+	for _, tt := range tests {
+		got := tt.got(&tt.snapshot)
+		switch {
+		case tt.want == nil && got != nil:
+			t.Errorf("%s = %v, want nil", tt.name, *got)
+		case tt.want != nil && (got == nil || math.Abs(*got-*tt.want) > 1e-9):
+			t.Errorf("%s = %v (nil: %v), want %v", tt.name, value(got), got == nil, *tt.want)
+		}
+	}
+}
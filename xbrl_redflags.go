@@ -0,0 +1,147 @@
+package edgar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Red flag kinds
+const (
+	RedFlagGoingConcern   = "going_concern"   // Substantial doubt about the ability to continue as a going concern
+	RedFlagCovenantBreach = "covenant_breach" // Debt covenant violations, waivers and defaults
+	RedFlagRestatement    = "restatement"     // Restated prior financial statements
+)
+
+// RedFlag is distress or accounting language found in a filing's narrative
+type RedFlag struct {
+	Kind    string `json:"kind"`              // RedFlagGoingConcern, RedFlagCovenantBreach or RedFlagRestatement
+	Concept string `json:"concept,omitempty"` // Text block or DEI flag it was found in
+	Excerpt string `json:"excerpt"`           // The sentence that matched
+}
+
+func (f RedFlag) String() string {
+	return f.Kind
+}
+
+// redFlagPatterns find red flag language. A sentence matching skip is
+// boilerplate or a denial ("management evaluates whether there is substantial
+// doubt...", "the Company was in compliance with all covenants"), not a flag,
+// unless it also matches keep ("plans do not alleviate the substantial doubt").
+var redFlagPatterns = []struct {
+	kind  string
+	match *regexp.Regexp
+	skip  *regexp.Regexp
+	keep  *regexp.Regexp
+}{
+	{
+		RedFlagGoingConcern,
+		regexp.MustCompile(`(?i)substantial doubt (exists |existed |remains )?(about|regarding|as to|on|of) .{0,40}ability to continue as a going concern`),
+		// Denials are anchored to the phrase, so "which could harm our ability
+		// to obtain financing" later in the sentence is not one
+		regexp.MustCompile(`(?i)` + strings.Join([]string{
+			`\b(no|not) substantial doubt`,
+			`\bnot (raise|exist)\w* .{0,20}substantial doubt`,
+			`substantial doubt .{0,60}\b(does|did|do) not exist`,
+			`alleviat\w* (the |this |that |such )?substantial doubt`,
+			`substantial doubt .{0,120}\b(is|was|were|has been|have been|had been) (\w+ )?alleviated`,
+			`\bwhether (there (is|are|was|were)|conditions|events) .{0,120}substantial doubt`,
+			`\b(could|may|might|would)( \w+){0,2} (raise|create|cause|be|give rise to|result in|lead to) (\w+ )?substantial doubt`,
+		}, "|")),
+		regexp.MustCompile(`(?i)\bnot( \w+)? alleviat\w*|\bnot sufficient to alleviate`),
+	},
+	{
+		RedFlagCovenantBreach,
+		regexp.MustCompile(`(?i)\b((were|was|are|is|remains?|remained) (not in compliance with|in (breach|violation|default) (of|under)) .{0,80}covenants?|(obtained|received|entered into|requested) .{0,40}waivers? .{0,60}covenants?|covenants? (breach|violation)s? (occurred|existed))`),
+		// Anchored like the going concern denials: "if we cannot obtain a
+		// waiver" after a breach doesn't deny it
+		regexp.MustCompile(`(?i)` + strings.Join([]string{
+			`\bif (we|the company|it|they) (were|was|are|is|remains?|remained) (not in compliance|in (breach|violation|default))`,
+			`\bif (we|the company|it|they) (obtained|received|entered into|requested) .{0,40}waivers?`,
+			`\bno covenants? (breach|violation)(es|s)? (occurred|existed)`,
+		}, "|")),
+		nil,
+	},
+	{
+		RedFlagRestatement,
+		// "As previously reported" alone is also reclassification boilerplate,
+		// so it needs restatement language nearby
+		regexp.MustCompile(`(?i)\b(restatement of .{0,60}financial statements|restated .{0,60}financial statements|as previously reported.{0,120}\b(restat|misstat|correction of (an )?error)|(restat\w*|misstat\w*|correction of (an )?errors?)\b.{0,120}as previously reported|should no longer be relied upon|non-reliance on previously issued)`),
+		regexp.MustCompile(`(?i)` + strings.Join([]string{
+			`\bnot (been )?restated (\w+ ){0,4}financial statements`,
+			`\bno restatement of`,
+			`\bamended and restated (\w+ ){0,3}(agreements?|plans?|certificates?|bylaws|charters?|notes?|credit|indentures?|leases?)\b`,
+			`\bwhether (a |any |to )?restat\w*`,
+			`\bif (a |any |the )?restatement`,
+			`\b(could|may|might|would)( \w+){0,2} (restate|restatement)\b`,
+		}, "|")),
+		nil,
+	},
+}
+
+// FindRedFlags scans narrative text, such as a 10-K section or text block, for
+// going-concern, covenant breach and restatement language. Each kind is
+// reported once, with the first sentence that raises it.
+func FindRedFlags(text string) []RedFlag {
+	var flags []RedFlag
+	for _, p := range redFlagPatterns {
+		for _, loc := range p.match.FindAllStringIndex(text, -1) {
+			sentence := sentenceAround(text, loc[0], loc[1])
+			if p.skip.MatchString(sentence) && (p.keep == nil || !p.keep.MatchString(sentence)) {
+				continue
+			}
+			flags = append(flags, RedFlag{Kind: p.kind, Excerpt: sentence})
+			break
+		}
+	}
+	return flags
+}
+
+// RedFlags scans the filing's text blocks (see GetTextBlocks) for red flag
+// language, one flag per kind and text block, and adds a restatement flag when
+// the cover page's error correction checkbox (dei:DocumentFinStmtErrorCorrectionFlag)
+// is ticked
+//
+// The language is matched sentence by sentence and denials and boilerplate are
+// skipped, so a flag is a prompt to read the disclosure, not a conclusion.
+func (x *XBRL) RedFlags() []RedFlag {
+	flags := []RedFlag{}
+	for _, fact := range x.Facts {
+		if fact.Concept == "dei:DocumentFinStmtErrorCorrectionFlag" && strings.EqualFold(strings.TrimSpace(fact.Value), "true") {
+			flags = append(flags, RedFlag{
+				Kind:    RedFlagRestatement,
+				Concept: fact.Concept,
+				Excerpt: "The financial statements included in the filing reflect the correction of an error to previously issued financial statements",
+			})
+			break
+		}
+	}
+	for _, block := range x.GetTextBlocks() {
+		for _, flag := range FindRedFlags(block.Text) {
+			flag.Concept = block.Concept
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// maxExcerpt bounds a red flag excerpt around its match
+const maxExcerpt = 400
+
+// sentenceAround returns the sentence containing text[start:end]
+func sentenceAround(text string, start, end int) string {
+	from := max(0, start-maxExcerpt/2)
+	if i := strings.LastIndexAny(text[from:start], "\n"); i >= 0 {
+		from += i + 1
+	}
+	if i := strings.LastIndex(text[from:start], ". "); i >= 0 {
+		from += i + 2
+	}
+	to := min(len(text), end+maxExcerpt/2)
+	if i := strings.IndexAny(text[end:to], "\n"); i >= 0 {
+		to = end + i
+	}
+	if i := strings.Index(text[end:to], ". "); i >= 0 {
+		to = end + i + 1
+	}
+	return strings.TrimSpace(text[from:to])
+}
@@ -0,0 +1,185 @@
+package edgar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const redFlagXBRL = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:us-gaap="http://fasb.org/us-gaap/2024" xmlns:dei="http://xbrl.sec.gov/dei/2024">
+  <xbrli:context id="FY2024">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <dei:DocumentFinStmtErrorCorrectionFlag contextRef="FY2024">true</dei:DocumentFinStmtErrorCorrectionFlag>
+  <us-gaap:SubstantialDoubtAboutGoingConcernTextBlock contextRef="FY2024">&lt;p&gt;Going Concern&lt;/p&gt;&lt;p&gt;The Company has incurred recurring losses. These conditions raise substantial doubt about the Company's ability to continue as a going concern within one year after the date that the financial statements are issued. Management plans to raise additional capital.&lt;/p&gt;</us-gaap:SubstantialDoubtAboutGoingConcernTextBlock>
+  <us-gaap:DebtDisclosureTextBlock contextRef="FY2024">&lt;p&gt;As of December 31, 2024, the Company was not in compliance with the minimum liquidity covenant under the Term Loan. The lender has not waived the breach.&lt;/p&gt;</us-gaap:DebtDisclosureTextBlock>
+</xbrli:xbrl>`
+
+func TestFindRedFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "going concern",
+			text: "We have limited cash. These factors raise substantial doubt about our ability to continue as a going concern. We plan to raise capital.",
+			want: []string{RedFlagGoingConcern},
+		},
+		{
+			name: "going concern evaluation boilerplate",
+			text: "Management evaluates whether there are conditions that raise substantial doubt about the Company's ability to continue as a going concern within one year.",
+		},
+		{
+			name: "going concern alleviated",
+			text: "Management's plans have alleviated substantial doubt about the Company's ability to continue as a going concern.",
+		},
+		{
+			name: "going concern not alleviated",
+			text: "Management's plans do not alleviate the substantial doubt about the Company's ability to continue as a going concern.",
+			want: []string{RedFlagGoingConcern},
+		},
+		{
+			name: "going concern with consequences",
+			text: "As of December 31, 2024, our recurring losses raise substantial doubt about our ability to continue as a going concern, which could harm our ability to obtain financing.",
+			want: []string{RedFlagGoingConcern},
+		},
+		{
+			name: "going concern exists",
+			text: "Substantial doubt exists about our ability to continue as a going concern.",
+			want: []string{RedFlagGoingConcern},
+		},
+		{
+			name: "going concern risk factor",
+			text: "If we are unable to raise additional capital, our losses could raise substantial doubt about our ability to continue as a going concern.",
+		},
+		{
+			name: "going concern denied",
+			text: "Based on its evaluation, management concluded there is no substantial doubt about the Company's ability to continue as a going concern.",
+		},
+		{
+			name: "covenant breach",
+			text: "At September 30, 2024, we were in violation of the fixed charge coverage covenant under our credit facility.",
+			want: []string{RedFlagCovenantBreach},
+		},
+		{
+			name: "covenant waiver",
+			text: "In March 2024 the Company obtained a waiver from its lenders of the leverage ratio covenant for the fourth quarter.",
+			want: []string{RedFlagCovenantBreach},
+		},
+		{
+			name: "covenant breach with consequences",
+			text: "We were in breach of the minimum liquidity covenant under our term loan at June 30, 2024, and if we cannot obtain a waiver, the lenders may accelerate the debt.",
+			want: []string{RedFlagCovenantBreach},
+		},
+		{
+			name: "covenant breach hypothetical",
+			text: "If we were in breach of the financial covenants under our credit agreement, the lenders could accelerate repayment.",
+		},
+		{
+			name: "covenant compliance",
+			text: "The credit agreement contains financial covenants. As of December 31, 2024, the Company was in compliance with all covenants.",
+		},
+		{
+			name: "restatement",
+			text: "The accompanying financial statements include the restatement of our previously issued financial statements for the year ended December 31, 2023.",
+			want: []string{RedFlagRestatement},
+		},
+		{
+			name: "restatement with conditional",
+			text: "As discussed in Note 2, we restated our previously issued financial statements for 2023, and if additional errors are identified, further restatements may be required.",
+			want: []string{RedFlagRestatement},
+		},
+		{
+			name: "restatement as previously reported",
+			text: "Revenue for 2023, as previously reported, was $10.2 million and, as restated, is $9.8 million.",
+			want: []string{RedFlagRestatement},
+		},
+		{
+			name: "reclassification boilerplate",
+			text: "Certain amounts, as previously reported, have been reclassified to conform to the current presentation.",
+		},
+		{
+			name: "restatement evaluation",
+			text: "The audit committee is evaluating whether a restatement of the previously issued financial statements is required.",
+		},
+		{
+			name: "amended and restated agreement",
+			text: "The Company entered into an amended and restated credit agreement. The restated agreement extends the maturity.",
+		},
+		{
+			name: "all three",
+			text: "Our prior financial statements should no longer be relied upon.\nWe are in default under the covenants of our notes.\nThere is substantial doubt about our ability to continue as a going concern.",
+			want: []string{RedFlagGoingConcern, RedFlagCovenantBreach, RedFlagRestatement},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := FindRedFlags(tt.text)
+			var kinds []string
+			for _, f := range flags {
+				kinds = append(kinds, f.Kind)
+				if f.Excerpt == "" || !strings.Contains(tt.text, f.Excerpt) {
+					t.Errorf("%s excerpt %q is not a sentence of the text", f.Kind, f.Excerpt)
+				}
+			}
+			if strings.Join(kinds, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("kinds = %v, want %v (flags %+v)", kinds, tt.want, flags)
+			}
+		})
+	}
+
+	flags := FindRedFlags("We have limited cash. These factors raise substantial doubt about our ability to continue as a going concern. We plan to raise capital.")
+	if want := "These factors raise substantial doubt about our ability to continue as a going concern."; flags[0].Excerpt != want {
+		t.Errorf("excerpt = %q, want %q", flags[0].Excerpt, want)
+	}
+}
+
+func TestXBRLRedFlags(t *testing.T) {
+	x, err := ParseXBRL([]byte(redFlagXBRL))
+	if err != nil {
+		t.Fatalf("ParseXBRL: %v", err)
+	}
+
+	flags := x.RedFlags()
+	want := []struct{ kind, concept string }{
+		{RedFlagRestatement, "dei:DocumentFinStmtErrorCorrectionFlag"},
+		{RedFlagGoingConcern, "us-gaap:SubstantialDoubtAboutGoingConcernTextBlock"},
+		{RedFlagCovenantBreach, "us-gaap:DebtDisclosureTextBlock"},
+	}
+	if len(flags) != len(want) {
+		t.Fatalf("got %d flags, want %d: %+v", len(flags), len(want), flags)
+	}
+	for i, w := range want {
+		if flags[i].Kind != w.kind || flags[i].Concept != w.concept {
+			t.Errorf("flag %d = %s in %s, want %s in %s", i, flags[i].Kind, flags[i].Concept, w.kind, w.concept)
+		}
+	}
+
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if len(snapshot.RedFlags) != 3 {
+		t.Errorf("snapshot has %d red flags, want 3", len(snapshot.RedFlags))
+	}
+}
+
+func TestXBRLRedFlagsModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	// A healthy filer: amended and restated agreements and covenant
+	// descriptions aren't red flags
+	if flags := x.RedFlags(); len(flags) != 0 {
+		t.Errorf("got red flags %+v, want none", flags)
+	}
+}
@@ -0,0 +1,151 @@
+package edgar
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RunwayAssumptions configure a Runway projection
+type RunwayAssumptions struct {
+	TrailingQuarters           int     `json:"trailingQuarters"`           // Quarters of cash flow the burn is averaged over (0 for 4)
+	BurnGrowth                 float64 `json:"burnGrowth"`                 // Quarterly change in burn, e.g. 0.05 for 5% more each quarter
+	IncludeCapitalExpenditures bool    `json:"includeCapitalExpenditures"` // Burn free cash flow where capital expenditures are reported
+	ExcludeInvestments         bool    `json:"excludeInvestments"`         // Cash and cash equivalents only, without short-term investments
+	IncludeLongTermInvestments bool    `json:"includeLongTermInvestments"` // Count long-term investments too (TotalLiquidity)
+	MinimumCash                float64 `json:"minimumCash"`                // Cash held back, not counted as spendable
+}
+
+// DefaultRunwayAssumptions project the average operating cash burn of the
+// trailing four quarters, flat, against cash and short-term investments
+var DefaultRunwayAssumptions = RunwayAssumptions{TrailingQuarters: 4}
+
+// RunwayProjection is how long a company's liquidity lasts at its trailing
+// cash burn
+type RunwayProjection struct {
+	AsOf                 string            `json:"asOf"`                  // Balance sheet date of the liquidity
+	Cash                 float64           `json:"cash"`                  // Cash and cash equivalents
+	ShortTermInvestments *float64          `json:"shortTermInvestments"`  // Nil when excluded or not reported
+	LongTermInvestments  *float64          `json:"longTermInvestments"`   // Nil unless included and reported
+	Liquidity            float64           `json:"liquidity"`             // Cash + investments - minimum cash
+	BurnFrom             string            `json:"burnFrom"`              // Start of the cash flow periods averaged
+	BurnTo               string            `json:"burnTo"`                // End of the cash flow periods averaged
+	BurnMonths           float64           `json:"burnMonths"`            // Months of cash flow averaged
+	QuarterlyBurn        float64           `json:"quarterlyBurn"`         // Average cash used per quarter; negative when generating cash
+	Quarters             *float64          `json:"quarters"`              // Projected quarters of runway from AsOf; nil when liquidity never runs out
+	CashOutDate          string            `json:"cashOutDate,omitempty"` // Projected date liquidity runs out
+	Assumptions          RunwayAssumptions `json:"assumptions"`
+}
+
+// Runway projects how many quarters a company's cash and short-term
+// investments last at its trailing cash burn, from one or more snapshots of
+// the same company (e.g. GetSnapshots, or a year of 10-Q and 10-K snapshots).
+//
+// Liquidity is taken from the most recent snapshot with cash reported. The
+// burn is the average operating cash flow (see RunwayAssumptions) over the
+// most recent non-overlapping cash flow periods covering TrailingQuarters:
+// cash flow statements are year to date, so a Q3 10-Q covers its Q1 and Q2
+// and the latest of them is used. With BurnGrowth the burn changes by that
+// fraction each quarter; Quarters is nil when the company is not burning cash,
+// or when a shrinking burn never exhausts liquidity.
+func Runway(snapshots []*FinancialSnapshot, a RunwayAssumptions) (*RunwayProjection, error) {
+	if a.TrailingQuarters <= 0 {
+		a.TrailingQuarters = DefaultRunwayAssumptions.TrailingQuarters
+	}
+	sorted := make([]*FinancialSnapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		if s != nil {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].FiscalYearEnd > sorted[j].FiscalYearEnd })
+
+	p := &RunwayProjection{Assumptions: a}
+	for _, s := range sorted {
+		if s.Cash == nil {
+			continue
+		}
+		p.AsOf, p.Cash = s.FiscalYearEnd, *s.Cash
+		if !a.ExcludeInvestments {
+			p.ShortTermInvestments = s.ShortTermInvestments
+			if a.IncludeLongTermInvestments {
+				p.LongTermInvestments = s.LongTermInvestments
+			}
+		}
+		break
+	}
+	if p.AsOf == "" {
+		return nil, fmt.Errorf("no snapshot reports cash and cash equivalents")
+	}
+	p.Liquidity = *sumValues(&p.Cash, p.ShortTermInvestments, p.LongTermInvestments) - a.MinimumCash
+
+	// Walk back from the latest cash flow period, skipping periods that
+	// overlap one already counted (the earlier quarters of a year to date)
+	var covered time.Time
+	var flow float64
+	for _, s := range sorted {
+		months := cashFlowMonths(s.FiscalPeriod)
+		end, err := time.Parse("2006-01-02", s.FiscalYearEnd)
+		if months == 0 || err != nil {
+			continue
+		}
+		cf := s.CashFlowOperations
+		if fcf := s.FreeCashFlow(); a.IncludeCapitalExpenditures && fcf != nil {
+			cf = fcf
+		}
+		if cf == nil {
+			continue
+		}
+		// Period ends drift a few days around month ends (52/53-week years)
+		if !covered.IsZero() && end.After(covered.AddDate(0, 0, 7)) {
+			continue
+		}
+		start := end.AddDate(0, -int(months), 0)
+		if p.BurnTo == "" {
+			p.BurnTo = s.FiscalYearEnd
+		}
+		p.BurnFrom = start.Format("2006-01-02")
+		p.BurnMonths += months
+		flow += *cf
+		covered = start
+		if p.BurnMonths >= float64(3*a.TrailingQuarters) {
+			break
+		}
+	}
+	if p.BurnMonths == 0 {
+		return nil, fmt.Errorf("no snapshot reports operating cash flow for a known period length")
+	}
+	p.QuarterlyBurn = -flow / p.BurnMonths * 3
+
+	if quarters := runwayQuarters(p.Liquidity, p.QuarterlyBurn, a.BurnGrowth); quarters != nil {
+		p.Quarters = quarters
+		if asOf, err := time.Parse("2006-01-02", p.AsOf); err == nil {
+			p.CashOutDate = asOf.AddDate(0, 0, int(math.Round(*quarters*365.25/4))).Format("2006-01-02")
+		}
+	}
+	return p, nil
+}
+
+// runwayQuarters solves for the quarters n until burning liquidity at burn a
+// quarter, changing by growth each quarter, uses it up:
+// burn × ((1+growth)^n - 1) / growth = liquidity
+func runwayQuarters(liquidity, burn, growth float64) *float64 {
+	if burn <= 0 {
+		return nil
+	}
+	var n float64
+	switch {
+	case liquidity <= 0:
+		n = 0
+	case growth == 0:
+		n = liquidity / burn
+	default:
+		x := 1 + liquidity*growth/burn
+		if growth <= -1 || x <= 0 {
+			return nil // A shrinking burn that never adds up to liquidity
+		}
+		n = math.Log(x) / math.Log(1+growth)
+	}
+	return &n
+}
@@ -0,0 +1,109 @@
+package edgar
+
+import (
+	"math"
+	"os"
+	"testing"
+)
+
+func TestRunwayModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL: %v", err)
+	}
+	snapshots, err := x.GetSnapshots()
+	if err != nil {
+		t.Fatalf("GetSnapshots: %v", err)
+	}
+
+	r, err := Runway(snapshots, DefaultRunwayAssumptions)
+	if err != nil {
+		t.Fatalf("Runway: %v", err)
+	}
+	if r.AsOf != "2024-12-31" || r.BurnFrom != "2023-12-31" || r.BurnTo != "2024-12-31" || r.BurnMonths != 12 {
+		t.Errorf("periods = as of %s, burn %s to %s (%v months)", r.AsOf, r.BurnFrom, r.BurnTo, r.BurnMonths)
+	}
+	if r.ShortTermInvestments == nil || *r.ShortTermInvestments != 5.098e9 {
+		t.Errorf("ShortTermInvestments = %v, want 5.098e9", r.ShortTermInvestments)
+	}
+	want := (1.927e9 + 5.098e9) / (3.004e9 / 4)
+	if r.Quarters == nil || math.Abs(*r.Quarters-want) > 1e-9 {
+		t.Errorf("Quarters = %v, want %.4f", r.Quarters, want)
+	}
+	if r.CashOutDate != "2027-05-04" {
+		t.Errorf("CashOutDate = %s, want 2027-05-04", r.CashOutDate)
+	}
+
+	all, err := Runway(snapshots, RunwayAssumptions{IncludeLongTermInvestments: true})
+	if err != nil {
+		t.Fatalf("Runway: %v", err)
+	}
+	if all.Liquidity != *snapshots[0].TotalLiquidity {
+		t.Errorf("Liquidity with long-term investments = %v, want TotalLiquidity %v", all.Liquidity, *snapshots[0].TotalLiquidity)
+	}
+
+	cashOnly, err := Runway(snapshots, RunwayAssumptions{ExcludeInvestments: true, IncludeCapitalExpenditures: true})
+	if err != nil {
+		t.Fatalf("Runway: %v", err)
+	}
+	// Same as the snapshot's CashRunwayMonths, in quarters
+	if want := *snapshots[0].Ratios.CashRunwayMonths / 3; cashOnly.Quarters == nil || math.Abs(*cashOnly.Quarters-want) > 1e-9 {
+		t.Errorf("cash only Quarters = %v, want %.4f", cashOnly.Quarters, want)
+	}
+}
+
+func TestRunway(t *testing.T) {
+
+	// Q3 year to date overlaps Q1 and Q2, so the trailing year is Q3 YTD plus
+	// the prior 10-K (21 months)
+	quarters := []*FinancialSnapshot{
+		{FiscalYearEnd: "2024-03-31", FiscalPeriod: "Q1", Cash: ptrFloat(900), CashFlowOperations: ptrFloat(-100)},
+		{FiscalYearEnd: "2024-09-30", FiscalPeriod: "Q3", Cash: ptrFloat(600), ShortTermInvestments: ptrFloat(300), CashFlowOperations: ptrFloat(-270)},
+		{FiscalYearEnd: "2023-12-31", FiscalPeriod: "FY", Cash: ptrFloat(1000), CashFlowOperations: ptrFloat(-360)},
+		{FiscalYearEnd: "2024-06-30", FiscalPeriod: "Q2", Cash: ptrFloat(800), CashFlowOperations: ptrFloat(-180)},
+	}
+	r, err := Runway(quarters, DefaultRunwayAssumptions)
+	if err != nil {
+		t.Fatalf("Runway: %v", err)
+	}
+	if r.AsOf != "2024-09-30" || r.Liquidity != 900 || r.BurnMonths != 21 || r.BurnFrom != "2022-12-31" {
+		t.Errorf("projection = %+v", r)
+	}
+	if r.QuarterlyBurn != 90 || r.Quarters == nil || *r.Quarters != 10 {
+		t.Errorf("burn %v a quarter, %v quarters; want 90 and 10", r.QuarterlyBurn, r.Quarters)
+	}
+
+	// One quarter trailing: only the Q3 year to date
+	r, _ = Runway(quarters, RunwayAssumptions{TrailingQuarters: 1, MinimumCash: 450})
+	if r.BurnMonths != 9 || r.Liquidity != 450 || *r.Quarters != 5 {
+		t.Errorf("projection = %+v, %v quarters", r, *r.Quarters)
+	}
+
+	// Burn doubling each quarter: 90 + 180 + 360 + 720 = 1350
+	r, _ = Runway(quarters, RunwayAssumptions{BurnGrowth: 1, MinimumCash: -450})
+	if math.Abs(*r.Quarters-4) > 1e-9 {
+		t.Errorf("growing burn Quarters = %v, want 4", *r.Quarters)
+	}
+
+	// A burn halving each quarter never uses more than twice the first quarter's
+	r, _ = Runway(quarters, RunwayAssumptions{BurnGrowth: -0.5})
+	if r.Quarters != nil {
+		t.Errorf("shrinking burn Quarters = %v, want nil", *r.Quarters)
+	}
+
+	generating := []*FinancialSnapshot{{FiscalYearEnd: "2024-12-31", FiscalPeriod: "FY", Cash: ptrFloat(100), CashFlowOperations: ptrFloat(40)}}
+	if r, err := Runway(generating, DefaultRunwayAssumptions); err != nil || r.Quarters != nil || r.QuarterlyBurn != -10 {
+		t.Errorf("cash generating projection = %+v, %v", r, err)
+	}
+
+	if _, err := Runway([]*FinancialSnapshot{{FiscalYearEnd: "2024-12-31", FiscalPeriod: "FY", CashFlowOperations: ptrFloat(-40)}}, DefaultRunwayAssumptions); err == nil {
+		t.Error("Runway without cash should return error")
+	}
+	if _, err := Runway([]*FinancialSnapshot{{FiscalYearEnd: "2024-12-31", Cash: ptrFloat(100), CashFlowOperations: ptrFloat(-40)}}, DefaultRunwayAssumptions); err == nil {
+		t.Error("Runway without a known cash flow period should return error")
+	}
+}
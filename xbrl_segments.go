@@ -0,0 +1,125 @@
+package edgar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SegmentAxis is the dimension companies use to report their reportable segments (ASC 280)
+const SegmentAxis = "us-gaap:StatementBusinessSegmentsAxis"
+
+// BusinessSegment is revenue and operating income for one reportable segment
+type BusinessSegment struct {
+	Member          string   `json:"member"` // e.g. "abc:RetailMember"
+	Name            string   `json:"name"`   // Readable member name, e.g. "Retail"
+	StartDate       string   `json:"startDate"`
+	EndDate         string   `json:"endDate"`
+	Revenue         *float64 `json:"revenue"`         // nil when not reported for the segment
+	OperatingIncome *float64 `json:"operatingIncome"` // nil when not reported for the segment
+}
+
+// GetSegments returns revenue and operating income by reportable segment for the
+// latest period (latest end date, longest duration, so a 10-K's fiscal year)
+//
+// Only facts qualified by the segment axis alone are used; further breakdowns
+// (segment by product or geography) are skipped. Companies with a single
+// reportable segment often report no segment facts, in which case an error is
+// returned.
+func (x *XBRL) GetSegments() ([]BusinessSegment, error) {
+	var facts []Fact
+	for _, f := range x.Query().WithDimension(SegmentAxis, "").DurationOnly().Get() {
+		if len(f.Dimensions) != 1 || f.NumericValue == nil {
+			continue
+		}
+		if f.StandardLabel == "Revenue" || f.StandardLabel == "Operating Income (Loss)" {
+			facts = append(facts, f)
+		}
+	}
+	if len(facts) == 0 {
+		return nil, fmt.Errorf("no segment revenue or operating income found")
+	}
+
+	// Latest end date, then longest duration
+	period := *facts[0].Period
+	for _, f := range facts[1:] {
+		if f.Period.EndDate > period.EndDate ||
+			(f.Period.EndDate == period.EndDate && f.Period.StartDate < period.StartDate) {
+			period = *f.Period
+		}
+	}
+
+	type pick struct {
+		value    float64
+		priority int
+	}
+	bySegment := make(map[string]map[string]pick) // member -> label -> best fact
+	for _, f := range facts {
+		if *f.Period != period {
+			continue
+		}
+		member, _ := f.Member(SegmentAxis)
+		if bySegment[member] == nil {
+			bySegment[member] = make(map[string]pick)
+		}
+		// Several concepts map to one label; prefer the one listed first in the mappings
+		priority := x.mappings().priority(f.StandardLabel, f.Concept)
+		if current, ok := bySegment[member][f.StandardLabel]; !ok || priority < current.priority {
+			bySegment[member][f.StandardLabel] = pick{*f.NumericValue, priority}
+		}
+	}
+
+	var segments []BusinessSegment
+	for member, values := range bySegment {
+		seg := BusinessSegment{
+			Member:    member,
+			Name:      segmentName(member),
+			StartDate: period.StartDate,
+			EndDate:   period.EndDate,
+		}
+		if v, ok := values["Revenue"]; ok {
+			seg.Revenue = &v.value
+		}
+		if v, ok := values["Operating Income (Loss)"]; ok {
+			seg.OperatingIncome = &v.value
+		}
+		segments = append(segments, seg)
+	}
+
+	// Largest revenue first; segments without revenue last
+	sort.Slice(segments, func(i, j int) bool {
+		ri, rj := segments[i].Revenue, segments[j].Revenue
+		if (ri == nil) != (rj == nil) {
+			return ri != nil
+		}
+		if ri != nil && *ri != *rj {
+			return *ri > *rj
+		}
+		return segments[i].Member < segments[j].Member
+	})
+
+	return segments, nil
+}
+
+// segmentName turns a member QName into a readable name
+// e.g. "abc:ConsumerHealthcareMember" -> "Consumer Healthcare"
+func segmentName(member string) string {
+	name := member[strings.Index(member, ":")+1:]
+	return splitCamelCase(strings.TrimSuffix(name, "Member"))
+}
+
+// splitCamelCase separates the words of a CamelCase name, keeping acronyms together
+// e.g. "USMarketRevenue" -> "US Market Revenue"
+func splitCamelCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
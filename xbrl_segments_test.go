@@ -0,0 +1,88 @@
+package edgar
+
+import "testing"
+
+const segmentXBRL = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+  xmlns:us-gaap="http://fasb.org/us-gaap/2024" xmlns:abc="http://example.com/abc/2024">
+  <xbrli:context id="FY2024_Pharma">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:PharmaceuticalsMember</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="FY2024_CH">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:ConsumerHealthcareMember</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="Q4_Pharma">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:PharmaceuticalsMember</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-10-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="FY2023_Pharma">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment><xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:PharmaceuticalsMember</xbrldi:explicitMember></xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2023-01-01</xbrli:startDate><xbrli:endDate>2023-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="FY2024_Pharma_US">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:PharmaceuticalsMember</xbrldi:explicitMember>
+        <xbrldi:explicitMember dimension="srt:StatementGeographicalAxis">country:US</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <us-gaap:RevenueFromContractWithCustomerExcludingAssessedTax contextRef="FY2024_Pharma" unitRef="usd" decimals="0">790</us-gaap:RevenueFromContractWithCustomerExcludingAssessedTax>
+  <us-gaap:Revenues contextRef="FY2024_Pharma" unitRef="usd" decimals="0">800</us-gaap:Revenues>
+  <us-gaap:OperatingIncomeLoss contextRef="FY2024_Pharma" unitRef="usd" decimals="0">200</us-gaap:OperatingIncomeLoss>
+  <us-gaap:Revenues contextRef="FY2024_CH" unitRef="usd" decimals="0">300</us-gaap:Revenues>
+  <us-gaap:OperatingIncomeLoss contextRef="FY2024_CH" unitRef="usd" decimals="0">-15</us-gaap:OperatingIncomeLoss>
+  <us-gaap:Revenues contextRef="Q4_Pharma" unitRef="usd" decimals="0">210</us-gaap:Revenues>
+  <us-gaap:Revenues contextRef="FY2023_Pharma" unitRef="usd" decimals="0">700</us-gaap:Revenues>
+  <us-gaap:Revenues contextRef="FY2024_Pharma_US" unitRef="usd" decimals="0">500</us-gaap:Revenues>
+</xbrli:xbrl>`
+
+func TestGetSegments(t *testing.T) {
+	x, err := ParseXBRL([]byte(segmentXBRL))
+	if err != nil {
+		t.Fatalf("ParseXBRL: %v", err)
+	}
+
+	segments, err := x.GetSegments()
+	if err != nil {
+		t.Fatalf("GetSegments: %v", err)
+	}
+	if len(segments) != 2 {
+		t.Fatalf("got %d segments, want 2: %+v", len(segments), segments)
+	}
+
+	pharma, ch := segments[0], segments[1]
+	if pharma.Name != "Pharmaceuticals" || ch.Name != "Consumer Healthcare" {
+		t.Errorf("names = %q, %q", pharma.Name, ch.Name)
+	}
+	if pharma.StartDate != "2024-01-01" || pharma.EndDate != "2024-12-31" {
+		t.Errorf("period = %s to %s, want the FY2024 year", pharma.StartDate, pharma.EndDate)
+	}
+	// us-gaap:Revenues is mapped ahead of RevenueFromContractWithCustomer...; the
+	// US-only breakdown and the Q4 and FY2023 values are ignored
+	if pharma.Revenue == nil || *pharma.Revenue != 800 {
+		t.Errorf("pharma revenue = %v, want 800", pharma.Revenue)
+	}
+	if pharma.OperatingIncome == nil || *pharma.OperatingIncome != 200 {
+		t.Errorf("pharma operating income = %v, want 200", pharma.OperatingIncome)
+	}
+	if ch.Revenue == nil || *ch.Revenue != 300 || ch.OperatingIncome == nil || *ch.OperatingIncome != -15 {
+		t.Errorf("consumer healthcare = %+v", ch)
+	}
+
+	// Single-segment companies report no segment facts
+	if _, err := (&XBRL{}).GetSegments(); err == nil {
+		t.Error("expected an error without segment facts")
+	}
+}
@@ -0,0 +1,69 @@
+package edgar
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// snapshotColumn is one flattened FinancialSnapshot field, named by its JSON tag
+type snapshotColumn struct {
+	name    string
+	numeric bool  // float64 or *float64
+	index   []int // Field index path
+}
+
+// snapshotColumns returns the tabular view of FinancialSnapshot shared by the
+// CSV, Parquet and XLSX exports and CompareSnapshots. Columns follow the
+// struct's JSON field names and order, so the table tracks FinancialSnapshot
+// without a second list to maintain. Nested structs (Ratios) are flattened
+// into their fields' columns.
+func snapshotColumns() []snapshotColumn {
+	var columns []snapshotColumn
+	var addFields func(typ reflect.Type, path []int)
+	addFields = func(typ reflect.Type, path []int) {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			index := append(slices.Clone(path), i)
+			if field.Type.Kind() == reflect.Struct {
+				addFields(field.Type, index)
+				continue
+			}
+			typ := field.Type
+			if typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+			columns = append(columns, snapshotColumn{name: name, numeric: typ.Kind() == reflect.Float64, index: index})
+		}
+	}
+	addFields(reflect.TypeOf(FinancialSnapshot{}), nil)
+	return columns
+}
+
+// value returns the column's value for a snapshot: a float64 for numeric
+// columns, a string otherwise (slices joined with ";"), or nil when missing
+func (c snapshotColumn) value(snap *FinancialSnapshot) interface{} {
+	fv := reflect.ValueOf(snap).Elem().FieldByIndex(c.index)
+	switch fv.Kind() {
+	case reflect.Float64:
+		return fv.Float()
+	case reflect.Ptr:
+		if fv.IsNil() {
+			return nil
+		}
+		return fv.Elem().Interface()
+	case reflect.Slice:
+		parts := make([]string, fv.Len())
+		for k := range parts {
+			parts[k] = fmt.Sprint(fv.Index(k).Interface())
+		}
+		return strings.Join(parts, ";")
+	default:
+		return fmt.Sprint(fv.Interface())
+	}
+}
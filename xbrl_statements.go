@@ -0,0 +1,330 @@
+package edgar
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Presentation is one extended link role of a presentation linkbase: the line
+// items of a statement or note in the order the filer presents them
+type Presentation struct {
+	Role  string              // e.g. "http://www.modernatx.com/role/CONSOLIDATEDBALANCESHEETS"
+	Roots []*PresentationNode // Usually a single abstract root
+}
+
+// PresentationNode is a concept and its children in a Presentation
+type PresentationNode struct {
+	Concept        string
+	PreferredLabel string // Label role, e.g. ".../role/totalLabel" or ".../role/negatedLabel"
+	Children       []*PresentationNode
+}
+
+// StatementType identifies a face financial statement
+type StatementType string
+
+const (
+	BalanceSheet    StatementType = "balance_sheet"
+	IncomeStatement StatementType = "income_statement"
+	CashFlow        StatementType = "cash_flow"
+)
+
+// Statement is a financial statement rebuilt from the presentation linkbase
+type Statement struct {
+	Role    string           `json:"role"`
+	Type    StatementType    `json:"type,omitempty"` // Empty for notes and other roles
+	Periods []Period         `json:"periods"`        // Columns, most recent first
+	Lines   []*StatementLine `json:"lines"`
+}
+
+// StatementLine is one line item; values align with Statement.Periods
+type StatementLine struct {
+	Concept  string           `json:"concept"`
+	Label    string           `json:"label"`
+	Abstract bool             `json:"abstract,omitempty"` // Heading without values
+	Total    bool             `json:"total,omitempty"`    // Presented as a total
+	Negated  bool             `json:"negated,omitempty"`  // Presented with the opposite sign
+	Values   []*float64       `json:"values,omitempty"`   // nil where not reported
+	Children []*StatementLine `json:"children,omitempty"`
+}
+
+type presentationLinkbase struct {
+	Links []struct {
+		Role string `xml:"role,attr"`
+		Locs []struct {
+			Href  string `xml:"href,attr"`
+			Label string `xml:"label,attr"`
+		} `xml:"loc"`
+		Arcs []struct {
+			From           string `xml:"from,attr"`
+			To             string `xml:"to,attr"`
+			Order          string `xml:"order,attr"`
+			PreferredLabel string `xml:"preferredLabel,attr"`
+		} `xml:"presentationArc"`
+	} `xml:"presentationLink"`
+}
+
+// PresentationLinkbaseURL returns the presentation linkbase of a filing from the
+// URL of its XBRL instance or inline XBRL document, following SEC file naming
+// e.g. ".../mrna-20241231.htm" -> ".../mrna-20241231_pre.xml"
+func PresentationLinkbaseURL(instanceURL string) string {
+	return strings.TrimSuffix(LabelLinkbaseURL(instanceURL), "_lab.xml") + "_pre.xml"
+}
+
+// FetchPresentationLinkbase fetches and parses a presentation linkbase
+func FetchPresentationLinkbase(url string, email string) ([]Presentation, error) {
+	return defaultClient(email).FetchPresentationLinkbase(url)
+}
+
+// FetchPresentationLinkbase fetches and parses a presentation linkbase
+func (c *Client) FetchPresentationLinkbase(url string) ([]Presentation, error) {
+	body, err := c.get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch presentation linkbase: %w", err)
+	}
+	defer body.Close()
+	return ParsePresentationLinkbase(body)
+}
+
+// ParsePresentationLinkbase parses a presentation linkbase into one ordered
+// tree of concepts per role
+func ParsePresentationLinkbase(r io.Reader) ([]Presentation, error) {
+	var lb presentationLinkbase
+	if err := xml.NewDecoder(r).Decode(&lb); err != nil {
+		return nil, fmt.Errorf("failed to parse presentation linkbase: %w", err)
+	}
+
+	var presentations []Presentation
+	for _, link := range lb.Links {
+		concepts := make(map[string]string) // Locator label -> concept
+		for _, loc := range link.Locs {
+			concepts[loc.Label] = conceptFromHref(loc.Href)
+		}
+
+		type child struct {
+			node  *PresentationNode
+			order float64
+		}
+		nodes := make(map[string]*PresentationNode) // Locator label -> node
+		children := make(map[string][]child)
+		hasParent := make(map[string]bool)
+		var order []string                            // Locator labels in document order
+		aliases := make(map[*PresentationNode]string) // Node for a preferred label -> locator label
+
+		for _, arc := range link.Arcs {
+			if concepts[arc.From] == "" || concepts[arc.To] == "" {
+				continue
+			}
+			for _, label := range []string{arc.From, arc.To} {
+				if nodes[label] == nil {
+					nodes[label] = &PresentationNode{Concept: concepts[label]}
+					order = append(order, label)
+				}
+			}
+			// The preferred label belongs to the arc, so it gets its own node
+			// sharing the concept's children
+			to := nodes[arc.To]
+			if arc.PreferredLabel != "" {
+				to = &PresentationNode{Concept: to.Concept, PreferredLabel: arc.PreferredLabel}
+				aliases[to] = arc.To
+			}
+			n, _ := strconv.ParseFloat(arc.Order, 64)
+			children[arc.From] = append(children[arc.From], child{to, n})
+			hasParent[arc.To] = true
+		}
+
+		for label, kids := range children {
+			sort.SliceStable(kids, func(i, j int) bool { return kids[i].order < kids[j].order })
+			for _, k := range kids {
+				nodes[label].Children = append(nodes[label].Children, k.node)
+			}
+		}
+		for alias, label := range aliases {
+			alias.Children = nodes[label].Children
+		}
+
+		p := Presentation{Role: link.Role}
+		for _, label := range order {
+			if !hasParent[label] {
+				p.Roots = append(p.Roots, nodes[label])
+			}
+		}
+		if len(p.Roots) > 0 {
+			presentations = append(presentations, p)
+		}
+	}
+	return presentations, nil
+}
+
+// GetStatements rebuilds every role of x.Presentations as a statement with the
+// entity-wide values of each line item by period
+func (x *XBRL) GetStatements() ([]Statement, error) {
+	if len(x.Presentations) == 0 {
+		return nil, fmt.Errorf("no presentation linkbase loaded")
+	}
+
+	// Entity-wide facts by concept
+	facts := make(map[string][]*Fact)
+	for i := range x.Facts {
+		f := &x.Facts[i]
+		if f.Period != nil && !f.IsDimensional() {
+			facts[f.Concept] = append(facts[f.Concept], f)
+		}
+	}
+
+	var statements []Statement
+	for _, p := range x.Presentations {
+		s := Statement{Role: p.Role, Type: statementType(p.Role)}
+
+		// Columns: every period reported for a line item of the statement
+		seen := make(map[Period]bool)
+		visited := make(map[*PresentationNode]bool)
+		var walk func(nodes []*PresentationNode)
+		walk = func(nodes []*PresentationNode) {
+			for _, n := range nodes {
+				if visited[n] {
+					continue
+				}
+				visited[n] = true
+				for _, f := range facts[n.Concept] {
+					if f.NumericValue != nil && !seen[*f.Period] {
+						seen[*f.Period] = true
+						s.Periods = append(s.Periods, *f.Period)
+					}
+				}
+				walk(n.Children)
+			}
+		}
+		walk(p.Roots)
+		sortPeriods(s.Periods)
+
+		for _, root := range p.Roots {
+			s.Lines = append(s.Lines, buildStatementLine(root, s.Periods, facts, make(map[*PresentationNode]bool)))
+		}
+		statements = append(statements, s)
+	}
+	return statements, nil
+}
+
+// GetStatement returns the face statement of the given type (the first matching
+// role that is not a parenthetical)
+func (x *XBRL) GetStatement(t StatementType) (*Statement, error) {
+	statements, err := x.GetStatements()
+	if err != nil {
+		return nil, err
+	}
+	for i := range statements {
+		if statements[i].Type == t {
+			return &statements[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no %s found in presentation linkbase", t)
+}
+
+func buildStatementLine(n *PresentationNode, periods []Period, facts map[string][]*Fact, visiting map[*PresentationNode]bool) *StatementLine {
+	line := &StatementLine{
+		Concept:  n.Concept,
+		Label:    conceptTitle(n.Concept),
+		Abstract: isStructuralConcept(n.Concept),
+		Total:    strings.HasSuffix(n.PreferredLabel, "/totalLabel"),
+		Negated:  strings.Contains(n.PreferredLabel, "/negated"),
+	}
+	if line.Abstract {
+		return buildStatementChildren(line, n, periods, facts, visiting)
+	}
+
+	// Taxonomy label (as on the statement) when loaded, else the standardized label
+	var taxonomyLabel, standardLabel string
+	line.Values = make([]*float64, len(periods))
+	for _, f := range facts[n.Concept] {
+		if f.Label != "" {
+			taxonomyLabel = f.Label
+		}
+		if f.StandardLabel != "" {
+			standardLabel = f.StandardLabel
+		}
+		if f.NumericValue == nil {
+			continue
+		}
+		for i, p := range periods {
+			if *f.Period == p && line.Values[i] == nil {
+				line.Values[i] = f.NumericValue
+			}
+		}
+	}
+	if taxonomyLabel != "" {
+		line.Label = taxonomyLabel
+	} else if standardLabel != "" {
+		line.Label = standardLabel
+	}
+	return buildStatementChildren(line, n, periods, facts, visiting)
+}
+
+func buildStatementChildren(line *StatementLine, n *PresentationNode, periods []Period, facts map[string][]*Fact, visiting map[*PresentationNode]bool) *StatementLine {
+	// Guard against cycles in malformed linkbases
+	visiting[n] = true
+	for _, child := range n.Children {
+		if !visiting[child] {
+			line.Children = append(line.Children, buildStatementLine(child, periods, facts, visiting))
+		}
+	}
+	delete(visiting, n)
+	return line
+}
+
+// statementType classifies a role URI as one of the face statements
+// e.g. ".../role/CONSOLIDATEDSTATEMENTSOFOPERATIONS" -> IncomeStatement
+func statementType(role string) StatementType {
+	name := strings.ToUpper(role[strings.LastIndex(role, "/")+1:])
+	if strings.Contains(name, "PARENTHETICAL") || strings.Contains(name, "DETAIL") ||
+		strings.Contains(name, "POLICIES") || strings.Contains(name, "TABLES") {
+		return ""
+	}
+	switch {
+	case strings.Contains(name, "BALANCESHEET"),
+		strings.Contains(name, "STATEMENT") && strings.Contains(name, "FINANCIALPOSITION"):
+		return BalanceSheet
+	case !strings.Contains(name, "STATEMENT"):
+		return "" // Notes, e.g. "INCOMETAXES"
+	case strings.Contains(name, "CASHFLOW"):
+		return CashFlow
+	case strings.Contains(name, "COMPREHENSIVE") && !strings.Contains(name, "OPERATIONS"):
+		return ""
+	case strings.Contains(name, "OPERATIONS") || strings.Contains(name, "INCOME") ||
+		strings.Contains(name, "EARNINGS") || strings.Contains(name, "LOSS"):
+		return IncomeStatement
+	}
+	return ""
+}
+
+// isStructuralConcept reports whether a concept only organizes a statement
+// (headings, tables, axes, members) and has no values of its own
+func isStructuralConcept(concept string) bool {
+	for _, suffix := range []string{"Abstract", "Table", "Axis", "Domain", "Member", "LineItems"} {
+		if strings.HasSuffix(concept, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortPeriods orders periods by end date (latest first), then longest duration first
+func sortPeriods(periods []Period) {
+	end := func(p Period) string { return p.EndDate + p.Instant }
+	sort.SliceStable(periods, func(i, j int) bool {
+		if end(periods[i]) != end(periods[j]) {
+			return end(periods[i]) > end(periods[j])
+		}
+		return periods[i].StartDate < periods[j].StartDate
+	})
+}
+
+// conceptTitle turns a concept name into a readable title
+// e.g. "us-gaap:AssetsCurrent" -> "Assets Current"
+func conceptTitle(concept string) string {
+	name := concept[strings.Index(concept, ":")+1:]
+	return splitCamelCase(strings.TrimSuffix(name, "Abstract"))
+}
@@ -0,0 +1,96 @@
+package edgar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const testPresentationLinkbase = `<?xml version="1.0" encoding="utf-8"?>
+<link:linkbase xmlns:link="http://www.xbrl.org/2003/linkbase" xmlns:xlink="http://www.w3.org/1999/xlink">
+  <link:presentationLink xlink:type="extended" xlink:role="http://www.modernatx.com/role/CONSOLIDATEDBALANCESHEETS">
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_StatementOfFinancialPositionAbstract" xlink:label="loc_root"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_AssetsAbstract" xlink:label="loc_assetsAbstract"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_CashAndCashEquivalentsAtCarryingValue" xlink:label="loc_cash"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_Assets" xlink:label="loc_assets"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_LiabilitiesAndStockholdersEquity" xlink:label="loc_le"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_root" xlink:to="loc_le" order="2"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_root" xlink:to="loc_assetsAbstract" order="1"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_assetsAbstract" xlink:to="loc_assets" order="2" preferredLabel="http://www.xbrl.org/2003/role/totalLabel"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_assetsAbstract" xlink:to="loc_cash" order="1"/>
+  </link:presentationLink>
+  <link:presentationLink xlink:type="extended" xlink:role="http://www.modernatx.com/role/CONSOLIDATEDSTATEMENTSOFOPERATIONS">
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_IncomeStatementAbstract" xlink:label="loc_root"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_ResearchAndDevelopmentExpense" xlink:label="loc_rd"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_NetIncomeLoss" xlink:label="loc_ni"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_root" xlink:to="loc_rd" order="1"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_root" xlink:to="loc_ni" order="2"/>
+  </link:presentationLink>
+  <link:presentationLink xlink:type="extended" xlink:role="http://www.modernatx.com/role/INCOMETAXES">
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_IncomeTaxDisclosureAbstract" xlink:label="loc_root"/>
+    <link:loc xlink:type="locator" xlink:href="us-gaap-2024.xsd#us-gaap_IncomeTaxDisclosureTextBlock" xlink:label="loc_note"/>
+    <link:presentationArc xlink:type="arc" xlink:arcrole="http://www.xbrl.org/2003/arcrole/parent-child" xlink:from="loc_root" xlink:to="loc_note" order="1"/>
+  </link:presentationLink>
+</link:linkbase>`
+
+func TestGetStatements(t *testing.T) {
+	presentations, err := ParsePresentationLinkbase(strings.NewReader(testPresentationLinkbase))
+	if err != nil {
+		t.Fatalf("ParsePresentationLinkbase: %v", err)
+	}
+	if len(presentations) != 3 {
+		t.Fatalf("got %d presentations, want 3", len(presentations))
+	}
+
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	if _, err := x.GetStatements(); err == nil {
+		t.Error("expected an error without a presentation linkbase")
+	}
+	x.Presentations = presentations
+
+	bs, err := x.GetStatement(BalanceSheet)
+	if err != nil {
+		t.Fatalf("GetStatement(BalanceSheet): %v", err)
+	}
+	if len(bs.Periods) == 0 || bs.Periods[0].Instant != "2024-12-31" {
+		t.Fatalf("periods = %+v, want 2024-12-31 first", bs.Periods)
+	}
+
+	// Root heading, then the assets section in presentation order
+	if len(bs.Lines) != 1 || len(bs.Lines[0].Children) != 2 {
+		t.Fatalf("lines = %+v", bs.Lines)
+	}
+	assets := bs.Lines[0].Children[0]
+	if !assets.Abstract || assets.Label != "Assets" || len(assets.Children) != 2 {
+		t.Fatalf("assets section = %+v", assets)
+	}
+	cash, total := assets.Children[0], assets.Children[1]
+	if cash.Label != "Cash and Cash Equivalents" || cash.Values[0] == nil || *cash.Values[0] != 1_927_000_000 {
+		t.Errorf("cash line = %+v", cash)
+	}
+	if total.Concept != "us-gaap:Assets" || !total.Total || total.Values[0] == nil {
+		t.Errorf("total assets line = %+v", total)
+	}
+
+	is, err := x.GetStatement(IncomeStatement)
+	if err != nil {
+		t.Fatalf("GetStatement(IncomeStatement): %v", err)
+	}
+	if p := is.Periods[0]; p.StartDate != "2024-01-01" || p.EndDate != "2024-12-31" {
+		t.Errorf("income statement period = %+v, want FY2024", p)
+	}
+	if ni := is.Lines[0].Children[1]; ni.Values[0] == nil || *ni.Values[0] >= 0 {
+		t.Errorf("net income line = %+v, want the FY2024 loss", ni)
+	}
+
+	if _, err := x.GetStatement(CashFlow); err == nil {
+		t.Error("expected no cash flow statement")
+	}
+}
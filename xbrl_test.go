@@ -3,7 +3,9 @@ package edgar
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"slices"
 	"testing"
 )
 
@@ -107,7 +109,7 @@ func TestParseInlineXBRL_Moderna(t *testing.T) {
 	t.Logf("%-30s %20s", "R&D Expense", formatCurrency(snapshot.RDExpense))
 	t.Logf("%-30s %20s", "G&A Expense", formatCurrency(snapshot.GAExpense))
 	t.Logf("%-30s %20s", "Cash Flow from Ops", formatCurrency(snapshot.CashFlowOperations))
-	t.Logf("%-30s %20.1fM", "Diluted Shares", snapshot.DilutedShares/1_000_000)
+	t.Logf("%-30s %20.1fM", "Diluted Shares", value(snapshot.DilutedShares)/1_000_000)
 
 	// Report missing required fields
 	if len(snapshot.MissingRequiredFields) > 0 {
@@ -118,20 +120,40 @@ func TestParseInlineXBRL_Moderna(t *testing.T) {
 	}
 
 	// Basic sanity checks
-	if snapshot.Cash <= 0 {
+	if value(snapshot.Cash) <= 0 {
 		t.Errorf("Cash should be positive, got: %s", formatCurrency(snapshot.Cash))
 	}
-	if snapshot.RDExpense <= 0 {
+	// Most of Moderna's liquidity is in marketable securities
+	if value(snapshot.ShortTermInvestments) != 5_098_000_000 || value(snapshot.LongTermInvestments) != 2_494_000_000 || value(snapshot.TotalLiquidity) != 9_519_000_000 {
+		t.Errorf("investments = %s short-term, %s long-term, %s total liquidity", formatCurrency(snapshot.ShortTermInvestments),
+			formatCurrency(snapshot.LongTermInvestments), formatCurrency(snapshot.TotalLiquidity))
+	}
+	if value(snapshot.RDExpense) <= 0 {
 		t.Errorf("R&D should be positive, got: %s", formatCurrency(snapshot.RDExpense))
 	}
-	if snapshot.TotalAssets <= 0 {
+	if value(snapshot.EntitySharesOutstanding) != 385_815_877 || value(snapshot.PublicFloat) != 42_100_000_000 {
+		t.Errorf("cover page = %.0f shares, %s float", value(snapshot.EntitySharesOutstanding), formatCurrency(snapshot.PublicFloat))
+	}
+	if value(snapshot.NetIncome) >= 0 {
+		// Moderna reported a net loss; the iXBRL shows it unsigned with sign="-"
+		t.Errorf("Net income should be a loss, got: %s", formatCurrency(snapshot.NetIncome))
+	}
+	if value(snapshot.Revenue) < 3_000_000_000 {
+		// Product line breakdowns (srt:ProductOrServiceAxis) must not replace the total
+		t.Errorf("Revenue should be the FY2024 total (~$3.2B), got: %s", formatCurrency(snapshot.Revenue))
+	}
+	if value(snapshot.TotalAssets) <= 0 {
 		t.Errorf("Total Assets should be positive, got: %s", formatCurrency(snapshot.TotalAssets))
 	}
 
 	t.Log("\n✅ All validations passed")
 }
 
-func formatCurrency(value float64) string {
+func formatCurrency(v *float64) string {
+	if v == nil {
+		return "n/a"
+	}
+	value := *v
 	if value == 0 {
 		return "$0"
 	}
@@ -139,12 +161,484 @@ func formatCurrency(value float64) string {
 	billions := value / 1_000_000_000
 	millions := value / 1_000_000
 
-	if billions >= 1 {
+	if math.Abs(billions) >= 1 {
 		return fmt.Sprintf("$%.2fB", billions)
 	}
 	return fmt.Sprintf("$%.1fM", millions)
 }
 
+// value returns a snapshot metric, or 0 when it is not reported
+func value(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func TestParseInlineXBRLReader(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	want, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL: %v", err)
+	}
+
+	// Stream from the file without loading it first
+	f, err := os.Open("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to open Moderna 10-K: %v", err)
+	}
+	defer f.Close()
+
+	got, err := ParseInlineXBRLReader(f)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL from reader: %v", err)
+	}
+
+	if len(got.Contexts) != len(want.Contexts) || len(got.Units) != len(want.Units) || len(got.Facts) != len(want.Facts) {
+		t.Errorf("Reader parse mismatch: got %d/%d/%d contexts/units/facts, want %d/%d/%d",
+			len(got.Contexts), len(got.Units), len(got.Facts),
+			len(want.Contexts), len(want.Units), len(want.Facts))
+	}
+}
+
+func TestSnapshotDEIMetadata(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL: %v", err)
+	}
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+
+	if snapshot.Ticker != "MRNA" || snapshot.Exchange != "NASDAQ" {
+		t.Errorf("Ticker/Exchange = %q/%q, want MRNA/NASDAQ", snapshot.Ticker, snapshot.Exchange)
+	}
+	if snapshot.FiscalYear != 2024 || snapshot.FiscalYearEndMonthDay != "12-31" {
+		t.Errorf("FiscalYear = %d, FiscalYearEndMonthDay = %q, want 2024, 12-31", snapshot.FiscalYear, snapshot.FiscalYearEndMonthDay)
+	}
+	if snapshot.IsAmendment {
+		t.Error("IsAmendment = true for an original 10-K")
+	}
+	if value(snapshot.PublicFloat) != 42.1e9 || value(snapshot.EntitySharesOutstanding) != 385815877 {
+		t.Errorf("PublicFloat = %s, EntitySharesOutstanding = %v", formatCurrency(snapshot.PublicFloat), value(snapshot.EntitySharesOutstanding))
+	}
+
+	// Carried over to prior-period snapshots
+	snapshots, err := x.GetSnapshots()
+	if err != nil {
+		t.Fatalf("GetSnapshots: %v", err)
+	}
+	if prior := snapshots[len(snapshots)-1]; prior.Ticker != "MRNA" || prior.FiscalYearEndMonthDay != "12-31" {
+		t.Errorf("prior snapshot Ticker = %q, FiscalYearEndMonthDay = %q", prior.Ticker, prior.FiscalYearEndMonthDay)
+	}
+
+	// An amendment, with the exchange of the symbol's class of security
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:ixt="http://www.xbrl.org/inlineXBRL/transformation/2020-02-12" xmlns:dei="http://xbrl.sec.gov/dei/2024"><body>
+<ix:nonNumeric name="dei:AmendmentFlag" contextRef="c-1" format="ixt:fixed-true">Yes</ix:nonNumeric>
+<ix:nonNumeric name="dei:SecurityExchangeName" contextRef="c-2">NYSE</ix:nonNumeric>
+<ix:nonNumeric name="dei:TradingSymbol" contextRef="c-3">abc</ix:nonNumeric>
+<ix:nonNumeric name="dei:SecurityExchangeName" contextRef="c-3">NASDAQ</ix:nonNumeric>
+</body></html>`
+	x, err = ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	var amended FinancialSnapshot
+	extractMetadata(x, &amended)
+	if !amended.IsAmendment || amended.Ticker != "ABC" || amended.Exchange != "NASDAQ" {
+		t.Errorf("IsAmendment = %v, Ticker = %q, Exchange = %q; want true, ABC, NASDAQ", amended.IsAmendment, amended.Ticker, amended.Exchange)
+	}
+}
+
+func TestSnapshotZeroVersusMissing(t *testing.T) {
+	year := &Period{StartDate: "2024-01-01", EndDate: "2024-12-31"}
+	zero, loss := 0.0, -50e6
+	x := &XBRL{Facts: []Fact{
+		{Concept: "us-gaap:Revenues", StandardLabel: "Revenue", Period: year, NumericValue: &zero},
+		{Concept: "us-gaap:NetIncomeLoss", StandardLabel: "Net Income (Loss)", Period: year, NumericValue: &loss},
+	}}
+
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if snapshot.Revenue == nil || *snapshot.Revenue != 0 {
+		t.Errorf("Revenue = %s, want a reported $0", formatCurrency(snapshot.Revenue))
+	}
+	if snapshot.Cash != nil || snapshot.TotalDebt != nil {
+		t.Errorf("unreported Cash = %s, TotalDebt = %s, want nil", formatCurrency(snapshot.Cash), formatCurrency(snapshot.TotalDebt))
+	}
+	for _, field := range snapshot.MissingRequiredFields {
+		if field == "Revenue" || field == "Net Income (Loss)" {
+			t.Errorf("%s reported but flagged missing", field)
+		}
+	}
+	if !slices.Contains(snapshot.MissingRequiredFields, "Total Assets") {
+		t.Errorf("MissingRequiredFields = %v, want Total Assets", snapshot.MissingRequiredFields)
+	}
+}
+
+func TestGetSnapshotsModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse iXBRL: %v", err)
+	}
+
+	snapshots, err := x.GetSnapshots()
+	if err != nil {
+		t.Fatalf("GetSnapshots: %v", err)
+	}
+	// The 10-K presents three fiscal years of operations
+	if len(snapshots) != 3 {
+		t.Fatalf("got %d snapshots, want FY2024, FY2023 and FY2022", len(snapshots))
+	}
+
+	tests := []struct {
+		fiscalYearEnd string
+		revenue       float64
+		netIncome     float64
+		cash          float64
+	}{
+		{"2024-12-31", 3_236_000_000, -3_561_000_000, 1_927_000_000},
+		{"2023-12-31", 6_848_000_000, -4_714_000_000, 2_907_000_000},
+		{"2022-12-31", 19_263_000_000, 8_362_000_000, 3_205_000_000},
+	}
+	for i, tt := range tests {
+		s := snapshots[i]
+		if s.FiscalYearEnd != tt.fiscalYearEnd || value(s.Revenue) != tt.revenue || value(s.NetIncome) != tt.netIncome || value(s.Cash) != tt.cash {
+			t.Errorf("snapshot %d = %s revenue %s, net income %s, cash %s; want %s %s, %s, %s", i,
+				s.FiscalYearEnd, formatCurrency(s.Revenue), formatCurrency(s.NetIncome), formatCurrency(s.Cash),
+				tt.fiscalYearEnd, formatCurrency(&tt.revenue), formatCurrency(&tt.netIncome), formatCurrency(&tt.cash))
+		}
+		if s.CompanyName != "Moderna, Inc." || s.FiscalPeriod != "FY" {
+			t.Errorf("snapshot %d metadata = %q %q", i, s.CompanyName, s.FiscalPeriod)
+		}
+	}
+
+	// Cover page values belong to the current period; FY2022 has no balance sheet
+	if snapshots[0].EntitySharesOutstanding == nil || snapshots[1].EntitySharesOutstanding != nil {
+		t.Error("cover page values should only be on the most recent snapshot")
+	}
+	if snapshots[2].TotalAssets != nil {
+		t.Errorf("FY2022 total assets = %v, want none (not presented)", *snapshots[2].TotalAssets)
+	}
+}
+
+func TestGetSnapshotForPeriodModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	xbrl, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	fy2023, err := xbrl.GetSnapshotFiscalYear(2023)
+	if err != nil {
+		t.Fatalf("GetSnapshotFiscalYear(2023): %v", err)
+	}
+	if fy2023.FiscalYearEnd != "2023-12-31" || value(fy2023.Revenue) != 6_848_000_000 || value(fy2023.Cash) != 2_907_000_000 {
+		t.Errorf("FY2023 = %s revenue %s, cash %s", fy2023.FiscalYearEnd, formatCurrency(fy2023.Revenue), formatCurrency(fy2023.Cash))
+	}
+	if fy2023.EntitySharesOutstanding != nil {
+		t.Error("cover page values should only be on the current period")
+	}
+
+	fy2022, err := xbrl.GetSnapshotForPeriod("2022-12-31")
+	if err != nil {
+		t.Fatalf("GetSnapshotForPeriod(2022-12-31): %v", err)
+	}
+	if value(fy2022.NetIncome) != 8_362_000_000 {
+		t.Errorf("FY2022 net income = %s, want $8.36B", formatCurrency(fy2022.NetIncome))
+	}
+
+	current, err := xbrl.GetSnapshotForPeriod("2024-12-31")
+	if err != nil {
+		t.Fatalf("GetSnapshotForPeriod(2024-12-31): %v", err)
+	}
+	if value(current.Revenue) != 3_236_000_000 || current.EntitySharesOutstanding == nil {
+		t.Errorf("FY2024 = revenue %s, cover shares %s", formatCurrency(current.Revenue), formatCurrency(current.EntitySharesOutstanding))
+	}
+
+	if _, err := xbrl.GetSnapshotFiscalYear(2019); err == nil {
+		t.Error("GetSnapshotFiscalYear(2019) should return error")
+	}
+	if _, err := xbrl.GetSnapshotForPeriod("2024-06-15"); err == nil {
+		t.Error("GetSnapshotForPeriod(no values) should return error")
+	}
+	if _, err := xbrl.GetSnapshotForPeriod("12/31/2024"); err == nil {
+		t.Error("GetSnapshotForPeriod(invalid date) should return error")
+	}
+}
+
+func TestGetSnapshotForPeriodBalanceSheetOnly(t *testing.T) {
+	quarter := &Period{StartDate: "2024-01-01", EndDate: "2024-03-31"}
+	q1End, yearEnd := &Period{Instant: "2024-03-31"}, &Period{Instant: "2023-12-31"}
+	fact := func(concept, label string, period *Period, v float64) Fact {
+		return Fact{Concept: concept, StandardLabel: label, Period: period, NumericValue: &v}
+	}
+	x := &XBRL{Facts: []Fact{
+		{Concept: "dei:DocumentType", Value: "10-Q", Period: quarter},
+		fact("us-gaap:Revenues", "Revenue", quarter, 100),
+		fact("us-gaap:Assets", "Total Assets", q1End, 1000),
+		fact("us-gaap:Assets", "Total Assets", yearEnd, 900),
+	}}
+
+	s, err := x.GetSnapshotForPeriod("2023-12-31")
+	if err != nil {
+		t.Fatalf("GetSnapshotForPeriod: %v", err)
+	}
+	if value(s.TotalAssets) != 900 || s.Revenue != nil {
+		t.Errorf("prior year end = total assets %v, revenue %v; want 900 and none", value(s.TotalAssets), s.Revenue)
+	}
+}
+
+const dimensionalXBRL = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+  xmlns:us-gaap="http://fasb.org/us-gaap/2024" xmlns:abc="http://example.com/abc/2024">
+  <xbrli:context id="FY2024">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="FY2024_Retail">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:RetailMember</xbrldi:explicitMember>
+        <xbrldi:explicitMember dimension="srt:StatementGeographicalAxis">country:US</xbrldi:explicitMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <xbrli:context id="FY2024_Wholesale">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+    <xbrli:scenario>
+      <xbrldi:explicitMember dimension="us-gaap:StatementBusinessSegmentsAxis">abc:WholesaleMember</xbrldi:explicitMember>
+    </xbrli:scenario>
+  </xbrli:context>
+  <xbrli:context id="Lease3">
+    <xbrli:entity>
+      <xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+      <xbrli:segment>
+        <xbrldi:typedMember dimension="us-gaap:LeaseIdentifierAxis"><abc:LeaseDomain> Building 3 </abc:LeaseDomain></xbrldi:typedMember>
+      </xbrli:segment>
+    </xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period>
+  </xbrli:context>
+  <us-gaap:Revenues contextRef="FY2024" unitRef="usd" decimals="0">1000</us-gaap:Revenues>
+  <us-gaap:Revenues contextRef="FY2024_Retail" unitRef="usd" decimals="0">600</us-gaap:Revenues>
+  <us-gaap:Revenues contextRef="FY2024_Wholesale" unitRef="usd" decimals="0">400</us-gaap:Revenues>
+  <us-gaap:OperatingLeaseLiability contextRef="Lease3" unitRef="usd" decimals="0">250</us-gaap:OperatingLeaseLiability>
+</xbrli:xbrl>`
+
+func TestXBRLDimensions(t *testing.T) {
+	x, err := ParseXBRL([]byte(dimensionalXBRL))
+	if err != nil {
+		t.Fatalf("ParseXBRL: %v", err)
+	}
+
+	byContext := make(map[string]Fact)
+	for _, f := range x.Facts {
+		byContext[f.ContextRef] = f
+	}
+
+	if total := byContext["FY2024"]; total.IsDimensional() {
+		t.Errorf("entity-wide fact has dimensions: %+v", total.Dimensions)
+	}
+	retail := byContext["FY2024_Retail"]
+	if m, ok := retail.Member("us-gaap:StatementBusinessSegmentsAxis"); !ok || m != "abc:RetailMember" {
+		t.Errorf("retail segment member = %q, %v", m, ok)
+	}
+	if len(retail.Dimensions) != 2 {
+		t.Errorf("retail dimensions = %+v, want segment and geography", retail.Dimensions)
+	}
+	wholesale := byContext["FY2024_Wholesale"]
+	if m, _ := wholesale.Member("us-gaap:StatementBusinessSegmentsAxis"); m != "abc:WholesaleMember" {
+		t.Errorf("scenario member = %q", m)
+	}
+	lease := byContext["Lease3"].Dimensions
+	if len(lease) != 1 || !lease[0].Typed || lease[0].Member != "Building 3" {
+		t.Errorf("typed member = %+v", lease)
+	}
+
+	// Totals only by default
+	if sum, _ := x.Query().ByConcept("us-gaap:Revenues").Sum(); sum != 1000 {
+		t.Errorf("default query sum = %v, want 1000 (total only)", sum)
+	}
+	if n := len(x.Query().ByConcept("us-gaap:Revenues").IncludeDimensional().Get()); n != 3 {
+		t.Errorf("IncludeDimensional returned %d facts, want 3", n)
+	}
+	if sum, _ := x.Query().ByConcept("us-gaap:Revenues").WithDimension("us-gaap:StatementBusinessSegmentsAxis", "").Sum(); sum != 1000 {
+		t.Errorf("segment breakdown sum = %v, want 1000", sum)
+	}
+	fact, err := x.Query().ByConcept("us-gaap:Revenues").WithDimension("us-gaap:StatementBusinessSegmentsAxis", "abc:RetailMember").First()
+	if err != nil || fact.Value != "600" {
+		t.Errorf("retail revenue = %v, %v", fact, err)
+	}
+}
+
+func TestInlineXBRLContinuations(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:us-gaap="http://fasb.org/us-gaap/2024">
+<body>
+<ix:header><ix:resources>
+  <xbrli:context id="c-1"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+</ix:resources></ix:header>
+<ix:nonNumeric name="us-gaap:DebtDisclosureTextBlock" contextRef="c-1" id="f-1" continuedAt="f-1-1" escape="true">
+  <div><span>Note 7. Debt</span></div>
+  <p>We repaid <ix:nonFraction name="us-gaap:RepaymentsOfDebt" contextRef="c-1" unitRef="usd" decimals="-6" scale="6">125</ix:nonFraction> million of notes.</p>
+</ix:nonNumeric>
+<div>Page 41<ix:continuation id="f-1-1" continuedAt="f-1-2"><p>The notes bear interest at 4%.</p><ix:exclude><p>Page 42</p></ix:exclude></ix:continuation></div>
+<ix:continuation id="f-1-2"><p>No covenants were breached.</p></ix:continuation>
+</body></html>`
+
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	if len(x.Facts) != 2 {
+		t.Fatalf("got %d facts, want the text block and its nested value", len(x.Facts))
+	}
+
+	want := "Note 7. Debt\nWe repaid 125 million of notes.\nThe notes bear interest at 4%.\nNo covenants were breached."
+	if x.Facts[0].Value != want {
+		t.Errorf("text block = %q, want %q", x.Facts[0].Value, want)
+	}
+	if x.Facts[1].Concept != "us-gaap:RepaymentsOfDebt" || x.Facts[1].Value != "125000000" {
+		t.Errorf("nested fact = %s %q", x.Facts[1].Concept, x.Facts[1].Value)
+	}
+}
+
+func TestInlineXBRLTransformations(t *testing.T) {
+	tests := []struct {
+		format, text, want string
+	}{
+		{"ixt:num-dot-decimal", "1,234.50", "1234.50"},
+		{"ixt:numdotdecimal", "12 345", "12345"},
+		{"ixt:num-comma-decimal", "1.234,5", "1234.5"},
+		{"ixt:num-unit-decimal", "5 dollars and 50 cents", "5.50"},
+		{"ixt:fixed-zero", "—", "0"},
+		{"ixt:zerodash", "-", "0"},
+		{"ixt:fixed-true", "☒", "true"},
+		{"ixt:date-monthname-day-year-en", "March 31, 2024", "2024-03-31"},
+		{"ixt:datemonthdayyearen", "Sept. 1st, 2023", "2023-09-01"},
+		{"ixt:date-day-monthname-year-en", "31 December 2024", "2024-12-31"},
+		{"ixt:dateslashus", "02/29/24", "2024-02-29"},
+		{"ixt:date-day-month-year", "31.01.2024", "2024-01-31"},
+		{"ixt:date-monthname-day-en", "December 31", "--12-31"},
+		{"ixt:date-monthname-year-en", "June 2024", "2024-06"},
+		{"ixt-sec:numwordsen", "twenty-one", "21"},
+		{"ixt-sec:numwordsen", "None", "0"},
+		{"ixt-sec:numwordsen", "one hundred and five thousand", "105000"},
+		{"ixt-sec:exchnameen", "The Nasdaq Stock Market LLC", "NASDAQ"},
+		{"ixt-sec:exchnameen", "New York Stock Exchange", "NYSE"},
+		{"ixt-sec:exchnameen", "NYSE American LLC", "NYSEAMER"},
+	}
+	for _, tt := range tests {
+		got, ok, err := transformInlineValue(tt.format, tt.text)
+		if !ok || err != nil || got != tt.want {
+			t.Errorf("%s(%q) = %q, %v, %v; want %q", tt.format, tt.text, got, ok, err, tt.want)
+		}
+	}
+
+	if _, _, err := transformInlineValue("ixt:date-monthname-day-year-en", "February 30, 2024"); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+	if got, ok, _ := transformInlineValue("ixt-sec:stateprovnameen", "Delaware"); ok || got != "Delaware" {
+		t.Errorf("unsupported format = %q, %v; want the text unchanged", got, ok)
+	}
+}
+
+func TestInlineXBRLSignAndScale(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:ixt="http://www.xbrl.org/inlineXBRL/transformation/2020-02-12" xmlns:us-gaap="http://fasb.org/us-gaap/2024"><body>
+(<ix:nonFraction name="us-gaap:NetIncomeLoss" contextRef="c-1" unitRef="usd" decimals="-5" scale="6" sign="-" format="ixt:num-dot-decimal">3,561.2</ix:nonFraction>)
+<ix:nonFraction name="us-gaap:EffectiveIncomeTaxRateContinuingOperations" contextRef="c-1" unitRef="pure" decimals="3" scale="-2" format="ixt:num-dot-decimal">5.5</ix:nonFraction>%
+<ix:nonFraction name="us-gaap:Goodwill" contextRef="c-1" unitRef="usd" decimals="INF" scale="6" sign="-" format="ixt:fixed-zero">—</ix:nonFraction>
+<ix:nonFraction name="us-gaap:Revenues" contextRef="c-1" unitRef="usd" decimals="-3" scale="3" format="ixt:num-dot-decimal">1,250</ix:nonFraction>
+</body></html>`
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	if len(x.Facts) != 4 {
+		t.Fatalf("got %d facts, want 4", len(x.Facts))
+	}
+	want := map[string]string{
+		"us-gaap:NetIncomeLoss":                              "-3561200000", // Loss shown in parentheses, in millions
+		"us-gaap:EffectiveIncomeTaxRateContinuingOperations": "0.055",       // Percent: scale -2
+		"us-gaap:Goodwill":                                   "0",           // No sign on zero
+		"us-gaap:Revenues":                                   "1250000",
+	}
+	for _, f := range x.Facts {
+		if f.Value != want[f.Concept] {
+			t.Errorf("%s = %q, want %q", f.Concept, f.Value, want[f.Concept])
+		}
+	}
+}
+
+func TestInlineXBRLHiddenFacts(t *testing.T) {
+	doc := `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL"
+  xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:xbrldi="http://xbrl.org/2006/xbrldi"
+  xmlns:dei="http://xbrl.sec.gov/dei/2024" xmlns:us-gaap="http://fasb.org/us-gaap/2024"><body>
+<div style="display:none"><ix:header>
+<ix:hidden>
+  <ix:nonNumeric name="dei:AmendmentFlag" contextRef="FY">false</ix:nonNumeric>
+  <ix:nonFraction name="dei:EntityCommonStockSharesOutstanding" contextRef="Cover_A" unitRef="shares" decimals="INF">1,000</ix:nonFraction>
+  <ix:nonFraction name="dei:EntityCommonStockSharesOutstanding" contextRef="Cover_B" unitRef="shares" decimals="INF">250</ix:nonFraction>
+</ix:hidden>
+<ix:resources>
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:context id="Cover_A"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+    <xbrli:segment><xbrldi:explicitMember dimension="us-gaap:StatementClassOfStockAxis">us-gaap:CommonClassAMember</xbrldi:explicitMember></xbrli:segment></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-02-14</xbrli:instant></xbrli:period></xbrli:context>
+  <xbrli:context id="Cover_B"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier>
+    <xbrli:segment><xbrldi:explicitMember dimension="us-gaap:StatementClassOfStockAxis">us-gaap:CommonClassBMember</xbrldi:explicitMember></xbrli:segment></xbrli:entity>
+    <xbrli:period><xbrli:instant>2025-02-14</xbrli:instant></xbrli:period></xbrli:context>
+  <xbrli:context id="Q2"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:instant>2024-06-30</xbrli:instant></xbrli:period></xbrli:context>
+</ix:resources>
+</ix:header></div>
+<p>Aggregate market value held by non-affiliates: $<ix:nonFraction name="dei:EntityPublicFloat" contextRef="Q2" unitRef="usd" decimals="-8" scale="9" format="ixt:num-dot-decimal">1.2</ix:nonFraction> billion</p>
+</body></html>`
+
+	x, err := ParseInlineXBRL([]byte(doc))
+	if err != nil {
+		t.Fatalf("ParseInlineXBRL: %v", err)
+	}
+	if fact, err := x.Query().ByConcept("dei:AmendmentFlag").First(); err != nil || fact.Value != "false" {
+		t.Errorf("hidden AmendmentFlag = %v, %v", fact, err)
+	}
+
+	snapshot, err := x.GetSnapshot()
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+	if value(snapshot.EntitySharesOutstanding) != 1250 {
+		t.Errorf("EntitySharesOutstanding = %v, want both classes (1250)", value(snapshot.EntitySharesOutstanding))
+	}
+	if value(snapshot.PublicFloat) != 1_200_000_000 {
+		t.Errorf("PublicFloat = %v, want 1.2B", value(snapshot.PublicFloat))
+	}
+}
+
 func TestDetectXBRLType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,171 @@
+package edgar
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Text blocks are facts whose value is a whole narrative disclosure or table:
+// accounting policies (us-gaap:RevenueRecognitionPolicyTextBlock), notes
+// (us-gaap:CommitmentsAndContingenciesDisclosureTextBlock) and cover or
+// governance items (cyd:CybersecurityRiskManagementProcessesIntegratedTextBlock).
+// Their values are extracted as plain text: paragraphs and table rows on their
+// own lines, table cells separated by spaces.
+
+// TextBlock is the plain text of a narrative disclosure
+type TextBlock struct {
+	Concept string `json:"concept"`          // e.g. "us-gaap:CommitmentsAndContingenciesDisclosureTextBlock"
+	Label   string `json:"label"`            // Taxonomy label when loaded, else from the concept name
+	Period  string `json:"period,omitempty"` // Period label, e.g. "2024-01-01 to 2024-12-31"
+	Text    string `json:"text"`
+}
+
+// IsTextBlock reports whether a concept is a text block (narrative disclosure)
+func IsTextBlock(concept string) bool {
+	return strings.HasSuffix(concept, "TextBlock")
+}
+
+// TextBlocksOnly returns only text block facts
+func (q *FactQuery) TextBlocksOnly() *FactQuery {
+	q.textBlocksOnly = true
+	return q
+}
+
+// GetTextBlocks returns the entity-wide text blocks of the document in document
+// order, one per concept (the most recent period when repeated)
+func (x *XBRL) GetTextBlocks() []TextBlock {
+	facts := x.Query().TextBlocksOnly().Get()
+
+	index := make(map[string]int) // Concept -> position in blocks
+	var blocks []TextBlock
+	var ends []string
+	for _, f := range facts {
+		end := ""
+		if f.Period != nil {
+			end = f.Period.EndDate + f.Period.Instant
+		}
+		block := TextBlock{
+			Concept: f.Concept,
+			Label:   f.Label,
+			Period:  f.GetPeriodLabel(),
+			Text:    f.Value,
+		}
+		if block.Label == "" {
+			block.Label = textBlockTitle(f.Concept)
+		}
+
+		i, ok := index[f.Concept]
+		if !ok {
+			index[f.Concept] = len(blocks)
+			blocks = append(blocks, block)
+			ends = append(ends, end)
+			continue
+		}
+		if end > ends[i] {
+			blocks[i], ends[i] = block, end
+		}
+	}
+	return blocks
+}
+
+// GetTextBlock returns the text block for a concept, matched as in
+// FactQuery.ByConcept, e.g. "RiskFactorsTextBlock" or
+// "us-gaap:SignificantAccountingPoliciesTextBlock". When several concepts
+// match, the first in document order is returned.
+func (x *XBRL) GetTextBlock(concept string) (*TextBlock, error) {
+	for _, block := range x.GetTextBlocks() {
+		if block.Concept == concept {
+			return &block, nil
+		}
+	}
+	for _, block := range x.GetTextBlocks() {
+		if strings.Contains(block.Concept, concept) {
+			return &block, nil
+		}
+	}
+	return nil, fmt.Errorf("text block %s not found", concept)
+}
+
+// cleanTextBlock converts a text block value to plain text. XBRL instances carry
+// the disclosure as escaped HTML; inline XBRL values are already text and are
+// only normalized.
+func cleanTextBlock(value string) string {
+	if strings.HasPrefix(strings.TrimSpace(value), "<") {
+		value = htmlToText(value)
+	}
+	return cleanInlineText(string(NormalizeText([]byte(value))))
+}
+
+// htmlToText extracts the text of an HTML fragment with the same line breaks as
+// inline XBRL text (see elementBreak)
+func htmlToText(fragment string) string {
+	var buf strings.Builder
+	z := html.NewTokenizer(strings.NewReader(fragment))
+	cells, skip := 0, 0
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return buf.String()
+		case html.TextToken:
+			if skip == 0 {
+				buf.Write(z.Text())
+			}
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch {
+			case tag == "script" || tag == "style":
+				skip++
+			case isTableCell(tag):
+				cells++
+			case tag == "br":
+				buf.WriteString(elementBreak(tag, cells > 0))
+			}
+		case html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			buf.WriteString(elementBreak(string(name), cells > 0))
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			switch {
+			case (tag == "script" || tag == "style") && skip > 0:
+				skip--
+			case isTableCell(tag) && cells > 0:
+				cells--
+			}
+			buf.WriteString(elementBreak(tag, cells > 0))
+		}
+	}
+}
+
+// elementBreak returns the text written at the end of an element: a line break
+// after block elements, a space after table cells and after blocks within a cell
+func elementBreak(tag string, inCell bool) string {
+	switch tag {
+	case "p", "div", "br", "tr", "li", "table", "h1", "h2", "h3", "h4", "h5", "h6":
+		if inCell {
+			return " "
+		}
+		return "\n"
+	case "td", "th":
+		return " "
+	}
+	return ""
+}
+
+func isTableCell(tag string) bool {
+	return tag == "td" || tag == "th"
+}
+
+// textBlockTitle turns a text block concept into a readable title
+// e.g. "us-gaap:IncomeTaxDisclosureTextBlock" -> "Income Tax Disclosure"
+func textBlockTitle(concept string) string {
+	name := concept[strings.Index(concept, ":")+1:]
+	name = strings.TrimSuffix(name, "TextBlock")
+	if strings.HasSuffix(name, "PolicyPolicy") { // e.g. "BasisOfAccountingPolicyPolicyTextBlock"
+		name = strings.TrimSuffix(name, "Policy")
+	}
+	return splitCamelCase(name)
+}
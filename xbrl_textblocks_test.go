@@ -0,0 +1,78 @@
+package edgar
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+const textBlockXBRL = `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:us-gaap="http://fasb.org/us-gaap/2024">
+  <xbrli:context id="FY2024">
+    <xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">0000000001</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period>
+  </xbrli:context>
+  <us-gaap:CommitmentsAndContingenciesDisclosureTextBlock contextRef="FY2024">&lt;div&gt;&lt;p style="font-weight:bold"&gt;Commitments&amp;nbsp;and Contingencies&lt;/p&gt;&lt;p&gt;We lease office space.&lt;/p&gt;&lt;table&gt;&lt;tr&gt;&lt;td&gt;2025&lt;/td&gt;&lt;td&gt;&lt;p&gt;$&lt;/p&gt;&lt;p&gt;1,200&lt;/p&gt;&lt;/td&gt;&lt;/tr&gt;&lt;tr&gt;&lt;td&gt;2026&lt;/td&gt;&lt;td&gt;900&lt;/td&gt;&lt;/tr&gt;&lt;/table&gt;&lt;/div&gt;</us-gaap:CommitmentsAndContingenciesDisclosureTextBlock>
+  <us-gaap:BasisOfAccountingPolicyPolicyTextBlock contextRef="FY2024">&lt;p&gt;Prepared under GAAP.&lt;/p&gt;</us-gaap:BasisOfAccountingPolicyPolicyTextBlock>
+</xbrli:xbrl>`
+
+func TestTextBlocksFromInstance(t *testing.T) {
+	x, err := ParseXBRL([]byte(textBlockXBRL))
+	if err != nil {
+		t.Fatalf("ParseXBRL: %v", err)
+	}
+
+	blocks := x.GetTextBlocks()
+	if len(blocks) != 2 {
+		t.Fatalf("got %d text blocks, want 2", len(blocks))
+	}
+	want := "Commitments and Contingencies\nWe lease office space.\n2025 $ 1,200\n2026 900"
+	if blocks[0].Text != want {
+		t.Errorf("text = %q, want %q", blocks[0].Text, want)
+	}
+	if blocks[1].Label != "Basis Of Accounting Policy" || blocks[1].Period != "2024-01-01 to 2024-12-31" {
+		t.Errorf("block = %+v", blocks[1])
+	}
+
+	block, err := x.GetTextBlock("BasisOfAccounting")
+	if err != nil || block.Text != "Prepared under GAAP." {
+		t.Errorf("GetTextBlock = %+v, %v", block, err)
+	}
+	if _, err := x.GetTextBlock("RiskFactorsTextBlock"); err == nil {
+		t.Error("GetTextBlock(missing) should return error")
+	}
+}
+
+func TestTextBlocksModerna(t *testing.T) {
+	data, err := os.ReadFile("testdata/xbrl/moderna_10k/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read Moderna 10-K: %v", err)
+	}
+	x, err := ParseInlineXBRL(data)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if n := len(x.Query().TextBlocksOnly().Get()); n < 50 {
+		t.Errorf("got %d text block facts, want the notes and policies (50+)", n)
+	}
+
+	block, err := x.GetTextBlock("us-gaap:CommitmentsAndContingenciesDisclosureTextBlock")
+	if err != nil {
+		t.Fatalf("GetTextBlock: %v", err)
+	}
+	if !strings.HasPrefix(block.Text, "11. Commitments and Contingencies\nLegal Proceedings\n") {
+		t.Errorf("commitments text starts %q", block.Text[:min(len(block.Text), 80)])
+	}
+
+	// Table cells are separated, rows are lines
+	cash, err := x.GetTextBlock("ScheduleOfCashAndCashEquivalentsTableTextBlock")
+	if err != nil {
+		t.Fatalf("GetTextBlock: %v", err)
+	}
+	for _, line := range []string{"2024 2023 2022", "Restricted cash(1) 1 17 —"} {
+		if !strings.Contains(cash.Text, line+"\n") {
+			t.Errorf("cash table missing line %q:\n%s", line, cash.Text)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package edgar
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// XBRLZipURL returns the URL of a filing's XBRL archive, which holds the XBRL
+// instance (and inline XBRL document) with its schema and linkbases
+// e.g. https://www.sec.gov/Archives/edgar/data/1682852/000168285225000011/0001682852-25-000011-xbrl.zip
+func XBRLZipURL(cik, accessionNumber string) string {
+	return strings.TrimSuffix(FilingIndexURL(cik, accessionNumber), "-index.htm") + "-xbrl.zip"
+}
+
+// FetchXBRLZip fetches and parses a filing's XBRL archive (see ParseXBRLZip)
+func FetchXBRLZip(cik, accessionNumber, email string) (*XBRL, error) {
+	return defaultClient(email).FetchXBRLZip(cik, accessionNumber)
+}
+
+// FetchXBRLZip fetches and parses a filing's XBRL archive (see ParseXBRLZip)
+func (c *Client) FetchXBRLZip(cik, accessionNumber string) (*XBRL, error) {
+	body, err := c.get(XBRLZipURL(cik, accessionNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch XBRL archive: %w", err)
+	}
+	defer body.Close()
+
+	// zip needs random access
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read XBRL archive: %w", err)
+	}
+	return ParseXBRLZip(data)
+}
+
+// ParseXBRLZip parses a filing's XBRL archive (see XBRLZipURL)
+func ParseXBRLZip(data []byte) (*XBRL, error) {
+	return ParseXBRLZipReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// ParseXBRLZipReader parses an XBRL archive of size bytes: the instance
+// document, with the archive's label, calculation and presentation linkbases
+// loaded (Fact.Label, Calculations, Presentations)
+//
+// The standalone instance (e.g. mrna-20241231_htm.xml) is used when present,
+// otherwise the largest inline XBRL document.
+func ParseXBRLZipReader(r io.ReaderAt, size int64) (*XBRL, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XBRL archive: %w", err)
+	}
+
+	var instance, inline []byte
+	var labels, calculations, presentations *zip.File
+	for _, f := range archive.File {
+		name := strings.ToLower(path.Base(f.Name))
+		switch {
+		case f.FileInfo().IsDir():
+		case strings.HasSuffix(name, "_lab.xml"):
+			labels = f
+		case strings.HasSuffix(name, "_cal.xml"):
+			calculations = f
+		case strings.HasSuffix(name, "_pre.xml"):
+			presentations = f
+		case strings.HasSuffix(name, "_def.xml"), name == "filingsummary.xml":
+			// Not needed for facts
+		case strings.HasSuffix(name, ".xml"):
+			if instance != nil {
+				continue
+			}
+			data, err := readZipFile(f)
+			if err != nil {
+				return nil, err
+			}
+			if DetectXBRLType(data) == "standalone" {
+				instance = data
+			}
+		case strings.HasSuffix(name, ".htm"), strings.HasSuffix(name, ".html"):
+			if instance != nil || f.UncompressedSize64 <= uint64(len(inline)) {
+				continue
+			}
+			data, err := readZipFile(f)
+			if err != nil {
+				return nil, err
+			}
+			if DetectXBRLType(data) == "inline" {
+				inline = data
+			}
+		}
+	}
+
+	var x *XBRL
+	switch {
+	case instance != nil:
+		x, err = ParseXBRL(instance)
+	case inline != nil:
+		x, err = ParseInlineXBRL(inline)
+	default:
+		return nil, fmt.Errorf("no XBRL instance found in archive: %w", ErrNotXBRL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if labels != nil {
+		rc, err := labels.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", labels.Name, err)
+		}
+		m, err := ParseLabelLinkbase(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		x.ApplyLabels(m)
+	}
+	if calculations != nil {
+		rc, err := calculations.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", calculations.Name, err)
+		}
+		x.Calculations, err = ParseCalculationLinkbase(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if presentations != nil {
+		rc, err := presentations.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", presentations.Name, err)
+		}
+		x.Presentations, err = ParsePresentationLinkbase(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// isZip reports whether data starts like a zip archive
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// readZipFile reads one file of an archive
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	return data, nil
+}
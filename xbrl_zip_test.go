@@ -0,0 +1,102 @@
+package edgar
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// buildZip returns an archive of name -> content, in the order given
+func buildZip(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		if err != nil {
+			t.Fatalf("zip create %s: %v", f[0], err)
+		}
+		if _, err := fw.Write([]byte(f[1])); err != nil {
+			t.Fatalf("zip write %s: %v", f[0], err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseXBRLZip(t *testing.T) {
+	instance := `<?xml version="1.0" encoding="UTF-8"?>
+<xbrli:xbrl xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:iso4217="http://www.xbrl.org/2003/iso4217"
+  xmlns:us-gaap="http://fasb.org/us-gaap/2024">
+  <xbrli:context id="FY"><xbrli:entity><xbrli:identifier scheme="http://www.sec.gov/CIK">1682852</xbrli:identifier></xbrli:entity>
+    <xbrli:period><xbrli:startDate>2024-01-01</xbrli:startDate><xbrli:endDate>2024-12-31</xbrli:endDate></xbrli:period></xbrli:context>
+  <xbrli:unit id="usd"><xbrli:measure>iso4217:USD</xbrli:measure></xbrli:unit>
+  <us-gaap:Revenues contextRef="FY" unitRef="usd" decimals="-6">3236000000</us-gaap:Revenues>
+  <us-gaap:NetIncomeLoss contextRef="FY" unitRef="usd" decimals="-6">-3561000000</us-gaap:NetIncomeLoss>
+</xbrli:xbrl>`
+	data := buildZip(t,
+		[2]string{"mrna-20241231.htm", strings.Replace(ifrsDoc, "1,200", "9,999", 1)}, // Inline copy, not used
+		[2]string{"mrna-20241231.xsd", `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema"/>`},
+		[2]string{"mrna-20241231_lab.xml", testLabelLinkbase},
+		[2]string{"mrna-20241231_cal.xml", testCalculationLinkbase},
+		[2]string{"mrna-20241231_pre.xml", testPresentationLinkbase},
+		[2]string{"FilingSummary.xml", `<FilingSummary><Version>3.24</Version></FilingSummary>`},
+		[2]string{"mrna-20241231_htm.xml", instance},
+	)
+
+	x, err := ParseXBRLZip(data)
+	if err != nil {
+		t.Fatalf("ParseXBRLZip: %v", err)
+	}
+	if len(x.Facts) != 2 {
+		t.Fatalf("got %d facts, want the 2 of the standalone instance", len(x.Facts))
+	}
+	revenue, err := x.Query().ByConcept("us-gaap:Revenues").First()
+	if err != nil || revenue.Label != "Revenues" || value(revenue.NumericValue) != 3236e6 {
+		t.Errorf("revenue = %+v, %v, want the instance value labeled from the label linkbase", revenue, err)
+	}
+	if len(x.Calculations) != 2 || len(x.Presentations) != 3 {
+		t.Errorf("got %d calculations and %d presentations, want 2 and 3", len(x.Calculations), len(x.Presentations))
+	}
+
+	if got := XBRLZipURL("0001682852", "0001682852-25-000011"); got != "https://www.sec.gov/Archives/edgar/data/1682852/000168285225000011/0001682852-25-000011-xbrl.zip" {
+		t.Errorf("XBRLZipURL = %s", got)
+	}
+}
+
+func TestParseXBRLZipInline(t *testing.T) {
+	exhibit := "<html><body>" + strings.Repeat("<p>Exhibit 21.1 Subsidiaries</p>", 500) + "</body></html>"
+	data := buildZip(t,
+		[2]string{"ex21.htm", exhibit}, // Larger, but not inline XBRL
+		[2]string{"abc-20241231.htm", ifrsDoc},
+	)
+	x, err := ParseXBRLZip(data)
+	if err != nil {
+		t.Fatalf("ParseXBRLZip: %v", err)
+	}
+	if snapshot, _ := x.GetSnapshot(); value(snapshot.Revenue) != 1200e6 {
+		t.Errorf("Revenue = %s, want the inline document's", formatCurrency(snapshot.Revenue))
+	}
+	if x.Calculations != nil || x.Presentations != nil {
+		t.Error("linkbases loaded from an archive without them")
+	}
+
+	// ParseAny (and the CLI) recognize an archive
+	parsed, err := ParseAny(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseAny: %v", err)
+	}
+	if snapshot, ok := parsed.Data.(*FinancialSnapshot); !ok || snapshot.FormType != "20-F" {
+		t.Errorf("ParseAny = %s %T, want the 20-F snapshot", parsed.FormType, parsed.Data)
+	}
+
+	if _, err := ParseXBRLZip(buildZip(t, [2]string{"ex21.htm", exhibit})); err == nil {
+		t.Error("expected an error for an archive without an instance")
+	}
+	if _, err := ParseXBRLZip([]byte("not a zip")); err == nil {
+		t.Error("expected an error for data that is not an archive")
+	}
+}
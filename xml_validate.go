@@ -0,0 +1,317 @@
+package edgar
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+//go:embed xml_schemas.json
+var xmlSchemasJSON []byte
+
+// xmlSchema is one schema of xml_schemas.json: the rules for documents with
+// the given root element (and namespace, when set)
+type xmlSchema struct {
+	Name      string    `json:"name"`
+	Root      string    `json:"root"`
+	Namespace string    `json:"namespace"`
+	Rules     []xmlRule `json:"rules"`
+}
+
+// xmlRule constrains the elements at Path (local names below the root)
+type xmlRule struct {
+	Path     string   `json:"path"`
+	Required bool     `json:"required"`
+	Repeated bool     `json:"repeated"`
+	Type     string   `json:"type"`   // cik, date, usDate, decimal, boolean, schemaVersion
+	Values   []string `json:"values"` // Allowed values
+}
+
+var xmlSchemas []*xmlSchema
+
+func init() {
+	var doc struct {
+		Schemas map[string]*xmlSchema `json:"schemas"`
+	}
+	if err := json.Unmarshal(xmlSchemasJSON, &doc); err != nil {
+		panic(fmt.Sprintf("Failed to load XML schemas: %v", err))
+	}
+	for _, id := range []string{"ownership", "schedule13D", "schedule13G"} {
+		schema := doc.Schemas[id]
+		for _, rule := range schema.Rules {
+			if _, ok := xmlValueTypes[rule.Type]; !ok && rule.Type != "" {
+				panic(fmt.Sprintf("XML schema %s: unknown type %q for %s", id, rule.Type, rule.Path))
+			}
+		}
+		xmlSchemas = append(xmlSchemas, schema)
+	}
+}
+
+// SchemaViolation is one way a document breaks its SEC schema
+type SchemaViolation struct {
+	Line    int    `json:"line"`
+	Path    string `json:"path"` // Element path below the root, e.g. "issuer/issuerCik"
+	Message string `json:"message"`
+}
+
+func (v SchemaViolation) String() string {
+	if v.Path == "" {
+		return fmt.Sprintf("line %d: %s", v.Line, v.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", v.Line, v.Path, v.Message)
+}
+
+// SchemaError is returned by ValidateXML (and strict parsing) for a document
+// that doesn't match its schema; it matches ErrSchemaViolation
+type SchemaError struct {
+	Schema     string // "ownership", "Schedule 13D" or "Schedule 13G"
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	msg := fmt.Sprintf("%s schema validation failed: %s", e.Schema, e.Violations[0])
+	if n := len(e.Violations) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return msg
+}
+
+// Is matches ErrSchemaViolation
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrSchemaViolation
+}
+
+// ValidateXML checks an ownership (Form 3, 4, 5) or Schedule 13D/G XML
+// document against the SEC schema rules shipped in xml_schemas.json, so
+// truncated or corrupted filings surface as explicit errors instead of
+// parsing into empty structs. It reports malformed XML, missing required
+// elements, elements repeated where only one is allowed, and values of the
+// wrong type (CIKs, dates, numbers, booleans, codes).
+//
+// The result is nil or a *SchemaError; documents of other kinds (HTML,
+// XBRL, text) return an error wrapping ErrUnsupportedForm.
+func ValidateXML(data []byte) error {
+	schema := xmlSchemaFor(data)
+	if schema == nil {
+		return fmt.Errorf("%w: no XML schema for this document", ErrUnsupportedForm)
+	}
+	return schema.validate(data)
+}
+
+// validateStrict validates documents that have a schema and lets others
+// (HTML, XBRL, text filings) through
+func validateStrict(data []byte) error {
+	schema := xmlSchemaFor(data)
+	if schema == nil {
+		return nil
+	}
+	return schema.validate(data)
+}
+
+// xmlSchemaFor returns the schema for the document's root element, or nil
+func xmlSchemaFor(data []byte) *xmlSchema {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil // Empty, or not XML
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		for _, schema := range xmlSchemas {
+			if start.Name.Local == schema.Root && (schema.Namespace == "" || strings.EqualFold(start.Name.Space, schema.Namespace)) {
+				return schema
+			}
+		}
+		return nil
+	}
+}
+
+// xmlNode is an element of a parsed document
+type xmlNode struct {
+	name     string
+	text     strings.Builder
+	line     int
+	parent   *xmlNode
+	children []*xmlNode
+}
+
+// path returns the node's path below the root, with positions for repeated
+// elements, e.g. "nonDerivativeTable/nonDerivativeTransaction[2]"
+func (n *xmlNode) path() string {
+	if n.parent == nil {
+		return ""
+	}
+	name := n.name
+	if siblings := n.parent.named(n.name); len(siblings) > 1 {
+		name += fmt.Sprintf("[%d]", slices.Index(siblings, n)+1)
+	}
+	if parent := n.parent.path(); parent != "" {
+		return parent + "/" + name
+	}
+	return name
+}
+
+// named returns the node's children with the given local name
+func (n *xmlNode) named(name string) []*xmlNode {
+	var out []*xmlNode
+	for _, child := range n.children {
+		if child.name == name {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// readXMLTree parses a document into a tree of elements, with the line each
+// starts on
+func readXMLTree(data []byte) (*xmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = true
+	d.Entity = xml.HTMLEntity
+	var root, cur *xmlNode
+	for {
+		tok, err := d.Token()
+		line, _ := d.InputPos()
+		if err == io.EOF {
+			if root == nil {
+				return nil, fmt.Errorf("no root element")
+			}
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local, line: line, parent: cur}
+			if cur != nil {
+				cur.children = append(cur.children, node)
+			} else if root == nil {
+				root = node
+			}
+			cur = node
+		case xml.EndElement:
+			cur = cur.parent
+		case xml.CharData:
+			if cur != nil {
+				cur.text.Write(t)
+			}
+		}
+	}
+}
+
+// validate checks a document against the schema's rules
+func (s *xmlSchema) validate(data []byte) error {
+	root, err := readXMLTree(data)
+	if err != nil {
+		line := 0
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			line = syntaxErr.Line
+		}
+		return &SchemaError{Schema: s.Name, Violations: []SchemaViolation{{Line: line, Message: "malformed XML: " + err.Error()}}}
+	}
+
+	var violations []SchemaViolation
+	for _, rule := range s.Rules {
+		parentPath, name := "", rule.Path
+		if i := strings.LastIndex(rule.Path, "/"); i >= 0 {
+			parentPath, name = rule.Path[:i], rule.Path[i+1:]
+		}
+		for _, parent := range findXMLNodes(root, parentPath) {
+			elems := parent.named(name)
+			if rule.Required && len(elems) == 0 {
+				path := name
+				if p := parent.path(); p != "" {
+					path = p + "/" + name
+				}
+				violations = append(violations, SchemaViolation{Line: parent.line, Path: path, Message: "missing required element"})
+			}
+			if !rule.Repeated && len(elems) > 1 {
+				violations = append(violations, SchemaViolation{Line: elems[1].line, Path: elems[1].path(), Message: fmt.Sprintf("appears %d times, at most once allowed", len(elems))})
+			}
+			for _, elem := range elems {
+				if msg := rule.check(elem); msg != "" {
+					violations = append(violations, SchemaViolation{Line: elem.line, Path: elem.path(), Message: msg})
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(violations, func(a, b SchemaViolation) int { return a.Line - b.Line })
+	if len(violations) > 0 {
+		return &SchemaError{Schema: s.Name, Violations: violations}
+	}
+	return nil
+}
+
+// findXMLNodes returns every node at path ("" for the root itself)
+func findXMLNodes(root *xmlNode, path string) []*xmlNode {
+	nodes := []*xmlNode{root}
+	if path == "" {
+		return nodes
+	}
+	for _, name := range strings.Split(path, "/") {
+		var next []*xmlNode
+		for _, n := range nodes {
+			next = append(next, n.named(name)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// check returns what is wrong with an element's value, or ""
+// Elements with child elements have no value of their own and aren't checked
+func (r *xmlRule) check(n *xmlNode) string {
+	if len(n.children) > 0 || r.Type == "" && len(r.Values) == 0 {
+		return ""
+	}
+	value := strings.TrimSpace(n.text.String())
+	if len(r.Values) > 0 && !slices.Contains(r.Values, value) {
+		return fmt.Sprintf("%q is not one of %s", value, strings.Join(r.Values, ", "))
+	}
+	if valid, ok := xmlValueTypes[r.Type]; ok && !valid(value) {
+		return fmt.Sprintf("%q is not a valid %s", value, r.Type)
+	}
+	return ""
+}
+
+var (
+	cikPattern     = regexp.MustCompile(`^[0-9]{1,10}$`)
+	decimalPattern = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+	xsdDatePattern = regexp.MustCompile(`^([0-9]{4}-[0-9]{2}-[0-9]{2})(Z|[+-][0-9]{2}:[0-9]{2})?$`)
+)
+
+// xmlValueTypes validates leaf values by rule type
+var xmlValueTypes = map[string]func(string) bool{
+	"cik":     cikPattern.MatchString,
+	"decimal": decimalPattern.MatchString,
+	"date": func(s string) bool { // xs:date, with an optional time zone
+		m := xsdDatePattern.FindStringSubmatch(s)
+		if m == nil {
+			return false
+		}
+		_, err := time.Parse("2006-01-02", m[1])
+		return err == nil
+	},
+	"usDate": func(s string) bool { // MM/DD/YYYY, as in the Schedule 13D/G cover pages
+		_, err := time.Parse("01/02/2006", s)
+		return err == nil
+	},
+	"boolean": func(s string) bool {
+		return slices.Contains([]string{"0", "1", "true", "false"}, s)
+	},
+	"schemaVersion": ownershipSchemaVersion.MatchString,
+}
@@ -0,0 +1,114 @@
+package edgar
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateXML_Fixtures(t *testing.T) {
+	files, _ := filepath.Glob("testdata/form4/*/input.xml")
+	more, _ := filepath.Glob("testdata/schedule13/*_xml/input.xml")
+	files = append(files, more...)
+	if len(files) < 7 {
+		t.Fatalf("Expected the Form 4 and Schedule 13D/G XML fixtures, got %v", files)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if err := ValidateXML(data); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+	}
+}
+
+func TestValidateXML_Violations(t *testing.T) {
+	form4, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	sc13g, err := os.ReadFile("testdata/schedule13/jushi_13g_xml/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	edit := func(data []byte, old, new string) []byte {
+		if !bytes.Contains(data, []byte(old)) {
+			t.Fatalf("Fixture has no %q", old)
+		}
+		return bytes.Replace(data, []byte(old), []byte(new), 1)
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		schema string
+		want   []string // Substrings of the violations, in order
+	}{
+		{"truncated", form4[:len(form4)/2], "ownership", []string{"malformed XML: XML syntax error on line 272: unexpected EOF"}},
+		{"missing issuer CIK", edit(form4, "<issuerCik>0001640147</issuerCik>", ""), "ownership", []string{"line 6: issuer/issuerCik: missing required element"}},
+		{"bad values", edit(edit(form4, "<periodOfReport>2022-12-13", "<periodOfReport>12/13/2022"), "<transactionCode>M<", "<transactionCode>Q<"),
+			"ownership", []string{`periodOfReport: "12/13/2022" is not a valid date`, `nonDerivativeTable/nonDerivativeTransaction[1]/transactionCoding/transactionCode: "Q" is not one of`}},
+		{"repeated", edit(form4, "<documentType>4</documentType>", "<documentType>4</documentType><documentType>4</documentType>"), "ownership", []string{"documentType[2]: appears 2 times"}},
+		{"13G shares", edit(sc13g, "<soleVotingPower>10000000.00<", "<soleVotingPower>10,000,000<"), "Schedule 13G", []string{`coverPageHeaderReportingPersonDetails[1]/reportingPersonBeneficiallyOwnedNumberOfShares/soleVotingPower: "10,000,000" is not a valid decimal`}},
+	}
+	for _, tt := range tests {
+		err := ValidateXML(tt.data)
+		var schemaErr *SchemaError
+		if !errors.As(err, &schemaErr) || !errors.Is(err, ErrSchemaViolation) {
+			t.Errorf("%s: expected a *SchemaError, got %v", tt.name, err)
+			continue
+		}
+		if schemaErr.Schema != tt.schema || len(schemaErr.Violations) < len(tt.want) {
+			t.Errorf("%s: got %s violations %v", tt.name, schemaErr.Schema, schemaErr.Violations)
+			continue
+		}
+		for i, want := range tt.want {
+			if got := schemaErr.Violations[i].String(); !strings.Contains(got, want) {
+				t.Errorf("%s: violation %d = %q, want it to contain %q", tt.name, i, got, want)
+			}
+		}
+	}
+
+	if err := ValidateXML([]byte("<html><body>SCHEDULE 13D</body></html>")); !errors.Is(err, ErrUnsupportedForm) {
+		t.Errorf("Expected ErrUnsupportedForm for HTML, got %v", err)
+	}
+}
+
+func TestParseAnyWithOptions_Strict(t *testing.T) {
+	data, err := os.ReadFile("testdata/form4/snow/input.xml")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	broken := bytes.Replace(data, []byte("<issuerCik>0001640147</issuerCik>"), nil, 1)
+
+	// Without strict mode the filing parses, with an empty issuer CIK
+	parsed, err := ParseAny(bytes.NewReader(broken))
+	if err != nil {
+		t.Fatalf("ParseAny failed: %v", err)
+	}
+	if parsed.GetIssuerCIK() != "" {
+		t.Fatalf("Expected an empty issuer CIK, got %q", parsed.GetIssuerCIK())
+	}
+
+	_, err = ParseAnyWithOptions(bytes.NewReader(broken), ParseOptions{Strict: true})
+	if !errors.Is(err, ErrSchemaViolation) || ErrorCategory(err) != CategoryParse {
+		t.Errorf("Expected a schema violation, got %v", err)
+	}
+	if _, err := ParseAnyWithOptions(bytes.NewReader(data), ParseOptions{Strict: true}); err != nil {
+		t.Errorf("Strict parse of a valid filing failed: %v", err)
+	}
+
+	// Documents without a schema are parsed as usual
+	html, err := os.ReadFile("testdata/schedule13/vtv_13d_item4/input.htm")
+	if err != nil {
+		t.Fatalf("Failed to read test file: %v", err)
+	}
+	if _, err := ParseAnyWithOptions(bytes.NewReader(html), ParseOptions{Strict: true}); err != nil {
+		t.Errorf("Strict parse of an HTML filing failed: %v", err)
+	}
+}